@@ -3,11 +3,11 @@ package ante
 import (
 	"github.com/ethereum/go-ethereum/common"
 	ethcore "github.com/ethereum/go-ethereum/core"
-	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/auth/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	evmtypes "github.com/okex/exchain/x/evm/types"
 	"math/big"
 )
@@ -67,7 +67,7 @@ func (egcd EthGasConsumeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simula
 	}
 
 	gasLimit := msgEthTx.GetGas()
-	gas, err := ethcore.IntrinsicGas(msgEthTx.Data.Payload, []ethtypes.AccessTuple{}, msgEthTx.To() == nil, true, false)
+	gas, err := ethcore.IntrinsicGas(msgEthTx.Data.Payload, msgEthTx.AccessList(), msgEthTx.To() == nil, true, false)
 	if err != nil {
 		return ctx, sdkerrors.Wrap(err, "failed to compute intrinsic gas cost")
 	}
@@ -84,6 +84,19 @@ func (egcd EthGasConsumeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simula
 
 		evmDenom := sdk.DefaultBondDenom
 
+		// since Earth the sender pays the effective gas price. The sender of a dynamic fee tx must afford its
+		// fee cap, the difference to the effective gas price is refunded at once by deducting the fee only
+		if tmtypes.HigherThanEarth(ctx.BlockHeight()) {
+			maxCost := cost
+			cost = msgEthTx.Fee()
+			if balance := senderAcc.GetCoins().AmountOf(evmDenom); maxCost.Cmp(cost) > 0 && balance.BigInt().Cmp(maxCost) < 0 {
+				return ctx, sdkerrors.Wrapf(
+					sdkerrors.ErrInsufficientFunds,
+					"sender balance < tx max gas cost (%s%s < %s%s)", balance.String(), evmDenom, sdk.NewDecFromBigIntWithPrec(maxCost, sdk.Precision).String(), evmDenom,
+				)
+			}
+		}
+
 		feeAmt := sdk.NewCoins(
 			sdk.NewCoin(evmDenom, sdk.NewDecFromBigIntWithPrec(cost, sdk.Precision)), // int2dec
 		)
@@ -40,6 +40,7 @@ func (emfd EthMempoolFeeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simula
 	evmDenom := sdk.DefaultBondDenom
 
 	// fee = gas price * gas limit
+	// NOTE: the gas price of an EIP-1559 dynamic fee tx is its effective gas price, i.e. the tip as there is no base fee
 	fee := sdk.NewDecCoinFromDec(evmDenom, sdk.NewDecFromBigIntWithPrec(msgEthTx.Fee(), sdk.Precision))
 
 	minGasPrices := ctx.MinGasPrices()
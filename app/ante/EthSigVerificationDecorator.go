@@ -4,6 +4,7 @@ import (
 	ethermint "github.com/okex/exchain/app/types"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	evmtypes "github.com/okex/exchain/x/evm/types"
 )
 
@@ -28,6 +29,11 @@ func (esvd EthSigVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, s
 		return ctx, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "invalid transaction type: %T", tx)
 	}
 
+	// the typed txs and their fee market are enabled since Earth
+	if msgEthTx.IsTyped() && !tmtypes.HigherThanEarth(ctx.BlockHeight()) {
+		return ctx, sdkerrors.Wrapf(sdkerrors.ErrTxDecode, "tx type %d is not supported before Earth height", msgEthTx.TxType())
+	}
+
 	// parse the chainID from a string to a base-10 integer
 	chainIDEpoch, err := ethermint.ParseChainID(ctx.ChainID())
 	if err != nil {
@@ -11,6 +11,7 @@ import (
 
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
 	tmcrypto "github.com/okex/exchain/libs/tendermint/crypto"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 
@@ -298,3 +299,59 @@ func (suite *AnteTestSuite) TestEthInvalidChainID() {
 	ctx := suite.ctx.WithChainID("bad-chain-id")
 	requireInvalidTx(suite.T(), suite.anteHandler, ctx, tx, false)
 }
+
+func (suite *AnteTestSuite) TestEthDynamicFeeTx() {
+	tmtypes.UnittestOnlySetMilestoneVenusHeight(1)
+	defer tmtypes.UnittestOnlySetMilestoneVenusHeight(0)
+	tmtypes.UnittestOnlySetMilestoneEarthHeight(2)
+	defer tmtypes.UnittestOnlySetMilestoneEarthHeight(0)
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+
+	addr1, priv1 := newTestAddrKey()
+	addr2, _ := newTestAddrKey()
+
+	to := ethcmn.BytesToAddress(addr2.Bytes())
+	amt := big.NewInt(32)
+	gasLimit := uint64(22000)
+	tip, feeCap := big.NewInt(10), big.NewInt(20)
+	newTx := func(nonce uint64) sdk.Tx {
+		ethMsg := evmtypes.NewDynamicFeeMsgEthereumTx(big.NewInt(3), nonce, &to, amt, gasLimit, tip, feeCap, []byte("test"), nil)
+		tx, err := newTestEthTx(suite.ctx, ethMsg, priv1)
+		suite.Require().NoError(err)
+		return tx
+	}
+	setBalance := func(balance *big.Int) {
+		acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr1)
+		_ = acc.SetCoins(sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewDecFromBigIntWithPrec(balance, sdk.Precision))))
+		suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+	}
+
+	// the typed txs are rejected before Earth
+	maxFee := new(big.Int).Mul(feeCap, new(big.Int).SetUint64(gasLimit))
+	maxCost := new(big.Int).Add(maxFee, amt)
+	setBalance(maxCost)
+	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx, newTx(0), false)
+
+	// the legacy txs pay the gas price before Earth
+	legacyMsg := evmtypes.NewMsgEthereumTx(0, &to, amt, gasLimit, feeCap, []byte("test"))
+	legacyTx, err := newTestEthTx(suite.ctx, legacyMsg, priv1)
+	suite.Require().NoError(err)
+	requireValidTx(suite.T(), suite.anteHandler, suite.ctx, legacyTx, false)
+	balance := suite.app.AccountKeeper.GetAccount(suite.ctx, addr1).GetCoins().AmountOf(sdk.DefaultBondDenom)
+	suite.Require().Equal(sdk.NewDecFromBigIntWithPrec(amt, sdk.Precision), balance)
+
+	suite.ctx = suite.ctx.WithBlockHeight(2)
+
+	// the balance must cover the fee cap, and the amount in check tx
+	setBalance(new(big.Int).Sub(maxFee, big.NewInt(1)))
+	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx, newTx(0), false)
+	setBalance(new(big.Int).Sub(maxCost, big.NewInt(1)))
+	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx.WithIsCheckTx(true), newTx(0), false)
+
+	// the sender only pays the effective gas price, which is the tip as there is no base fee
+	setBalance(maxCost)
+	requireValidTx(suite.T(), suite.anteHandler, suite.ctx, newTx(0), false)
+	paid := new(big.Int).Mul(tip, new(big.Int).SetUint64(gasLimit))
+	balance = suite.app.AccountKeeper.GetAccount(suite.ctx, addr1).GetCoins().AmountOf(sdk.DefaultBondDenom)
+	suite.Require().Equal(sdk.NewDecFromBigIntWithPrec(new(big.Int).Sub(maxCost, paid), sdk.Precision), balance)
+}
@@ -1,8 +1,10 @@
 package app
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"math/big"
 	"os"
 	"sync"
@@ -19,6 +21,7 @@ import (
 	"github.com/okex/exchain/libs/cosmos-sdk/server"
 	"github.com/okex/exchain/libs/cosmos-sdk/simapp"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
 	"github.com/okex/exchain/libs/cosmos-sdk/types/module"
 	"github.com/okex/exchain/libs/cosmos-sdk/version"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
@@ -40,9 +43,12 @@ import (
 	"github.com/okex/exchain/x/dex"
 	dexclient "github.com/okex/exchain/x/dex/client"
 	distr "github.com/okex/exchain/x/distribution"
+	"github.com/okex/exchain/x/erc20"
+	erc20client "github.com/okex/exchain/x/erc20/client"
 	"github.com/okex/exchain/x/evidence"
 	"github.com/okex/exchain/x/evm"
 	evmclient "github.com/okex/exchain/x/evm/client"
+	"github.com/okex/exchain/x/evm/precompiles"
 	evmtypes "github.com/okex/exchain/x/evm/types"
 	"github.com/okex/exchain/x/farm"
 	farmclient "github.com/okex/exchain/x/farm/client"
@@ -94,6 +100,7 @@ var (
 			evmclient.ManageContractBlockedListProposalHandler,
 			evmclient.ManageContractMethodBlockedListProposalHandler,
 			govclient.ManageTreasuresProposalHandler,
+			erc20client.TokenMappingProposalHandler,
 		),
 		params.AppModuleBasic{},
 		crisis.AppModuleBasic{},
@@ -106,6 +113,7 @@ var (
 		order.AppModuleBasic{},
 		ammswap.AppModuleBasic{},
 		farm.AppModuleBasic{},
+		erc20.AppModuleBasic{},
 	)
 
 	// module account permissions
@@ -123,6 +131,7 @@ var (
 		farm.ModuleName:           nil,
 		farm.YieldFarmingAccount:  nil,
 		farm.MintFarmingAccount:   {supply.Burner},
+		erc20.ModuleName:          {supply.Minter, supply.Burner},
 	}
 
 	GlobalGpIndex = GasPriceIndex{}
@@ -167,6 +176,7 @@ type OKExChainApp struct {
 	OrderKeeper    order.Keeper
 	SwapKeeper     ammswap.Keeper
 	FarmKeeper     farm.Keeper
+	Erc20Keeper    erc20.Keeper
 
 	// the module manager
 	mm *module.Manager
@@ -225,7 +235,7 @@ func NewOKExChainApp(
 		supply.StoreKey, mint.StoreKey, distr.StoreKey, slashing.StoreKey,
 		gov.StoreKey, params.StoreKey, upgrade.StoreKey, evidence.StoreKey,
 		evm.StoreKey, token.StoreKey, token.KeyLock, dex.StoreKey, dex.TokenPairStoreKey,
-		order.OrderStoreKey, ammswap.StoreKey, farm.StoreKey,
+		order.OrderStoreKey, ammswap.StoreKey, farm.StoreKey, erc20.StoreKey,
 	)
 
 	tkeys := sdk.NewTransientStoreKeys(params.TStoreKey)
@@ -308,6 +318,10 @@ func NewOKExChainApp(
 	app.FarmKeeper = farm.NewKeeper(auth.FeeCollectorName, app.SupplyKeeper, app.TokenKeeper, app.SwapKeeper, *app.EvmKeeper, app.subspaces[farm.StoreKey],
 		app.keys[farm.StoreKey], app.cdc)
 
+	app.Erc20Keeper = erc20.NewKeeper(app.cdc, app.keys[erc20.StoreKey], app.SupplyKeeper, app.TokenKeeper, app.EvmKeeper)
+	// register the evm hooks, the erc20 module converts the erc20 tokens transferred to it
+	app.EvmKeeper.SetHooks(evm.NewMultiEvmHooks(app.Erc20Keeper.Hooks()))
+
 	// create evidence keeper with router
 	evidenceKeeper := evidence.NewKeeper(
 		cdc, keys[evidence.StoreKey], app.subspaces[evidence.ModuleName], &app.StakingKeeper, app.SlashingKeeper,
@@ -325,13 +339,15 @@ func NewOKExChainApp(
 		AddRoute(dex.RouterKey, dex.NewProposalHandler(&app.DexKeeper)).
 		AddRoute(farm.RouterKey, farm.NewManageWhiteListProposalHandler(&app.FarmKeeper)).
 		AddRoute(evm.RouterKey, evm.NewManageContractDeploymentWhitelistProposalHandler(app.EvmKeeper)).
-		AddRoute(mint.RouterKey, mint.NewManageTreasuresProposalHandler(&app.MintKeeper))
+		AddRoute(mint.RouterKey, mint.NewManageTreasuresProposalHandler(&app.MintKeeper)).
+		AddRoute(erc20.RouterKey, erc20.NewTokenMappingProposalHandler(&app.Erc20Keeper))
 	govProposalHandlerRouter := keeper.NewProposalHandlerRouter()
 	govProposalHandlerRouter.AddRoute(params.RouterKey, &app.ParamsKeeper).
 		AddRoute(dex.RouterKey, &app.DexKeeper).
 		AddRoute(farm.RouterKey, &app.FarmKeeper).
 		AddRoute(evm.RouterKey, app.EvmKeeper).
-		AddRoute(mint.RouterKey, &app.MintKeeper)
+		AddRoute(mint.RouterKey, &app.MintKeeper).
+		AddRoute(erc20.RouterKey, &app.Erc20Keeper)
 	app.GovKeeper = gov.NewKeeper(
 		app.cdc, app.keys[gov.StoreKey], app.ParamsKeeper, app.subspaces[gov.DefaultParamspace],
 		app.SupplyKeeper, &stakingKeeper, gov.DefaultParamspace, govRouter,
@@ -342,6 +358,7 @@ func NewOKExChainApp(
 	app.FarmKeeper.SetGovKeeper(app.GovKeeper)
 	app.EvmKeeper.SetGovKeeper(app.GovKeeper)
 	app.MintKeeper.SetGovKeeper(app.GovKeeper)
+	app.Erc20Keeper.SetGovKeeper(app.GovKeeper)
 
 	// register the staking hooks
 	// NOTE: stakingKeeper above is passed by reference, so that it will contain these hooks
@@ -369,6 +386,7 @@ func NewOKExChainApp(
 		order.NewAppModule(commonversion.ProtocolVersionV0, app.OrderKeeper, app.SupplyKeeper),
 		ammswap.NewAppModule(app.SwapKeeper),
 		farm.NewAppModule(app.FarmKeeper),
+		erc20.NewAppModule(app.Erc20Keeper),
 		params.NewAppModule(app.ParamsKeeper),
 	)
 
@@ -403,11 +421,14 @@ func NewOKExChainApp(
 		auth.ModuleName, distr.ModuleName, staking.ModuleName, bank.ModuleName,
 		slashing.ModuleName, gov.ModuleName, mint.ModuleName, supply.ModuleName,
 		token.ModuleName, dex.ModuleName, order.ModuleName, ammswap.ModuleName, farm.ModuleName,
-		evm.ModuleName, crisis.ModuleName, genutil.ModuleName, params.ModuleName, evidence.ModuleName,
+		evm.ModuleName, erc20.ModuleName, crisis.ModuleName, genutil.ModuleName, params.ModuleName, evidence.ModuleName,
 	)
 
 	app.mm.RegisterInvariants(&app.CrisisKeeper)
 	app.mm.RegisterRoutes(app.Router(), app.QueryRouter())
+	app.EvmKeeper.SetPrecompiles(
+		precompiles.NewPrecompiles(app.Router(), app.StakingKeeper, app.TokenKeeper, app.SwapKeeper)...,
+	)
 
 	// create the simulation manager and define the order of the modules for deterministic simulations
 	//
@@ -430,6 +451,7 @@ func NewOKExChainApp(
 	// initialize stores
 	app.MountKVStores(keys)
 	app.MountTransientStores(tkeys)
+	app.SetStoreUpgradeVersion(keys[erc20.StoreKey], Erc20StoreUpgradeHeight())
 
 	// initialize BaseApp
 	app.SetInitChainer(app.InitChainer)
@@ -438,6 +460,7 @@ func NewOKExChainApp(
 	app.SetEndBlocker(app.EndBlocker)
 	app.SetGasRefundHandler(refund.NewGasRefundHandler(app.AccountKeeper, app.SupplyKeeper))
 	app.SetAccHandler(NewAccHandler(app.AccountKeeper))
+	app.SetStateOverrideHandler(NewStateOverrideHandler(app.EvmKeeper))
 	app.SetParallelTxHandlers(updateFeeCollectorHandler(app.BankKeeper, app.SupplyKeeper), evmTxFeeHandler(), fixLogForParallelTxHandler(app.EvmKeeper))
 
 	if loadLatest {
@@ -547,6 +570,16 @@ func GetMaccPerms() map[string][]string {
 	return dupMaccPerms
 }
 
+// Erc20StoreUpgradeHeight returns the height from which the erc20 store is
+// committed, which is the earth milestone. The store is never committed if the
+// milestone is not enabled.
+func Erc20StoreUpgradeHeight() int64 {
+	if earthHeight := tmtypes.GetEarthHeight(); earthHeight != 0 {
+		return earthHeight
+	}
+	return math.MaxInt64
+}
+
 func validateMsgHook(orderKeeper order.Keeper) ante.ValidateMsgHandler {
 	return func(newCtx sdk.Context, msgs []sdk.Msg) error {
 
@@ -566,10 +599,22 @@ func validateMsgHook(orderKeeper order.Keeper) ante.ValidateMsgHandler {
 					return wrongMsgErr
 				}
 				err = order.ValidateMsgCancelOrders(newCtx, orderKeeper, assertedMsg)
+			case order.MsgNewTriggerOrder:
+				if len(msgs) > 1 {
+					return wrongMsgErr
+				}
+				err = order.ValidateMsgNewTriggerOrder(newCtx, orderKeeper, assertedMsg)
+			case order.MsgCancelTriggerOrder:
+				if len(msgs) > 1 {
+					return wrongMsgErr
+				}
+				err = order.ValidateMsgCancelTriggerOrder(newCtx, orderKeeper, assertedMsg)
 			case evmtypes.MsgEthereumTx:
 				if len(msgs) > 1 {
 					return wrongMsgErr
 				}
+			case ammswap.MsgTokenToToken:
+				err = assertedMsg.ValidateSwapPath(newCtx.BlockHeight())
 			}
 
 			if err != nil {
@@ -588,6 +633,17 @@ func NewAccHandler(ak auth.AccountKeeper) sdk.AccHandler {
 	}
 }
 
+// NewStateOverrideHandler applies the evm state overrides of a simulated call, see eth_call
+func NewStateOverrideHandler(ek *evm.Keeper) sdk.StateOverrideHandler {
+	return func(ctx sdk.Context, overrides []byte) error {
+		var stateOverrides evmtypes.StateOverrides
+		if err := json.Unmarshal(overrides, &stateOverrides); err != nil {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+		}
+		return ek.ApplyStateOverrides(ctx, stateOverrides)
+	}
+}
+
 func PreRun(ctx *server.Context) error {
 	// set the dynamic config
 	appconfig.RegisterDynamicConfig(ctx.Logger.With("module", "config"))
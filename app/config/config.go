@@ -74,7 +74,7 @@ const (
 	FlagGasLimitBuffer         = "gas-limit-buffer"
 	FlagEnableDynamicGp        = "enable-dynamic-gp"
 	FlagDynamicGpWeight        = "dynamic-gp-weight"
-	FlagEnableWrappedTx       = "enable-wtx"
+	FlagEnableWrappedTx        = "enable-wtx"
 
 	FlagCsTimeoutPropose        = "consensus.timeout_propose"
 	FlagCsTimeoutProposeDelta   = "consensus.timeout_propose_delta"
@@ -156,6 +156,16 @@ func NewOecConfig() *OecConfig {
 		}
 	}
 
+	if path := viper.GetString(FlagDynamicConfigFile); path != "" {
+		fw := NewFileWatcher(path, c)
+		if err := fw.LoadConfig(); err != nil {
+			panic(err)
+		}
+		if err := fw.Watch(); err != nil {
+			panic(err)
+		}
+	}
+
 	return c
 }
 
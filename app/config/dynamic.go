@@ -0,0 +1,274 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/store/iavl"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/spf13/viper"
+)
+
+const (
+	FlagConfigAuditLog = "config.audit-log"
+
+	SourceFile  = "file"
+	SourceAdmin = "admin"
+)
+
+// dynamicKeys are the config items which can be hot-applied by the file watcher and the admin rpc
+var dynamicKeys = []string{
+	FlagMempoolRecheck,
+	FlagMempoolForceRecheckGap,
+	FlagMempoolSize,
+	FlagMempoolFlush,
+	FlagMaxTxNumPerBlock,
+	FlagMaxGasUsedPerBlock,
+	FlagGasLimitBuffer,
+	FlagEnableDynamicGp,
+	FlagDynamicGpWeight,
+	FlagCsTimeoutPropose,
+	FlagCsTimeoutProposeDelta,
+	FlagCsTimeoutPrevote,
+	FlagCsTimeoutPrevoteDelta,
+	FlagCsTimeoutPrecommit,
+	FlagCsTimeoutPrecommitDelta,
+	iavl.FlagIavlCacheSize,
+}
+
+// applyMtx serializes the changes from all the sources, so that the audit log is in order
+var applyMtx sync.Mutex
+
+// AuditEntry is a record of the audit log of the dynamic config changes
+type AuditEntry struct {
+	Time   time.Time `json:"time"`
+	Source string    `json:"source"`
+	Key    string    `json:"key"`
+	Old    string    `json:"old"`
+	New    string    `json:"new"`
+}
+
+// GetDynamicConfigs returns the current values of the hot-applicable config items
+func (c *OecConfig) GetDynamicConfigs() map[string]string {
+	values := make(map[string]string, len(dynamicKeys))
+	for _, key := range dynamicKeys {
+		values[key] = c.getValue(key)
+	}
+	return values
+}
+
+// ApplyChanges validates all the changes first and applies them only if every one of them is valid.
+// Each applied change is written to the audit log.
+func (c *OecConfig) ApplyChanges(source string, changes map[string]string) error {
+	keys := make([]string, 0, len(changes))
+	for key := range changes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	setters := make([]func(), 0, len(keys))
+	for _, key := range keys {
+		if !isDynamicKey(key) {
+			return fmt.Errorf("%s can not be changed dynamically", key)
+		}
+		setter, err := c.parse(key, changes[key])
+		if err != nil {
+			return err
+		}
+		setters = append(setters, setter)
+	}
+
+	applyMtx.Lock()
+	defer applyMtx.Unlock()
+	var entries []AuditEntry
+	for i, key := range keys {
+		old := c.getValue(key)
+		setters[i]()
+		if updated := c.getValue(key); updated != old {
+			entries = append(entries, AuditEntry{
+				Time:   time.Now().UTC(),
+				Source: source,
+				Key:    key,
+				Old:    old,
+				New:    updated,
+			})
+		}
+	}
+	return writeAudit(entries)
+}
+
+func isDynamicKey(key string) bool {
+	for _, k := range dynamicKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *OecConfig) getValue(key string) string {
+	switch key {
+	case FlagMempoolRecheck:
+		return strconv.FormatBool(c.GetMempoolRecheck())
+	case FlagMempoolForceRecheckGap:
+		return strconv.FormatInt(c.GetMempoolForceRecheckGap(), 10)
+	case FlagMempoolSize:
+		return strconv.Itoa(c.GetMempoolSize())
+	case FlagMempoolFlush:
+		return strconv.FormatBool(c.GetMempoolFlush())
+	case FlagMaxTxNumPerBlock:
+		return strconv.FormatInt(c.GetMaxTxNumPerBlock(), 10)
+	case FlagMaxGasUsedPerBlock:
+		return strconv.FormatInt(c.GetMaxGasUsedPerBlock(), 10)
+	case FlagGasLimitBuffer:
+		return strconv.FormatUint(c.GetGasLimitBuffer(), 10)
+	case FlagEnableDynamicGp:
+		return strconv.FormatBool(c.GetEnableDynamicGp())
+	case FlagDynamicGpWeight:
+		return strconv.Itoa(c.GetDynamicGpWeight())
+	case FlagCsTimeoutPropose:
+		return c.GetCsTimeoutPropose().String()
+	case FlagCsTimeoutProposeDelta:
+		return c.GetCsTimeoutProposeDelta().String()
+	case FlagCsTimeoutPrevote:
+		return c.GetCsTimeoutPrevote().String()
+	case FlagCsTimeoutPrevoteDelta:
+		return c.GetCsTimeoutPrevoteDelta().String()
+	case FlagCsTimeoutPrecommit:
+		return c.GetCsTimeoutPrecommit().String()
+	case FlagCsTimeoutPrecommitDelta:
+		return c.GetCsTimeoutPrecommitDelta().String()
+	case iavl.FlagIavlCacheSize:
+		return strconv.Itoa(c.GetIavlCacheSize())
+	}
+	return ""
+}
+
+// parse validates the value of the config item and returns the function to apply it
+func (c *OecConfig) parse(key, value string) (func(), error) {
+	switch key {
+	case FlagMempoolRecheck:
+		r, err := parseBool(key, value)
+		return func() { c.SetMempoolRecheck(r) }, err
+	case FlagMempoolForceRecheckGap:
+		r, err := parseInt(key, value, 1)
+		return func() { c.SetMempoolForceRecheckGap(r) }, err
+	case FlagMempoolSize:
+		r, err := parseInt(key, value, 0)
+		return func() { c.SetMempoolSize(int(r)) }, err
+	case FlagMempoolFlush:
+		r, err := parseBool(key, value)
+		return func() { c.SetMempoolFlush(r) }, err
+	case FlagMaxTxNumPerBlock:
+		r, err := parseInt(key, value, 0)
+		return func() { c.SetMaxTxNumPerBlock(r) }, err
+	case FlagMaxGasUsedPerBlock:
+		r, err := parseInt(key, value, -1)
+		return func() { c.SetMaxGasUsedPerBlock(r) }, err
+	case FlagGasLimitBuffer:
+		r, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %s", key, value)
+		}
+		return func() { c.SetGasLimitBuffer(r) }, nil
+	case FlagEnableDynamicGp:
+		r, err := parseBool(key, value)
+		return func() { c.SetEnableDynamicGp(r) }, err
+	case FlagDynamicGpWeight:
+		r, err := parseInt(key, value, 1)
+		if err == nil && r > 100 {
+			err = fmt.Errorf("%s must be between 1 and 100: %s", key, value)
+		}
+		return func() { c.SetDynamicGpWeight(int(r)) }, err
+	case FlagCsTimeoutPropose:
+		r, err := parseDuration(key, value)
+		return func() { c.SetCsTimeoutPropose(r) }, err
+	case FlagCsTimeoutProposeDelta:
+		r, err := parseDuration(key, value)
+		return func() { c.SetCsTimeoutProposeDelta(r) }, err
+	case FlagCsTimeoutPrevote:
+		r, err := parseDuration(key, value)
+		return func() { c.SetCsTimeoutPrevote(r) }, err
+	case FlagCsTimeoutPrevoteDelta:
+		r, err := parseDuration(key, value)
+		return func() { c.SetCsTimeoutPrevoteDelta(r) }, err
+	case FlagCsTimeoutPrecommit:
+		r, err := parseDuration(key, value)
+		return func() { c.SetCsTimeoutPrecommit(r) }, err
+	case FlagCsTimeoutPrecommitDelta:
+		r, err := parseDuration(key, value)
+		return func() { c.SetCsTimeoutPrecommitDelta(r) }, err
+	case iavl.FlagIavlCacheSize:
+		r, err := parseInt(key, value, 0)
+		return func() { c.SetIavlCacheSize(int(r)) }, err
+	}
+	return nil, fmt.Errorf("unknown config %s", key)
+}
+
+func parseBool(key, value string) (bool, error) {
+	r, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s: %s", key, value)
+	}
+	return r, nil
+}
+
+func parseInt(key, value string, min int64) (int64, error) {
+	r, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %s", key, value)
+	}
+	if r < min {
+		return 0, fmt.Errorf("%s must not be less than %d: %s", key, min, value)
+	}
+	return r, nil
+}
+
+func parseDuration(key, value string) (time.Duration, error) {
+	r, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %s", key, value)
+	}
+	if r < 0 {
+		return 0, fmt.Errorf("%s must not be negative: %s", key, value)
+	}
+	return r, nil
+}
+
+func writeAudit(entries []AuditEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	logger := confLogger
+	if logger == nil {
+		logger = log.NewNopLogger()
+	}
+	for _, e := range entries {
+		logger.Info("dynamic config changed", "source", e.Source, "key", e.Key, "old", e.Old, "new", e.New)
+	}
+
+	path := viper.GetString(FlagConfigAuditLog)
+	if path == "" {
+		return nil
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open config audit log: %w", err)
+	}
+	defer f.Close()
+	for _, e := range entries {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(append(line, '\n')); err != nil {
+			return fmt.Errorf("failed to write config audit log: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyChanges(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	auditPath := filepath.Join(dir, "audit.log")
+	viper.Set(FlagConfigAuditLog, auditPath)
+	defer viper.Set(FlagConfigAuditLog, "")
+
+	c := &OecConfig{}
+	c.SetMempoolSize(100)
+
+	err = c.ApplyChanges(SourceAdmin, map[string]string{
+		FlagMempoolSize:      "2000",
+		FlagCsTimeoutPropose: "2s",
+		FlagDynamicGpWeight:  "80",
+		FlagMempoolRecheck:   "false",
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2000, c.GetMempoolSize())
+	require.Equal(t, 2*time.Second, c.GetCsTimeoutPropose())
+	require.Equal(t, 80, c.GetDynamicGpWeight())
+	require.Equal(t, "2000", c.GetDynamicConfigs()[FlagMempoolSize])
+
+	// nothing is applied if any item is invalid
+	for _, changes := range []map[string]string{
+		{FlagMempoolSize: "3000", FlagDynamicGpWeight: "101"},
+		{FlagMempoolSize: "3000", FlagCsTimeoutPrevote: "-1s"},
+		{FlagMempoolSize: "3000", FlagMempoolRecheck: "yes"},
+		{FlagMempoolSize: "3000", FlagNodeKeyWhitelist: "id"},
+		{FlagMempoolSize: "3000", "unknown": "1"},
+		{FlagMempoolSize: "-1"},
+	} {
+		require.Error(t, c.ApplyChanges(SourceAdmin, changes))
+		require.Equal(t, 2000, c.GetMempoolSize())
+	}
+
+	// only the changed items are audited
+	f, err := os.Open(auditPath)
+	require.NoError(t, err)
+	defer f.Close()
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e AuditEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		entries = append(entries, e)
+	}
+	require.Len(t, entries, 3)
+	require.Equal(t, FlagCsTimeoutPropose, entries[0].Key)
+	require.Equal(t, FlagDynamicGpWeight, entries[1].Key)
+	require.Equal(t, FlagMempoolSize, entries[2].Key)
+	require.Equal(t, "100", entries[2].Old)
+	require.Equal(t, "2000", entries[2].New)
+	require.Equal(t, SourceAdmin, entries[2].Source)
+}
+
+func TestFileWatcher(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "dynamic.toml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`
+enable-dynamic-gp = true
+iavl-cache-size = 1000
+
+[mempool]
+size = 2000
+recheck = true
+
+[consensus]
+timeout_commit = "1s"
+`), 0644))
+
+	c := &OecConfig{}
+	fw := NewFileWatcher(path, c)
+	// timeout_commit is not a dynamic item
+	require.Error(t, fw.LoadConfig())
+	require.Equal(t, 0, c.GetMempoolSize())
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`
+enable-dynamic-gp = true
+iavl-cache-size = 1000
+
+[mempool]
+size = 2000
+recheck = true
+
+[consensus]
+timeout_propose = "1s"
+`), 0644))
+	require.NoError(t, fw.LoadConfig())
+	require.Equal(t, 2000, c.GetMempoolSize())
+	require.True(t, c.GetMempoolRecheck())
+	require.True(t, c.GetEnableDynamicGp())
+	require.Equal(t, 1000, c.GetIavlCacheSize())
+	require.Equal(t, time.Second, c.GetCsTimeoutPropose())
+
+	if err := fw.Watch(); err != nil {
+		t.Skipf("file watching is unavailable: %v", err)
+	}
+	defer fw.Stop()
+	require.NoError(t, ioutil.WriteFile(path, []byte(`
+[mempool]
+size = 3000
+`), 0644))
+	require.Eventually(t, func() bool {
+		applyMtx.Lock()
+		defer applyMtx.Unlock()
+		return c.GetMempoolSize() == 3000
+	}, 5*time.Second, 50*time.Millisecond)
+}
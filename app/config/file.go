@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+// FlagDynamicConfigFile is the path of a toml or yaml overlay of the dynamic config items,
+// e.g. a toml file with `size = 2000` under `[mempool]`
+const FlagDynamicConfigFile = "config.dynamic-file"
+
+// FileWatcher applies the overlay file on start and every time the file is changed.
+// An overlay with any invalid item is rejected as a whole.
+type FileWatcher struct {
+	path    string
+	oecConf *OecConfig
+	watcher *fsnotify.Watcher
+}
+
+func NewFileWatcher(path string, oecConf *OecConfig) *FileWatcher {
+	return &FileWatcher{
+		path:    filepath.Clean(path),
+		oecConf: oecConf,
+	}
+}
+
+// LoadConfig reads the overlay file and applies it
+func (fw *FileWatcher) LoadConfig() error {
+	v := viper.New()
+	v.SetConfigFile(fw.path)
+	if err := v.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to read dynamic config file: %w", err)
+	}
+
+	changes := make(map[string]string)
+	for _, key := range v.AllKeys() {
+		changes[key] = v.GetString(key)
+	}
+	return fw.oecConf.ApplyChanges(SourceFile, changes)
+}
+
+// Watch hot-applies the overlay file when it is changed. The directory is watched instead of
+// the file, so that editors replacing the file by renaming are also caught.
+func (fw *FileWatcher) Watch() error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to watch dynamic config file: %w", err)
+	}
+	if err := watcher.Add(filepath.Dir(fw.path)); err != nil {
+		watcher.Close()
+		return fmt.Errorf("failed to watch dynamic config file: %w", err)
+	}
+	fw.watcher = watcher
+
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != fw.path || event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+				if err := fw.LoadConfig(); err != nil && confLogger != nil {
+					confLogger.Error("failed to apply dynamic config file", "file", fw.path, "error", err)
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				if confLogger != nil {
+					confLogger.Error("dynamic config file watcher error", "error", err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop stops watching the overlay file
+func (fw *FileWatcher) Stop() error {
+	if fw.watcher == nil {
+		return nil
+	}
+	return fw.watcher.Close()
+}
@@ -21,6 +21,9 @@ import (
 	"github.com/okex/exchain/libs/tendermint/node"
 	"github.com/okex/exchain/libs/tendermint/proxy"
 	sm "github.com/okex/exchain/libs/tendermint/state"
+	"github.com/okex/exchain/libs/tendermint/state/blockindex"
+	blockidxkv "github.com/okex/exchain/libs/tendermint/state/blockindex/kv"
+	blockidxnull "github.com/okex/exchain/libs/tendermint/state/blockindex/null"
 	"github.com/okex/exchain/libs/tendermint/state/txindex"
 	"github.com/okex/exchain/libs/tendermint/state/txindex/kv"
 	"github.com/okex/exchain/libs/tendermint/state/txindex/null"
@@ -120,6 +123,9 @@ func RepairState(ctx *server.Context, onStart bool) {
 
 	err = repairApp.LoadStartVersion(startVersion)
 	panicError(err)
+	// the address and log indexes of the blocks to be replayed are rebuilt while replaying
+	repairApp.EvmKeeper.Watcher.DeleteAddressTxsFromHeight(uint64(startVersion + 1))
+	repairApp.EvmKeeper.Watcher.DeleteLogIndexFromHeight(uint64(startVersion + 1))
 
 	// repair data by apply the latest two blocks
 	doRepair(ctx, state, stateStoreDB, proxyApp, startVersion, latestBlockHeight, dataDir)
@@ -194,26 +200,35 @@ func startEventBusAndIndexerService(config *cfg.Config, eventBus *types.EventBus
 		return err
 	}
 	// Transaction indexing
-	var txIndexer txindex.TxIndexer
+	var (
+		txIndexer    txindex.TxIndexer
+		blockIndexer blockindex.BlockIndexer
+	)
 	switch config.TxIndex.Indexer {
 	case "kv":
 		store, err := openDB(txIndexDB, filepath.Join(config.RootDir, "data"))
 		if err != nil {
 			return err
 		}
+		blockStore := dbm.NewPrefixDB(store, []byte("block_events"))
 		switch {
 		case config.TxIndex.IndexKeys != "":
-			txIndexer = kv.NewTxIndex(store, kv.IndexEvents(splitAndTrimEmpty(config.TxIndex.IndexKeys, ",", " ")))
+			indexKeys := splitAndTrimEmpty(config.TxIndex.IndexKeys, ",", " ")
+			txIndexer = kv.NewTxIndex(store, kv.IndexEvents(indexKeys))
+			blockIndexer = blockidxkv.New(blockStore, blockidxkv.IndexEvents(indexKeys))
 		case config.TxIndex.IndexAllKeys:
 			txIndexer = kv.NewTxIndex(store, kv.IndexAllEvents())
+			blockIndexer = blockidxkv.New(blockStore, blockidxkv.IndexAllEvents())
 		default:
 			txIndexer = kv.NewTxIndex(store)
+			blockIndexer = blockidxkv.New(blockStore)
 		}
 	default:
 		txIndexer = &null.TxIndex{}
+		blockIndexer = &blockidxnull.BlockerIndexer{}
 	}
 
-	indexerService := txindex.NewIndexerService(txIndexer, eventBus)
+	indexerService := txindex.NewIndexerService(txIndexer, blockIndexer, eventBus)
 	indexerService.SetLogger(logger.With("module", "txindex"))
 	if err := indexerService.Start(); err != nil {
 		return err
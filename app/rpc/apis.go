@@ -19,6 +19,7 @@ import (
 	"github.com/okex/exchain/app/crypto/ethsecp256k1"
 	"github.com/okex/exchain/app/rpc/backend"
 	"github.com/okex/exchain/app/rpc/monitor"
+	"github.com/okex/exchain/app/rpc/namespaces/admin"
 	"github.com/okex/exchain/app/rpc/namespaces/debug"
 	"github.com/okex/exchain/app/rpc/namespaces/eth"
 	"github.com/okex/exchain/app/rpc/namespaces/eth/filters"
@@ -36,6 +37,7 @@ const (
 	NetNamespace      = "net"
 	TxpoolNamespace   = "txpool"
 	DebugNamespace    = "debug"
+	AdminNamespace    = "admin"
 
 	apiVersion = "1.0"
 )
@@ -110,6 +112,15 @@ func GetAPIs(clientCtx context.CLIContext, log log.Logger, keys ...ethsecp256k1.
 		})
 	}
 
+	if token := viper.GetString(FlagAdminToken); token != "" {
+		apis = append(apis, rpc.API{
+			Namespace: AdminNamespace,
+			Version:   apiVersion,
+			Service:   admin.NewAPI(token, log),
+			Public:    false,
+		})
+	}
+
 	if viper.GetBool(FlagEnableMonitor) {
 		for _, api := range apis {
 			makeMonitorMetrics(api.Namespace, api.Service)
@@ -45,10 +45,17 @@ type Backend interface {
 	PendingAddressList() ([]string, error)
 	GetPendingNonce(address string) (uint64, error)
 
+	// Used by txpool namespace, queued transactions wait for the nonce gap to be filled
+	QueuedTransactionCnt() (int, error)
+	UserQueuedTransactions(address string, limit int) ([]*rpctypes.Transaction, error)
+	QueuedAddressList() ([]string, error)
+
 	// Used by log filter
 	GetTransactionLogs(txHash common.Hash) ([]*ethtypes.Log, error)
 	BloomStatus() (uint64, uint64)
 	ServiceFilter(ctx context.Context, session *bloombits.MatcherSession)
+	LogIndexRange() (uint64, uint64, bool)
+	GetLogsByIndex(addresses []common.Address, topics []common.Hash, fromBlock, toBlock uint64, cursor *watcher.LogPosition, limit int) ([]*ethtypes.Log, *watcher.LogPosition, error)
 
 	// Used by eip-1898
 	ConvertToBlockNumber(rpctypes.BlockNumberOrHash) (rpctypes.BlockNumber, error)
@@ -233,6 +240,40 @@ func (b *EthermintBackend) GetTransactionLogs(txHash common.Hash) ([]*ethtypes.L
 	return execRes.Logs, nil
 }
 
+// LogIndexRange returns the range of the blocks whose logs are indexed by the watcher
+func (b *EthermintBackend) LogIndexRange() (uint64, uint64, bool) {
+	return b.wrappedBackend.GetLogIndexRange()
+}
+
+// GetLogsByIndex returns the logs emitted by the addresses with the first topics between the blocks, which are
+// found by the log index of the watcher. The returned cursor continues the query if there are more logs.
+func (b *EthermintBackend) GetLogsByIndex(addresses []common.Address, topics []common.Hash, fromBlock, toBlock uint64,
+	cursor *watcher.LogPosition, limit int) ([]*ethtypes.Log, *watcher.LogPosition, error) {
+	entries, next, err := b.wrappedBackend.GetLogIndexEntries(addresses, topics, fromBlock, toBlock, cursor, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logs := make([]*ethtypes.Log, 0, len(entries))
+	receipts := make(map[common.Hash]*watcher.TransactionReceipt)
+	for _, entry := range entries {
+		receipt, ok := receipts[entry.TxHash]
+		if !ok {
+			if receipt, err = b.wrappedBackend.GetTransactionReceipt(entry.TxHash); err != nil {
+				return nil, nil, fmt.Errorf("failed to get receipt of tx %s: %s", entry.TxHash.Hex(), err)
+			}
+			receipts[entry.TxHash] = receipt
+		}
+		for _, log := range receipt.Logs {
+			if uint64(log.Index) == entry.LogIndex {
+				logs = append(logs, log)
+				break
+			}
+		}
+	}
+	return logs, next, nil
+}
+
 // PendingTransactions returns the transactions that are in the transaction pool
 // and have a from address that is one of the accounts this node manages.
 func (b *EthermintBackend) PendingTransactions() ([]*rpctypes.Transaction, error) {
@@ -290,16 +331,45 @@ func (b *EthermintBackend) GetPendingNonce(address string) (uint64, error) {
 }
 
 func (b *EthermintBackend) UserPendingTransactions(address string, limit int) ([]*rpctypes.Transaction, error) {
-	info, err := b.clientCtx.Client.BlockchainInfo(0, 0)
+	result, err := b.clientCtx.Client.UserUnconfirmedTxs(address, limit)
 	if err != nil {
 		return nil, err
 	}
-	result, err := b.clientCtx.Client.UserUnconfirmedTxs(address, limit)
+	return b.toRPCTransactions(result.Txs)
+}
+
+func (b *EthermintBackend) QueuedTransactionCnt() (int, error) {
+	result, err := b.clientCtx.Client.NumQueuedTxs()
+	if err != nil {
+		return 0, err
+	}
+	return result.Count, nil
+}
+
+func (b *EthermintBackend) UserQueuedTransactions(address string, limit int) ([]*rpctypes.Transaction, error) {
+	result, err := b.clientCtx.Client.UserQueuedTxs(address, limit)
+	if err != nil {
+		return nil, err
+	}
+	return b.toRPCTransactions(result.Txs)
+}
+
+func (b *EthermintBackend) QueuedAddressList() ([]string, error) {
+	res, err := b.clientCtx.Client.GetQueuedAddressList()
+	if err != nil {
+		return nil, err
+	}
+	return res.Addresses, nil
+}
+
+// toRPCTransactions converts the evm txs in the mempool to rpc transactions
+func (b *EthermintBackend) toRPCTransactions(txs []tmtypes.Tx) ([]*rpctypes.Transaction, error) {
+	info, err := b.clientCtx.Client.BlockchainInfo(0, 0)
 	if err != nil {
 		return nil, err
 	}
-	transactions := make([]*rpctypes.Transaction, 0, len(result.Txs))
-	for _, tx := range result.Txs {
+	transactions := make([]*rpctypes.Transaction, 0, len(txs))
+	for _, tx := range txs {
 		ethTx, err := rpctypes.RawTxToEthTx(b.clientCtx, tx)
 		if err != nil {
 			// ignore non Ethermint EVM transactions
@@ -2,15 +2,22 @@ package rpc
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/okex/exchain/app/crypto/ethsecp256k1"
 	"github.com/okex/exchain/app/crypto/hd"
+	"github.com/okex/exchain/app/rpc/graphql"
 	"github.com/okex/exchain/app/rpc/nacos"
+	"github.com/okex/exchain/app/rpc/namespaces/admin"
+	"github.com/okex/exchain/app/rpc/namespaces/eth"
+	"github.com/okex/exchain/app/rpc/namespaces/eth/filters"
 	"github.com/okex/exchain/app/rpc/pendingtx"
+	"github.com/okex/exchain/app/rpc/ratelimit"
 	"github.com/okex/exchain/app/rpc/websockets"
 	"github.com/okex/exchain/libs/cosmos-sdk/client/flags"
 	"github.com/okex/exchain/libs/cosmos-sdk/client/input"
@@ -19,6 +26,7 @@ import (
 	cmserver "github.com/okex/exchain/libs/cosmos-sdk/server"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/okex/exchain/x/evm/watcher"
 
 	"github.com/spf13/viper"
 )
@@ -31,10 +39,27 @@ const (
 	FlagRateLimitAPI          = "rpc.rate-limit-api"
 	FlagRateLimitCount        = "rpc.rate-limit-count"
 	FlagRateLimitBurst        = "rpc.rate-limit-burst"
+	FlagRateLimitIPCount      = "rpc.rate-limit-ip-count"
+	FlagRateLimitIPBurst      = "rpc.rate-limit-ip-burst"
+	FlagRateLimitKeysFile     = "rpc.rate-limit-keys-file"
+	FlagRateLimitMethodCost   = "rpc.rate-limit-method-cost"
+	FlagRateLimitProxies      = "rpc.rate-limit-trusted-proxies"
 	FlagEnableMonitor         = "rpc.enable-monitor"
 	FlagDisableAPI            = "rpc.disable-api"
+	FlagAdminToken            = "rpc.admin-token"
+	FlagGraphQL               = "rpc.graphql"
+	FlagIPCPath               = "rpc.ipc-path"
 	FlagKafkaAddr             = "pendingtx.kafka-addr"
 	FlagKafkaTopic            = "pendingtx.kafka-topic"
+	FlagPendingTxSinks        = "pendingtx.sinks"
+	FlagWebhookURL            = "pendingtx.webhook-url"
+	FlagWebhookBatchSize      = "pendingtx.webhook-batch-size"
+	FlagWebhookFlushInterval  = "pendingtx.webhook-flush-interval"
+	FlagWebhookMaxRetries     = "pendingtx.webhook-max-retries"
+	FlagPendingTxFilePath     = "pendingtx.file-path"
+	FlagPendingTxFileMaxSize  = "pendingtx.file-max-size"
+	FlagPendingTxFileBackups  = "pendingtx.file-max-backups"
+	FlagPendingTxSocketPath   = "pendingtx.socket-path"
 	FlagNacosTmrpcUrls        = "rpc.tmrpc_nacos_urls"
 	FlagNacosTmrpcNamespaceID = "rpc.tmrpc_nacos_namespace_id"
 	FlagNacosTmrpcAppName     = "rpc.tmrpc_application_name"
@@ -44,6 +69,9 @@ const (
 	FlagRestNacosNamespaceId  = "rest.nacos_namespace_id"
 	FlagExternalListenAddr    = "rest.external_laddr"
 
+	// DefaultIPCPath is the name of the ipc socket in the node home
+	DefaultIPCPath = "exchaind.ipc"
+
 	MetricsNamespace = "x"
 	// MetricsSubsystem is a subsystem shared by all metrics exposed by this package.
 	MetricsSubsystem = "rpc"
@@ -96,21 +124,169 @@ func RegisterRoutes(rs *lcd.RestServer) {
 		}
 	}
 
+	// per-client rate limits of both the http and websocket requests
+	limiter, err := newClientLimiter()
+	if err != nil {
+		panic(err)
+	}
+
 	// Web3 RPC API route
-	rs.Mux.HandleFunc("/", server.ServeHTTP).Methods("POST", "OPTIONS")
+	rs.Mux.HandleFunc("/", limiter.Handler(admin.WithAuthToken(server.ServeHTTP))).Methods("POST", "OPTIONS")
+
+	// EIP-1767 graphql route
+	if viper.GetBool(FlagGraphQL) {
+		handler, err := newGraphQLHandler(apis, limiter)
+		if err != nil {
+			panic(err)
+		}
+		rs.Mux.Handle("/graphql", handler).Methods("POST", "OPTIONS")
+	}
 
 	// start websockets server
 	websocketAddr := viper.GetString(flagWebsocket)
-	ws := websockets.NewServer(rs.CliCtx, rs.Logger(), websocketAddr)
+	ws := websockets.NewServer(rs.CliCtx, rs.Logger(), websocketAddr, limiter)
 	ws.Start()
 
+	// ipc endpoint of the local clients, serving the same apis with the subscriptions of the websockets server
+	if endpoint := IPCEndpoint(viper.GetString(flags.FlagHome), viper.GetString(FlagIPCPath)); endpoint != "" {
+		// the rest-server keeps serving without the ipc endpoint, which is unavailable if the path is too long or in
+		// use by another node
+		if _, err := ws.StartIPC(endpoint, server); err != nil {
+			rs.Logger().Error("failed to open the ipc endpoint", "path", endpoint, "error", err)
+		}
+	}
+
 	// pending tx watcher
+	sender, err := newPendingTxSender(rs.Logger())
+	if err != nil {
+		panic(err)
+	}
+	if sender != nil {
+		ptw := pendingtx.NewWatcher(rs.CliCtx, rs.Logger(), sender)
+		ptw.Start()
+	}
+}
+
+// IPCEndpoint returns the path of the ipc socket, a relative path is resolved in the home directory.
+// It returns an empty path if the ipc transport is disabled.
+func IPCEndpoint(home, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(home, path)
+}
+
+// newClientLimiter creates the per-ip and per-api-key rate limiter, it returns nil if no limit is configured
+func newClientLimiter() (*ratelimit.Limiter, error) {
+	costs, err := ratelimit.ParseMethodCosts(viper.GetString(FlagRateLimitMethodCost))
+	if err != nil {
+		return nil, err
+	}
+	config := ratelimit.Config{
+		IPTier: ratelimit.Tier{
+			Rate:  viper.GetFloat64(FlagRateLimitIPCount),
+			Burst: viper.GetInt(FlagRateLimitIPBurst),
+		},
+		MethodCosts:    costs,
+		TrustedProxies: viper.GetInt(FlagRateLimitProxies),
+	}
+	if path := viper.GetString(FlagRateLimitKeysFile); path != "" {
+		keysFile, err := ratelimit.LoadKeysFile(path)
+		if err != nil {
+			return nil, err
+		}
+		config.Tiers, config.Keys = keysFile.Tiers, keysFile.Keys
+	}
+	return ratelimit.NewLimiter(config)
+}
+
+// newGraphQLHandler creates the graphql handler over the eth and filter apis
+func newGraphQLHandler(apis []rpc.API, limiter *ratelimit.Limiter) (*graphql.Handler, error) {
+	var ethAPI *eth.PublicEthereumAPI
+	var filterAPI *filters.PublicFilterAPI
+	for _, api := range apis {
+		switch service := api.Service.(type) {
+		case *eth.PublicEthereumAPI:
+			ethAPI = service
+		case *filters.PublicFilterAPI:
+			filterAPI = service
+		}
+	}
+	if ethAPI == nil || filterAPI == nil {
+		return nil, errors.New("the eth apis are required by the graphql service")
+	}
+	return graphql.NewHandler(ethBackend, ethAPI, filterAPI, watcher.NewQuerier(), limiter)
+}
+
+// newPendingTxSender creates the sinks of the pending tx watcher selected by FlagPendingTxSinks.
+// The kafka sink is used if no sink is selected but the kafka address and topic are set.
+func newPendingTxSender(logger log.Logger) (pendingtx.Sender, error) {
 	kafkaAddrs := viper.GetString(FlagKafkaAddr)
 	kafkaTopic := viper.GetString(FlagKafkaTopic)
-	if kafkaAddrs != "" && kafkaTopic != "" {
-		kafkaClient := pendingtx.NewKafkaClient(strings.Split(kafkaAddrs, ","), kafkaTopic)
-		ptw := pendingtx.NewWatcher(rs.CliCtx, rs.Logger(), kafkaClient)
-		ptw.Start()
+
+	sinks := viper.GetString(FlagPendingTxSinks)
+	if sinks == "" && kafkaAddrs != "" && kafkaTopic != "" {
+		sinks = pendingtx.SinkKafka
+	}
+
+	var senders pendingtx.MultiSender
+	for _, sink := range strings.Split(sinks, ",") {
+		switch strings.TrimSpace(sink) {
+		case "":
+		case pendingtx.SinkKafka:
+			if kafkaAddrs == "" || kafkaTopic == "" {
+				return nil, fmt.Errorf("%s and %s are required by the kafka sink", FlagKafkaAddr, FlagKafkaTopic)
+			}
+			senders = append(senders, pendingtx.NewKafkaClient(strings.Split(kafkaAddrs, ","), kafkaTopic))
+		case pendingtx.SinkWebhook:
+			url := viper.GetString(FlagWebhookURL)
+			if url == "" {
+				return nil, fmt.Errorf("%s is required by the webhook sink", FlagWebhookURL)
+			}
+			if flushInterval := viper.GetDuration(FlagWebhookFlushInterval); flushInterval <= 0 {
+				return nil, fmt.Errorf("%s must be positive, got %s", FlagWebhookFlushInterval, flushInterval)
+			}
+			senders = append(senders, pendingtx.NewWebhookSender(url,
+				viper.GetInt(FlagWebhookBatchSize),
+				viper.GetDuration(FlagWebhookFlushInterval),
+				viper.GetInt(FlagWebhookMaxRetries),
+				logger,
+			))
+		case pendingtx.SinkFile:
+			path := viper.GetString(FlagPendingTxFilePath)
+			if path == "" {
+				return nil, fmt.Errorf("%s is required by the file sink", FlagPendingTxFilePath)
+			}
+			fileSender, err := pendingtx.NewFileSender(path,
+				viper.GetInt64(FlagPendingTxFileMaxSize)*1024*1024,
+				viper.GetInt(FlagPendingTxFileBackups),
+			)
+			if err != nil {
+				return nil, err
+			}
+			senders = append(senders, fileSender)
+		case pendingtx.SinkSocket:
+			path := viper.GetString(FlagPendingTxSocketPath)
+			if path == "" {
+				return nil, fmt.Errorf("%s is required by the socket sink", FlagPendingTxSocketPath)
+			}
+			socketSender, err := pendingtx.NewSocketSender(path, logger)
+			if err != nil {
+				return nil, err
+			}
+			senders = append(senders, socketSender)
+		default:
+			return nil, fmt.Errorf("unknown pending tx sink %q", sink)
+		}
+	}
+
+	switch len(senders) {
+	case 0:
+		return nil, nil
+	case 1:
+		return senders[0], nil
+	default:
+		return senders, nil
 	}
 }
 
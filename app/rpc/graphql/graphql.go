@@ -0,0 +1,1123 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	ethfilters "github.com/ethereum/go-ethereum/eth/filters"
+
+	"github.com/okex/exchain/app/rpc/namespaces/eth/filters"
+	"github.com/okex/exchain/app/rpc/ratelimit"
+	rpctypes "github.com/okex/exchain/app/rpc/types"
+	"github.com/okex/exchain/x/evm/watcher"
+)
+
+// maxBlocks is the max number of the blocks returned by one blocks query
+const maxBlocks = 1000
+
+// Long is a 64 bit unsigned integer, represented as a json number
+type Long int64
+
+// ImplementsGraphQLType returns true if Long implements the provided GraphQL type.
+func (b Long) ImplementsGraphQLType(name string) bool { return name == "Long" }
+
+// UnmarshalGraphQL unmarshals the provided GraphQL query data.
+func (b *Long) UnmarshalGraphQL(input interface{}) error {
+	switch input := input.(type) {
+	case string:
+		value, err := strconv.ParseInt(input, 10, 64)
+		if err != nil {
+			if value, err := hexutil.DecodeUint64(input); err == nil {
+				*b = Long(value)
+				return nil
+			}
+			return err
+		}
+		*b = Long(value)
+	case int32:
+		*b = Long(input)
+	case int64:
+		*b = Long(input)
+	case float64:
+		// the numbers of the json variables are decoded as float64
+		if input != math.Trunc(input) {
+			return fmt.Errorf("invalid Long %v", input)
+		}
+		*b = Long(input)
+	default:
+		return fmt.Errorf("unexpected type %T for Long", input)
+	}
+	return nil
+}
+
+func longPtr(v uint64) *Long {
+	l := Long(v)
+	return &l
+}
+
+// Account represents an Ethereum account at a particular block.
+type Account struct {
+	r             *Resolver
+	address       common.Address
+	blockNrOrHash rpctypes.BlockNumberOrHash
+}
+
+func (a *Account) Address(ctx context.Context) common.Address {
+	return a.address
+}
+
+func (a *Account) Balance(ctx context.Context) (hexutil.Big, error) {
+	if err := a.r.allow(ctx, "eth_getBalance"); err != nil {
+		return hexutil.Big{}, err
+	}
+	balance, err := a.r.ethAPI.GetBalance(a.address, a.blockNrOrHash)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	if balance == nil {
+		return hexutil.Big{}, fmt.Errorf("failed to load balance %s", a.address.Hex())
+	}
+	return *balance, nil
+}
+
+func (a *Account) TransactionCount(ctx context.Context) (Long, error) {
+	if err := a.r.allow(ctx, "eth_getTransactionCount"); err != nil {
+		return 0, err
+	}
+	nonce, err := a.r.ethAPI.GetTransactionCount(a.address, a.blockNrOrHash)
+	if err != nil || nonce == nil {
+		return 0, err
+	}
+	return Long(*nonce), nil
+}
+
+func (a *Account) Code(ctx context.Context) (hexutil.Bytes, error) {
+	if err := a.r.allow(ctx, "eth_getCode"); err != nil {
+		return nil, err
+	}
+	code, err := a.r.ethAPI.GetCode(a.address, a.blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if code == nil {
+		code = hexutil.Bytes{}
+	}
+	return code, nil
+}
+
+func (a *Account) Storage(ctx context.Context, args struct{ Slot common.Hash }) (common.Hash, error) {
+	if err := a.r.allow(ctx, "eth_getStorageAt"); err != nil {
+		return common.Hash{}, err
+	}
+	value, err := a.r.ethAPI.GetStorageAt(a.address, args.Slot.Hex(), a.blockNrOrHash)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(value), nil
+}
+
+// Log represents an individual log message.
+type Log struct {
+	r           *Resolver
+	transaction *Transaction
+	log         *ethtypes.Log
+}
+
+func (l *Log) Transaction(ctx context.Context) *Transaction {
+	return l.transaction
+}
+
+func (l *Log) Account(ctx context.Context, args BlockNumberArgs) *Account {
+	return &Account{r: l.r, address: l.log.Address, blockNrOrHash: args.NumberOrLatest()}
+}
+
+func (l *Log) Index(ctx context.Context) int32 {
+	return int32(l.log.Index)
+}
+
+func (l *Log) Topics(ctx context.Context) []common.Hash {
+	return l.log.Topics
+}
+
+func (l *Log) Data(ctx context.Context) hexutil.Bytes {
+	return l.log.Data
+}
+
+// AccessTuple represents EIP-2930
+type AccessTuple struct {
+	address     common.Address
+	storageKeys []common.Hash
+}
+
+func (at *AccessTuple) Address(ctx context.Context) common.Address {
+	return at.address
+}
+
+func (at *AccessTuple) StorageKeys(ctx context.Context) *[]common.Hash {
+	return &at.storageKeys
+}
+
+// Transaction represents an Ethereum transaction. The hash is mandatory, the others are fetched when required.
+type Transaction struct {
+	r    *Resolver
+	hash common.Hash
+
+	mtx           sync.Mutex
+	tx            *rpctypes.Transaction
+	block         *Block
+	receipt       *watcher.TransactionReceipt
+	receiptLoaded bool
+}
+
+// resolve returns the transaction, fetching it if needed. It returns nil if the transaction is not found.
+func (t *Transaction) resolve(ctx context.Context) (*rpctypes.Transaction, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if t.tx != nil {
+		return t.tx, nil
+	}
+
+	if err := t.r.allow(ctx, "eth_getTransactionByHash"); err != nil {
+		return nil, err
+	}
+	tx, err := t.r.querier.GetTransactionByHash(t.hash)
+	if err != nil {
+		// the transaction isn't saved by the watcher, or it's pending
+		if tx, err = t.r.ethAPI.GetTransactionByHash(t.hash); err != nil {
+			return nil, err
+		}
+	}
+	if tx == nil {
+		return nil, nil
+	}
+	t.tx = tx
+	if t.block == nil && tx.BlockNumber != nil {
+		t.block = t.r.blockByNumber(rpctypes.BlockNumber(tx.BlockNumber.ToInt().Int64()))
+	}
+	return t.tx, nil
+}
+
+// getReceipt returns the receipt of the transaction, it returns nil if the transaction is not mined.
+func (t *Transaction) getReceipt(ctx context.Context) (*watcher.TransactionReceipt, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil || tx.BlockHash == nil {
+		return nil, err
+	}
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if t.receiptLoaded {
+		return t.receipt, nil
+	}
+	if err := t.r.allow(ctx, "eth_getTransactionReceipt"); err != nil {
+		return nil, err
+	}
+	receipt, err := t.r.querier.GetTransactionReceipt(t.hash)
+	if err != nil {
+		if receipt, err = t.r.ethAPI.GetTransactionReceipt(t.hash); err != nil {
+			return nil, err
+		}
+	}
+	t.receipt, t.receiptLoaded = receipt, true
+	return t.receipt, nil
+}
+
+func (t *Transaction) Hash(ctx context.Context) common.Hash {
+	return t.hash
+}
+
+func (t *Transaction) InputData(ctx context.Context) (hexutil.Bytes, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return hexutil.Bytes{}, err
+	}
+	return tx.Input, nil
+}
+
+func (t *Transaction) Gas(ctx context.Context) (Long, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return 0, err
+	}
+	return Long(tx.Gas), nil
+}
+
+func (t *Transaction) GasPrice(ctx context.Context) (hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil || tx.GasPrice == nil {
+		return hexutil.Big{}, err
+	}
+	return *tx.GasPrice, nil
+}
+
+func (t *Transaction) EffectiveGasPrice(ctx context.Context) (*hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil || tx.BlockHash == nil {
+		return nil, err
+	}
+	return tx.GasPrice, nil
+}
+
+func (t *Transaction) MaxFeePerGas(ctx context.Context) (*hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	return tx.MaxFeePerGas, nil
+}
+
+func (t *Transaction) MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	return tx.MaxPriorityFeePerGas, nil
+}
+
+func (t *Transaction) Value(ctx context.Context) (hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return hexutil.Big{}, err
+	}
+	if tx.Value == nil {
+		return hexutil.Big{}, fmt.Errorf("invalid transaction value %s", t.hash.Hex())
+	}
+	return *tx.Value, nil
+}
+
+func (t *Transaction) Nonce(ctx context.Context) (Long, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return 0, err
+	}
+	return Long(tx.Nonce), nil
+}
+
+func (t *Transaction) To(ctx context.Context, args BlockNumberArgs) (*Account, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil || tx.To == nil {
+		return nil, err
+	}
+	return &Account{r: t.r, address: *tx.To, blockNrOrHash: args.NumberOrLatest()}, nil
+}
+
+func (t *Transaction) From(ctx context.Context, args BlockNumberArgs) (*Account, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("transaction %s not found", t.hash.Hex())
+	}
+	return &Account{r: t.r, address: tx.From, blockNrOrHash: args.NumberOrLatest()}, nil
+}
+
+func (t *Transaction) Block(ctx context.Context) (*Block, error) {
+	if _, err := t.resolve(ctx); err != nil {
+		return nil, err
+	}
+	return t.block, nil
+}
+
+func (t *Transaction) Index(ctx context.Context) (*int32, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil || tx.BlockHash == nil || tx.TransactionIndex == nil {
+		return nil, err
+	}
+	index := int32(*tx.TransactionIndex)
+	return &index, nil
+}
+
+func (t *Transaction) Status(ctx context.Context) (*Long, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	return longPtr(uint64(receipt.Status)), nil
+}
+
+func (t *Transaction) GasUsed(ctx context.Context) (*Long, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	return longPtr(uint64(receipt.GasUsed)), nil
+}
+
+func (t *Transaction) CumulativeGasUsed(ctx context.Context) (*Long, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	return longPtr(uint64(receipt.CumulativeGasUsed)), nil
+}
+
+func (t *Transaction) CreatedContract(ctx context.Context, args BlockNumberArgs) (*Account, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil || receipt.ContractAddress == nil {
+		return nil, err
+	}
+	return &Account{r: t.r, address: *receipt.ContractAddress, blockNrOrHash: args.NumberOrLatest()}, nil
+}
+
+func (t *Transaction) Logs(ctx context.Context) (*[]*Log, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	ret := make([]*Log, 0, len(receipt.Logs))
+	for _, log := range receipt.Logs {
+		ret = append(ret, &Log{r: t.r, transaction: t, log: log})
+	}
+	return &ret, nil
+}
+
+func (t *Transaction) Type(ctx context.Context) (*int32, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	txType := int32(tx.Type)
+	return &txType, nil
+}
+
+func (t *Transaction) AccessList(ctx context.Context) (*[]*AccessTuple, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil || tx.Accesses == nil {
+		return nil, err
+	}
+	ret := make([]*AccessTuple, 0, len(*tx.Accesses))
+	for _, al := range *tx.Accesses {
+		ret = append(ret, &AccessTuple{address: al.Address, storageKeys: al.StorageKeys})
+	}
+	return &ret, nil
+}
+
+func (t *Transaction) R(ctx context.Context) (hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil || tx.R == nil {
+		return hexutil.Big{}, err
+	}
+	return *tx.R, nil
+}
+
+func (t *Transaction) S(ctx context.Context) (hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil || tx.S == nil {
+		return hexutil.Big{}, err
+	}
+	return *tx.S, nil
+}
+
+func (t *Transaction) V(ctx context.Context) (hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil || tx.V == nil {
+		return hexutil.Big{}, err
+	}
+	return *tx.V, nil
+}
+
+// blockData is the block with full transactions returned by the backend, which is either a watcher.EthBlock or a
+// map of the same fields
+type blockData struct {
+	Number           hexutil.Uint64          `json:"number"`
+	Hash             hexutil.Bytes           `json:"hash"`
+	ParentHash       hexutil.Bytes           `json:"parentHash"`
+	Nonce            hexutil.Bytes           `json:"nonce"`
+	UncleHash        hexutil.Bytes           `json:"sha3Uncles"`
+	LogsBloom        hexutil.Bytes           `json:"logsBloom"`
+	TransactionsRoot hexutil.Bytes           `json:"transactionsRoot"`
+	StateRoot        hexutil.Bytes           `json:"stateRoot"`
+	Miner            common.Address          `json:"miner"`
+	MixHash          hexutil.Bytes           `json:"mixHash"`
+	Difficulty       hexutil.Uint64          `json:"difficulty"`
+	TotalDifficulty  hexutil.Uint64          `json:"totalDifficulty"`
+	ExtraData        hexutil.Bytes           `json:"extraData"`
+	GasLimit         hexutil.Uint64          `json:"gasLimit"`
+	GasUsed          *hexutil.Big            `json:"gasUsed"`
+	Timestamp        hexutil.Uint64          `json:"timestamp"`
+	ReceiptsRoot     hexutil.Bytes           `json:"receiptsRoot"`
+	Transactions     []*rpctypes.Transaction `json:"transactions"`
+}
+
+// Block represents an Ethereum block. Either the number or the hash is set, a nil number with a zero hash means
+// the latest block. The block is fetched when required.
+type Block struct {
+	r      *Resolver
+	number *rpctypes.BlockNumber
+	hash   common.Hash
+
+	mtx    sync.Mutex
+	block  *blockData
+	loaded bool
+}
+
+// resolve returns the block, fetching it if necessary. It returns nil if the block is not found.
+func (b *Block) resolve(ctx context.Context) (*blockData, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if b.loaded {
+		return b.block, nil
+	}
+
+	var res interface{}
+	var err error
+	if b.hash != (common.Hash{}) {
+		if err = b.r.allow(ctx, "eth_getBlockByHash"); err != nil {
+			return nil, err
+		}
+		res, err = b.r.backend.GetBlockByHash(b.hash, true)
+	} else {
+		number := rpctypes.LatestBlockNumber
+		if b.number != nil {
+			number = *b.number
+		}
+		if err = b.r.allow(ctx, "eth_getBlockByNumber"); err != nil {
+			return nil, err
+		}
+		res, err = b.r.backend.GetBlockByNumber(number, true)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	b.loaded = true
+	// the backend returns a nil map or watcher block if the block is not found
+	bz, err := json.Marshal(res)
+	if err != nil || string(bz) == "null" {
+		return nil, err
+	}
+	block := new(blockData)
+	if err := json.Unmarshal(bz, block); err != nil {
+		return nil, err
+	}
+	b.block = block
+	return b.block, nil
+}
+
+// numberOrHash returns the block number of the account states at the block
+func (b *Block) numberOrHash(ctx context.Context) (rpctypes.BlockNumberOrHash, error) {
+	block, err := b.resolve(ctx)
+	if err != nil {
+		return rpctypes.BlockNumberOrHash{}, err
+	}
+	if block == nil {
+		return rpctypes.BlockNumberOrHash{}, errors.New("block not found")
+	}
+	return rpctypes.BlockNumberOrHashWithNumber(rpctypes.BlockNumber(block.Number)), nil
+}
+
+// mustResolve returns the block, or an error if the block is not found
+func (b *Block) mustResolve(ctx context.Context) (*blockData, error) {
+	block, err := b.resolve(ctx)
+	if err == nil && block == nil {
+		err = errors.New("block not found")
+	}
+	return block, err
+}
+
+func (b *Block) Number(ctx context.Context) (Long, error) {
+	block, err := b.mustResolve(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return Long(block.Number), nil
+}
+
+func (b *Block) Hash(ctx context.Context) (common.Hash, error) {
+	block, err := b.mustResolve(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(block.Hash), nil
+}
+
+func (b *Block) GasLimit(ctx context.Context) (Long, error) {
+	block, err := b.mustResolve(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return Long(block.GasLimit), nil
+}
+
+func (b *Block) GasUsed(ctx context.Context) (Long, error) {
+	block, err := b.mustResolve(ctx)
+	if err != nil || block.GasUsed == nil {
+		return 0, err
+	}
+	return Long(block.GasUsed.ToInt().Uint64()), nil
+}
+
+// BaseFeePerGas returns nil, there is no base fee of the blocks
+func (b *Block) BaseFeePerGas(ctx context.Context) (*hexutil.Big, error) {
+	return nil, nil
+}
+
+func (b *Block) Parent(ctx context.Context) (*Block, error) {
+	block, err := b.mustResolve(ctx)
+	if err != nil || block.Number <= 1 {
+		return nil, err
+	}
+	return &Block{r: b.r, hash: common.BytesToHash(block.ParentHash)}, nil
+}
+
+func (b *Block) Difficulty(ctx context.Context) (hexutil.Big, error) {
+	block, err := b.mustResolve(ctx)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*new(big.Int).SetUint64(uint64(block.Difficulty))), nil
+}
+
+func (b *Block) TotalDifficulty(ctx context.Context) (hexutil.Big, error) {
+	block, err := b.mustResolve(ctx)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*new(big.Int).SetUint64(uint64(block.TotalDifficulty))), nil
+}
+
+func (b *Block) Timestamp(ctx context.Context) (Long, error) {
+	block, err := b.mustResolve(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return Long(block.Timestamp), nil
+}
+
+func (b *Block) Nonce(ctx context.Context) (hexutil.Bytes, error) {
+	block, err := b.mustResolve(ctx)
+	if err != nil {
+		return hexutil.Bytes{}, err
+	}
+	return block.Nonce, nil
+}
+
+func (b *Block) MixHash(ctx context.Context) (common.Hash, error) {
+	block, err := b.mustResolve(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(block.MixHash), nil
+}
+
+func (b *Block) TransactionsRoot(ctx context.Context) (common.Hash, error) {
+	block, err := b.mustResolve(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(block.TransactionsRoot), nil
+}
+
+func (b *Block) StateRoot(ctx context.Context) (common.Hash, error) {
+	block, err := b.mustResolve(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(block.StateRoot), nil
+}
+
+func (b *Block) ReceiptsRoot(ctx context.Context) (common.Hash, error) {
+	block, err := b.mustResolve(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(block.ReceiptsRoot), nil
+}
+
+func (b *Block) OmmerHash(ctx context.Context) (common.Hash, error) {
+	block, err := b.mustResolve(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(block.UncleHash), nil
+}
+
+func (b *Block) OmmerCount(ctx context.Context) (*int32, error) {
+	if _, err := b.mustResolve(ctx); err != nil {
+		return nil, err
+	}
+	count := int32(0)
+	return &count, nil
+}
+
+func (b *Block) Ommers(ctx context.Context) (*[]*Block, error) {
+	if _, err := b.mustResolve(ctx); err != nil {
+		return nil, err
+	}
+	return &[]*Block{}, nil
+}
+
+func (b *Block) OmmerAt(ctx context.Context, args struct{ Index int32 }) (*Block, error) {
+	return nil, nil
+}
+
+func (b *Block) ExtraData(ctx context.Context) (hexutil.Bytes, error) {
+	block, err := b.mustResolve(ctx)
+	if err != nil {
+		return hexutil.Bytes{}, err
+	}
+	if block.ExtraData == nil {
+		return hexutil.Bytes{}, nil
+	}
+	return block.ExtraData, nil
+}
+
+func (b *Block) LogsBloom(ctx context.Context) (hexutil.Bytes, error) {
+	block, err := b.mustResolve(ctx)
+	if err != nil {
+		return hexutil.Bytes{}, err
+	}
+	return block.LogsBloom, nil
+}
+
+// BlockNumberArgs encapsulates arguments to accessors that specify a block number.
+type BlockNumberArgs struct {
+	Block *Long
+}
+
+// NumberOrLatest returns the provided block number argument, or the "latest" block number if none was provided.
+func (a BlockNumberArgs) NumberOrLatest() rpctypes.BlockNumberOrHash {
+	if a.Block != nil {
+		return rpctypes.BlockNumberOrHashWithNumber(rpctypes.BlockNumber(*a.Block))
+	}
+	return rpctypes.BlockNumberOrHashWithNumber(rpctypes.LatestBlockNumber)
+}
+
+func (b *Block) Miner(ctx context.Context, args BlockNumberArgs) (*Account, error) {
+	block, err := b.mustResolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Account{r: b.r, address: block.Miner, blockNrOrHash: args.NumberOrLatest()}, nil
+}
+
+func (b *Block) TransactionCount(ctx context.Context) (*int32, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	count := int32(len(block.Transactions))
+	return &count, nil
+}
+
+func (b *Block) transaction(tx *rpctypes.Transaction) *Transaction {
+	return &Transaction{r: b.r, hash: tx.Hash, tx: tx, block: b}
+}
+
+func (b *Block) Transactions(ctx context.Context) (*[]*Transaction, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	ret := make([]*Transaction, 0, len(block.Transactions))
+	for _, tx := range block.Transactions {
+		ret = append(ret, b.transaction(tx))
+	}
+	return &ret, nil
+}
+
+func (b *Block) TransactionAt(ctx context.Context, args struct{ Index int32 }) (*Transaction, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	if args.Index < 0 || int(args.Index) >= len(block.Transactions) {
+		return nil, nil
+	}
+	return b.transaction(block.Transactions[args.Index]), nil
+}
+
+// BlockFilterCriteria encapsulates criteria passed to a `logs` accessor inside a block.
+type BlockFilterCriteria struct {
+	Addresses *[]common.Address // restricts matches to events created by specific contracts
+	Topics    *[][]common.Hash  // restricts matches to particular event topics
+}
+
+func (b *Block) Logs(ctx context.Context, args struct{ Filter BlockFilterCriteria }) ([]*Log, error) {
+	block, err := b.mustResolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hash := common.BytesToHash(block.Hash)
+	criteria := ethfilters.FilterCriteria{BlockHash: &hash}
+	if args.Filter.Addresses != nil {
+		criteria.Addresses = *args.Filter.Addresses
+	}
+	if args.Filter.Topics != nil {
+		criteria.Topics = *args.Filter.Topics
+	}
+	return b.r.logs(ctx, criteria)
+}
+
+func (b *Block) Account(ctx context.Context, args struct{ Address common.Address }) (*Account, error) {
+	blockNrOrHash, err := b.numberOrHash(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Account{r: b.r, address: args.Address, blockNrOrHash: blockNrOrHash}, nil
+}
+
+// CallData encapsulates arguments to `call` or `estimateGas`. All arguments are optional.
+type CallData struct {
+	From                 *common.Address // The Ethereum address the call is from.
+	To                   *common.Address // The Ethereum address the call is to.
+	Gas                  *Long           // The amount of gas provided for the call.
+	GasPrice             *hexutil.Big    // The price of each unit of gas, in wei.
+	MaxFeePerGas         *hexutil.Big    // The max price of each unit of gas, in wei (1559).
+	MaxPriorityFeePerGas *hexutil.Big    // The max tip of each unit of gas, in wei (1559).
+	Value                *hexutil.Big    // The value sent along with the call.
+	Data                 *hexutil.Bytes  // Any data sent with the call.
+}
+
+// callArgs converts the call data to the args of eth_call, the max fee is the gas price if no gas price is given
+func (c CallData) callArgs() rpctypes.CallArgs {
+	args := rpctypes.CallArgs{
+		From:     c.From,
+		To:       c.To,
+		GasPrice: c.GasPrice,
+		Value:    c.Value,
+		Data:     c.Data,
+	}
+	if c.Gas != nil {
+		gas := hexutil.Uint64(*c.Gas)
+		args.Gas = &gas
+	}
+	if args.GasPrice == nil {
+		args.GasPrice = c.MaxFeePerGas
+	}
+	return args
+}
+
+// CallResult encapsulates the result of an invocation of the `call` accessor.
+type CallResult struct {
+	data    hexutil.Bytes // The return data from the call
+	gasUsed Long          // The amount of gas used
+	status  Long          // The return status of the call - 0 for failure or 1 for success.
+}
+
+func (c *CallResult) Data() hexutil.Bytes {
+	return c.data
+}
+
+func (c *CallResult) GasUsed() Long {
+	return c.gasUsed
+}
+
+func (c *CallResult) Status() Long {
+	return c.status
+}
+
+func (b *Block) Call(ctx context.Context, args struct{ Data CallData }) (*CallResult, error) {
+	blockNrOrHash, err := b.numberOrHash(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return b.r.call(ctx, args.Data, blockNrOrHash)
+}
+
+func (b *Block) EstimateGas(ctx context.Context, args struct{ Data CallData }) (Long, error) {
+	blockNrOrHash, err := b.numberOrHash(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return b.r.estimateGas(ctx, args.Data, blockNrOrHash)
+}
+
+// Pending represents the current pending state.
+type Pending struct {
+	r *Resolver
+}
+
+func (p *Pending) TransactionCount(ctx context.Context) (int32, error) {
+	if err := p.r.allow(ctx, "eth_pendingTransactions"); err != nil {
+		return 0, err
+	}
+	count, err := p.r.backend.PendingTransactionCnt()
+	return int32(count), err
+}
+
+func (p *Pending) Transactions(ctx context.Context) (*[]*Transaction, error) {
+	if err := p.r.allow(ctx, "eth_pendingTransactions"); err != nil {
+		return nil, err
+	}
+	txs, err := p.r.backend.PendingTransactions()
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*Transaction, 0, len(txs))
+	for _, tx := range txs {
+		ret = append(ret, &Transaction{r: p.r, hash: tx.Hash, tx: tx})
+	}
+	return &ret, nil
+}
+
+func (p *Pending) Account(ctx context.Context, args struct{ Address common.Address }) *Account {
+	return &Account{
+		r:             p.r,
+		address:       args.Address,
+		blockNrOrHash: rpctypes.BlockNumberOrHashWithNumber(rpctypes.PendingBlockNumber),
+	}
+}
+
+func (p *Pending) Call(ctx context.Context, args struct{ Data CallData }) (*CallResult, error) {
+	return p.r.call(ctx, args.Data, rpctypes.BlockNumberOrHashWithNumber(rpctypes.PendingBlockNumber))
+}
+
+func (p *Pending) EstimateGas(ctx context.Context, args struct{ Data CallData }) (Long, error) {
+	return p.r.estimateGas(ctx, args.Data, rpctypes.BlockNumberOrHashWithNumber(rpctypes.PendingBlockNumber))
+}
+
+// Resolver is the top-level object in the GraphQL hierarchy.
+type Resolver struct {
+	backend   Backend
+	ethAPI    EthAPI
+	filterAPI FilterAPI
+	querier   Querier
+	limiter   *ratelimit.Limiter
+}
+
+// allow checks the disable-api and rate-limit settings of the json-rpc method serving the same data as the
+// resolver, and takes the cost of the method from the bucket of the client
+func (r *Resolver) allow(ctx context.Context, method string) error {
+	if r.backend.IsDisabled(method) {
+		return fmt.Errorf("%w: %s", filters.ErrMethodNotAllowed, method)
+	}
+	if rateLimiter := r.backend.GetRateLimiter(method); rateLimiter != nil && !rateLimiter.Allow() {
+		return filters.ErrServerBusy
+	}
+	return r.charge(ctx, method)
+}
+
+// charge takes the cost of the method from the bucket of the client
+func (r *Resolver) charge(ctx context.Context, method string) error {
+	client, _ := ctx.Value(clientKey{}).(ratelimit.Client)
+	return r.limiter.Allow(client, []string{method}, ratelimit.TransportGraphQL)
+}
+
+func (r *Resolver) blockByNumber(number rpctypes.BlockNumber) *Block {
+	return &Block{r: r, number: &number}
+}
+
+func (r *Resolver) call(ctx context.Context, data CallData, blockNrOrHash rpctypes.BlockNumberOrHash) (*CallResult, error) {
+	if err := r.allow(ctx, "eth_call"); err != nil {
+		return nil, err
+	}
+	ret, gasUsed, err := r.ethAPI.CallWithGasUsed(data.callArgs(), blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return &CallResult{data: ret, gasUsed: Long(gasUsed), status: 1}, nil
+}
+
+func (r *Resolver) estimateGas(ctx context.Context, data CallData, blockNrOrHash rpctypes.BlockNumberOrHash) (Long, error) {
+	if err := r.allow(ctx, "eth_estimateGas"); err != nil {
+		return 0, err
+	}
+	gas, err := r.ethAPI.EstimateGas(data.callArgs(), &blockNrOrHash, nil)
+	return Long(gas), err
+}
+
+// logs returns the logs found by the filter api, which applies the settings of eth_getLogs itself
+func (r *Resolver) logs(ctx context.Context, criteria ethfilters.FilterCriteria) ([]*Log, error) {
+	if err := r.charge(ctx, "eth_getLogs"); err != nil {
+		return nil, err
+	}
+	logs, err := r.filterAPI.GetLogs(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*Log, 0, len(logs))
+	for _, log := range logs {
+		ret = append(ret, &Log{r: r, transaction: &Transaction{r: r, hash: log.TxHash}, log: log})
+	}
+	return ret, nil
+}
+
+func (r *Resolver) Block(ctx context.Context, args struct {
+	Number *Long
+	Hash   *common.Hash
+}) (*Block, error) {
+	var block *Block
+	if args.Number != nil {
+		if *args.Number < 0 {
+			return nil, nil
+		}
+		block = r.blockByNumber(rpctypes.BlockNumber(*args.Number))
+	} else if args.Hash != nil {
+		block = &Block{r: r, hash: *args.Hash}
+	} else {
+		block = &Block{r: r}
+	}
+	// return nil if the block doesn't exist
+	data, err := block.resolve(ctx)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+func (r *Resolver) Blocks(ctx context.Context, args struct {
+	From *Long
+	To   *Long
+}) ([]*Block, error) {
+	if args.From == nil {
+		return nil, errors.New("from is required")
+	}
+	from := *args.From
+	var to Long
+	if args.To != nil {
+		to = *args.To
+	} else {
+		latest, err := r.backend.BlockNumber()
+		if err != nil {
+			return nil, err
+		}
+		to = Long(latest)
+	}
+	if to < from {
+		return []*Block{}, nil
+	}
+	if to-from >= maxBlocks {
+		return nil, fmt.Errorf("the range of the blocks exceeds the limit of %d", maxBlocks)
+	}
+
+	ret := make([]*Block, 0, to-from+1)
+	for i := from; i <= to; i++ {
+		ret = append(ret, r.blockByNumber(rpctypes.BlockNumber(i)))
+	}
+	return ret, nil
+}
+
+func (r *Resolver) Pending(ctx context.Context) *Pending {
+	return &Pending{r: r}
+}
+
+func (r *Resolver) Transaction(ctx context.Context, args struct{ Hash common.Hash }) (*Transaction, error) {
+	tx := &Transaction{r: r, hash: args.Hash}
+	// return nil if the transaction doesn't exist
+	t, err := tx.resolve(ctx)
+	if err != nil || t == nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+func (r *Resolver) SendRawTransaction(ctx context.Context, args struct{ Data hexutil.Bytes }) (common.Hash, error) {
+	if err := r.allow(ctx, "eth_sendRawTransaction"); err != nil {
+		return common.Hash{}, err
+	}
+	return r.ethAPI.SendRawTransaction(args.Data)
+}
+
+// FilterCriteria encapsulates the arguments to `logs` on the root resolver object.
+type FilterCriteria struct {
+	FromBlock *Long             // beginning of the queried range, nil means latest block
+	ToBlock   *Long             // end of the range, nil means latest block
+	Addresses *[]common.Address // restricts matches to events created by specific contracts
+	Topics    *[][]common.Hash  // restricts matches to particular event topics
+}
+
+func (r *Resolver) Logs(ctx context.Context, args struct{ Filter FilterCriteria }) ([]*Log, error) {
+	var criteria ethfilters.FilterCriteria
+	if args.Filter.FromBlock != nil {
+		criteria.FromBlock = big.NewInt(int64(*args.Filter.FromBlock))
+	}
+	if args.Filter.ToBlock != nil {
+		criteria.ToBlock = big.NewInt(int64(*args.Filter.ToBlock))
+	}
+	if args.Filter.Addresses != nil {
+		criteria.Addresses = *args.Filter.Addresses
+	}
+	if args.Filter.Topics != nil {
+		criteria.Topics = *args.Filter.Topics
+	}
+	return r.logs(ctx, criteria)
+}
+
+func (r *Resolver) GasPrice(ctx context.Context) (hexutil.Big, error) {
+	if err := r.allow(ctx, "eth_gasPrice"); err != nil {
+		return hexutil.Big{}, err
+	}
+	return *r.ethAPI.GasPrice(), nil
+}
+
+func (r *Resolver) MaxPriorityFeePerGas(ctx context.Context) (hexutil.Big, error) {
+	if err := r.allow(ctx, "eth_maxPriorityFeePerGas"); err != nil {
+		return hexutil.Big{}, err
+	}
+	return *r.ethAPI.MaxPriorityFeePerGas(), nil
+}
+
+func (r *Resolver) ChainID(ctx context.Context) (hexutil.Big, error) {
+	if err := r.allow(ctx, "eth_chainId"); err != nil {
+		return hexutil.Big{}, err
+	}
+	chainID, err := r.ethAPI.ChainId()
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*new(big.Int).SetUint64(uint64(chainID))), nil
+}
+
+// SyncState represents the synchronisation status returned from the `syncing` accessor.
+type SyncState struct {
+	progress struct {
+		StartingBlock hexutil.Uint64 `json:"startingBlock"`
+		CurrentBlock  hexutil.Uint64 `json:"currentBlock"`
+		HighestBlock  hexutil.Uint64 `json:"highestBlock"`
+	}
+}
+
+func (s *SyncState) StartingBlock() Long {
+	return Long(s.progress.StartingBlock)
+}
+
+func (s *SyncState) CurrentBlock() Long {
+	return Long(s.progress.CurrentBlock)
+}
+
+func (s *SyncState) HighestBlock() Long {
+	return Long(s.progress.HighestBlock)
+}
+
+func (s *SyncState) PulledStates() *Long {
+	return nil
+}
+
+func (s *SyncState) KnownStates() *Long {
+	return nil
+}
+
+// Syncing returns nil if the node is not syncing, or the progress of the sync
+func (r *Resolver) Syncing(ctx context.Context) (*SyncState, error) {
+	if err := r.allow(ctx, "eth_syncing"); err != nil {
+		return nil, err
+	}
+	progress, err := r.ethAPI.Syncing()
+	if err != nil {
+		return nil, err
+	}
+	if syncing, ok := progress.(bool); ok && !syncing {
+		return nil, nil
+	}
+	// the progress is a map of the fields of SyncState
+	bz, err := json.Marshal(progress)
+	if err != nil {
+		return nil, err
+	}
+	state := new(SyncState)
+	if err := json.Unmarshal(bz, &state.progress); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
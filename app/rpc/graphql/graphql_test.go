@@ -0,0 +1,327 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	ethfilters "github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/okex/exchain/app/rpc/ratelimit"
+	rpctypes "github.com/okex/exchain/app/rpc/types"
+	"github.com/okex/exchain/x/evm/watcher"
+)
+
+var (
+	testFrom     = common.HexToAddress("0x0a")
+	testContract = common.HexToAddress("0x0b")
+	testTxHash   = common.HexToHash("0x01")
+	testTopic    = common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+)
+
+func testBlockHash(number uint64) common.Hash {
+	return common.BigToHash(new(big.Int).SetUint64(0xb000 + number))
+}
+
+type mockBackend struct {
+	latest   uint64
+	disabled map[string]bool
+}
+
+func (b *mockBackend) BlockNumber() (hexutil.Uint64, error) {
+	return hexutil.Uint64(b.latest), nil
+}
+
+func (b *mockBackend) block(number uint64) *watcher.EthBlock {
+	if number == 0 || number > b.latest {
+		return nil
+	}
+	blockHash := testBlockHash(number)
+	var txs []*rpctypes.Transaction
+	if number == 2 {
+		index := hexutil.Uint64(0)
+		txs = append(txs, &rpctypes.Transaction{
+			BlockHash:        &blockHash,
+			BlockNumber:      (*hexutil.Big)(new(big.Int).SetUint64(number)),
+			From:             testFrom,
+			Gas:              21000,
+			GasPrice:         (*hexutil.Big)(big.NewInt(1e9)),
+			Hash:             testTxHash,
+			Input:            hexutil.Bytes{},
+			To:               &testContract,
+			TransactionIndex: &index,
+			Value:            (*hexutil.Big)(big.NewInt(100)),
+			V:                (*hexutil.Big)(big.NewInt(1)),
+			R:                (*hexutil.Big)(big.NewInt(2)),
+			S:                (*hexutil.Big)(big.NewInt(3)),
+		})
+	}
+	return &watcher.EthBlock{
+		Number:       hexutil.Uint64(number),
+		Hash:         blockHash,
+		ParentHash:   testBlockHash(number - 1),
+		Miner:        testFrom,
+		GasLimit:     1000000,
+		GasUsed:      (*hexutil.Big)(big.NewInt(21000)),
+		Timestamp:    hexutil.Uint64(1600000000 + number),
+		Uncles:       []common.Hash{},
+		Transactions: txs,
+	}
+}
+
+func (b *mockBackend) GetBlockByNumber(blockNum rpctypes.BlockNumber, fullTx bool) (interface{}, error) {
+	number := uint64(blockNum)
+	if blockNum == rpctypes.LatestBlockNumber {
+		number = b.latest
+	}
+	if block := b.block(number); block != nil {
+		return block, nil
+	}
+	return nil, nil
+}
+
+func (b *mockBackend) GetBlockByHash(hash common.Hash, fullTx bool) (interface{}, error) {
+	for number := uint64(1); number <= b.latest; number++ {
+		if testBlockHash(number) == hash {
+			return b.block(number), nil
+		}
+	}
+	return nil, nil
+}
+
+func (b *mockBackend) PendingTransactions() ([]*rpctypes.Transaction, error) {
+	return []*rpctypes.Transaction{{Hash: common.HexToHash("0x02"), From: testFrom}}, nil
+}
+
+func (b *mockBackend) PendingTransactionCnt() (int, error) {
+	return 1, nil
+}
+
+func (b *mockBackend) GetRateLimiter(apiName string) *rate.Limiter {
+	return nil
+}
+
+func (b *mockBackend) IsDisabled(apiName string) bool {
+	return b.disabled[apiName]
+}
+
+type mockEthAPI struct{}
+
+func (mockEthAPI) ChainId() (hexutil.Uint, error) { return 66, nil } // nolint
+
+func (mockEthAPI) GasPrice() *hexutil.Big { return (*hexutil.Big)(big.NewInt(1e9)) }
+
+func (mockEthAPI) MaxPriorityFeePerGas() *hexutil.Big { return (*hexutil.Big)(big.NewInt(1e9)) }
+
+func (mockEthAPI) Syncing() (interface{}, error) { return false, nil }
+
+func (mockEthAPI) GetBalance(address common.Address, blockNrOrHash rpctypes.BlockNumberOrHash) (*hexutil.Big, error) {
+	number, _ := blockNrOrHash.Number()
+	// the balance is the block number of the state
+	return (*hexutil.Big)(big.NewInt(number.Int64())), nil
+}
+
+func (mockEthAPI) GetTransactionCount(address common.Address, blockNrOrHash rpctypes.BlockNumberOrHash) (*hexutil.Uint64, error) {
+	nonce := hexutil.Uint64(7)
+	return &nonce, nil
+}
+
+func (mockEthAPI) GetCode(address common.Address, blockNrOrHash rpctypes.BlockNumberOrHash) (hexutil.Bytes, error) {
+	return hexutil.Bytes{0x60, 0x80}, nil
+}
+
+func (mockEthAPI) GetStorageAt(address common.Address, key string, blockNrOrHash rpctypes.BlockNumberOrHash) (hexutil.Bytes, error) {
+	return common.HexToHash(key).Bytes(), nil
+}
+
+func (mockEthAPI) GetTransactionByHash(hash common.Hash) (*rpctypes.Transaction, error) {
+	return nil, nil
+}
+
+func (mockEthAPI) GetTransactionReceipt(hash common.Hash) (*watcher.TransactionReceipt, error) {
+	return nil, nil
+}
+
+func (mockEthAPI) CallWithGasUsed(args rpctypes.CallArgs, blockNrOrHash rpctypes.BlockNumberOrHash) (hexutil.Bytes, uint64, error) {
+	if args.To == nil {
+		return nil, 0, errors.New("execution reverted")
+	}
+	return hexutil.Bytes{0x01}, 30000, nil
+}
+
+func (mockEthAPI) EstimateGas(args rpctypes.CallArgs, blockNrOrHash *rpctypes.BlockNumberOrHash,
+	overrides *map[common.Address]rpctypes.Account) (hexutil.Uint64, error) {
+	return 21000, nil
+}
+
+func (mockEthAPI) SendRawTransaction(data hexutil.Bytes) (common.Hash, error) {
+	return testTxHash, nil
+}
+
+func testLog() *ethtypes.Log {
+	return &ethtypes.Log{
+		Address:     testContract,
+		Topics:      []common.Hash{testTopic},
+		Data:        []byte{0x01},
+		BlockNumber: 2,
+		TxHash:      testTxHash,
+		BlockHash:   testBlockHash(2),
+	}
+}
+
+type mockFilterAPI struct{}
+
+func (mockFilterAPI) GetLogs(ctx context.Context, criteria ethfilters.FilterCriteria) ([]*ethtypes.Log, error) {
+	if criteria.BlockHash != nil && *criteria.BlockHash != testBlockHash(2) {
+		return nil, nil
+	}
+	return []*ethtypes.Log{testLog()}, nil
+}
+
+type mockQuerier struct{ backend *mockBackend }
+
+func (q mockQuerier) GetTransactionByHash(hash common.Hash) (*rpctypes.Transaction, error) {
+	if hash != testTxHash {
+		return nil, errors.New("not found")
+	}
+	return q.backend.block(2).Transactions.([]*rpctypes.Transaction)[0], nil
+}
+
+func (q mockQuerier) GetTransactionReceipt(hash common.Hash) (*watcher.TransactionReceipt, error) {
+	if hash != testTxHash {
+		return nil, errors.New("not found")
+	}
+	return &watcher.TransactionReceipt{
+		Status:            1,
+		CumulativeGasUsed: 21000,
+		GasUsed:           21000,
+		Logs:              []*ethtypes.Log{testLog()},
+	}, nil
+}
+
+func newTestHandler(t *testing.T, disabled map[string]bool, limiter *ratelimit.Limiter) *Handler {
+	backend := &mockBackend{latest: 3, disabled: disabled}
+	handler, err := NewHandler(backend, mockEthAPI{}, mockFilterAPI{}, mockQuerier{backend}, limiter)
+	require.NoError(t, err)
+	return handler
+}
+
+func query(handler *Handler, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+	req.RemoteAddr = "1.2.3.4:5678"
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+func TestGraphQL(t *testing.T) {
+	handler := newTestHandler(t, nil, nil)
+
+	for i, tt := range []struct {
+		body string
+		want string
+		code int
+	}{
+		{
+			body: `{"query": "{block{number hash parent{number}}}"}`,
+			want: `{"data":{"block":{"number":3,"hash":"0x000000000000000000000000000000000000000000000000000000000000b003","parent":{"number":2}}}}`,
+			code: http.StatusOK,
+		},
+		{
+			body: `{"query": "{block(number: 5){number}}"}`,
+			want: `{"data":{"block":null}}`,
+			code: http.StatusOK,
+		},
+		{
+			body: `{"query": "{block(number: 2){transactionCount transactions{hash index status gasUsed from{address} logs{index topics transaction{hash}}}}}"}`,
+			want: `{"data":{"block":{"transactionCount":1,"transactions":[{"hash":"0x0000000000000000000000000000000000000000000000000000000000000001","index":0,"status":1,"gasUsed":21000,"from":{"address":"0x000000000000000000000000000000000000000a"},"logs":[{"index":0,"topics":["0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"],"transaction":{"hash":"0x0000000000000000000000000000000000000000000000000000000000000001"}}]}]}}}`,
+			code: http.StatusOK,
+		},
+		{
+			// the account states are read at the block
+			body: `{"query": "query($n: Long){block(number: $n){account(address: \"0x000000000000000000000000000000000000000a\"){balance transactionCount}}}", "variables": {"n": 2}}`,
+			want: `{"data":{"block":{"account":{"balance":"0x2","transactionCount":7}}}}`,
+			code: http.StatusOK,
+		},
+		{
+			body: `{"query": "{transaction(hash: \"0x0000000000000000000000000000000000000000000000000000000000000001\"){block{number} value to{code}}}"}`,
+			want: `{"data":{"transaction":{"block":{"number":2},"value":"0x64","to":{"code":"0x6080"}}}}`,
+			code: http.StatusOK,
+		},
+		{
+			body: `{"query": "{blocks(from: 2){number} logs(filter: {fromBlock: 1, addresses: [\"0x000000000000000000000000000000000000000b\"]}){data account{address}}}"}`,
+			want: `{"data":{"blocks":[{"number":2},{"number":3}],"logs":[{"data":"0x01","account":{"address":"0x000000000000000000000000000000000000000b"}}]}}`,
+			code: http.StatusOK,
+		},
+		{
+			body: `{"query": "{block(number: 2){call(data: {to: \"0x000000000000000000000000000000000000000b\"}){data gasUsed status} estimateGas(data: {})} pending{transactionCount} chainID}"}`,
+			want: `{"data":{"block":{"call":{"data":"0x01","gasUsed":30000,"status":1},"estimateGas":21000},"pending":{"transactionCount":1},"chainID":"0x42"}}`,
+			code: http.StatusOK,
+		},
+		{
+			body: `{"query": "mutation{sendRawTransaction(data: \"0x01\")}"}`,
+			want: `{"data":{"sendRawTransaction":"0x0000000000000000000000000000000000000000000000000000000000000001"}}`,
+			code: http.StatusOK,
+		},
+	} {
+		w := query(handler, tt.body)
+		require.Equal(t, tt.code, w.Code, "testcase %d: %s", i, w.Body.String())
+		require.JSONEq(t, tt.want, w.Body.String(), "testcase %d", i)
+	}
+
+	w := query(handler, `{"query": "{blocks(from: 1, to: 5000){number}}"}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "exceeds the limit")
+}
+
+func TestGraphQLDisabledAPI(t *testing.T) {
+	handler := newTestHandler(t, map[string]bool{"eth_getBalance": true}, nil)
+
+	w := query(handler, `{"query": "{block{number account(address: \"0x000000000000000000000000000000000000000a\"){balance}}}"}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "the method is not allowed: eth_getBalance")
+
+	w = query(handler, `{"query": "{block{number}}"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestGraphQLRateLimit(t *testing.T) {
+	limiter, err := ratelimit.NewLimiter(ratelimit.Config{
+		IPTier: ratelimit.Tier{Rate: 0.001, Burst: 5},
+		Tiers:  map[string]ratelimit.Tier{"pro": {Rate: 0.001, Burst: 100}},
+		Keys:   map[string]string{"secret": "pro"},
+	})
+	require.NoError(t, err)
+	handler := newTestHandler(t, nil, limiter)
+
+	// the request and every block cost one token of the bucket of the client
+	w := query(handler, `{"query": "{blocks(from: 1, to: 3){number}}"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	w = query(handler, `{"query": "{blocks(from: 1, to: 3){number}}"}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), ratelimit.ErrLimitExceeded.Error())
+	w = query(handler, `{"query": "{block{number}}"}`)
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+
+	// the api keys have their own buckets
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query": "{block{number}}"}`))
+	req.RemoteAddr = "1.2.3.4:5678"
+	req.Header.Set(ratelimit.HeaderAPIKey, "secret")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	req.Header.Set(ratelimit.HeaderAPIKey, "wrong")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,135 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	ethfilters "github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/graph-gophers/graphql-go"
+	"golang.org/x/time/rate"
+
+	"github.com/okex/exchain/app/rpc/ratelimit"
+	rpctypes "github.com/okex/exchain/app/rpc/types"
+	"github.com/okex/exchain/x/evm/watcher"
+)
+
+const (
+	// Method is the name of a graphql request in the per-client rate limits, every request costs the cost of Method
+	// besides the costs of the json-rpc methods serving its fields
+	Method = "graphql"
+
+	// the limits of the query complexity
+	maxQueryDepth   = 10
+	maxParallelism  = 10
+	maxRequestBytes = 1024 * 1024
+)
+
+// Backend is the chain data required by the graphql service, implemented by backend.EthermintBackend
+type Backend interface {
+	BlockNumber() (hexutil.Uint64, error)
+	GetBlockByNumber(blockNum rpctypes.BlockNumber, fullTx bool) (interface{}, error)
+	GetBlockByHash(hash common.Hash, fullTx bool) (interface{}, error)
+	PendingTransactions() ([]*rpctypes.Transaction, error)
+	PendingTransactionCnt() (int, error)
+	GetRateLimiter(apiName string) *rate.Limiter
+	IsDisabled(apiName string) bool
+}
+
+// EthAPI serves the accounts, calls and transactions, implemented by eth.PublicEthereumAPI
+type EthAPI interface {
+	ChainId() (hexutil.Uint, error)
+	GasPrice() *hexutil.Big
+	MaxPriorityFeePerGas() *hexutil.Big
+	Syncing() (interface{}, error)
+	GetBalance(address common.Address, blockNrOrHash rpctypes.BlockNumberOrHash) (*hexutil.Big, error)
+	GetTransactionCount(address common.Address, blockNrOrHash rpctypes.BlockNumberOrHash) (*hexutil.Uint64, error)
+	GetCode(address common.Address, blockNrOrHash rpctypes.BlockNumberOrHash) (hexutil.Bytes, error)
+	GetStorageAt(address common.Address, key string, blockNrOrHash rpctypes.BlockNumberOrHash) (hexutil.Bytes, error)
+	GetTransactionByHash(hash common.Hash) (*rpctypes.Transaction, error)
+	GetTransactionReceipt(hash common.Hash) (*watcher.TransactionReceipt, error)
+	CallWithGasUsed(args rpctypes.CallArgs, blockNrOrHash rpctypes.BlockNumberOrHash) (hexutil.Bytes, uint64, error)
+	EstimateGas(args rpctypes.CallArgs, blockNrOrHash *rpctypes.BlockNumberOrHash,
+		overrides *map[common.Address]rpctypes.Account) (hexutil.Uint64, error)
+	SendRawTransaction(data hexutil.Bytes) (common.Hash, error)
+}
+
+// FilterAPI serves the logs, implemented by filters.PublicFilterAPI
+type FilterAPI interface {
+	GetLogs(ctx context.Context, criteria ethfilters.FilterCriteria) ([]*ethtypes.Log, error)
+}
+
+// Querier reads the transactions and receipts saved by the watcher in fast-query mode, implemented by
+// watcher.Querier
+type Querier interface {
+	GetTransactionByHash(hash common.Hash) (*rpctypes.Transaction, error)
+	GetTransactionReceipt(hash common.Hash) (*watcher.TransactionReceipt, error)
+}
+
+// clientKey is the context key of the rate-limit client of the request
+type clientKey struct{}
+
+// Handler answers the graphql queries over http
+type Handler struct {
+	schema  *graphql.Schema
+	limiter *ratelimit.Limiter
+}
+
+// NewHandler creates the handler of the EIP-1767 schema. The fields of the queries are checked against the
+// disable-api and rate-limit settings of the json-rpc methods serving the same data.
+func NewHandler(backend Backend, ethAPI EthAPI, filterAPI FilterAPI, querier Querier,
+	limiter *ratelimit.Limiter) (*Handler, error) {
+	resolver := &Resolver{
+		backend:   backend,
+		ethAPI:    ethAPI,
+		filterAPI: filterAPI,
+		querier:   querier,
+		limiter:   limiter,
+	}
+	schema, err := graphql.ParseSchema(schema, resolver,
+		graphql.MaxDepth(maxQueryDepth), graphql.MaxParallelism(maxParallelism))
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{schema: schema, limiter: limiter}, nil
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	client, err := h.limiter.ClientFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if err := h.limiter.Allow(client, []string{Method}, ratelimit.TransportGraphQL); err != nil {
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+		return
+	}
+
+	var params struct {
+		Query         string                 `json:"query"`
+		OperationName string                 `json:"operationName"`
+		Variables     map[string]interface{} `json:"variables"`
+	}
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxRequestBytes)).Decode(&params); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), clientKey{}, client)
+	response := h.schema.Exec(ctx, params.Query, params.OperationName, params.Variables)
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if len(response.Errors) > 0 {
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	_, _ = w.Write(responseJSON)
+}
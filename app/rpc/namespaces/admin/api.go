@@ -0,0 +1,89 @@
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/okex/exchain/app/config"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+)
+
+type tokenKey struct{}
+
+var errUnauthorized = errors.New("unauthorized admin request")
+
+// PrivateAdminAPI is the admin_ prefixed set of APIs to manage the node config at runtime.
+// Every call has to carry the admin token as a bearer token in the Authorization header.
+type PrivateAdminAPI struct {
+	token  string
+	logger log.Logger
+}
+
+// NewAPI creates an instance of the Admin API.
+func NewAPI(token string, log log.Logger) *PrivateAdminAPI {
+	return &PrivateAdminAPI{
+		token:  token,
+		logger: log.With("module", "json-rpc", "namespace", "admin"),
+	}
+}
+
+// WithAuthToken passes the bearer token of the http request to the rpc calls
+func WithAuthToken(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if strings.HasPrefix(auth, "Bearer ") {
+			r = r.WithContext(context.WithValue(r.Context(), tokenKey{}, strings.TrimPrefix(auth, "Bearer ")))
+		}
+		next(w, r)
+	}
+}
+
+// GetConfig returns the current values of the config items which can be changed at runtime.
+func (api *PrivateAdminAPI) GetConfig(ctx context.Context) (map[string]string, error) {
+	if err := api.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return config.GetOecConfig().GetDynamicConfigs(), nil
+}
+
+// SetConfig validates and applies the given config items, then returns the updated values.
+// Nothing is applied if any of the items is invalid.
+func (api *PrivateAdminAPI) SetConfig(ctx context.Context, changes map[string]interface{}) (map[string]string, error) {
+	if err := api.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(changes))
+	for key, value := range changes {
+		switch v := value.(type) {
+		case string:
+			values[key] = v
+		case bool:
+			values[key] = strconv.FormatBool(v)
+		case float64:
+			values[key] = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			return nil, fmt.Errorf("invalid value of %s: %v", key, value)
+		}
+	}
+
+	oecConfig := config.GetOecConfig()
+	if err := oecConfig.ApplyChanges(config.SourceAdmin, values); err != nil {
+		api.logger.Error("failed to set config", "error", err)
+		return nil, err
+	}
+	return oecConfig.GetDynamicConfigs(), nil
+}
+
+func (api *PrivateAdminAPI) authorize(ctx context.Context) error {
+	token, _ := ctx.Value(tokenKey{}).(string)
+	if api.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(api.token)) != 1 {
+		return errUnauthorized
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/okex/exchain/app/config"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorize(t *testing.T) {
+	api := NewAPI("secret", log.NewNopLogger())
+
+	var ctx context.Context
+	handler := WithAuthToken(func(w http.ResponseWriter, r *http.Request) {
+		ctx = r.Context()
+	})
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	handler(httptest.NewRecorder(), req)
+	_, err := api.GetConfig(ctx)
+	require.Equal(t, errUnauthorized, err)
+
+	req.Header.Set("Authorization", "Bearer wrong")
+	handler(httptest.NewRecorder(), req)
+	_, err = api.SetConfig(ctx, map[string]interface{}{config.FlagMempoolSize: float64(10)})
+	require.Equal(t, errUnauthorized, err)
+
+	req.Header.Set("Authorization", "Bearer secret")
+	handler(httptest.NewRecorder(), req)
+	values, err := api.SetConfig(ctx, map[string]interface{}{
+		config.FlagMempoolSize:     float64(10),
+		config.FlagEnableDynamicGp: false,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "10", values[config.FlagMempoolSize])
+	require.Equal(t, "false", values[config.FlagEnableDynamicGp])
+
+	_, err = api.SetConfig(ctx, map[string]interface{}{config.FlagMempoolSize: []int{1}})
+	require.Error(t, err)
+
+	values, err = api.GetConfig(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "10", values[config.FlagMempoolSize])
+}
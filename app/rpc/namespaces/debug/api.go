@@ -2,14 +2,23 @@ package debug
 
 import (
 	"encoding/json"
+	"fmt"
+	"math/big"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/eth/tracers"
 	clientcontext "github.com/okex/exchain/libs/cosmos-sdk/client/context"
+	authclient "github.com/okex/exchain/libs/cosmos-sdk/x/auth/client/utils"
 
 	"github.com/okex/exchain/app/rpc/backend"
+	rpctypes "github.com/okex/exchain/app/rpc/types"
+	ethermint "github.com/okex/exchain/app/types"
+	"github.com/okex/exchain/libs/tendermint/global"
 	"github.com/okex/exchain/libs/tendermint/libs/log"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	evmtypes "github.com/okex/exchain/x/evm/types"
 )
 
 // PublicTxPoolAPI offers and API for the transaction pool. It only operates on data that is non confidential.
@@ -19,6 +28,13 @@ type PublicDebugAPI struct {
 	backend   backend.Backend
 }
 
+// txTraceResult is the result of a single transaction trace of a block
+type txTraceResult struct {
+	TxHash common.Hash `json:"txHash"`
+	Result interface{} `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
 // NewPublicTxPoolAPI creates a new tx pool service that gives information about the transaction pool.
 func NewAPI(clientCtx clientcontext.CLIContext, log log.Logger, backend backend.Backend) *PublicDebugAPI {
 	api := &PublicDebugAPI{
@@ -31,20 +47,172 @@ func NewAPI(clientCtx clientcontext.CLIContext, log log.Logger, backend backend.
 
 // TraceTransaction returns the structured logs created during the execution of EVM
 // and returns them as a JSON object.
-func (api *PublicDebugAPI) TraceTransaction(txHash common.Hash) (interface{}, error) {
-	resTrace, _, err := api.clientCtx.QueryWithData("app/trace", txHash.Bytes())
+func (api *PublicDebugAPI) TraceTransaction(txHash common.Hash, config *tracers.TraceConfig) (interface{}, error) {
+	configBytes, err := marshalTraceConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	queryParams, err := json.Marshal(sdk.QueryTraceTx{TxHash: txHash.Bytes(), TraceConfig: configBytes})
+	if err != nil {
+		return nil, err
+	}
+	resTrace, _, err := api.clientCtx.QueryWithData("app/traceTx", queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.decodeTraceResult(resTrace)
+}
+
+// TraceBlockByNumber returns the structured logs created during the execution of
+// EVM for all the evm transactions in the block.
+func (api *PublicDebugAPI) TraceBlockByNumber(blockNum rpctypes.BlockNumber, config *tracers.TraceConfig) ([]*txTraceResult, error) {
+	height := blockNum.Int64()
+	if blockNum == rpctypes.LatestBlockNumber || blockNum == rpctypes.PendingBlockNumber {
+		latest, err := api.backend.BlockNumber()
+		if err != nil {
+			return nil, err
+		}
+		height = int64(latest)
+	}
+	if height <= 0 {
+		return nil, fmt.Errorf("genesis is not traceable")
+	}
+	return api.traceBlock(height, config)
+}
+
+// TraceBlockByHash returns the structured logs created during the execution of
+// EVM for all the evm transactions in the block.
+func (api *PublicDebugAPI) TraceBlockByHash(hash common.Hash, config *tracers.TraceConfig) ([]*txTraceResult, error) {
+	header, err := api.backend.HeaderByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	return api.traceBlock(header.Number.Int64(), config)
+}
+
+// TraceCall lets you trace a given eth_call. It collects the structured logs created
+// during the execution of EVM on the state of the given block.
+func (api *PublicDebugAPI) TraceCall(args rpctypes.CallArgs, blockNrOrHash rpctypes.BlockNumberOrHash, config *tracers.TraceConfig) (interface{}, error) {
+	blockNum, err := api.backend.ConvertToBlockNumber(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	clientCtx := api.clientCtx
+	// pass the given block height to the context if the height is not pending or latest
+	if !(blockNum == rpctypes.PendingBlockNumber || blockNum == rpctypes.LatestBlockNumber) {
+		clientCtx = api.clientCtx.WithHeight(blockNum.Int64())
+	}
+
+	txBytes, err := api.buildCallTx(args)
+	if err != nil {
+		return nil, err
+	}
+	configBytes, err := marshalTraceConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	queryParams, err := json.Marshal(sdk.QueryTraceCall{TxBytes: txBytes, TraceConfig: configBytes})
 	if err != nil {
 		return nil, err
 	}
 
+	var from common.Address
+	if args.From != nil {
+		from = *args.From
+	}
+	resTrace, _, err := clientCtx.QueryWithData(fmt.Sprintf("app/traceCall/%s", from.String()), queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.decodeTraceResult(resTrace)
+}
+
+func (api *PublicDebugAPI) traceBlock(height int64, config *tracers.TraceConfig) ([]*txTraceResult, error) {
+	configBytes, err := marshalTraceConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	queryParams, err := json.Marshal(sdk.QueryTraceBlock{Height: height, TraceConfig: configBytes})
+	if err != nil {
+		return nil, err
+	}
+	resTrace, _, err := api.clientCtx.QueryWithData("app/traceBlock", queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	var txResults []sdk.TraceTxResult
+	if err := json.Unmarshal(resTrace, &txResults); err != nil {
+		return nil, err
+	}
+	results := make([]*txTraceResult, len(txResults))
+	for i, txResult := range txResults {
+		results[i] = &txTraceResult{
+			TxHash: common.BytesToHash(txResult.TxHash),
+			Error:  txResult.Error,
+		}
+		if len(txResult.Result) == 0 {
+			continue
+		}
+		var decodedResult interface{}
+		if err := json.Unmarshal(txResult.Result, &decodedResult); err != nil {
+			results[i].Error = string(txResult.Result)
+			continue
+		}
+		results[i].Result = decodedResult
+	}
+	return results, nil
+}
+
+func (api *PublicDebugAPI) decodeTraceResult(resTrace []byte) (interface{}, error) {
 	var res sdk.Result
 	if err := api.clientCtx.Codec.UnmarshalBinaryBare(resTrace, &res); err != nil {
 		return nil, err
 	}
 	var decodedResult interface{}
 	if err := json.Unmarshal(res.Data, &decodedResult); err != nil {
-		return nil, err
+		// the tracer reports its error as plain text
+		return nil, fmt.Errorf("%s", string(res.Data))
 	}
 
 	return decodedResult, nil
 }
+
+// buildCallTx generates the tx to be traced, the signature isn't needed
+func (api *PublicDebugAPI) buildCallTx(args rpctypes.CallArgs) ([]byte, error) {
+	gas := uint64(ethermint.DefaultRPCGasLimit)
+	if args.Gas != nil {
+		gas = uint64(*args.Gas)
+	}
+	gasPrice := new(big.Int).SetUint64(ethermint.DefaultGasPrice)
+	if args.GasPrice != nil {
+		gasPrice = args.GasPrice.ToInt()
+	}
+	value := new(big.Int)
+	if args.Value != nil {
+		value = args.Value.ToInt()
+	}
+	var data []byte
+	if args.Data != nil {
+		data = []byte(*args.Data)
+	}
+	msg := evmtypes.NewMsgEthereumTx(0, args.To, value, gas, gasPrice, data)
+
+	var txEncoder sdk.TxEncoder
+	if tmtypes.HigherThanVenus(global.GetGlobalHeight()) {
+		txEncoder = authclient.GetTxEncoder(nil, authclient.WithEthereumTx())
+	} else {
+		txEncoder = authclient.GetTxEncoder(api.clientCtx.Codec)
+	}
+	// rlp encoder need pointer type, amino encoder will first dereference pointers.
+	return txEncoder(&msg)
+}
+
+func marshalTraceConfig(config *tracers.TraceConfig) ([]byte, error) {
+	if config == nil {
+		return nil, nil
+	}
+	return json.Marshal(config)
+}
@@ -0,0 +1,197 @@
+package debug
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/exchain/app/rpc/backend"
+	rpctypes "github.com/okex/exchain/app/rpc/types"
+	clientcontext "github.com/okex/exchain/libs/cosmos-sdk/client/context"
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/libs/tendermint/libs/bytes"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/okex/exchain/libs/tendermint/rpc/client"
+	"github.com/okex/exchain/libs/tendermint/rpc/client/mock"
+	ctypes "github.com/okex/exchain/libs/tendermint/rpc/core/types"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+)
+
+// mockClient records the last query and answers it with value
+type mockClient struct {
+	mock.Client
+	path   *string
+	data   *[]byte
+	height *int64
+	value  []byte
+}
+
+func (c mockClient) ABCIQueryWithOptions(path string, data bytes.HexBytes,
+	opts client.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
+	*c.path, *c.data, *c.height = path, data, opts.Height
+	return &ctypes.ResultABCIQuery{Response: abci.ResponseQuery{Value: c.value}}, nil
+}
+
+type mockBackend struct {
+	backend.Backend
+	latest  uint64
+	headers map[common.Hash]*ethtypes.Header
+}
+
+func (b mockBackend) BlockNumber() (hexutil.Uint64, error) {
+	return hexutil.Uint64(b.latest), nil
+}
+
+func (b mockBackend) HeaderByHash(hash common.Hash) (*ethtypes.Header, error) {
+	header, ok := b.headers[hash]
+	if !ok {
+		return nil, fmt.Errorf("header %s not found", hash)
+	}
+	return header, nil
+}
+
+func (b mockBackend) ConvertToBlockNumber(blockNrOrHash rpctypes.BlockNumberOrHash) (rpctypes.BlockNumber, error) {
+	return *blockNrOrHash.BlockNumber, nil
+}
+
+type debugAPITest struct {
+	*PublicDebugAPI
+	path   string
+	data   []byte
+	height int64
+}
+
+func newDebugAPITest(value []byte, b mockBackend) *debugAPITest {
+	test := &debugAPITest{}
+	cdc := codec.New()
+	evmtypes.RegisterCodec(cdc)
+	clientCtx := clientcontext.NewCLIContext().WithCodec(cdc).WithTrustNode(true).
+		WithClient(mockClient{path: &test.path, data: &test.data, height: &test.height, value: value})
+	test.PublicDebugAPI = NewAPI(clientCtx, log.NewNopLogger(), b)
+	return test
+}
+
+func TestTraceBlock(t *testing.T) {
+	txResults := []sdk.TraceTxResult{
+		{TxHash: common.HexToHash("0x01").Bytes(), Result: []byte(`{"gas":21000}`)},
+		{TxHash: common.HexToHash("0x02").Bytes(), Error: "out of gas"},
+		{TxHash: common.HexToHash("0x03").Bytes(), Result: []byte("execution timeout")},
+	}
+	value, err := json.Marshal(txResults)
+	require.NoError(t, err)
+	blockHash := common.HexToHash("0x07")
+	api := newDebugAPITest(value, mockBackend{
+		latest:  5,
+		headers: map[common.Hash]*ethtypes.Header{blockHash: {Number: big.NewInt(7)}},
+	})
+
+	checkResults := func(results []*txTraceResult) {
+		require.Equal(t, 3, len(results))
+		require.Equal(t, common.HexToHash("0x01"), results[0].TxHash)
+		require.Equal(t, map[string]interface{}{"gas": float64(21000)}, results[0].Result)
+		require.Empty(t, results[0].Error)
+		require.Equal(t, common.HexToHash("0x02"), results[1].TxHash)
+		require.Nil(t, results[1].Result)
+		require.Equal(t, "out of gas", results[1].Error)
+		// the tracer reports its error as plain text
+		require.Nil(t, results[2].Result)
+		require.Equal(t, "execution timeout", results[2].Error)
+	}
+	checkQuery := func(height int64, config []byte) {
+		require.Equal(t, "app/traceBlock", api.path)
+		var queryParams sdk.QueryTraceBlock
+		require.NoError(t, json.Unmarshal(api.data, &queryParams))
+		require.Equal(t, height, queryParams.Height)
+		require.Equal(t, config, queryParams.TraceConfig)
+	}
+
+	results, err := api.TraceBlockByNumber(rpctypes.BlockNumber(3), nil)
+	require.NoError(t, err)
+	checkResults(results)
+	checkQuery(3, nil)
+
+	tracer := "callTracer"
+	config := &tracers.TraceConfig{Tracer: &tracer}
+	configBytes, err := json.Marshal(config)
+	require.NoError(t, err)
+	results, err = api.TraceBlockByNumber(rpctypes.LatestBlockNumber, config)
+	require.NoError(t, err)
+	checkResults(results)
+	checkQuery(5, configBytes)
+
+	results, err = api.TraceBlockByHash(blockHash, config)
+	require.NoError(t, err)
+	checkResults(results)
+	checkQuery(7, configBytes)
+
+	_, err = api.TraceBlockByHash(common.HexToHash("0x08"), config)
+	require.Error(t, err)
+
+	api = newDebugAPITest(value, mockBackend{})
+	_, err = api.TraceBlockByNumber(rpctypes.LatestBlockNumber, nil)
+	require.EqualError(t, err, "genesis is not traceable")
+}
+
+func TestTraceCall(t *testing.T) {
+	value := codec.New().MustMarshalBinaryBare(sdk.Result{Data: []byte(`{"gas":21000}`)})
+	api := newDebugAPITest(value, mockBackend{})
+
+	from, to := common.HexToAddress("0x01"), common.HexToAddress("0x02")
+	data := hexutil.Bytes{0x01}
+	blockNum := rpctypes.BlockNumber(3)
+	tracer := "callTracer"
+	config := &tracers.TraceConfig{Tracer: &tracer}
+	result, err := api.TraceCall(rpctypes.CallArgs{From: &from, To: &to, Data: &data},
+		rpctypes.BlockNumberOrHash{BlockNumber: &blockNum}, config)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"gas": float64(21000)}, result)
+	require.Equal(t, fmt.Sprintf("app/traceCall/%s", from.String()), api.path)
+	require.Equal(t, int64(3), api.height)
+
+	var queryParams sdk.QueryTraceCall
+	require.NoError(t, json.Unmarshal(api.data, &queryParams))
+	configBytes, err := json.Marshal(config)
+	require.NoError(t, err)
+	require.Equal(t, configBytes, queryParams.TraceConfig)
+	var tx evmtypes.MsgEthereumTx
+	require.NoError(t, api.clientCtx.Codec.UnmarshalBinaryLengthPrefixed(queryParams.TxBytes, &tx))
+	require.Equal(t, to, *tx.To())
+	require.Equal(t, []byte(data), tx.Data.Payload)
+
+	// the latest state is traced without the height
+	latest := rpctypes.LatestBlockNumber
+	_, err = api.TraceCall(rpctypes.CallArgs{To: &to}, rpctypes.BlockNumberOrHash{BlockNumber: &latest}, nil)
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("app/traceCall/%s", common.Address{}.String()), api.path)
+	require.Equal(t, int64(0), api.height)
+
+	// the tracer reports its error as plain text
+	api = newDebugAPITest(codec.New().MustMarshalBinaryBare(sdk.Result{Data: []byte("execution timeout")}), mockBackend{})
+	_, err = api.TraceCall(rpctypes.CallArgs{To: &to}, rpctypes.BlockNumberOrHash{BlockNumber: &latest}, nil)
+	require.EqualError(t, err, "execution timeout")
+}
+
+func TestTraceTransaction(t *testing.T) {
+	value := codec.New().MustMarshalBinaryBare(sdk.Result{Data: []byte(`{"gas":21000}`)})
+	api := newDebugAPITest(value, mockBackend{})
+
+	txHash := common.HexToHash("0x01")
+	result, err := api.TraceTransaction(txHash, nil)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"gas": float64(21000)}, result)
+	require.Equal(t, "app/traceTx", api.path)
+
+	var queryParams sdk.QueryTraceTx
+	require.NoError(t, json.Unmarshal(api.data, &queryParams))
+	require.Equal(t, txHash.Bytes(), queryParams.TxHash)
+	require.Empty(t, queryParams.TraceConfig)
+}
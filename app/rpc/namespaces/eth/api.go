@@ -19,7 +19,6 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/rpc"
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/okex/exchain/app"
 	"github.com/okex/exchain/app/config"
@@ -37,6 +36,7 @@ import (
 	cmserver "github.com/okex/exchain/libs/cosmos-sdk/server"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	"github.com/okex/exchain/libs/cosmos-sdk/types/innertx"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
 	authclient "github.com/okex/exchain/libs/cosmos-sdk/x/auth/client/utils"
 	authexported "github.com/okex/exchain/libs/cosmos-sdk/x/auth/exported"
@@ -55,6 +55,9 @@ import (
 const (
 	CacheOfEthCallLru = 40960
 
+	// maxAddressTxsLimit is the max number of the txs returned by eth_getTransactionsByAddress
+	maxAddressTxsLimit = 1000
+
 	FlagEnableMultiCall = "rpc.enable-multi-call"
 )
 
@@ -75,6 +78,8 @@ type PublicEthereumAPI struct {
 	txPool         *TxPool
 	Metrics        map[string]*monitor.RpcMetrics
 	callCache      *lru.Cache
+
+	feeHistoryCache *lru.Cache
 }
 
 // NewAPI creates an instance of the public ETH Web3 API.
@@ -102,6 +107,12 @@ func NewAPI(
 	}
 	api.evmFactory = simulation.NewEvmFactory(clientCtx.ChainID, api.wrappedBackend)
 
+	feeHistoryCache, err := lru.New(CacheOfFeeHistoryLru)
+	if err != nil {
+		panic(err)
+	}
+	api.feeHistoryCache = feeHistoryCache
+
 	if watcher.IsWatcherEnabled() {
 		callCache, err := lru.New(CacheOfEthCallLru)
 		if err != nil {
@@ -765,6 +776,11 @@ func (api *PublicEthereumAPI) SendRawTransaction(data hexutil.Bytes) (common.Has
 		return common.Hash{}, err
 	}
 
+	// the typed transactions can't be encoded by amino before Venus
+	if tx.IsTyped() && !tmtypes.HigherThanVenus(int64(height)) {
+		return common.Hash{}, fmt.Errorf("tx type %d is not supported before Venus height", tx.TxType())
+	}
+
 	txBytes := data
 	if !tmtypes.HigherThanVenus(int64(height)) {
 		txBytes, err = authclient.GetTxEncoder(api.clientCtx.Codec)(tx)
@@ -797,12 +813,12 @@ func (api *PublicEthereumAPI) SendRawTransaction(data hexutil.Bytes) (common.Has
 	return common.HexToHash(res.TxHash), nil
 }
 
-func (api *PublicEthereumAPI) buildKey(args rpctypes.CallArgs) common.Hash {
+func (api *PublicEthereumAPI) buildKey(args rpctypes.CallArgs, blockNr rpctypes.BlockNumber) common.Hash {
 	latest, e := api.wrappedBackend.GetLatestBlockNumber()
 	if e != nil {
 		return common.Hash{}
 	}
-	return sha256.Sum256([]byte(args.String() + strconv.Itoa(int(latest))))
+	return sha256.Sum256([]byte(args.String() + strconv.Itoa(int(latest)) + strconv.FormatInt(blockNr.Int64(), 10)))
 }
 
 func (api *PublicEthereumAPI) getFromCallCache(key common.Hash) ([]byte, bool) {
@@ -831,19 +847,23 @@ func (api *PublicEthereumAPI) addCallCache(key common.Hash, data []byte) {
 }
 
 // Call performs a raw contract call.
-func (api *PublicEthereumAPI) Call(args rpctypes.CallArgs, blockNrOrHash rpctypes.BlockNumberOrHash, _ *map[common.Address]rpctypes.Account) (hexutil.Bytes, error) {
+func (api *PublicEthereumAPI) Call(args rpctypes.CallArgs, blockNrOrHash rpctypes.BlockNumberOrHash, overrides *map[common.Address]rpctypes.Account) (hexutil.Bytes, error) {
 	monitor := monitor.GetMonitor("eth_call", api.logger, api.Metrics).OnBegin()
 	defer monitor.OnEnd("args", args, "block number", blockNrOrHash)
-	key := api.buildKey(args)
-	cacheData, ok := api.getFromCallCache(key)
-	if ok {
-		return cacheData, nil
-	}
 	blockNr, err := api.backend.ConvertToBlockNumber(blockNrOrHash)
 	if err != nil {
 		return nil, err
 	}
-	simRes, err := api.doCall(args, blockNr, big.NewInt(ethermint.DefaultRPCGasLimit), false)
+	// the result of a call with state overrides is not cacheable
+	cacheable := overrides == nil || len(*overrides) == 0
+	key := api.buildKey(args, blockNr)
+	if cacheable {
+		cacheData, ok := api.getFromCallCache(key)
+		if ok {
+			return cacheData, nil
+		}
+	}
+	simRes, err := api.doCall(args, blockNr, big.NewInt(ethermint.DefaultRPCGasLimit), false, overrides)
 	if err != nil {
 		return []byte{}, TransformDataError(err, "eth_call")
 	}
@@ -852,12 +872,35 @@ func (api *PublicEthereumAPI) Call(args rpctypes.CallArgs, blockNrOrHash rpctype
 	if err != nil {
 		return []byte{}, TransformDataError(err, "eth_call")
 	}
-	api.addCallCache(key, data.Ret)
+	if cacheable {
+		api.addCallCache(key, data.Ret)
+	}
 	return data.Ret, nil
 }
 
+// CallWithGasUsed performs a raw contract call like Call, and returns the gas used by the call as well.
+// It isn't cached, and isn't exposed by the json-rpc server as it returns more than one result.
+func (api *PublicEthereumAPI) CallWithGasUsed(args rpctypes.CallArgs, blockNrOrHash rpctypes.BlockNumberOrHash) (hexutil.Bytes, uint64, error) {
+	monitor := monitor.GetMonitor("eth_call", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd("args", args, "block number", blockNrOrHash)
+	blockNr, err := api.backend.ConvertToBlockNumber(blockNrOrHash)
+	if err != nil {
+		return nil, 0, err
+	}
+	simRes, err := api.doCall(args, blockNr, big.NewInt(ethermint.DefaultRPCGasLimit), false, nil)
+	if err != nil {
+		return nil, 0, TransformDataError(err, "eth_call")
+	}
+
+	data, err := evmtypes.DecodeResultData(simRes.Result.Data)
+	if err != nil {
+		return nil, 0, TransformDataError(err, "eth_call")
+	}
+	return data.Ret, simRes.GasInfo.GasUsed, nil
+}
+
 // MultiCall performs multiple raw contract call.
-func (api *PublicEthereumAPI) MultiCall(args []rpctypes.CallArgs, blockNr rpctypes.BlockNumber, _ *map[common.Address]rpctypes.Account) ([]hexutil.Bytes, error) {
+func (api *PublicEthereumAPI) MultiCall(args []rpctypes.CallArgs, blockNr rpctypes.BlockNumber, overrides *map[common.Address]rpctypes.Account) ([]hexutil.Bytes, error) {
 	if !viper.GetBool(FlagEnableMultiCall) {
 		return nil, errors.New("the method is not allowed")
 	}
@@ -868,7 +911,7 @@ func (api *PublicEthereumAPI) MultiCall(args []rpctypes.CallArgs, blockNr rpctyp
 	blockNrOrHash := rpctypes.BlockNumberOrHashWithNumber(blockNr)
 	rets := make([]hexutil.Bytes, 0, len(args))
 	for _, arg := range args {
-		ret, err := api.Call(arg, blockNrOrHash, nil)
+		ret, err := api.Call(arg, blockNrOrHash, overrides)
 		if err != nil {
 			return rets, err
 		}
@@ -881,6 +924,7 @@ func (api *PublicEthereumAPI) MultiCall(args []rpctypes.CallArgs, blockNr rpctyp
 // estimated gas used on the operation or an error if fails.
 func (api *PublicEthereumAPI) doCall(
 	args rpctypes.CallArgs, blockNum rpctypes.BlockNumber, globalGasCap *big.Int, isEstimate bool,
+	overrides *map[common.Address]rpctypes.Account,
 ) (*sdk.SimulationResponse, error) {
 
 	clientCtx := api.clientCtx
@@ -933,10 +977,21 @@ func (api *PublicEthereumAPI) doCall(
 	// Create new call message
 	msg := evmtypes.NewMsgEthereumTx(nonce, args.To, value, gas, gasPrice, data)
 
-	sim := api.evmFactory.BuildSimulator(api)
-	//only worked when fast-query has been enabled
-	if sim != nil {
-		return sim.DoCall(msg, addr.String())
+	// apply the state overrides on top of the chain state served to the simulator
+	var qoc simulation.QueryOnChainProxy = api
+	if overrides != nil {
+		var err error
+		if qoc, err = simulation.NewOverrideProxy(api, *overrides); err != nil {
+			return nil, err
+		}
+	}
+	//only worked when fast-query has been enabled, the simulator serves the latest state only and
+	//the precompiled contracts of the native modules need the app
+	latest := blockNum == rpctypes.PendingBlockNumber || blockNum == rpctypes.LatestBlockNumber
+	if latest && (args.To == nil || !evmtypes.IsPrecompileAddress(*args.To)) {
+		if sim := api.evmFactory.BuildSimulator(qoc); sim != nil {
+			return sim.DoCall(msg, addr.String())
+		}
 	}
 
 	//Generate tx to be used to simulate (signature isn't needed)
@@ -959,7 +1014,20 @@ func (api *PublicEthereumAPI) doCall(
 	// Transaction simulation through query. only pass from when eth_estimateGas.
 	// eth_call's from maybe nil
 	simulatePath := fmt.Sprintf("app/simulate/%s", addr.String())
-	res, _, err := clientCtx.QueryWithData(simulatePath, txBytes)
+	queryData := txBytes
+	if overrides != nil && len(*overrides) > 0 {
+		// the overrides are applied by the app on the state of the queried height
+		overridesBytes, err := json.Marshal(*overrides)
+		if err != nil {
+			return nil, err
+		}
+		queryData, err = json.Marshal(sdk.QuerySimulateWithOverrides{TxBytes: txBytes, StateOverrides: overridesBytes})
+		if err != nil {
+			return nil, err
+		}
+		simulatePath = fmt.Sprintf("app/simulateWithOverrides/%s", addr.String())
+	}
+	res, _, err := clientCtx.QueryWithData(simulatePath, queryData)
 	if err != nil {
 		return nil, err
 	}
@@ -974,12 +1042,21 @@ func (api *PublicEthereumAPI) doCall(
 
 // EstimateGas returns an estimate of gas usage for the given smart contract call.
 // It adds 1,000 gas to the returned value instead of using the gas adjustment
-// param from the SDK.
-func (api *PublicEthereumAPI) EstimateGas(args rpctypes.CallArgs) (hexutil.Uint64, error) {
+// param from the SDK. The block and the state overrides are optional.
+func (api *PublicEthereumAPI) EstimateGas(args rpctypes.CallArgs, blockNrOrHash *rpctypes.BlockNumberOrHash,
+	overrides *map[common.Address]rpctypes.Account) (hexutil.Uint64, error) {
 	monitor := monitor.GetMonitor("eth_estimateGas", api.logger, api.Metrics).OnBegin()
-	defer monitor.OnEnd("args", args)
+	defer monitor.OnEnd("args", args, "block number", blockNrOrHash)
 
-	simResponse, err := api.doCall(args, 0, big.NewInt(ethermint.DefaultRPCGasLimit), true)
+	blockNr := rpctypes.LatestBlockNumber
+	if blockNrOrHash != nil {
+		var err error
+		if blockNr, err = api.backend.ConvertToBlockNumber(*blockNrOrHash); err != nil {
+			return 0, err
+		}
+	}
+
+	simResponse, err := api.doCall(args, blockNr, big.NewInt(ethermint.DefaultRPCGasLimit), true, overrides)
 	if err != nil {
 		return 0, TransformDataError(err, "eth_estimateGas")
 	}
@@ -1236,6 +1313,49 @@ func (api *PublicEthereumAPI) GetTransactionsByBlock(blockNrOrHash rpctypes.Bloc
 	return txs, nil
 }
 
+// GetTransactionsByAddress returns the history of the transactions sent or received by an address, including
+// the transactions which the address takes part in by inner calls.
+func (api *PublicEthereumAPI) GetTransactionsByAddress(address common.Address, fromBlock, toBlock rpctypes.BlockNumber, offset, limit hexutil.Uint) ([]*watcher.AddressTransaction, error) {
+	monitor := monitor.GetMonitor("eth_getTransactionsByAddress", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd("address", address, "from block", fromBlock, "to block", toBlock, "offset", offset, "limit", limit)
+
+	if limit == 0 || limit > maxAddressTxsLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxAddressTxsLimit)
+	}
+	from, err := api.resolveBlockNumber(fromBlock)
+	if err != nil {
+		return nil, err
+	}
+	to, err := api.resolveBlockNumber(toBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	addrTxs, err := api.wrappedBackend.GetTransactionsByAddress(address, uint64(from), uint64(to), uint64(offset), uint64(limit))
+	if err != nil {
+		return nil, err
+	}
+	txs := make([]*watcher.AddressTransaction, 0, len(addrTxs))
+	for _, addrTx := range addrTxs {
+		tx, err := api.wrappedBackend.GetTransactionByHash(addrTx.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transaction %s: %w", addrTx.Hash.Hex(), err)
+		}
+		txs = append(txs, &watcher.AddressTransaction{Transaction: tx, Direction: addrTx.Direction})
+	}
+	return txs, nil
+}
+
+// resolveBlockNumber converts the latest and pending block numbers to the latest height
+func (api *PublicEthereumAPI) resolveBlockNumber(blockNum rpctypes.BlockNumber) (int64, error) {
+	switch blockNum {
+	case rpctypes.LatestBlockNumber, rpctypes.PendingBlockNumber:
+		return api.backend.LatestBlockNumber()
+	default:
+		return blockNum.Int64(), nil
+	}
+}
+
 // GetTransactionReceipt returns the transaction receipt identified by hash.
 func (api *PublicEthereumAPI) GetTransactionReceipt(hash common.Hash) (*watcher.TransactionReceipt, error) {
 	monitor := monitor.GetMonitor("eth_getTransactionReceipt", api.logger, api.Metrics).OnBegin()
@@ -1319,6 +1439,7 @@ func (api *PublicEthereumAPI) GetTransactionReceipt(hash common.Hash) (*watcher.
 		From:              from.String(),
 		To:                ethTx.To(),
 	}
+	receipt.SetTypedTxFields(ethTx)
 
 	return receipt, nil
 }
@@ -1419,6 +1540,7 @@ func (api *PublicEthereumAPI) GetTransactionReceiptsByBlock(blockNrOrHash rpctyp
 			From:             from.String(),
 			To:               ethTx.To(),
 		}
+		receipt.SetTypedTxFields(ethTx)
 		receipts = append(receipts, receipt)
 	}
 
@@ -1582,7 +1704,7 @@ func (api *PublicEthereumAPI) generateFromArgs(args rpctypes.SendTxArgs) (*evmty
 			Value:    args.Value,
 			Data:     &input,
 		}
-		gl, err := api.EstimateGas(callArgs)
+		gl, err := api.EstimateGas(callArgs, nil, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -1687,6 +1809,37 @@ func (api *PublicEthereumAPI) DeleteTxTrace(txHash common.Hash) string {
 	return "delete trace succeed"
 }
 
+// GetInnerTxs returns the internal transactions of a tx by txhash.
+func (api *PublicEthereumAPI) GetInnerTxs(txHash common.Hash) ([]*innertx.InnerTx, error) {
+	monitor := monitor.GetMonitor("eth_getInnerTxs", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd("hash", txHash)
+
+	return evmtypes.GetInnerTxsFromDB(txHash)
+}
+
+// GetBlockInnerTxsByHash returns the internal transactions of all the txs in the block identified by hash.
+func (api *PublicEthereumAPI) GetBlockInnerTxsByHash(hash common.Hash) (*evmtypes.BlockInnerTxs, error) {
+	monitor := monitor.GetMonitor("eth_getBlockInnerTxsByHash", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd("hash", hash)
+
+	return evmtypes.GetBlockInnerTxsByHashFromDB(hash)
+}
+
+// GetBlockInnerTxsByNumber returns the internal transactions of all the txs in the block identified by number.
+func (api *PublicEthereumAPI) GetBlockInnerTxsByNumber(blockNum rpctypes.BlockNumber) (*evmtypes.BlockInnerTxs, error) {
+	monitor := monitor.GetMonitor("eth_getBlockInnerTxsByNumber", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd("number", blockNum)
+
+	height := blockNum.Int64()
+	if blockNum == rpctypes.PendingBlockNumber || blockNum == rpctypes.LatestBlockNumber {
+		var err error
+		if height, err = api.backend.LatestBlockNumber(); err != nil {
+			return nil, err
+		}
+	}
+	return evmtypes.GetBlockInnerTxsByHeightFromDB(height)
+}
+
 func (api *PublicEthereumAPI) saveZeroAccount(address common.Address) {
 	zeroAccount := ethermint.EthAccount{BaseAccount: &auth.BaseAccount{}}
 	zeroAccount.SetAddress(address.Bytes())
@@ -1694,11 +1847,6 @@ func (api *PublicEthereumAPI) saveZeroAccount(address common.Address) {
 	api.watcherBackend.CommitAccountToRpcDb(zeroAccount)
 }
 
-func (e *PublicEthereumAPI) FeeHistory(blockCount rpc.DecimalOrHex, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*rpctypes.FeeHistoryResult, error) {
-	e.logger.Debug("eth_feeHistory")
-	return nil, fmt.Errorf("unsupported rpc function: eth_FeeHistory")
-}
-
 // FillTransaction fills the defaults (nonce, gas, gasPrice or 1559 fields)
 // on a given unsigned transaction, and returns it to the caller for further
 // processing (signing + broadcast).
@@ -0,0 +1,200 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/okex/exchain/app/rpc/monitor"
+	rpctypes "github.com/okex/exchain/app/rpc/types"
+)
+
+const (
+	// CacheOfFeeHistoryLru is the number of processed blocks kept for eth_feeHistory
+	CacheOfFeeHistoryLru = 2048
+
+	// maxFeeHistoryBlockCount is the max number of blocks requested by a single eth_feeHistory
+	maxFeeHistoryBlockCount = 1024
+	// maxFeeHistoryPercentiles is the max number of reward percentiles requested by a single eth_feeHistory
+	maxFeeHistoryPercentiles = 100
+)
+
+// txGasAndReward is the gas used and the reward per gas paid to the proposer of an evm tx
+type txGasAndReward struct {
+	gasUsed uint64
+	reward  *big.Int
+}
+
+// blockFees is the fee data of a block, which is cached and shared by all the percentiles
+type blockFees struct {
+	gasUsedRatio float64
+	// txs are sorted by reward in ascending order
+	txs []txGasAndReward
+}
+
+func newBlockFees(txs []txGasAndReward, blockGasUsed, gasLimit uint64) *blockFees {
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].reward.Cmp(txs[j].reward) < 0
+	})
+	fees := &blockFees{txs: txs}
+	if gasLimit > 0 {
+		fees.gasUsedRatio = float64(blockGasUsed) / float64(gasLimit)
+	}
+	return fees
+}
+
+// rewards returns the reward of every percentile, weighted by the gas used of the txs.
+// percentiles must be in ascending order.
+func (bf *blockFees) rewards(percentiles []float64) []*hexutil.Big {
+	rewards := make([]*hexutil.Big, len(percentiles))
+	if len(bf.txs) == 0 {
+		for i := range rewards {
+			rewards[i] = (*hexutil.Big)(new(big.Int))
+		}
+		return rewards
+	}
+
+	var totalGasUsed uint64
+	for _, tx := range bf.txs {
+		totalGasUsed += tx.gasUsed
+	}
+	var txIndex int
+	sumGasUsed := bf.txs[0].gasUsed
+	for i, p := range percentiles {
+		thresholdGasUsed := uint64(float64(totalGasUsed) * p / 100)
+		for sumGasUsed < thresholdGasUsed && txIndex < len(bf.txs)-1 {
+			txIndex++
+			sumGasUsed += bf.txs[txIndex].gasUsed
+		}
+		rewards[i] = (*hexutil.Big)(new(big.Int).Set(bf.txs[txIndex].reward))
+	}
+	return rewards
+}
+
+func validateRewardPercentiles(percentiles []float64) error {
+	if len(percentiles) > maxFeeHistoryPercentiles {
+		return fmt.Errorf("too many reward percentiles, max %d", maxFeeHistoryPercentiles)
+	}
+	for i, p := range percentiles {
+		if p < 0 || p > 100 {
+			return fmt.Errorf("invalid reward percentile: %f", p)
+		}
+		if i > 0 && p < percentiles[i-1] {
+			return fmt.Errorf("invalid reward percentile #%d: %f < %f", i, p, percentiles[i-1])
+		}
+	}
+	return nil
+}
+
+// FeeHistory returns the fee market history of the blocks up to newestBlock.
+// There is no base fee on the chain, so the base fees are always zero and the
+// rewards are the gas prices paid by the evm txs.
+func (api *PublicEthereumAPI) FeeHistory(blockCount rpc.DecimalOrHex, newestBlock rpctypes.BlockNumber,
+	rewardPercentiles []float64) (*rpctypes.FeeHistoryResult, error) {
+	monitor := monitor.GetMonitor("eth_feeHistory", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd("block count", blockCount, "newest block", newestBlock, "percentiles", rewardPercentiles)
+
+	if blockCount < 1 {
+		return &rpctypes.FeeHistoryResult{OldestBlock: (*hexutil.Big)(new(big.Int))}, nil
+	}
+	if blockCount > maxFeeHistoryBlockCount {
+		blockCount = maxFeeHistoryBlockCount
+	}
+	if err := validateRewardPercentiles(rewardPercentiles); err != nil {
+		return nil, err
+	}
+
+	latest, err := api.backend.BlockNumber()
+	if err != nil {
+		return nil, err
+	}
+	newest := newestBlock.Int64()
+	if newestBlock == rpctypes.LatestBlockNumber || newestBlock == rpctypes.PendingBlockNumber || newest > int64(latest) {
+		newest = int64(latest)
+	}
+	if newest < 1 {
+		return nil, errors.New("no block to report the fee history")
+	}
+	// the genesis block isn't reported
+	if int64(blockCount) > newest {
+		blockCount = rpc.DecimalOrHex(newest)
+	}
+	oldest := newest - int64(blockCount) + 1
+
+	result := &rpctypes.FeeHistoryResult{
+		OldestBlock:  (*hexutil.Big)(big.NewInt(oldest)),
+		BaseFee:      make([]*hexutil.Big, blockCount+1),
+		GasUsedRatio: make([]float64, blockCount),
+	}
+	if len(rewardPercentiles) != 0 {
+		result.Reward = make([][]*hexutil.Big, blockCount)
+	}
+	for i := range result.BaseFee {
+		result.BaseFee[i] = (*hexutil.Big)(new(big.Int))
+	}
+	for i := 0; i < int(blockCount); i++ {
+		fees, err := api.getBlockFees(oldest + int64(i))
+		if err != nil {
+			return nil, err
+		}
+		result.GasUsedRatio[i] = fees.gasUsedRatio
+		if result.Reward != nil {
+			result.Reward[i] = fees.rewards(rewardPercentiles)
+		}
+	}
+	return result, nil
+}
+
+// MaxPriorityFeePerGas returns a suggestion for the gas tip cap of dynamic fee txs.
+// There is no base fee on the chain, so the whole gas price is the tip.
+func (api *PublicEthereumAPI) MaxPriorityFeePerGas() *hexutil.Big {
+	monitor := monitor.GetMonitor("eth_maxPriorityFeePerGas", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd()
+
+	return api.GasPrice()
+}
+
+// getBlockFees returns the fee data of the block at the given height from the cache,
+// or computes it from the stored block and block results
+func (api *PublicEthereumAPI) getBlockFees(height int64) (*blockFees, error) {
+	if fees, ok := api.feeHistoryCache.Get(height); ok {
+		return fees.(*blockFees), nil
+	}
+
+	block, err := api.clientCtx.Client.Block(&height)
+	if err != nil {
+		return nil, err
+	}
+	blockResults, err := api.clientCtx.Client.BlockResults(&height)
+	if err != nil {
+		return nil, err
+	}
+	gasLimit, err := rpctypes.BlockMaxGasFromConsensusParams(api.ctx, api.clientCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	var blockGasUsed uint64
+	var txs []txGasAndReward
+	for i, tx := range block.Block.Txs {
+		if i >= len(blockResults.TxsResults) {
+			break
+		}
+		gasUsed := uint64(blockResults.TxsResults[i].GasUsed)
+		blockGasUsed += gasUsed
+
+		ethTx, err := rpctypes.RawTxToEthTx(api.clientCtx, tx)
+		if err != nil {
+			// skip the tx in case it's not a MsgEthereumTx
+			continue
+		}
+		txs = append(txs, txGasAndReward{gasUsed: gasUsed, reward: ethTx.EffectiveGasPrice()})
+	}
+
+	fees := newBlockFees(txs, blockGasUsed, uint64(gasLimit))
+	api.feeHistoryCache.Add(height, fees)
+	return fees, nil
+}
@@ -0,0 +1,40 @@
+package eth
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockFees_Rewards(t *testing.T) {
+	fees := newBlockFees([]txGasAndReward{
+		{gasUsed: 21000, reward: big.NewInt(300)},
+		{gasUsed: 63000, reward: big.NewInt(100)},
+		{gasUsed: 21000, reward: big.NewInt(200)},
+	}, 210000, 1000000)
+	require.Equal(t, 0.21, fees.gasUsedRatio)
+
+	// the rewards are weighted by the gas used
+	rewards := fees.rewards([]float64{0, 50, 60, 61, 81, 100})
+	expected := []int64{100, 100, 100, 200, 300, 300}
+	require.Equal(t, len(expected), len(rewards))
+	for i, reward := range rewards {
+		require.Equal(t, (*hexutil.Big)(big.NewInt(expected[i])), reward, "percentile #%d", i)
+	}
+
+	// the rewards of an empty block are zero
+	empty := newBlockFees(nil, 0, 0)
+	require.Equal(t, float64(0), empty.gasUsedRatio)
+	require.Equal(t, []*hexutil.Big{(*hexutil.Big)(new(big.Int)), (*hexutil.Big)(new(big.Int))}, empty.rewards([]float64{10, 90}))
+}
+
+func TestValidateRewardPercentiles(t *testing.T) {
+	require.NoError(t, validateRewardPercentiles(nil))
+	require.NoError(t, validateRewardPercentiles([]float64{0, 25, 25, 100}))
+	require.Error(t, validateRewardPercentiles([]float64{-1}))
+	require.Error(t, validateRewardPercentiles([]float64{101}))
+	require.Error(t, validateRewardPercentiles([]float64{50, 25}))
+	require.Error(t, validateRewardPercentiles(make([]float64, maxFeeHistoryPercentiles+1)))
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/bloombits"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth/filters"
@@ -20,6 +21,7 @@ import (
 	coretypes "github.com/okex/exchain/libs/tendermint/rpc/core/types"
 	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	evmtypes "github.com/okex/exchain/x/evm/types"
+	"github.com/okex/exchain/x/evm/watcher"
 
 	"golang.org/x/time/rate"
 )
@@ -37,6 +39,8 @@ type Backend interface {
 	GetTransactionLogs(txHash common.Hash) ([]*ethtypes.Log, error)
 	BloomStatus() (uint64, uint64)
 	ServiceFilter(ctx context.Context, session *bloombits.MatcherSession)
+	LogIndexRange() (uint64, uint64, bool)
+	GetLogsByIndex(addresses []common.Address, topics []common.Hash, fromBlock, toBlock uint64, cursor *watcher.LogPosition, limit int) ([]*ethtypes.Log, *watcher.LogPosition, error)
 	GetBlockHashByHeight(height rpctypes.BlockNumber) (common.Hash, error)
 	GetRateLimiter(apiName string) *rate.Limiter
 	IsDisabled(apiName string) bool
@@ -485,6 +489,66 @@ func (api *PublicFilterAPI) GetLogs(ctx context.Context, criteria filters.Filter
 	return returnLogs(logs), nil
 }
 
+// LogsPage is a page of the logs, the cursor continues the query if there are more logs
+type LogsPage struct {
+	Logs   []*ethtypes.Log `json:"logs"`
+	Cursor *string         `json:"cursor"`
+}
+
+// GetLogsPage returns a page of the logs matching the given argument from the cursor, which are found by the log
+// index. The addresses are required, and the block range is not limited by the height span.
+func (api *PublicFilterAPI) GetLogsPage(criteria filters.FilterCriteria, cursor *string, limit *hexutil.Uint) (*LogsPage, error) {
+	monitor := monitor.GetMonitor("eth_getLogsPage", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd("args", criteria, "cursor", cursor, "limit", limit)
+	if api.backend.IsDisabled("eth_getLogsPage") {
+		return nil, ErrMethodNotAllowed
+	}
+	rateLimiter := api.backend.GetRateLimiter("eth_getLogsPage")
+	if rateLimiter != nil && !rateLimiter.Allow() {
+		return nil, ErrServerBusy
+	}
+	if criteria.BlockHash != nil {
+		return nil, errors.New("blockHash is not supported, use fromBlock and toBlock instead")
+	}
+
+	pageLimit := defaultLogsPageLimit
+	if limit != nil {
+		if *limit == 0 || int(*limit) > maxIndexedLogs {
+			return nil, fmt.Errorf("limit must be between 1 and %d", maxIndexedLogs)
+		}
+		pageLimit = int(*limit)
+	}
+	var pos *watcher.LogPosition
+	if cursor != nil {
+		p, err := watcher.ParseLogCursor(*cursor)
+		if err != nil {
+			return nil, err
+		}
+		pos = &p
+	}
+
+	begin := rpc.LatestBlockNumber.Int64()
+	if criteria.FromBlock != nil {
+		begin = criteria.FromBlock.Int64()
+	}
+	end := rpc.LatestBlockNumber.Int64()
+	if criteria.ToBlock != nil {
+		end = criteria.ToBlock.Int64()
+	}
+	filter := NewRangeFilter(api.backend, begin, end, criteria.Addresses, criteria.Topics)
+	logs, next, err := filter.LogsPage(pos, pageLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &LogsPage{Logs: returnLogs(logs)}
+	if next != nil {
+		c := next.Cursor()
+		page.Cursor = &c
+	}
+	return page, nil
+}
+
 // UninstallFilter removes the filter with the given filter id.
 //
 // https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_uninstallfilter
@@ -27,10 +27,19 @@ import (
 var (
 	txEvents        = tmtypes.QueryForEvent(tmtypes.EventTx).String()
 	pendingtxEvents = tmtypes.QueryForEvent(tmtypes.EventPendingTx).String()
+	rmPendingEvents = tmtypes.QueryForEvent(tmtypes.EventRmPendingTx).String()
 	evmEvents       = tmquery.MustParse(fmt.Sprintf("%s='%s' AND %s.%s='%s'", tmtypes.EventTypeKey, tmtypes.EventTx, sdk.EventTypeMessage, sdk.AttributeKeyModule, evmtypes.ModuleName)).String()
 	headerEvents    = tmtypes.QueryForEvent(tmtypes.EventNewBlockHeader).String()
 )
 
+// RmPendingTransactionsSubscription queries hashes of the txs leaving the mempool without being
+// included in a block. It extends the subscription types of go-ethereum.
+const RmPendingTransactionsSubscription = filters.LastIndexSubscription
+
+// ModuleEventsSubscription queries the events of the native modules emitted in blocks or txs. It extends the
+// subscription types of go-ethereum.
+const ModuleEventsSubscription = RmPendingTransactionsSubscription + 1
+
 // EventSystem creates subscriptions, processes events and broadcasts them to the
 // subscription which match the subscription criteria using the Tendermint's RPC client.
 type EventSystem struct {
@@ -59,7 +68,7 @@ type EventSystem struct {
 // or by stopping the given mux.
 func NewEventSystem(client rpcclient.Client) *EventSystem {
 	index := make(filterIndex)
-	for i := filters.UnknownSubscription; i < filters.LastIndexSubscription; i++ {
+	for i := filters.UnknownSubscription; i <= ModuleEventsSubscription; i++ {
 		index[i] = make(map[rpc.ID]*Subscription)
 	}
 
@@ -99,7 +108,7 @@ func (es *EventSystem) subscribe(sub *Subscription) (*Subscription, context.Canc
 	es.ctx, cancelFn = context.WithTimeout(context.Background(), deadline)
 
 	switch sub.typ {
-	case filters.PendingTransactionsSubscription:
+	case filters.PendingTransactionsSubscription, RmPendingTransactionsSubscription, ModuleEventsSubscription:
 		eventCh, err = es.client.Subscribe(es.ctx, string(sub.id), sub.event, es.channelLength)
 	case filters.PendingLogsSubscription, filters.MinedAndPendingLogsSubscription:
 		eventCh, err = es.client.Subscribe(es.ctx, string(sub.id), sub.event, es.channelLength)
@@ -238,6 +247,38 @@ func (es EventSystem) SubscribePendingTxs() (*Subscription, context.CancelFunc,
 	return es.subscribe(sub)
 }
 
+// SubscribeRmPendingTxs subscribes to the events of txs leaving the mempool without being included
+// in a block.
+func (es EventSystem) SubscribeRmPendingTxs() (*Subscription, context.CancelFunc, error) {
+	sub := &Subscription{
+		id:        rpc.NewID(),
+		typ:       RmPendingTransactionsSubscription,
+		event:     rmPendingEvents,
+		created:   time.Now().UTC(),
+		hashes:    make(chan []common.Hash),
+		installed: make(chan struct{}, 1),
+		err:       make(chan error, 1),
+	}
+	return es.subscribe(sub)
+}
+
+// SubscribeModuleEvents subscribes to the tendermint events matching the query, which selects the events of the
+// native modules.
+func (es EventSystem) SubscribeModuleEvents(query string) (*Subscription, context.CancelFunc, error) {
+	if _, err := tmquery.New(query); err != nil {
+		return nil, nil, err
+	}
+	sub := &Subscription{
+		id:        rpc.NewID(),
+		typ:       ModuleEventsSubscription,
+		event:     query,
+		created:   time.Now().UTC(),
+		installed: make(chan struct{}, 1),
+		err:       make(chan error, 1),
+	}
+	return es.subscribe(sub)
+}
+
 type filterIndex map[filters.Type]map[rpc.ID]*Subscription
 
 func (es *EventSystem) handleLogs(ev coretypes.ResultEvent) {
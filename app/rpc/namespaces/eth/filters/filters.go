@@ -11,11 +11,20 @@ import (
 	"github.com/ethereum/go-ethereum/eth/filters"
 	rpctypes "github.com/okex/exchain/app/rpc/types"
 	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/evm/watcher"
 	"github.com/spf13/viper"
 )
 
 const FlagGetLogsHeightSpan = "logs-height-span"
 
+const (
+	// maxIndexedLogs is the max number of the logs returned by eth_getLogs from the log index, the logs of a wider
+	// query are returned by pages
+	maxIndexedLogs = 10000
+	// defaultLogsPageLimit is the default number of the logs of a page
+	defaultLogsPageLimit = 1000
+)
+
 // Filter can be used to retrieve and filter logs.
 type Filter struct {
 	backend  Backend
@@ -94,26 +103,20 @@ func (f *Filter) Logs(ctx context.Context) ([]*ethtypes.Log, error) {
 	}
 
 	// Figure out the limits of the filter range
-	header, err := f.backend.HeaderByNumber(rpctypes.LatestBlockNumber)
-	if err != nil {
+	if ok, err := f.resolveRange(); !ok || err != nil {
 		return nil, err
 	}
 
-	if header == nil || header.Number == nil {
-		return nil, nil
-	}
-
-	head := header.Number.Int64()
-	if f.criteria.FromBlock.Int64() == -1 {
-		f.criteria.FromBlock = big.NewInt(head)
-	}
-	if f.criteria.ToBlock.Int64() == -1 {
-		f.criteria.ToBlock = big.NewInt(head)
-	}
-
-	if f.criteria.FromBlock.Int64() <= tmtypes.GetStartBlockHeight() ||
-		f.criteria.ToBlock.Int64() <= tmtypes.GetStartBlockHeight() {
-		return nil, fmt.Errorf("from and to block height must greater than %d", tmtypes.GetStartBlockHeight())
+	// the log index answers the queries of the addresses without the limit of the height span
+	if f.indexed() {
+		logs, cursor, err := f.indexedLogsPage(nil, maxIndexedLogs)
+		if err != nil {
+			return nil, err
+		}
+		if cursor != nil {
+			return nil, fmt.Errorf("query returns more than %d results, use eth_getLogsPage to paginate", maxIndexedLogs)
+		}
+		return logs, nil
 	}
 
 	heightSpan := viper.GetInt64(FlagGetLogsHeightSpan)
@@ -145,6 +148,82 @@ func (f *Filter) Logs(ctx context.Context) ([]*ethtypes.Log, error) {
 	return logs, err
 }
 
+// resolveRange replaces the latest block numbers of the filter range with the latest height, it returns false
+// if there are no blocks.
+func (f *Filter) resolveRange() (bool, error) {
+	header, err := f.backend.HeaderByNumber(rpctypes.LatestBlockNumber)
+	if err != nil {
+		return false, err
+	}
+
+	if header == nil || header.Number == nil {
+		return false, nil
+	}
+
+	head := header.Number.Int64()
+	if f.criteria.FromBlock.Int64() == -1 {
+		f.criteria.FromBlock = big.NewInt(head)
+	}
+	if f.criteria.ToBlock.Int64() == -1 {
+		f.criteria.ToBlock = big.NewInt(head)
+	}
+
+	if f.criteria.FromBlock.Int64() <= tmtypes.GetStartBlockHeight() ||
+		f.criteria.ToBlock.Int64() <= tmtypes.GetStartBlockHeight() {
+		return false, fmt.Errorf("from and to block height must greater than %d", tmtypes.GetStartBlockHeight())
+	}
+	return true, nil
+}
+
+// indexed returns true if the filter range is covered by the log index, which requires the addresses
+func (f *Filter) indexed() bool {
+	if len(f.criteria.Addresses) == 0 {
+		return false
+	}
+	from, to, ok := f.backend.LogIndexRange()
+	return ok && f.criteria.FromBlock.Uint64() >= from && f.criteria.ToBlock.Uint64() <= to
+}
+
+// indexedLogsPage returns a page of the logs found by the log index from the cursor. The index matches the
+// addresses and the first topics, the other topics are matched by the logs, so a page may have less logs than
+// the limit even if there are more logs.
+func (f *Filter) indexedLogsPage(cursor *watcher.LogPosition, limit int) ([]*ethtypes.Log, *watcher.LogPosition, error) {
+	var topics []common.Hash
+	if len(f.criteria.Topics) > 0 {
+		topics = f.criteria.Topics[0]
+	}
+	logs, next, err := f.backend.GetLogsByIndex(f.criteria.Addresses, topics, f.criteria.FromBlock.Uint64(),
+		f.criteria.ToBlock.Uint64(), cursor, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+	logs = filterLogs(logs, nil, nil, f.criteria.Addresses, f.criteria.Topics)
+	if logs == nil {
+		logs = []*ethtypes.Log{}
+	}
+	return logs, next, nil
+}
+
+// LogsPage returns a page of the logs from the cursor, which are found by the log index. The returned cursor
+// continues the query if there are more logs.
+func (f *Filter) LogsPage(cursor *watcher.LogPosition, limit int) ([]*ethtypes.Log, *watcher.LogPosition, error) {
+	if ok, err := f.resolveRange(); !ok || err != nil {
+		return []*ethtypes.Log{}, nil, err
+	}
+	if len(f.criteria.Addresses) == 0 {
+		return nil, nil, fmt.Errorf("addresses are required to query the log index")
+	}
+	if !f.indexed() {
+		from, to, ok := f.backend.LogIndexRange()
+		if !ok {
+			return nil, nil, fmt.Errorf("the log index is not enabled")
+		}
+		return nil, nil, fmt.Errorf("the blocks %d-%d are not covered by the log index, which has the blocks %d-%d",
+			f.criteria.FromBlock.Uint64(), f.criteria.ToBlock.Uint64(), from, to)
+	}
+	return f.indexedLogsPage(cursor, limit)
+}
+
 // blockLogs returns the logs matching the filter criteria within a single block.
 func (f *Filter) blockLogs(header *ethtypes.Header, hash common.Hash) ([]*ethtypes.Log, error) {
 	if !bloomFilter(header.Bloom, f.criteria.Addresses, f.criteria.Topics) {
@@ -20,6 +20,7 @@ import (
 	"github.com/okex/exchain/x/ammswap"
 	"github.com/okex/exchain/x/dex"
 	distr "github.com/okex/exchain/x/distribution"
+	"github.com/okex/exchain/x/erc20"
 	evmtypes "github.com/okex/exchain/x/evm/types"
 	"github.com/okex/exchain/x/evm/watcher"
 	"github.com/okex/exchain/x/farm"
@@ -131,6 +132,14 @@ func (p SubspaceProxy) SetParamSet(ctx sdk.Context, ps params.ParamSet) {
 
 }
 
+func (p SubspaceProxy) GetIfExists(ctx sdk.Context, key []byte, ptr interface{}) {
+
+}
+
+func (p SubspaceProxy) Set(ctx sdk.Context, key []byte, value interface{}) {
+
+}
+
 type BankKeeperProxy struct {
 	blacklistedAddrs map[string]bool
 }
@@ -151,6 +160,7 @@ func NewBankKeeperProxy() BankKeeperProxy {
 		farm.ModuleName:           nil,
 		farm.YieldFarmingAccount:  nil,
 		farm.MintFarmingAccount:   {supply.Burner},
+		erc20.ModuleName:          {supply.Minter, supply.Burner},
 	}
 
 	for acc := range maccPerms {
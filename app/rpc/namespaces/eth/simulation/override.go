@@ -0,0 +1,133 @@
+package simulation
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	rpctypes "github.com/okex/exchain/app/rpc/types"
+	"github.com/okex/exchain/app/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+)
+
+// StateOverride is the collection of overridden accounts of a simulated call, keyed by address
+type StateOverride map[common.Address]rpctypes.Account
+
+type overrideAccount struct {
+	account rpctypes.Account
+	// storage is keyed by the composite key of the evm store, see evmtypes.Keccak256HashWithCache
+	storage map[common.Hash]common.Hash
+}
+
+// OverrideProxy serves the overridden accounts, codes and storages on top of the chain state
+type OverrideProxy struct {
+	QueryOnChainProxy
+	accounts map[common.Address]overrideAccount
+	codes    map[common.Hash][]byte
+}
+
+// NewOverrideProxy returns a QueryOnChainProxy applying the state override to qoc.
+// qoc is returned directly if there's nothing to override.
+func NewOverrideProxy(qoc QueryOnChainProxy, override StateOverride) (QueryOnChainProxy, error) {
+	if len(override) == 0 {
+		return qoc, nil
+	}
+
+	proxy := OverrideProxy{
+		QueryOnChainProxy: qoc,
+		accounts:          make(map[common.Address]overrideAccount, len(override)),
+		codes:             make(map[common.Hash][]byte),
+	}
+	for addr, account := range override {
+		if account.State != nil && account.StateDiff != nil {
+			return nil, fmt.Errorf("account %s has both 'state' and 'stateDiff'", addr.Hex())
+		}
+		if account.Code != nil {
+			proxy.codes[ethcrypto.Keccak256Hash(*account.Code)] = *account.Code
+		}
+
+		overridden := overrideAccount{account: account}
+		slots := account.StateDiff
+		if account.State != nil {
+			slots = account.State
+		}
+		if slots != nil {
+			overridden.storage = make(map[common.Hash]common.Hash, len(*slots))
+			for key, value := range *slots {
+				overridden.storage[storageKey(addr, key)] = value
+			}
+		}
+		proxy.accounts[addr] = overridden
+	}
+	return proxy, nil
+}
+
+func storageKey(addr common.Address, key common.Hash) common.Hash {
+	compositeKey := make([]byte, 0, common.AddressLength+common.HashLength)
+	compositeKey = append(compositeKey, addr.Bytes()...)
+	compositeKey = append(compositeKey, key.Bytes()...)
+	return evmtypes.Keccak256HashWithCache(compositeKey)
+}
+
+// GetAccount returns the account with the overridden balance, nonce and code
+func (p OverrideProxy) GetAccount(address common.Address) (*types.EthAccount, error) {
+	acc, err := p.QueryOnChainProxy.GetAccount(address)
+	overridden, ok := p.accounts[address]
+	if !ok {
+		return acc, err
+	}
+
+	account := types.EthAccount{
+		BaseAccount: &auth.BaseAccount{},
+		CodeHash:    ethcrypto.Keccak256(nil),
+	}
+	if err == nil && acc != nil {
+		// copy the account to keep the one in the query cache untouched
+		account = *acc
+		baseAccount := *acc.BaseAccount
+		account.BaseAccount = &baseAccount
+	} else {
+		account.SetAddress(address.Bytes())
+	}
+
+	if overridden.account.Nonce != nil {
+		if err := account.SetSequence(uint64(*overridden.account.Nonce)); err != nil {
+			return nil, err
+		}
+	}
+	if overridden.account.Balance != nil && *overridden.account.Balance != nil {
+		balance := (*overridden.account.Balance).ToInt()
+		account.SetBalance(sdk.DefaultBondDenom, sdk.NewDecFromBigIntWithPrec(balance, sdk.Precision))
+	}
+	if overridden.account.Code != nil {
+		account.CodeHash = ethcrypto.Keccak256(*overridden.account.Code)
+	}
+	return &account, nil
+}
+
+// GetStorageAtInternal returns the overridden storage, the whole storage of the account is replaced
+// by 'state' while only the given slots are replaced by 'stateDiff'
+func (p OverrideProxy) GetStorageAtInternal(address common.Address, key []byte) (hexutil.Bytes, error) {
+	overridden, ok := p.accounts[address]
+	if !ok || overridden.storage == nil {
+		return p.QueryOnChainProxy.GetStorageAtInternal(address, key)
+	}
+	if value, ok := overridden.storage[common.BytesToHash(key)]; ok {
+		return value.Bytes(), nil
+	}
+	if overridden.account.State != nil {
+		return nil, nil
+	}
+	return p.QueryOnChainProxy.GetStorageAtInternal(address, key)
+}
+
+// GetCodeByHash returns the overridden code if the hash matches
+func (p OverrideProxy) GetCodeByHash(hash common.Hash) (hexutil.Bytes, error) {
+	if code, ok := p.codes[hash]; ok {
+		return code, nil
+	}
+	return p.QueryOnChainProxy.GetCodeByHash(hash)
+}
@@ -0,0 +1,104 @@
+package simulation
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	rpctypes "github.com/okex/exchain/app/rpc/types"
+	"github.com/okex/exchain/app/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
+	"github.com/stretchr/testify/require"
+)
+
+type mockQueryOnChainProxy struct {
+	accounts map[common.Address]*types.EthAccount
+	storage  map[common.Hash]hexutil.Bytes
+}
+
+func (m mockQueryOnChainProxy) GetAccount(address common.Address) (*types.EthAccount, error) {
+	if acc, ok := m.accounts[address]; ok {
+		return acc, nil
+	}
+	return nil, errors.New("account not found")
+}
+
+func (m mockQueryOnChainProxy) GetStorageAtInternal(address common.Address, key []byte) (hexutil.Bytes, error) {
+	return m.storage[common.BytesToHash(key)], nil
+}
+
+func (m mockQueryOnChainProxy) GetCodeByHash(hash common.Hash) (hexutil.Bytes, error) {
+	return nil, errors.New("code not found")
+}
+
+func TestOverrideProxy(t *testing.T) {
+	addr := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	newAddr := common.HexToAddress("0x1000000000000000000000000000000000000002")
+	slot1, slot2 := common.HexToHash("0x01"), common.HexToHash("0x02")
+
+	acc := &types.EthAccount{BaseAccount: &auth.BaseAccount{}, CodeHash: ethcrypto.Keccak256(nil)}
+	acc.SetAddress(addr.Bytes())
+	require.NoError(t, acc.SetSequence(3))
+	qoc := mockQueryOnChainProxy{
+		accounts: map[common.Address]*types.EthAccount{addr: acc},
+		storage: map[common.Hash]hexutil.Bytes{
+			storageKey(addr, slot1): common.HexToHash("0x11").Bytes(),
+			storageKey(addr, slot2): common.HexToHash("0x22").Bytes(),
+		},
+	}
+
+	// no override
+	proxy, err := NewOverrideProxy(qoc, nil)
+	require.NoError(t, err)
+	require.Equal(t, qoc, proxy)
+
+	// both state and stateDiff
+	state := map[common.Hash]common.Hash{slot1: common.HexToHash("0xaa")}
+	_, err = NewOverrideProxy(qoc, StateOverride{addr: rpctypes.Account{State: &state, StateDiff: &state}})
+	require.Error(t, err)
+
+	nonce := hexutil.Uint64(10)
+	balance := (*hexutil.Big)(big.NewInt(1000))
+	code := hexutil.Bytes{0x60, 0x00}
+	proxy, err = NewOverrideProxy(qoc, StateOverride{
+		addr:    rpctypes.Account{Nonce: &nonce, StateDiff: &state},
+		newAddr: rpctypes.Account{Balance: &balance, Code: &code, State: &state},
+	})
+	require.NoError(t, err)
+
+	// the nonce is overridden and the queried account is untouched
+	overridden, err := proxy.GetAccount(addr)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), overridden.GetSequence())
+	require.Equal(t, uint64(3), acc.GetSequence())
+
+	// the missing account is created with the overridden balance and code
+	overridden, err = proxy.GetAccount(newAddr)
+	require.NoError(t, err)
+	require.Equal(t, newAddr.Bytes(), overridden.GetAddress().Bytes())
+	require.Equal(t, sdk.NewDecFromBigIntWithPrec(big.NewInt(1000), sdk.Precision), overridden.Balance(sdk.DefaultBondDenom))
+	require.Equal(t, ethcrypto.Keccak256(code), overridden.CodeHash)
+	bz, err := proxy.GetCodeByHash(common.BytesToHash(overridden.CodeHash))
+	require.NoError(t, err)
+	require.Equal(t, code, bz)
+
+	// stateDiff only replaces the given slots
+	bz, err = proxy.GetStorageAtInternal(addr, storageKey(addr, slot1).Bytes())
+	require.NoError(t, err)
+	require.Equal(t, common.HexToHash("0xaa").Bytes(), []byte(bz))
+	bz, err = proxy.GetStorageAtInternal(addr, storageKey(addr, slot2).Bytes())
+	require.NoError(t, err)
+	require.Equal(t, common.HexToHash("0x22").Bytes(), []byte(bz))
+
+	// state replaces the whole storage
+	bz, err = proxy.GetStorageAtInternal(newAddr, storageKey(newAddr, slot1).Bytes())
+	require.NoError(t, err)
+	require.Equal(t, common.HexToHash("0xaa").Bytes(), []byte(bz))
+	bz, err = proxy.GetStorageAtInternal(newAddr, storageKey(newAddr, slot2).Bytes())
+	require.NoError(t, err)
+	require.Empty(t, bz)
+}
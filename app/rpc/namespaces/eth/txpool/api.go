@@ -27,28 +27,46 @@ func NewAPI(clientCtx clientcontext.CLIContext, log log.Logger, backend backend.
 	return api
 }
 
-// Content returns the transactions contained within the transaction pool.
-func (s *PublicTxPoolAPI) Content() map[string]map[string]map[string]*rpctypes.Transaction {
-	addressList, err := s.backend.PendingAddressList()
-	if err != nil {
-		s.logger.Error("txpool.Content addressList err: ", err)
-	}
-	content := map[string]map[string]map[string]*rpctypes.Transaction{
-		"queued": make(map[string]map[string]*rpctypes.Transaction),
+// txsByAddress returns the transactions of every address, the pending transactions are executable
+// while the queued ones wait for the nonce gap to be filled
+func (s *PublicTxPoolAPI) txsByAddress(queued bool) map[string][]*rpctypes.Transaction {
+	addressListFn, txsFn := s.backend.PendingAddressList, s.backend.UserPendingTransactions
+	if queued {
+		addressListFn, txsFn = s.backend.QueuedAddressList, s.backend.UserQueuedTransactions
 	}
 
+	addressList, err := addressListFn()
+	if err != nil {
+		s.logger.Error("txpool addressList err: ", err, "queued", queued)
+	}
+	txsByAddress := make(map[string][]*rpctypes.Transaction, len(addressList))
 	for _, address := range addressList {
-		txs, err := s.backend.UserPendingTransactions(address, -1)
+		txs, err := txsFn(address, -1)
 		if err != nil {
-			s.logger.Error("txpool.Content err: ", err)
+			s.logger.Error("txpool txs err: ", err, "queued", queued)
+			continue
 		}
+		txsByAddress[address] = txs
+	}
+	return txsByAddress
+}
+
+// Content returns the transactions contained within the transaction pool.
+func (s *PublicTxPoolAPI) Content() map[string]map[string]map[string]*rpctypes.Transaction {
+	content := map[string]map[string]map[string]*rpctypes.Transaction{
+		"pending": make(map[string]map[string]*rpctypes.Transaction),
+		"queued":  make(map[string]map[string]*rpctypes.Transaction),
+	}
 
-		// Flatten the queued transactions
-		dump := make(map[string]*rpctypes.Transaction)
-		for _, tx := range txs {
-			dump[fmt.Sprintf("%d", tx.Nonce)] = tx
+	for section, queued := range map[string]bool{"pending": false, "queued": true} {
+		for address, txs := range s.txsByAddress(queued) {
+			// Flatten the transactions
+			dump := make(map[string]*rpctypes.Transaction)
+			for _, tx := range txs {
+				dump[fmt.Sprintf("%d", tx.Nonce)] = tx
+			}
+			content[section][address] = dump
 		}
-		content["queued"][address] = dump
 	}
 
 	return content
@@ -61,41 +79,42 @@ func (s *PublicTxPoolAPI) Status() map[string]hexutil.Uint {
 		s.logger.Error("txpool.Status err: ", err)
 		return nil
 	}
+	numQueued, err := s.backend.QueuedTransactionCnt()
+	if err != nil {
+		s.logger.Error("txpool.Status err: ", err)
+		return nil
+	}
 	return map[string]hexutil.Uint{
-		"queued": hexutil.Uint(numRes),
+		"pending": hexutil.Uint(numRes),
+		"queued":  hexutil.Uint(numQueued),
 	}
 }
 
 // Inspect retrieves the content of the transaction pool and flattens it into an
 // easily inspectable list.
 func (s *PublicTxPoolAPI) Inspect() map[string]map[string]map[string]string {
-	addressList, err := s.backend.PendingAddressList()
-	if err != nil {
-		s.logger.Error("txpool.Inspect addressList err: ", err)
-	}
 	content := map[string]map[string]map[string]string{
-		"queued": make(map[string]map[string]string),
+		"pending": make(map[string]map[string]string),
+		"queued":  make(map[string]map[string]string),
 	}
-	for _, address := range addressList {
-		txs, err := s.backend.UserPendingTransactions(address, -1)
-		if err != nil {
-			s.logger.Error("txpool.Inspect err: ", err)
-		}
 
-		// Define a formatter to flatten a transaction into a string
-		var format = func(tx *rpctypes.Transaction) string {
-			if to := tx.To; to != nil {
-				return fmt.Sprintf("%s: %v wei + %v gas × %v wei", tx.To.Hex(), tx.Value, tx.Gas, tx.GasPrice)
-			}
-			return fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", tx.Value, tx.Gas, tx.GasPrice)
+	// Define a formatter to flatten a transaction into a string
+	var format = func(tx *rpctypes.Transaction) string {
+		if to := tx.To; to != nil {
+			return fmt.Sprintf("%s: %v wei + %v gas × %v wei", tx.To.Hex(), tx.Value, tx.Gas, tx.GasPrice)
 		}
+		return fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", tx.Value, tx.Gas, tx.GasPrice)
+	}
 
-		// Flatten the queued transactions
-		dump := make(map[string]string)
-		for _, tx := range txs {
-			dump[fmt.Sprintf("%d", tx.Nonce)] = format(tx)
+	for section, queued := range map[string]bool{"pending": false, "queued": true} {
+		for address, txs := range s.txsByAddress(queued) {
+			// Flatten the transactions
+			dump := make(map[string]string)
+			for _, tx := range txs {
+				dump[fmt.Sprintf("%d", tx.Nonce)] = format(tx)
+			}
+			content[section][address] = dump
 		}
-		content["queued"][address] = dump
 	}
 
 	return content
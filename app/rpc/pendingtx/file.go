@@ -0,0 +1,138 @@
+package pendingtx
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	rpctypes "github.com/okex/exchain/app/rpc/types"
+)
+
+const backupTimeFormat = "20060102T150405.000"
+
+// FileSender appends the pending txs to a local file as json lines. The file is rotated once it
+// exceeds maxSize bytes, and only the newest maxBackups rotated files are kept.
+type FileSender struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	mtx  sync.Mutex
+	file *os.File
+	size int64
+}
+
+func NewFileSender(path string, maxSize int64, maxBackups int) (*FileSender, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	fs := &FileSender{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+	if err := fs.open(); err != nil {
+		return nil, err
+	}
+	return fs, nil
+}
+
+func (fs *FileSender) Send(hash []byte, tx *rpctypes.Transaction) error {
+	return fs.write(newPendingMsg(hash, tx))
+}
+
+func (fs *FileSender) SendRmPending(hash []byte, tx *RmPendingTx) error {
+	return fs.write(newRemovedMsg(hash, tx))
+}
+
+// Close closes the current file
+func (fs *FileSender) Close() error {
+	fs.mtx.Lock()
+	defer fs.mtx.Unlock()
+	if fs.file == nil {
+		return nil
+	}
+	err := fs.file.Close()
+	fs.file = nil
+	return err
+}
+
+func (fs *FileSender) write(msg *Msg) error {
+	line, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	fs.mtx.Lock()
+	defer fs.mtx.Unlock()
+	if fs.file == nil {
+		return fmt.Errorf("pending tx file %s is closed", fs.path)
+	}
+	if fs.maxSize > 0 && fs.size > 0 && fs.size+int64(len(line)) > fs.maxSize {
+		if err := fs.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := fs.file.Write(line)
+	fs.size += int64(n)
+	return err
+}
+
+func (fs *FileSender) open() error {
+	f, err := os.OpenFile(fs.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	fs.file = f
+	fs.size = info.Size()
+	return nil
+}
+
+// rotate renames the current file with a timestamp suffix, opens a new one and removes the old
+// backups. The caller must hold the lock.
+func (fs *FileSender) rotate() error {
+	if err := fs.file.Close(); err != nil {
+		return err
+	}
+	fs.file = nil
+
+	backup := fs.path + "." + time.Now().UTC().Format(backupTimeFormat)
+	if err := os.Rename(fs.path, backup); err != nil {
+		return err
+	}
+	if err := fs.open(); err != nil {
+		return err
+	}
+	return fs.pruneBackups()
+}
+
+func (fs *FileSender) pruneBackups() error {
+	if fs.maxBackups <= 0 {
+		return nil
+	}
+	backups, err := filepath.Glob(fs.path + ".*")
+	if err != nil {
+		return err
+	}
+	if len(backups) <= fs.maxBackups {
+		return nil
+	}
+	// the timestamp suffixes sort chronologically
+	sort.Strings(backups)
+	for _, backup := range backups[:len(backups)-fs.maxBackups] {
+		if err := os.Remove(backup); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -47,3 +47,26 @@ func (kc *KafkaClient) Send(hash []byte, tx *rpctypes.Transaction) error {
 		},
 	)
 }
+
+type KafkaRmMsg struct {
+	Topic  string       `json:"topic"`
+	Source interface{}  `json:"source"`
+	Data   *RmPendingTx `json:"data"`
+}
+
+func (kc *KafkaClient) SendRmPending(hash []byte, tx *RmPendingTx) error {
+	msg, err := json.Marshal(KafkaRmMsg{
+		Topic: kc.Topic,
+		Data:  tx,
+	})
+	if err != nil {
+		return err
+	}
+
+	return kc.WriteMessages(context.Background(),
+		kafka.Message{
+			Key:   hash,
+			Value: msg,
+		},
+	)
+}
@@ -0,0 +1,73 @@
+package pendingtx
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	rpctypes "github.com/okex/exchain/app/rpc/types"
+)
+
+const (
+	SinkKafka   = "kafka"
+	SinkWebhook = "webhook"
+	SinkFile    = "file"
+	SinkSocket  = "socket"
+
+	MsgTypePending = "pending"
+	MsgTypeRemoved = "removed"
+)
+
+// Msg is the message pushed to the webhook, file and socket sinks
+type Msg struct {
+	Type    string                `json:"type"`
+	Hash    common.Hash           `json:"hash"`
+	Tx      *rpctypes.Transaction `json:"tx,omitempty"`
+	Removed *RmPendingTx          `json:"removed,omitempty"`
+}
+
+func newPendingMsg(hash []byte, tx *rpctypes.Transaction) *Msg {
+	return &Msg{
+		Type: MsgTypePending,
+		Hash: common.BytesToHash(hash),
+		Tx:   tx,
+	}
+}
+
+func newRemovedMsg(hash []byte, tx *RmPendingTx) *Msg {
+	return &Msg{
+		Type:    MsgTypeRemoved,
+		Hash:    common.BytesToHash(hash),
+		Removed: tx,
+	}
+}
+
+// MultiSender pushes the pending txs to all of its senders
+type MultiSender []Sender
+
+func (ms MultiSender) Send(hash []byte, tx *rpctypes.Transaction) error {
+	var errs []string
+	for _, s := range ms {
+		if err := s.Send(hash, tx); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	return joinErrors(errs)
+}
+
+func (ms MultiSender) SendRmPending(hash []byte, tx *RmPendingTx) error {
+	var errs []string
+	for _, s := range ms {
+		if err := s.SendRmPending(hash, tx); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	return joinErrors(errs)
+}
+
+func joinErrors(errs []string) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s", strings.Join(errs, "; "))
+}
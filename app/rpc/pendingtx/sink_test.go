@@ -0,0 +1,184 @@
+package pendingtx
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	rpctypes "github.com/okex/exchain/app/rpc/types"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/stretchr/testify/require"
+)
+
+func testTx(i byte) ([]byte, *rpctypes.Transaction) {
+	hash := common.BytesToHash([]byte{i})
+	return hash.Bytes(), &rpctypes.Transaction{Hash: hash}
+}
+
+func TestWebhookSenderBatchAndRetry(t *testing.T) {
+	var (
+		mtx      sync.Mutex
+		received []*Msg
+		calls    int32
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the first post fails and has to be retried
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var batch []*Msg
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+		mtx.Lock()
+		received = append(received, batch...)
+		mtx.Unlock()
+	}))
+	defer server.Close()
+
+	sender := NewWebhookSender(server.URL, 2, time.Hour, 3, log.NewNopLogger())
+	for i := byte(0); i < 3; i++ {
+		hash, tx := testTx(i)
+		require.NoError(t, sender.Send(hash, tx))
+	}
+	hash, _ := testTx(3)
+	require.NoError(t, sender.SendRmPending(hash, &RmPendingTx{Hash: common.BytesToHash(hash), Reason: "replaced"}))
+	sender.Stop()
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	require.Len(t, received, 4)
+	require.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	for i := 0; i < 3; i++ {
+		require.Equal(t, MsgTypePending, received[i].Type)
+		require.Equal(t, common.BytesToHash([]byte{byte(i)}), received[i].Tx.Hash)
+	}
+	require.Equal(t, MsgTypeRemoved, received[3].Type)
+	require.Equal(t, "replaced", received[3].Removed.Reason)
+}
+
+func TestWebhookSenderNoRetryOnClientError(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	sender := NewWebhookSender(server.URL, 1, time.Hour, 3, log.NewNopLogger())
+	hash, tx := testTx(1)
+	require.NoError(t, sender.Send(hash, tx))
+	sender.Stop()
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+// the batch is flushed by the default interval if the flush interval isn't positive
+func TestWebhookSenderDefaultFlushInterval(t *testing.T) {
+	received := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- struct{}{}
+	}))
+	defer server.Close()
+
+	for _, flushInterval := range []time.Duration{0, -time.Second} {
+		sender := NewWebhookSender(server.URL, 10, flushInterval, 0, log.NewNopLogger())
+		require.Equal(t, defaultWebhookFlushInterval, sender.flushInterval)
+		hash, tx := testTx(1)
+		require.NoError(t, sender.Send(hash, tx))
+		select {
+		case <-received:
+		case <-time.After(5 * defaultWebhookFlushInterval):
+			t.Fatal("the batch isn't flushed")
+		}
+		sender.Stop()
+	}
+}
+
+func TestFileSenderRotate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pendingtx")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "pending.jsonl")
+	hash, tx := testTx(1)
+	line, err := json.Marshal(newPendingMsg(hash, tx))
+	require.NoError(t, err)
+
+	// every file holds two lines
+	sender, err := NewFileSender(path, int64(2*(len(line)+1)), 2)
+	require.NoError(t, err)
+	for i := 0; i < 7; i++ {
+		require.NoError(t, sender.Send(hash, tx))
+		// backups are named by milliseconds
+		time.Sleep(2 * time.Millisecond)
+	}
+	require.NoError(t, sender.Close())
+
+	backups, err := filepath.Glob(path + ".*")
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	var lines int
+	for scanner.Scan() {
+		var msg Msg
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &msg))
+		require.Equal(t, MsgTypePending, msg.Type)
+		lines++
+	}
+	require.Equal(t, 1, lines)
+
+	require.Error(t, sender.Send(hash, tx))
+}
+
+func TestSocketSender(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pendingtx")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "pending.sock")
+	sender, err := NewSocketSender(path, log.NewNopLogger())
+	require.NoError(t, err)
+	defer sender.Close()
+
+	conn, err := net.Dial("unix", path)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// wait for the client to be accepted
+	require.Eventually(t, func() bool {
+		sender.mtx.Lock()
+		defer sender.mtx.Unlock()
+		return len(sender.clients) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	hash, tx := testTx(1)
+	require.NoError(t, sender.Send(hash, tx))
+	require.NoError(t, sender.SendRmPending(hash, &RmPendingTx{Hash: common.BytesToHash(hash), Reason: "recheck"}))
+
+	reader := bufio.NewReader(conn)
+	var msg Msg
+	line, err := reader.ReadBytes('\n')
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(line, &msg))
+	require.Equal(t, MsgTypePending, msg.Type)
+	require.Equal(t, common.BytesToHash(hash), msg.Hash)
+
+	line, err = reader.ReadBytes('\n')
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(line, &msg))
+	require.Equal(t, MsgTypeRemoved, msg.Type)
+	require.Equal(t, "recheck", msg.Removed.Reason)
+}
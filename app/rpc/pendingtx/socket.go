@@ -0,0 +1,117 @@
+package pendingtx
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+	"sync"
+
+	rpctypes "github.com/okex/exchain/app/rpc/types"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+)
+
+const socketClientBufferSize = 1000
+
+// SocketSender streams the pending txs as json lines to every client connected to a unix socket.
+// A client that can't keep up with the stream is disconnected.
+type SocketSender struct {
+	listener net.Listener
+	logger   log.Logger
+
+	mtx     sync.Mutex
+	clients map[net.Conn]chan []byte
+}
+
+func NewSocketSender(path string, logger log.Logger) (*SocketSender, error) {
+	// remove the socket file left by the last run
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	ss := &SocketSender{
+		listener: listener,
+		logger:   logger.With("module", "pendingtx-socket"),
+		clients:  make(map[net.Conn]chan []byte),
+	}
+	go ss.acceptLoop()
+	return ss, nil
+}
+
+func (ss *SocketSender) Send(hash []byte, tx *rpctypes.Transaction) error {
+	return ss.broadcast(newPendingMsg(hash, tx))
+}
+
+func (ss *SocketSender) SendRmPending(hash []byte, tx *RmPendingTx) error {
+	return ss.broadcast(newRemovedMsg(hash, tx))
+}
+
+// Close stops listening and disconnects all clients
+func (ss *SocketSender) Close() error {
+	err := ss.listener.Close()
+	ss.mtx.Lock()
+	for conn, ch := range ss.clients {
+		close(ch)
+		delete(ss.clients, conn)
+	}
+	ss.mtx.Unlock()
+	return err
+}
+
+func (ss *SocketSender) broadcast(msg *Msg) error {
+	line, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	ss.mtx.Lock()
+	defer ss.mtx.Unlock()
+	for conn, ch := range ss.clients {
+		select {
+		case ch <- line:
+		default:
+			ss.logger.Info("disconnect slow pending tx socket client")
+			close(ch)
+			delete(ss.clients, conn)
+		}
+	}
+	return nil
+}
+
+func (ss *SocketSender) acceptLoop() {
+	for {
+		conn, err := ss.listener.Accept()
+		if err != nil {
+			// the listener is closed
+			return
+		}
+		ch := make(chan []byte, socketClientBufferSize)
+		ss.mtx.Lock()
+		ss.clients[conn] = ch
+		ss.mtx.Unlock()
+		go ss.writeLoop(conn, ch)
+	}
+}
+
+func (ss *SocketSender) writeLoop(conn net.Conn, ch chan []byte) {
+	defer conn.Close()
+	for line := range ch {
+		if _, err := conn.Write(line); err != nil {
+			ss.logger.Debug("pending tx socket client disconnected", "error", err)
+			ss.removeClient(conn)
+			return
+		}
+	}
+}
+
+func (ss *SocketSender) removeClient(conn net.Conn) {
+	ss.mtx.Lock()
+	defer ss.mtx.Unlock()
+	if ch, ok := ss.clients[conn]; ok {
+		close(ch)
+		delete(ss.clients, conn)
+	}
+}
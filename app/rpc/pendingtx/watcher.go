@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	rpcfilters "github.com/okex/exchain/app/rpc/namespaces/eth/filters"
 	rpctypes "github.com/okex/exchain/app/rpc/types"
 	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
@@ -22,6 +23,23 @@ type Watcher struct {
 
 type Sender interface {
 	Send(hash []byte, tx *rpctypes.Transaction) error
+	SendRmPending(hash []byte, tx *RmPendingTx) error
+}
+
+// RmPendingTx is the notification of a tx leaving the mempool without being included in a block
+type RmPendingTx struct {
+	From   string         `json:"from"`
+	Hash   common.Hash    `json:"hash"`
+	Nonce  hexutil.Uint64 `json:"nonce"`
+	Reason string         `json:"reason"`
+}
+
+// rmReasons maps the removal reasons of the mempool to the names pushed to the sinks
+var rmReasons = map[int]string{
+	tmtypes.Recheck:  "recheck",
+	tmtypes.Replaced: "replaced",
+	tmtypes.Stale:    "stale",
+	tmtypes.Flushed:  "flushed",
 }
 
 func NewWatcher(clientCtx context.CLIContext, log log.Logger, sender Sender) *Watcher {
@@ -74,4 +92,35 @@ func (w *Watcher) Start() {
 			}
 		}
 	}(sub.Event(), sub.Err())
+
+	rmSub, _, err := w.events.SubscribeRmPendingTxs()
+	if err != nil {
+		w.logger.Error("error creating rm pending tx filter", "error", err.Error())
+		return
+	}
+
+	go func(rmCh <-chan coretypes.ResultEvent) {
+		for ev := range rmCh {
+			data, ok := ev.Data.(tmtypes.EventDataRmPendingTx)
+			if !ok {
+				w.logger.Error(fmt.Sprintf("invalid data type %T, expected EventDataRmPendingTx", ev.Data), "ID", rmSub.ID())
+				continue
+			}
+			txHash := common.BytesToHash(data.Hash)
+			w.logger.Debug("receive rm pending tx from mempool", "txHash=", txHash.String())
+
+			rmTx := &RmPendingTx{
+				From:   data.From,
+				Hash:   txHash,
+				Nonce:  hexutil.Uint64(data.Nonce),
+				Reason: rmReasons[data.Reason],
+			}
+			go func(hash []byte, tx *RmPendingTx) {
+				err := w.sender.SendRmPending(hash, tx)
+				if err != nil {
+					w.logger.Error("failed to send rm pending tx", "hash", tx.Hash.String(), "error", err)
+				}
+			}(txHash.Bytes(), rmTx)
+		}
+	}(rmSub.Event())
 }
@@ -0,0 +1,175 @@
+package pendingtx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	rpctypes "github.com/okex/exchain/app/rpc/types"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+)
+
+const (
+	webhookQueueSize   = 10000
+	webhookTimeout     = 10 * time.Second
+	webhookBaseBackoff = 500 * time.Millisecond
+
+	defaultWebhookFlushInterval = time.Second
+)
+
+var errWebhookQueueFull = errors.New("webhook queue is full")
+
+// WebhookSender posts the pending txs to a http endpoint as json arrays. Messages are batched
+// until the batch is full or the flush interval elapses, and failed posts are retried
+// with exponential backoff.
+type WebhookSender struct {
+	url           string
+	client        *http.Client
+	batchSize     int
+	flushInterval time.Duration
+	maxRetries    int
+	logger        log.Logger
+
+	queue    chan *Msg
+	quit     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func NewWebhookSender(url string, batchSize int, flushInterval time.Duration, maxRetries int, logger log.Logger) *WebhookSender {
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+	if flushInterval <= 0 {
+		flushInterval = defaultWebhookFlushInterval
+	}
+	ws := &WebhookSender{
+		url:           url,
+		client:        &http.Client{Timeout: webhookTimeout},
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		maxRetries:    maxRetries,
+		logger:        logger.With("module", "pendingtx-webhook"),
+		queue:         make(chan *Msg, webhookQueueSize),
+		quit:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	go ws.loop()
+	return ws
+}
+
+func (ws *WebhookSender) Send(hash []byte, tx *rpctypes.Transaction) error {
+	return ws.enqueue(newPendingMsg(hash, tx))
+}
+
+func (ws *WebhookSender) SendRmPending(hash []byte, tx *RmPendingTx) error {
+	return ws.enqueue(newRemovedMsg(hash, tx))
+}
+
+// Stop flushes the queued messages and stops the sender
+func (ws *WebhookSender) Stop() {
+	ws.stopOnce.Do(func() {
+		close(ws.quit)
+		<-ws.done
+	})
+}
+
+func (ws *WebhookSender) enqueue(msg *Msg) error {
+	select {
+	case ws.queue <- msg:
+		return nil
+	default:
+		return errWebhookQueueFull
+	}
+}
+
+func (ws *WebhookSender) loop() {
+	defer close(ws.done)
+
+	ticker := time.NewTicker(ws.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*Msg, 0, ws.batchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := ws.post(batch); err != nil {
+			ws.logger.Error("failed to post pending txs, dropped", "count", len(batch), "error", err)
+		}
+		batch = make([]*Msg, 0, ws.batchSize)
+	}
+
+	for {
+		select {
+		case msg := <-ws.queue:
+			batch = append(batch, msg)
+			if len(batch) >= ws.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-ws.quit:
+			for {
+				select {
+				case msg := <-ws.queue:
+					batch = append(batch, msg)
+					if len(batch) >= ws.batchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// post sends a batch, retrying on transport errors and on 429 and 5xx responses
+func (ws *WebhookSender) post(batch []*Msg) error {
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return err
+	}
+
+	backoff := webhookBaseBackoff
+	for attempt := 0; ; attempt++ {
+		retry, err := ws.doPost(body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= ws.maxRetries {
+			return err
+		}
+		ws.logger.Debug("retry posting pending txs", "attempt", attempt+1, "error", err)
+
+		select {
+		case <-time.After(backoff):
+		case <-ws.quit:
+			// keep retrying without waiting the whole backoff when stopping
+		}
+		backoff *= 2
+	}
+}
+
+func (ws *WebhookSender) doPost(body []byte) (retry bool, err error) {
+	resp, err := ws.client.Post(ws.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return true, err
+	}
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	default:
+		return false, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,147 @@
+package ratelimit
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+)
+
+const (
+	// HeaderAPIKey and QueryAPIKey carry the api key of the client
+	HeaderAPIKey = "X-Api-Key"
+	QueryAPIKey  = "apikey"
+
+	// headerInternal marks the requests forwarded by the websocket server
+	headerInternal = "X-Rpc-Internal"
+
+	// the methods are parsed from the first maxParsedBodySize bytes of the request body, which is the max content
+	// length of the rpc server
+	maxParsedBodySize = 5 * 1024 * 1024
+)
+
+// ClientFromRequest identifies the client of the http request by its api key or ip address. It returns
+// ErrInvalidAPIKey if the api key is unknown.
+func (l *Limiter) ClientFromRequest(r *http.Request) (Client, error) {
+	if l == nil {
+		return Client{}, nil
+	}
+	if token := r.Header.Get(headerInternal); token != "" && token == l.internalToken {
+		return Client{internal: true}, nil
+	}
+
+	key := r.Header.Get(HeaderAPIKey)
+	if key == "" {
+		key = r.URL.Query().Get(QueryAPIKey)
+	}
+	if key != "" {
+		tier, ok := l.config.Keys[key]
+		if !ok {
+			return Client{}, ErrInvalidAPIKey
+		}
+		return Client{Key: key, Tier: tier}, nil
+	}
+	return Client{Key: l.clientIP(r), Tier: TierIP}, nil
+}
+
+// clientIP returns the ip of the client. Behind n trusted proxies, it's the n-th address from the right of the
+// X-Forwarded-For header, since the addresses on its left are set by the client.
+func (l *Limiter) clientIP(r *http.Request) string {
+	if n := l.config.TrustedProxies; n > 0 {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			hops := strings.Split(forwarded, ",")
+			// the request doesn't go through all the proxies if there are fewer hops
+			if len(hops) >= n {
+				return strings.TrimSpace(hops[len(hops)-n])
+			}
+		} else if realIP := r.Header.Get("X-Real-IP"); realIP != "" {
+			return strings.TrimSpace(realIP)
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// SetInternal marks the request forwarded by the websocket server, whose limits have been applied already
+func (l *Limiter) SetInternal(r *http.Request) {
+	if l != nil {
+		r.Header.Set(headerInternal, l.internalToken)
+	}
+}
+
+// Handler applies the limits to the json-rpc requests over http, the rejected requests are responded with 429
+func (l *Limiter) Handler(next http.HandlerFunc) http.HandlerFunc {
+	if l == nil {
+		return next
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		client, err := l.ClientFromRequest(r)
+		if err != nil {
+			writeError(w, http.StatusUnauthorized, err)
+			return
+		}
+		if client.internal || r.Method != http.MethodPost {
+			next(w, r)
+			return
+		}
+
+		body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxParsedBodySize))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		// the rest of the oversized body is left to the rpc server, which rejects it
+		r.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+
+		if err := l.Allow(client, ParseMethods(body), TransportHTTP); err != nil {
+			writeError(w, http.StatusTooManyRequests, err)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// ParseMethods returns the methods of a json-rpc request or batch
+func ParseMethods(body []byte) []string {
+	type request struct {
+		Method string `json:"method"`
+	}
+
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var batch []request
+		if err := json.Unmarshal(body, &batch); err != nil {
+			return nil
+		}
+		methods := make([]string, len(batch))
+		for i, req := range batch {
+			methods[i] = req.Method
+		}
+		return methods
+	}
+
+	var req request
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil
+	}
+	return []string{req.Method}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      nil,
+		"error": map[string]interface{}{
+			"code":    ErrCodeLimitExceeded,
+			"message": err.Error(),
+		},
+	})
+}
@@ -0,0 +1,48 @@
+package ratelimit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+// KeysFile is the file of the api keys and their tiers, e.g.
+//
+//	{
+//	  "tiers": {"free": {"rate": 10, "burst": 20}, "pro": {"rate": 100, "burst": 200}},
+//	  "keys": {"9f2c0a...": "free", "41be7d...": "pro"}
+//	}
+type KeysFile struct {
+	Tiers map[string]Tier   `json:"tiers"`
+	Keys  map[string]string `json:"keys"`
+}
+
+// LoadKeysFile reads the api keys and their tiers from the local file
+func LoadKeysFile(path string) (KeysFile, error) {
+	var file KeysFile
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return file, err
+	}
+	if err = json.Unmarshal(bz, &file); err != nil {
+		return file, fmt.Errorf("invalid api keys file %s: %s", path, err)
+	}
+
+	for name, tier := range file.Tiers {
+		if name == TierIP {
+			return file, fmt.Errorf("tier name %s is reserved", TierIP)
+		}
+		if tier.Rate < 0 || tier.Burst <= 0 {
+			return file, fmt.Errorf("invalid rate or burst of tier %s", name)
+		}
+	}
+	for key, tier := range file.Keys {
+		if key == "" {
+			return file, fmt.Errorf("empty api key of tier %s", tier)
+		}
+		if _, ok := file.Tiers[tier]; !ok {
+			return file, fmt.Errorf("unknown tier %s of api key %s", tier, key)
+		}
+	}
+	return file, nil
+}
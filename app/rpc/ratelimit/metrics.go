@@ -0,0 +1,47 @@
+package ratelimit
+
+import (
+	"sync"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+
+	"github.com/okex/exchain/x/common/monitor"
+)
+
+var (
+	rejectedCounter     metrics.Counter
+	rejectedCounterOnce sync.Once
+)
+
+// rejectMetrics counts the rejected requests by the tier of their clients, their transport and methods. The methods
+// without a cost weight are counted as "other", so that the clients can't blow up the labels.
+type rejectMetrics struct {
+	rejected metrics.Counter
+}
+
+func newRejectMetrics() *rejectMetrics {
+	// the counter is registered to prometheus once, since registering it twice panics
+	rejectedCounterOnce.Do(func() {
+		rejectedCounter = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: monitor.XNameSpace,
+			Subsystem: "rpc",
+			Name:      "rate_limit_rejected",
+			Help:      "the number of the json-rpc requests rejected by the rate limits",
+		}, []string{"tier", "transport", "method"})
+	})
+	return &rejectMetrics{rejected: rejectedCounter}
+}
+
+func (m *rejectMetrics) reject(tier, transport string, methods []string, costs map[string]int) {
+	if len(methods) == 0 {
+		methods = []string{""}
+	}
+	for _, method := range methods {
+		if _, ok := costs[method]; !ok {
+			method = "other"
+		}
+		m.rejected.With("tier", tier, "transport", transport, "method", method).Add(1)
+	}
+}
@@ -0,0 +1,210 @@
+package ratelimit
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// TierIP is the tier of the clients identified by their ip addresses
+	TierIP = "ip"
+
+	// TransportHTTP, TransportWS and TransportGraphQL are the transports of the requests, which label the rejections
+	TransportHTTP    = "http"
+	TransportWS      = "ws"
+	TransportGraphQL = "graphql"
+
+	// ErrCodeLimitExceeded is the json-rpc error code of the rejected requests, see EIP-1474
+	ErrCodeLimitExceeded = -32005
+
+	// the buckets idle for longer than bucketIdleTimeout are dropped, the sweep runs once per sweepInterval
+	bucketIdleTimeout = 10 * time.Minute
+	sweepInterval     = time.Minute
+)
+
+var (
+	ErrLimitExceeded = errors.New("rate limit exceeded")
+	ErrInvalidAPIKey = errors.New("invalid api key")
+)
+
+// DefaultMethodCosts are the cost weights of the expensive methods, the other methods cost 1
+var DefaultMethodCosts = map[string]int{
+	"eth_getLogs":     10,
+	"eth_getLogsPage": 10,
+	"eth_call":        5,
+}
+
+// Tier is the token bucket config of a class of clients
+type Tier struct {
+	// Rate is the count of the tokens refilled per second
+	Rate float64 `json:"rate"`
+	// Burst is the capacity of the bucket, which is the max cost of one request
+	Burst int `json:"burst"`
+}
+
+// Client identifies the owner of the requests, the requests of a client share one bucket
+type Client struct {
+	// Key is the api key of the client, or its ip address if the client has no key
+	Key  string
+	Tier string
+	// internal requests are forwarded by the websocket server, which has already applied the limits
+	internal bool
+}
+
+// Config is the config of the limiter
+type Config struct {
+	// IPTier limits the clients without an api key, a zero rate disables the limit
+	IPTier Tier
+	// Tiers are the tiers of the api keys, and Keys maps the api keys to their tiers
+	Tiers map[string]Tier
+	Keys  map[string]string
+	// MethodCosts are the tokens taken by the methods, the other methods cost 1
+	MethodCosts map[string]int
+	// TrustedProxies is the number of the reverse proxies in front of the server, the client ip is taken from the
+	// X-Forwarded-For header appended by them, or the X-Real-IP header set by the nearest one
+	TrustedProxies int
+}
+
+// Limiter applies the token buckets per ip address and per api key to the json-rpc requests
+type Limiter struct {
+	config        Config
+	internalToken string
+	metrics       *rejectMetrics
+
+	mtx       sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// NewLimiter creates the limiter, it returns nil if no limit is configured. The methods of a nil limiter allow
+// all the requests.
+func NewLimiter(config Config) (*Limiter, error) {
+	if config.IPTier.Rate <= 0 && len(config.Keys) == 0 {
+		return nil, nil
+	}
+	for key, tier := range config.Keys {
+		if _, ok := config.Tiers[tier]; !ok {
+			return nil, fmt.Errorf("unknown tier %s of api key %s", tier, key)
+		}
+	}
+	if config.MethodCosts == nil {
+		config.MethodCosts = DefaultMethodCosts
+	}
+
+	bz := make([]byte, 16)
+	if _, err := rand.Read(bz); err != nil {
+		return nil, err
+	}
+	return &Limiter{
+		config:        config,
+		internalToken: hex.EncodeToString(bz),
+		metrics:       newRejectMetrics(),
+		buckets:       make(map[string]*bucket),
+		lastSweep:     time.Now(),
+	}, nil
+}
+
+// ParseMethodCosts parses the cost weights such as "eth_getLogs=10,eth_call=5"
+func ParseMethodCosts(s string) (map[string]int, error) {
+	costs := make(map[string]int)
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid method cost %s, expected method=cost", item)
+		}
+		cost, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil || cost <= 0 {
+			return nil, fmt.Errorf("invalid cost of method %s", parts[0])
+		}
+		costs[strings.TrimSpace(parts[0])] = cost
+	}
+	if len(costs) == 0 {
+		// the limiter falls back to DefaultMethodCosts
+		return nil, nil
+	}
+	return costs, nil
+}
+
+// Cost returns the tokens taken by the methods of a request
+func (l *Limiter) Cost(methods []string) int {
+	if len(methods) == 0 {
+		return 1
+	}
+	cost := 0
+	for _, method := range methods {
+		if c, ok := l.config.MethodCosts[method]; ok {
+			cost += c
+		} else {
+			cost++
+		}
+	}
+	return cost
+}
+
+// Allow takes the tokens of the methods from the bucket of the client, and returns ErrLimitExceeded if the bucket
+// doesn't have enough tokens or the cost exceeds the burst
+func (l *Limiter) Allow(client Client, methods []string, transport string) error {
+	if l == nil || client.internal {
+		return nil
+	}
+	tier, ok := l.tier(client.Tier)
+	if !ok || tier.Rate <= 0 {
+		return nil
+	}
+
+	// a request costing more than the burst, such as an oversized batch, is never allowed
+	if l.bucket(client, tier).AllowN(time.Now(), l.Cost(methods)) {
+		return nil
+	}
+	l.metrics.reject(client.Tier, transport, methods, l.config.MethodCosts)
+	return ErrLimitExceeded
+}
+
+func (l *Limiter) tier(name string) (Tier, bool) {
+	if name == TierIP {
+		return l.config.IPTier, true
+	}
+	tier, ok := l.config.Tiers[name]
+	return tier, ok
+}
+
+func (l *Limiter) bucket(client Client, tier Tier) *rate.Limiter {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	now := time.Now()
+	if now.Sub(l.lastSweep) > sweepInterval {
+		for key, b := range l.buckets {
+			if now.Sub(b.lastSeen) > bucketIdleTimeout {
+				delete(l.buckets, key)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	key := client.Tier + ":" + client.Key
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(rate.Limit(tier.Rate), tier.Burst)}
+		l.buckets[key] = b
+	}
+	b.lastSeen = now
+	return b.limiter
+}
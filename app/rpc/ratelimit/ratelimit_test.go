@@ -0,0 +1,178 @@
+package ratelimit
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLimiter(t *testing.T) *Limiter {
+	limiter, err := NewLimiter(Config{
+		IPTier: Tier{Rate: 0.001, Burst: 10},
+		Tiers:  map[string]Tier{"pro": {Rate: 0.001, Burst: 100}},
+		Keys:   map[string]string{"secret": "pro"},
+	})
+	require.NoError(t, err)
+	return limiter
+}
+
+func TestNewLimiter(t *testing.T) {
+	limiter, err := NewLimiter(Config{})
+	require.NoError(t, err)
+	require.Nil(t, limiter)
+	// a nil limiter allows all the requests
+	require.NoError(t, limiter.Allow(Client{Key: "1.2.3.4", Tier: TierIP}, []string{"eth_getLogs"}, TransportHTTP))
+
+	_, err = NewLimiter(Config{Keys: map[string]string{"secret": "unknown"}})
+	require.Error(t, err)
+}
+
+func TestAllow(t *testing.T) {
+	limiter := newTestLimiter(t)
+	client := Client{Key: "1.2.3.4", Tier: TierIP}
+
+	// eth_getLogs costs 10 tokens, which drain the bucket of the ip
+	require.NoError(t, limiter.Allow(client, []string{"eth_getLogs"}, TransportHTTP))
+	require.Equal(t, ErrLimitExceeded, limiter.Allow(client, []string{"eth_blockNumber"}, TransportHTTP))
+
+	// the buckets are per client
+	require.NoError(t, limiter.Allow(Client{Key: "5.6.7.8", Tier: TierIP}, []string{"eth_blockNumber"}, TransportHTTP))
+	for i := 0; i < 10; i++ {
+		require.NoError(t, limiter.Allow(Client{Key: "secret", Tier: "pro"}, []string{"eth_call", "eth_call"}, TransportWS))
+	}
+	require.Error(t, limiter.Allow(Client{Key: "secret", Tier: "pro"}, []string{"eth_call"}, TransportWS))
+
+	// a batch costing more than the burst is rejected without taking the tokens
+	client = Client{Key: "9.9.9.9", Tier: TierIP}
+	require.Equal(t, ErrLimitExceeded, limiter.Allow(client, make([]string, 11), TransportHTTP))
+	require.Equal(t, ErrLimitExceeded, limiter.Allow(client, []string{"eth_getLogs", "eth_blockNumber"}, TransportHTTP))
+	require.NoError(t, limiter.Allow(client, make([]string, 10), TransportHTTP))
+}
+
+func TestParseMethodCosts(t *testing.T) {
+	costs, err := ParseMethodCosts("eth_getLogs=20, eth_call = 3")
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"eth_getLogs": 20, "eth_call": 3}, costs)
+
+	costs, err = ParseMethodCosts("")
+	require.NoError(t, err)
+	require.Nil(t, costs)
+
+	_, err = ParseMethodCosts("eth_getLogs")
+	require.Error(t, err)
+	_, err = ParseMethodCosts("eth_getLogs=0")
+	require.Error(t, err)
+}
+
+func TestParseMethods(t *testing.T) {
+	require.Equal(t, []string{"eth_call"}, ParseMethods([]byte(`{"jsonrpc":"2.0","id":1,"method":"eth_call"}`)))
+	require.Equal(t, []string{"eth_call", "eth_getLogs"},
+		ParseMethods([]byte(` [{"method":"eth_call"},{"method":"eth_getLogs"}]`)))
+	require.Nil(t, ParseMethods([]byte("invalid")))
+}
+
+func TestHandler(t *testing.T) {
+	limiter := newTestLimiter(t)
+	var body string
+	handler := limiter.Handler(func(w http.ResponseWriter, r *http.Request) {
+		bz, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		body = string(bz)
+	})
+
+	request := func(apiKey, data string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(data))
+		req.RemoteAddr = "1.2.3.4:5678"
+		if apiKey != "" {
+			req.Header.Set(HeaderAPIKey, apiKey)
+		}
+		w := httptest.NewRecorder()
+		handler(w, req)
+		return w
+	}
+
+	// the body is still readable by the rpc server
+	data := `{"jsonrpc":"2.0","id":1,"method":"eth_getLogs"}`
+	require.Equal(t, http.StatusOK, request("", data).Code)
+	require.Equal(t, data, body)
+
+	w := request("", data)
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.Contains(t, w.Body.String(), "-32005")
+
+	require.Equal(t, http.StatusOK, request("secret", data).Code)
+	require.Equal(t, http.StatusUnauthorized, request("wrong", data).Code)
+
+	// the requests forwarded by the websocket server are not limited again
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(data))
+	req.RemoteAddr = "1.2.3.4:5678"
+	limiter.SetInternal(req)
+	w = httptest.NewRecorder()
+	handler(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestClientFromRequest(t *testing.T) {
+	limiter := newTestLimiter(t)
+	req := httptest.NewRequest(http.MethodGet, "/?apikey=secret", nil)
+	client, err := limiter.ClientFromRequest(req)
+	require.NoError(t, err)
+	require.Equal(t, Client{Key: "secret", Tier: "pro"}, client)
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "1.2.3.4:5678"
+	req.Header.Set("X-Forwarded-For", "5.6.7.8, 10.0.0.1")
+	client, err = limiter.ClientFromRequest(req)
+	require.NoError(t, err)
+	require.Equal(t, Client{Key: "1.2.3.4", Tier: TierIP}, client)
+
+	limiter.config.TrustedProxies = 2
+	client, err = limiter.ClientFromRequest(req)
+	require.NoError(t, err)
+	require.Equal(t, Client{Key: "5.6.7.8", Tier: TierIP}, client)
+
+	// the addresses prepended by the client are skipped
+	req.Header.Set("X-Forwarded-For", "9.9.9.9, 5.6.7.8, 10.0.0.1")
+	client, err = limiter.ClientFromRequest(req)
+	require.NoError(t, err)
+	require.Equal(t, Client{Key: "5.6.7.8", Tier: TierIP}, client)
+
+	limiter.config.TrustedProxies = 1
+	client, err = limiter.ClientFromRequest(req)
+	require.NoError(t, err)
+	require.Equal(t, Client{Key: "10.0.0.1", Tier: TierIP}, client)
+
+	// the request which doesn't go through all the proxies is limited by its remote address
+	limiter.config.TrustedProxies = 4
+	client, err = limiter.ClientFromRequest(req)
+	require.NoError(t, err)
+	require.Equal(t, Client{Key: "1.2.3.4", Tier: TierIP}, client)
+}
+
+func TestLoadKeysFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ratelimit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "keys.json")
+	require.NoError(t, ioutil.WriteFile(path,
+		[]byte(`{"tiers":{"free":{"rate":10,"burst":20}},"keys":{"secret":"free"}}`), 0600))
+	file, err := LoadKeysFile(path)
+	require.NoError(t, err)
+	require.Equal(t, map[string]Tier{"free": {Rate: 10, Burst: 20}}, file.Tiers)
+	require.Equal(t, map[string]string{"secret": "free"}, file.Keys)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"tiers":{},"keys":{"secret":"free"}}`), 0600))
+	_, err = LoadKeysFile(path)
+	require.Error(t, err)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"tiers":{"ip":{"rate":10,"burst":20}}}`), 0600))
+	_, err = LoadKeysFile(path)
+	require.Error(t, err)
+}
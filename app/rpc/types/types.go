@@ -46,6 +46,13 @@ type Transaction struct {
 	V                *hexutil.Big    `json:"v"`
 	R                *hexutil.Big    `json:"r"`
 	S                *hexutil.Big    `json:"s"`
+
+	// EIP-2718 typed transaction fields
+	Type                 hexutil.Uint64       `json:"type"`
+	ChainID              *hexutil.Big         `json:"chainId,omitempty"`
+	Accesses             *ethtypes.AccessList `json:"accessList,omitempty"`
+	MaxFeePerGas         *hexutil.Big         `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big         `json:"maxPriorityFeePerGas,omitempty"`
 }
 
 // SendTxArgs represents the arguments to submit a new transaction into the transaction pool.
@@ -65,7 +65,11 @@ func NewTransaction(tx *evmtypes.MsgEthereumTx, txHash, blockHash common.Hash, b
 		S:        (*hexutil.Big)(tx.Data.S),
 	}
 
+	setTypedTxFields(rpcTx, tx)
+
 	if blockHash != (common.Hash{}) {
+		// the gas price of a mined tx is the effective one, the same as go-ethereum
+		rpcTx.GasPrice = (*hexutil.Big)(tx.EffectiveGasPrice())
 		rpcTx.BlockHash = &blockHash
 		rpcTx.BlockNumber = (*hexutil.Big)(new(big.Int).SetUint64(blockNumber))
 		rpcTx.TransactionIndex = (*hexutil.Uint64)(&index)
@@ -87,9 +91,29 @@ func ToTransaction(tx *evmtypes.MsgEthereumTx, from *common.Address) *Transactio
 		R:        (*hexutil.Big)(tx.Data.R),
 		S:        (*hexutil.Big)(tx.Data.S),
 	}
+	setTypedTxFields(rpcTx, tx)
 	return rpcTx
 }
 
+// setTypedTxFields sets the type, access list and fee caps of the EIP-2718 typed transactions
+func setTypedTxFields(rpcTx *Transaction, tx *evmtypes.MsgEthereumTx) {
+	rpcTx.Type = hexutil.Uint64(tx.TxType())
+	if !tx.IsTyped() {
+		return
+	}
+
+	accesses := tx.AccessList()
+	if accesses == nil {
+		accesses = ethtypes.AccessList{}
+	}
+	rpcTx.ChainID = (*hexutil.Big)(tx.Data.ChainID)
+	rpcTx.Accesses = &accesses
+	if tx.TxType() == ethtypes.DynamicFeeTxType {
+		rpcTx.MaxFeePerGas = (*hexutil.Big)(tx.Data.GasFeeCap)
+		rpcTx.MaxPriorityFeePerGas = (*hexutil.Big)(tx.Data.GasTipCap)
+	}
+}
+
 // EthBlockFromTendermint returns a JSON-RPC compatible Ethereum blockfrom a given Tendermint block.
 func EthBlockFromTendermint(clientCtx clientcontext.CLIContext, block *tmtypes.Block, fullTx bool) (map[string]interface{}, error) {
 	var blockTxs interface{}
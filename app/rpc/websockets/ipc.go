@@ -0,0 +1,128 @@
+package websockets
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// StartIPC serves the json-rpc requests and the subscriptions over the unix domain socket at endpoint. The
+// requests are served by handler in-process, and the local ipc clients are not rate limited.
+func (s *Server) StartIPC(endpoint string, handler http.Handler) (net.Listener, error) {
+	listener, err := ipcListen(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				if !errors.Is(err, net.ErrClosed) {
+					s.logger.Error("failed to accept the ipc connection", "error", err)
+				}
+				return
+			}
+			go s.readLoop(&wsConn{
+				mux:     new(sync.Mutex),
+				conn:    newIPCConn(conn),
+				handler: handler,
+			})
+		}
+	}()
+	s.logger.Info("ipc endpoint opened", "path", endpoint)
+	return listener, nil
+}
+
+// maxIPCPathLength is the limit of the path of a unix domain socket, sizeof(sockaddr_un.sun_path) - 1 on linux
+const maxIPCPathLength = 107
+
+// ipcListen creates the unix domain socket at endpoint, which is accessible by the owner only
+func ipcListen(endpoint string) (net.Listener, error) {
+	if len(endpoint) > maxIPCPathLength {
+		return nil, fmt.Errorf("ipc endpoint %s is longer than %d bytes", endpoint, maxIPCPathLength)
+	}
+	if err := os.MkdirAll(filepath.Dir(endpoint), 0751); err != nil {
+		return nil, err
+	}
+	// remove the socket left by a crashed process, but not the one of a running process
+	if _, err := os.Stat(endpoint); err == nil {
+		if conn, err := net.DialTimeout("unix", endpoint, time.Second); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("ipc endpoint %s is in use", endpoint)
+		}
+		if err := os.Remove(endpoint); err != nil {
+			return nil, err
+		}
+	}
+	listener, err := net.Listen("unix", endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(endpoint, 0600); err != nil {
+		listener.Close()
+		return nil, err
+	}
+	return listener, nil
+}
+
+// ipcConn reads and writes the json messages of an ipc connection as a stream of json values
+type ipcConn struct {
+	conn net.Conn
+	dec  *json.Decoder
+	enc  *json.Encoder
+}
+
+func newIPCConn(conn net.Conn) *ipcConn {
+	return &ipcConn{
+		conn: conn,
+		dec:  json.NewDecoder(conn),
+		enc:  json.NewEncoder(conn),
+	}
+}
+
+func (c *ipcConn) ReadMessage() ([]byte, error) {
+	var msg json.RawMessage
+	if err := c.dec.Decode(&msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func (c *ipcConn) WriteJSON(v interface{}) error {
+	return c.enc.Encode(v)
+}
+
+func (c *ipcConn) Close() error {
+	return c.conn.Close()
+}
+
+// responseBuffer records the response of a request served in-process
+type responseBuffer struct {
+	header http.Header
+	code   int
+	body   bytes.Buffer
+}
+
+func newResponseBuffer() *responseBuffer {
+	return &responseBuffer{header: make(http.Header), code: http.StatusOK}
+}
+
+func (r *responseBuffer) Header() http.Header {
+	return r.header
+}
+
+func (r *responseBuffer) Write(p []byte) (int, error) {
+	return r.body.Write(p)
+}
+
+func (r *responseBuffer) WriteHeader(code int) {
+	r.code = code
+}
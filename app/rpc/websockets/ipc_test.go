@@ -0,0 +1,87 @@
+package websockets
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+)
+
+type ipcTestService struct{}
+
+func (ipcTestService) Echo(s string) string {
+	return s
+}
+
+func TestIPC(t *testing.T) {
+	handler := rpc.NewServer()
+	require.NoError(t, handler.RegisterName("test", ipcTestService{}))
+
+	endpoint := filepath.Join(t.TempDir(), "ipc", "exchaind.ipc")
+	s := &Server{logger: log.NewNopLogger()}
+	listener, err := s.StartIPC(endpoint, handler)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	info, err := os.Stat(endpoint)
+	require.NoError(t, err)
+	require.Equal(t, os.ModeSocket|0600, info.Mode())
+
+	// the path of a socket is limited
+	_, err = s.StartIPC(filepath.Join(t.TempDir(), strings.Repeat("a", maxIPCPathLength)), handler)
+	require.Error(t, err)
+
+	// the socket of a running server is not replaced
+	_, err = s.StartIPC(endpoint, handler)
+	require.Error(t, err)
+
+	conn, err := net.Dial("unix", endpoint)
+	require.NoError(t, err)
+	defer conn.Close()
+	dec := json.NewDecoder(conn)
+
+	var res struct {
+		ID     int    `json:"id"`
+		Result string `json:"result"`
+	}
+	_, err = conn.Write([]byte(`{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["a"]}`))
+	require.NoError(t, err)
+	require.NoError(t, dec.Decode(&res))
+	require.Equal(t, 1, res.ID)
+	require.Equal(t, "a", res.Result)
+
+	// the requests of a batch are answered one by one
+	_, err = conn.Write([]byte(`[{"jsonrpc":"2.0","id":2,"method":"test_echo","params":["b"]},
+		{"jsonrpc":"2.0","id":3,"method":"test_echo","params":["c"]}]`))
+	require.NoError(t, err)
+	require.NoError(t, dec.Decode(&res))
+	require.Equal(t, "b", res.Result)
+	require.NoError(t, dec.Decode(&res))
+	require.Equal(t, "c", res.Result)
+
+	var errRes struct {
+		ID    int `json:"id"`
+		Error struct {
+			Code int `json:"code"`
+		} `json:"error"`
+	}
+	_, err = conn.Write([]byte(`{"jsonrpc":"2.0","id":4,"method":"test_unknown","params":[]}`))
+	require.NoError(t, err)
+	require.NoError(t, dec.Decode(&errRes))
+	require.Equal(t, 4, errRes.ID)
+	require.Equal(t, -32601, errRes.Error.Code)
+
+	// the socket left by a stopped server is replaced
+	require.NoError(t, listener.Close())
+	require.NoError(t, os.WriteFile(endpoint, nil, 0644))
+	listener, err = s.StartIPC(endpoint, handler)
+	require.NoError(t, err)
+	defer listener.Close()
+}
@@ -0,0 +1,141 @@
+package websockets
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	rpctypes "github.com/okex/exchain/app/rpc/types"
+)
+
+// pendingTxFilter selects the txs of the newPendingTransactions subscription
+type pendingTxFilter struct {
+	// fullTx notifies the txs instead of their hashes
+	fullTx    bool
+	from      map[common.Address]struct{}
+	to        map[common.Address]struct{}
+	selectors map[[4]byte]struct{}
+}
+
+// newPendingTxFilter parses the params of the newPendingTransactions subscription, which follows the method name.
+// A bool param is the fullTx flag of geth, an object param is the criteria:
+// {"fullTx": true, "from": [addresses], "to": [addresses], "selectors": ["0xa9059cbb"]}
+func newPendingTxFilter(params []interface{}) (pendingTxFilter, error) {
+	var f pendingTxFilter
+	for _, param := range params {
+		switch param := param.(type) {
+		case nil:
+		case bool:
+			f.fullTx = param
+		case map[string]interface{}:
+			if err := f.parseCriteria(param); err != nil {
+				return f, err
+			}
+		default:
+			return f, fmt.Errorf("invalid parameters")
+		}
+	}
+	return f, nil
+}
+
+func (f *pendingTxFilter) parseCriteria(criteria map[string]interface{}) error {
+	for key, value := range criteria {
+		switch key {
+		case "fullTx":
+			fullTx, ok := value.(bool)
+			if !ok {
+				return fmt.Errorf("invalid fullTx")
+			}
+			f.fullTx = fullTx
+		case "from", "to":
+			addrs, err := parseStrings(key, value)
+			if err != nil {
+				return err
+			}
+			set := make(map[common.Address]struct{}, len(addrs))
+			for _, addr := range addrs {
+				if !common.IsHexAddress(addr) {
+					return fmt.Errorf("invalid %s address %s", key, addr)
+				}
+				set[common.HexToAddress(addr)] = struct{}{}
+			}
+			if key == "from" {
+				f.from = set
+			} else {
+				f.to = set
+			}
+		case "selectors":
+			selectors, err := parseStrings(key, value)
+			if err != nil {
+				return err
+			}
+			f.selectors = make(map[[4]byte]struct{}, len(selectors))
+			for _, selector := range selectors {
+				bz, err := hexutil.Decode(selector)
+				if err != nil || len(bz) != 4 {
+					return fmt.Errorf("invalid selector %s", selector)
+				}
+				var sel [4]byte
+				copy(sel[:], bz)
+				f.selectors[sel] = struct{}{}
+			}
+		default:
+			return fmt.Errorf("unsupported criteria %s", key)
+		}
+	}
+	return nil
+}
+
+// parseStrings accepts a string or an array of strings
+func parseStrings(key string, value interface{}) ([]string, error) {
+	switch value := value.(type) {
+	case string:
+		return []string{value}, nil
+	case []interface{}:
+		res := make([]string, len(value))
+		for i, v := range value {
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid %s", key)
+			}
+			res[i] = s
+		}
+		return res, nil
+	default:
+		return nil, fmt.Errorf("invalid %s", key)
+	}
+}
+
+// needDecode returns true if the txs must be decoded to be notified
+func (f pendingTxFilter) needDecode() bool {
+	return f.fullTx || len(f.from) > 0 || len(f.to) > 0 || len(f.selectors) > 0
+}
+
+// match returns true if the tx is selected by the filter
+func (f pendingTxFilter) match(tx *rpctypes.Transaction) bool {
+	if len(f.from) > 0 {
+		if _, ok := f.from[tx.From]; !ok {
+			return false
+		}
+	}
+	if len(f.to) > 0 {
+		if tx.To == nil {
+			return false
+		}
+		if _, ok := f.to[*tx.To]; !ok {
+			return false
+		}
+	}
+	if len(f.selectors) > 0 {
+		if len(tx.Input) < 4 {
+			return false
+		}
+		var sel [4]byte
+		copy(sel[:], tx.Input[:4])
+		if _, ok := f.selectors[sel]; !ok {
+			return false
+		}
+	}
+	return true
+}
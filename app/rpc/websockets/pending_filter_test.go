@@ -0,0 +1,50 @@
+package websockets
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+
+	rpctypes "github.com/okex/exchain/app/rpc/types"
+)
+
+func TestPendingTxFilter(t *testing.T) {
+	var params []interface{}
+	require.NoError(t, json.Unmarshal([]byte(`[true]`), &params))
+	f, err := newPendingTxFilter(params)
+	require.NoError(t, err)
+	require.True(t, f.fullTx)
+	require.True(t, f.needDecode())
+
+	f, err = newPendingTxFilter(nil)
+	require.NoError(t, err)
+	require.False(t, f.needDecode())
+
+	from := common.HexToAddress("0x756F45E3FA69347A9A973A725E3C98bC4db0b4c1")
+	to := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	require.NoError(t, json.Unmarshal([]byte(`[{"from": "0x756F45E3FA69347A9A973A725E3C98bC4db0b4c1",
+		"to": ["0x00000000000000000000000000000000000000aa"], "selectors": "0xa9059cbb"}]`), &params))
+	f, err = newPendingTxFilter(params)
+	require.NoError(t, err)
+	require.False(t, f.fullTx)
+	require.True(t, f.needDecode())
+
+	tx := &rpctypes.Transaction{From: from, To: &to, Input: hexutil.MustDecode("0xa9059cbb0000")}
+	require.True(t, f.match(tx))
+	tx.Input = hexutil.MustDecode("0x095ea7b30000")
+	require.False(t, f.match(tx))
+	tx.Input = hexutil.MustDecode("0xa9059cbb0000")
+	tx.From = to
+	require.False(t, f.match(tx))
+	tx.From, tx.To = from, nil
+	require.False(t, f.match(tx))
+
+	for _, invalid := range []string{`[1]`, `[{"from": "0x01"}]`, `[{"selectors": ["0xa9059c"]}]`, `[{"foo": 1}]`} {
+		require.NoError(t, json.Unmarshal([]byte(invalid), &params))
+		_, err = newPendingTxFilter(params)
+		require.Error(t, err, invalid)
+	}
+}
@@ -12,7 +12,13 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/eth/filters"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/prometheus"
 	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
+	"github.com/okex/exchain/libs/cosmos-sdk/server"
+	"github.com/okex/exchain/x/common/monitor"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/viper"
 
 	rpcfilters "github.com/okex/exchain/app/rpc/namespaces/eth/filters"
 	rpctypes "github.com/okex/exchain/app/rpc/types"
@@ -26,16 +32,42 @@ type PubSubAPI struct {
 	filtersMu *sync.RWMutex
 	filters   map[rpc.ID]*wsSubscription
 	logger    log.Logger
+
+	droppedPendingTxs metrics.Counter
+	slowConsumers     metrics.Counter
 }
 
+var (
+	droppedPendingTxsCounter metrics.Counter
+	slowConsumersCounter     metrics.Counter
+	pubSubMetricsOnce        sync.Once
+)
+
 // NewAPI creates an instance of the ethereum PubSub API.
 func NewAPI(clientCtx context.CLIContext, log log.Logger) *PubSubAPI {
+	// the counters are registered to prometheus once, since registering them twice panics
+	pubSubMetricsOnce.Do(func() {
+		droppedPendingTxsCounter = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: monitor.XNameSpace,
+			Subsystem: "websocket",
+			Name:      "pending_tx_dropped",
+			Help:      "the number of pending txs dropped for the slow subscribers",
+		}, nil)
+		slowConsumersCounter = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: monitor.XNameSpace,
+			Subsystem: "websocket",
+			Name:      "slow_consumer_disconnected",
+			Help:      "the number of the slow subscribers of pending txs disconnected",
+		}, nil)
+	})
 	return &PubSubAPI{
-		clientCtx: clientCtx,
-		events:    rpcfilters.NewEventSystem(clientCtx.Client),
-		filtersMu: new(sync.RWMutex),
-		filters:   make(map[rpc.ID]*wsSubscription),
-		logger:    log.With("module", "websocket-client"),
+		clientCtx:         clientCtx,
+		events:            rpcfilters.NewEventSystem(clientCtx.Client),
+		filtersMu:         new(sync.RWMutex),
+		filters:           make(map[rpc.ID]*wsSubscription),
+		logger:            log.With("module", "websocket-client"),
+		droppedPendingTxs: droppedPendingTxsCounter,
+		slowConsumers:     slowConsumersCounter,
 	}
 }
 
@@ -56,9 +88,14 @@ func (api *PubSubAPI) subscribe(conn *wsConn, params []interface{}) (rpc.ID, err
 
 		return api.subscribeLogs(conn, nil)
 	case "newPendingTransactions":
-		return api.subscribePendingTransactions(conn)
+		return api.subscribePendingTransactions(conn, params[1:])
 	case "syncing":
 		return api.subscribeSyncing(conn)
+	case dexDealsSubscription, dexDepthSubscription, dexOrdersSubscription, ammSwapsSubscription:
+		if len(params) > 1 {
+			return api.subscribeModuleEvents(conn, method, params[1])
+		}
+		return api.subscribeModuleEvents(conn, method, nil)
 	default:
 		return "0", fmt.Errorf("unsupported method %s", method)
 	}
@@ -75,6 +112,9 @@ func (api *PubSubAPI) unsubscribe(id rpc.ID) bool {
 	if api.filters[id].sub != nil {
 		api.filters[id].sub.Unsubscribe(api.events)
 	}
+	if api.filters[id].extraSub != nil {
+		api.filters[id].extraSub.Unsubscribe(api.events)
+	}
 	close(api.filters[id].unsubscribed)
 	delete(api.filters, id)
 	api.logger.Debug("close client channel & delete client from filters", "ID", id)
@@ -353,7 +393,11 @@ func isHex(str string) bool {
 	return true
 }
 
-func (api *PubSubAPI) subscribePendingTransactions(conn *wsConn) (rpc.ID, error) {
+func (api *PubSubAPI) subscribePendingTransactions(conn *wsConn, params []interface{}) (rpc.ID, error) {
+	f, err := newPendingTxFilter(params)
+	if err != nil {
+		return "", err
+	}
 	sub, _, err := api.events.SubscribePendingTxs()
 	if err != nil {
 		return "", fmt.Errorf("error creating block filter: %s", err.Error())
@@ -368,6 +412,43 @@ func (api *PubSubAPI) subscribePendingTransactions(conn *wsConn) (rpc.ID, error)
 	}
 	api.filtersMu.Unlock()
 
+	// the txs are buffered for the slow consumer, they are dropped when the buffer is full, or the consumer is
+	// disconnected if FlagWsDisconnectSlow is set
+	queue := make(chan interface{}, viper.GetInt(server.FlagWsPendingTxBuffer))
+	disconnectSlow := viper.GetBool(server.FlagWsDisconnectSlow)
+
+	go func() {
+		for {
+			select {
+			case result := <-queue:
+				api.filtersMu.RLock()
+				_, found := api.filters[sub.ID()]
+				api.filtersMu.RUnlock()
+				if !found {
+					return
+				}
+
+				// write to ws conn
+				res := &SubscriptionNotification{
+					Jsonrpc: "2.0",
+					Method:  "eth_subscription",
+					Params: &SubscriptionResult{
+						Subscription: sub.ID(),
+						Result:       result,
+					},
+				}
+				if err := conn.WriteJSON(res); err != nil {
+					api.logger.Error("failed to write pending tx", "ID", sub.ID(), "error", err)
+					api.unsubscribe(sub.ID())
+					return
+				}
+				api.logger.Debug("successfully write pending tx", "ID", sub.ID())
+			case <-unsubscribed:
+				return
+			}
+		}
+	}()
+
 	go func(txsCh <-chan coretypes.ResultEvent, errCh <-chan error) {
 		for {
 			select {
@@ -379,29 +460,34 @@ func (api *PubSubAPI) subscribePendingTransactions(conn *wsConn) (rpc.ID, error)
 				}
 				txHash := common.BytesToHash(data.Tx.Hash(data.Height))
 
-				api.filtersMu.RLock()
-				if f, found := api.filters[sub.ID()]; found {
-					// write to ws conn
-					res := &SubscriptionNotification{
-						Jsonrpc: "2.0",
-						Method:  "eth_subscription",
-						Params: &SubscriptionResult{
-							Subscription: sub.ID(),
-							Result:       txHash,
-						},
-					}
-
-					err = f.conn.WriteJSON(res)
+				var result interface{} = txHash
+				if f.needDecode() {
+					// the txs which are not evm txs can't be filtered
+					ethTx, err := rpctypes.RawTxToEthTx(api.clientCtx, data.Tx)
 					if err != nil {
-						api.logger.Error("failed to write pending tx", "ID", sub.ID(), "error", err)
-					} else {
-						api.logger.Debug("successfully write pending tx", "ID", sub.ID(), "txhash", txHash)
+						continue
+					}
+					tx, err := rpctypes.NewTransaction(ethTx, txHash, common.Hash{}, uint64(data.Height), 0)
+					if err != nil || !f.match(tx) {
+						continue
+					}
+					if f.fullTx {
+						result = tx
 					}
 				}
-				api.filtersMu.RUnlock()
 
-				if err != nil {
-					api.unsubscribe(sub.ID())
+				select {
+				case queue <- result:
+				default:
+					api.droppedPendingTxs.Add(1)
+					if disconnectSlow {
+						api.slowConsumers.Add(1)
+						api.logger.Info("disconnect the slow consumer of pending txs", "ID", sub.ID())
+						// close the conn first to break the write blocked by the consumer
+						_ = conn.Close()
+						api.unsubscribe(sub.ID())
+						return
+					}
 				}
 			case err := <-errCh:
 				if err != nil {
@@ -0,0 +1,272 @@
+package websockets
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	coretypes "github.com/okex/exchain/libs/tendermint/rpc/core/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+
+	rpcfilters "github.com/okex/exchain/app/rpc/namespaces/eth/filters"
+	ammswap "github.com/okex/exchain/x/ammswap/types"
+	order "github.com/okex/exchain/x/order/types"
+)
+
+// subscriptions of the dex, which are fed by the events of the order and ammswap modules
+const (
+	dexDealsSubscription  = "dexDeals"
+	dexDepthSubscription  = "dexDepth"
+	dexOrdersSubscription = "dexOrders"
+	ammSwapsSubscription  = "ammSwaps"
+)
+
+// ModuleEvent is the notification of the dex subscriptions, it's an event emitted by a module in a block or a tx
+type ModuleEvent struct {
+	BlockNumber hexutil.Uint64    `json:"blockNumber"`
+	TxHash      *common.Hash      `json:"transactionHash,omitempty"`
+	Type        string            `json:"type"`
+	Attributes  map[string]string `json:"attributes"`
+}
+
+// moduleEventFilter selects the events of a dex subscription
+type moduleEventFilter struct {
+	eventType string
+	// the events are filtered by the value of the attribute, an empty value selects all the events of the type
+	attrKey   string
+	attrValue string
+	// the sources of the events, the end blocker and the txs
+	inBlocks bool
+	inTxs    bool
+}
+
+func (f moduleEventFilter) query(tmEvent string) string {
+	if f.attrValue == "" {
+		return fmt.Sprintf("%s='%s' AND %s.%s EXISTS", tmtypes.EventTypeKey, tmEvent, f.eventType, f.attrKey)
+	}
+	return fmt.Sprintf("%s='%s' AND %s.%s='%s'", tmtypes.EventTypeKey, tmEvent, f.eventType, f.attrKey, f.attrValue)
+}
+
+func (f moduleEventFilter) match(event abci.Event) bool {
+	if event.Type != f.eventType {
+		return false
+	}
+	if f.attrValue == "" {
+		return true
+	}
+	for _, attr := range event.Attributes {
+		if string(attr.Key) == f.attrKey {
+			return string(attr.Value) == f.attrValue
+		}
+	}
+	return false
+}
+
+// newModuleEventFilter creates the filter of the dex subscription, extra is the criteria of the subscription,
+// e.g. {"product": "xxb_okt"}
+func newModuleEventFilter(method string, extra interface{}) (moduleEventFilter, error) {
+	var f moduleEventFilter
+	var criteria string
+	switch method {
+	case dexDealsSubscription:
+		f = moduleEventFilter{eventType: order.EventTypeDeal, attrKey: order.AttributeKeyProduct, inBlocks: true, inTxs: true}
+		criteria = "product"
+	case dexDepthSubscription:
+		f = moduleEventFilter{eventType: order.EventTypeDepthBook, attrKey: order.AttributeKeyProduct, inBlocks: true}
+		criteria = "product"
+	case dexOrdersSubscription:
+		f = moduleEventFilter{eventType: order.EventTypeOrderUpdate, attrKey: order.AttributeKeySender, inBlocks: true, inTxs: true}
+		criteria = "address"
+	case ammSwapsSubscription:
+		f = moduleEventFilter{eventType: ammswap.EventTypeSwap, attrKey: ammswap.AttributeKeyPool, inTxs: true}
+		criteria = "pool"
+	default:
+		return f, fmt.Errorf("unsupported method %s", method)
+	}
+
+	if extra == nil {
+		return f, nil
+	}
+	params, ok := extra.(map[string]interface{})
+	if !ok {
+		return f, fmt.Errorf("invalid criteria")
+	}
+	value, ok := params[criteria]
+	if !ok {
+		return f, nil
+	}
+	if f.attrValue, ok = value.(string); !ok {
+		return f, fmt.Errorf("invalid %s", criteria)
+	}
+	if f.attrValue == "" {
+		return f, nil
+	}
+	// the value is checked before it's put into the event query
+	switch criteria {
+	case "address":
+		// the sender of the order events is a bech32 address
+		if common.IsHexAddress(f.attrValue) {
+			f.attrValue = sdk.AccAddress(common.HexToAddress(f.attrValue).Bytes()).String()
+		} else if _, err := sdk.AccAddressFromBech32(f.attrValue); err != nil {
+			return f, fmt.Errorf("invalid address %s", f.attrValue)
+		}
+	case "product", "pool":
+		if err := validateTokenPairName(f.attrValue); err != nil {
+			return f, fmt.Errorf("invalid %s %q", criteria, f.attrValue)
+		}
+	}
+	return f, nil
+}
+
+// validateTokenPairName checks the name of a product or a swap pool, which is its base and quote denoms joined by "_"
+func validateTokenPairName(name string) error {
+	denoms := strings.Split(name, "_")
+	if len(denoms) != 2 {
+		return fmt.Errorf("invalid token pair name %s", name)
+	}
+	for _, denom := range denoms {
+		if err := sdk.ValidateDenom(denom); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (api *PubSubAPI) subscribeModuleEvents(conn *wsConn, method string, extra interface{}) (rpc.ID, error) {
+	f, err := newModuleEventFilter(method, extra)
+	if err != nil {
+		return "", err
+	}
+
+	var blockSub, txSub *rpcfilters.Subscription
+	if f.inBlocks {
+		if blockSub, _, err = api.events.SubscribeModuleEvents(f.query(tmtypes.EventNewBlockHeader)); err != nil {
+			return "", fmt.Errorf("error creating %s filter: %s", method, err.Error())
+		}
+	}
+	if f.inTxs {
+		if txSub, _, err = api.events.SubscribeModuleEvents(f.query(tmtypes.EventTx)); err != nil {
+			if blockSub != nil {
+				blockSub.Unsubscribe(api.events)
+			}
+			return "", fmt.Errorf("error creating %s filter: %s", method, err.Error())
+		}
+	}
+	// the subscription is identified by its first subscription of the events
+	sub, extraSub := blockSub, txSub
+	if sub == nil {
+		sub, extraSub = txSub, nil
+	}
+
+	unsubscribed := make(chan struct{})
+	api.filtersMu.Lock()
+	api.filters[sub.ID()] = &wsSubscription{
+		sub:          sub,
+		extraSub:     extraSub,
+		conn:         conn,
+		unsubscribed: unsubscribed,
+	}
+	api.filtersMu.Unlock()
+
+	var blockCh, txCh <-chan coretypes.ResultEvent
+	var blockErrCh, txErrCh <-chan error
+	if blockSub != nil {
+		blockCh, blockErrCh = blockSub.Event(), blockSub.Err()
+	}
+	if txSub != nil {
+		txCh, txErrCh = txSub.Event(), txSub.Err()
+	}
+
+	go func() {
+		for {
+			var events []*ModuleEvent
+			select {
+			case ev := <-blockCh:
+				data, ok := ev.Data.(tmtypes.EventDataNewBlockHeader)
+				if !ok {
+					api.logger.Error(fmt.Sprintf("invalid data type %T, expected EventDataNewBlockHeader", ev.Data), "ID", sub.ID())
+					continue
+				}
+				events = filterModuleEvents(f, data.ResultEndBlock.Events, data.Header.Height, nil)
+			case ev := <-txCh:
+				data, ok := ev.Data.(tmtypes.EventDataTx)
+				if !ok {
+					api.logger.Error(fmt.Sprintf("invalid data type %T, expected EventDataTx", ev.Data), "ID", sub.ID())
+					continue
+				}
+				if data.Result.IsErr() {
+					continue
+				}
+				txHash := common.BytesToHash(data.Tx.Hash(data.Height))
+				events = filterModuleEvents(f, data.Result.Events, data.Height, &txHash)
+			case err := <-blockErrCh:
+				if err != nil {
+					api.unsubscribe(sub.ID())
+					api.logger.Error("websocket recv error, close the conn", "ID", sub.ID(), "error", err)
+				}
+				return
+			case err := <-txErrCh:
+				if err != nil {
+					api.unsubscribe(sub.ID())
+					api.logger.Error("websocket recv error, close the conn", "ID", sub.ID(), "error", err)
+				}
+				return
+			case <-unsubscribed:
+				api.logger.Debug(fmt.Sprintf("%s channel is closed", method), "ID", sub.ID())
+				return
+			}
+
+			var err error
+			api.filtersMu.RLock()
+			if s, found := api.filters[sub.ID()]; found {
+				for _, event := range events {
+					// write to ws conn
+					res := &SubscriptionNotification{
+						Jsonrpc: "2.0",
+						Method:  "eth_subscription",
+						Params: &SubscriptionResult{
+							Subscription: sub.ID(),
+							Result:       event,
+						},
+					}
+					if err = s.conn.WriteJSON(res); err != nil {
+						api.logger.Error(fmt.Sprintf("failed to write %s event", method), "ID", sub.ID(), "error", err)
+						break
+					}
+				}
+			}
+			api.filtersMu.RUnlock()
+
+			if err != nil {
+				api.unsubscribe(sub.ID())
+			}
+		}
+	}()
+
+	return sub.ID(), nil
+}
+
+// filterModuleEvents returns the events selected by the filter
+func filterModuleEvents(f moduleEventFilter, events []abci.Event, height int64, txHash *common.Hash) []*ModuleEvent {
+	var res []*ModuleEvent
+	for _, event := range events {
+		if !f.match(event) {
+			continue
+		}
+		attrs := make(map[string]string, len(event.Attributes))
+		for _, attr := range event.Attributes {
+			attrs[string(attr.Key)] = string(attr.Value)
+		}
+		res = append(res, &ModuleEvent{
+			BlockNumber: hexutil.Uint64(height),
+			TxHash:      txHash,
+			Type:        event.Type,
+			Attributes:  attrs,
+		})
+	}
+	return res
+}
@@ -0,0 +1,30 @@
+package websockets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+)
+
+func TestModuleEventFilter(t *testing.T) {
+	f, err := newModuleEventFilter(dexDealsSubscription, map[string]interface{}{"product": "xxb-a4c_okt"})
+	require.NoError(t, err)
+	require.Equal(t, "tm.event='Tx' AND order_deal.product='xxb-a4c_okt'", f.query(tmtypes.EventTx))
+
+	f, err = newModuleEventFilter(ammSwapsSubscription, nil)
+	require.NoError(t, err)
+	require.Equal(t, "tm.event='Tx' AND swap_token.pool EXISTS", f.query(tmtypes.EventTx))
+
+	// the criteria can't inject into the query
+	for _, value := range []string{"okt' OR tm.event='Tx", "xxb_okt_usdt", "okt", "XXB_okt"} {
+		_, err = newModuleEventFilter(dexDepthSubscription, map[string]interface{}{"product": value})
+		require.Error(t, err, value)
+		_, err = newModuleEventFilter(ammSwapsSubscription, map[string]interface{}{"pool": value})
+		require.Error(t, err, value)
+	}
+
+	_, err = newModuleEventFilter(dexOrdersSubscription, map[string]interface{}{"address": "ex1' OR 1=1"})
+	require.Error(t, err)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/go-kit/kit/metrics/prometheus"
 	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
+	"github.com/okex/exchain/app/rpc/ratelimit"
 	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
 	"github.com/okex/exchain/libs/cosmos-sdk/server"
 	"github.com/okex/exchain/libs/tendermint/libs/log"
@@ -23,12 +24,19 @@ import (
 	"github.com/spf13/viper"
 )
 
+var (
+	currentConnGauge metrics.Gauge
+	maxConnGauge     metrics.Gauge
+	connMetricsOnce  sync.Once
+)
+
 // Server defines a server that handles Ethereum websockets.
 type Server struct {
 	rpcAddr string // listen address of rest-server
 	wsAddr  string // listen address of ws server
 	api     *PubSubAPI
 	logger  log.Logger
+	limiter *ratelimit.Limiter
 
 	connPool       chan struct{}
 	connPoolLock   *sync.Mutex
@@ -36,8 +44,8 @@ type Server struct {
 	maxConnNum     metrics.Gauge
 }
 
-// NewServer creates a new websocket server instance.
-func NewServer(clientCtx context.CLIContext, log log.Logger, wsAddr string) *Server {
+// NewServer creates a new websocket server instance, the requests of the connections are limited by limiter.
+func NewServer(clientCtx context.CLIContext, log log.Logger, wsAddr string, limiter *ratelimit.Limiter) *Server {
 	restServerAddr := viper.GetString(server.FlagListenAddr)
 	parts := strings.SplitN(restServerAddr, "://", 2)
 	if len(parts) != 2 {
@@ -50,25 +58,32 @@ func NewServer(clientCtx context.CLIContext, log log.Logger, wsAddr string) *Ser
 	}
 	port := urlParts[1]
 
-	return &Server{
-		rpcAddr:      "http://localhost:" + port,
-		wsAddr:       wsAddr,
-		api:          NewAPI(clientCtx, log),
-		logger:       log.With("module", "websocket-server"),
-		connPool:     make(chan struct{}, viper.GetInt(server.FlagWsMaxConnections)),
-		connPoolLock: new(sync.Mutex),
-		currentConnNum: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+	// the gauges are registered to prometheus once, since registering them twice panics
+	connMetricsOnce.Do(func() {
+		currentConnGauge = prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
 			Namespace: monitor.XNameSpace,
 			Subsystem: "websocket",
 			Name:      "connection_number",
 			Help:      "the number of current websocket client connections",
-		}, nil),
-		maxConnNum: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+		}, nil)
+		maxConnGauge = prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
 			Namespace: monitor.XNameSpace,
 			Subsystem: "websocket",
 			Name:      "connection_capacity",
 			Help:      "the capacity number of websocket client connections",
-		}, nil),
+		}, nil)
+	})
+
+	return &Server{
+		rpcAddr:        "http://localhost:" + port,
+		wsAddr:         wsAddr,
+		api:            NewAPI(clientCtx, log),
+		logger:         log.With("module", "websocket-server"),
+		limiter:        limiter,
+		connPool:       make(chan struct{}, viper.GetInt(server.FlagWsMaxConnections)),
+		connPoolLock:   new(sync.Mutex),
+		currentConnNum: currentConnGauge,
+		maxConnNum:     maxConnGauge,
 	}
 }
 
@@ -88,6 +103,12 @@ func (s *Server) Start() {
 }
 
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	client, err := s.limiter.ClientFromRequest(r)
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
 	s.connPoolLock.Lock()
 	defer s.connPoolLock.Unlock()
 	if len(s.connPool) >= cap(s.connPool) {
@@ -110,16 +131,22 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	s.connPool <- struct{}{}
 	s.currentConnNum.Set(float64(len(s.connPool)))
 	go s.readLoop(&wsConn{
-		mux:  new(sync.Mutex),
-		conn: conn,
+		mux:     new(sync.Mutex),
+		conn:    websocketConn{conn},
+		client:  client,
+		onClose: s.releaseConn,
 	})
 }
 
 func (s *Server) sendErrResponse(conn *wsConn, msg string) {
+	s.sendErrResponseWithCode(conn, -32600, msg)
+}
+
+func (s *Server) sendErrResponseWithCode(conn *wsConn, code int64, msg string) {
 	res := &ErrorResponseJSON{
 		Jsonrpc: "2.0",
 		Error: &ErrorMessageJSON{
-			Code:    big.NewInt(-32600),
+			Code:    big.NewInt(code),
 			Message: msg,
 		},
 		ID: big.NewInt(1),
@@ -130,9 +157,32 @@ func (s *Server) sendErrResponse(conn *wsConn, msg string) {
 	}
 }
 
+// msgConn is the transport of the json-rpc messages of a connection, a websocket or an ipc connection
+type msgConn interface {
+	ReadMessage() ([]byte, error)
+	WriteJSON(v interface{}) error
+	Close() error
+}
+
+// websocketConn reads the messages of a websocket connection regardless of their types
+type websocketConn struct {
+	*websocket.Conn
+}
+
+func (c websocketConn) ReadMessage() ([]byte, error) {
+	_, p, err := c.Conn.ReadMessage()
+	return p, err
+}
+
 type wsConn struct {
-	conn *websocket.Conn
+	conn msgConn
 	mux  *sync.Mutex
+	// the client of the rate limits, identified when the connection is upgraded
+	client ratelimit.Client
+	// handler serves the requests in-process if it is set, otherwise they are posted to the rest-server
+	handler http.Handler
+	// onClose is called when the connection is closed
+	onClose func()
 }
 
 func (w *wsConn) WriteJSON(v interface{}) error {
@@ -142,14 +192,12 @@ func (w *wsConn) WriteJSON(v interface{}) error {
 	return w.conn.WriteJSON(v)
 }
 
+// Close is not protected by the write mutex, so that it breaks the pending write blocked by a slow consumer
 func (w *wsConn) Close() error {
-	w.mux.Lock()
-	defer w.mux.Unlock()
-
 	return w.conn.Close()
 }
 
-func (w *wsConn) ReadMessage() (messageType int, p []byte, err error) {
+func (w *wsConn) ReadMessage() ([]byte, error) {
 	// not protected by write mutex
 
 	return w.conn.ReadMessage()
@@ -158,14 +206,19 @@ func (w *wsConn) ReadMessage() (messageType int, p []byte, err error) {
 func (s *Server) readLoop(wsConn *wsConn) {
 	subIds := make(map[rpc.ID]struct{})
 	for {
-		_, mb, err := wsConn.ReadMessage()
+		mb, err := wsConn.ReadMessage()
 		if err != nil {
 			_ = wsConn.Close()
 			s.logger.Error("failed to read message, close the websocket connection.", "error", err)
-			s.closeWsConnection(subIds)
+			s.closeWsConnection(wsConn, subIds)
 			return
 		}
 
+		if err = s.limiter.Allow(wsConn.client, ratelimit.ParseMethods(mb), ratelimit.TransportWS); err != nil {
+			s.sendErrResponseWithCode(wsConn, ratelimit.ErrCodeLimitExceeded, err.Error())
+			continue
+		}
+
 		var msg map[string]interface{}
 		if err = json.Unmarshal(mb, &msg); err != nil {
 			if err = s.batchCall(mb, wsConn); err != nil {
@@ -175,8 +228,8 @@ func (s *Server) readLoop(wsConn *wsConn) {
 		}
 
 		// check if method == eth_subscribe or eth_unsubscribe
-		method := msg["method"]
-		if method.(string) == "eth_subscribe" {
+		method, _ := msg["method"].(string)
+		if method == "eth_subscribe" {
 			params := msg["params"].([]interface{})
 			if len(params) == 0 {
 				s.sendErrResponse(wsConn, "invalid parameters")
@@ -209,7 +262,7 @@ func (s *Server) readLoop(wsConn *wsConn) {
 			s.logger.Debug("successfully subscribe", "ID", id)
 			subIds[id] = struct{}{}
 			continue
-		} else if method.(string) == "eth_unsubscribe" {
+		} else if method == "eth_unsubscribe" {
 			ids, ok := msg["params"].([]interface{})
 			if len(ids) == 0 {
 				s.sendErrResponse(wsConn, "invalid parameters")
@@ -255,11 +308,17 @@ func (s *Server) readLoop(wsConn *wsConn) {
 // tcpGetAndSendResponse connects to the rest-server over tcp, posts a JSON-RPC request, and sends the response
 // to the client over websockets
 func (s *Server) tcpGetAndSendResponse(conn *wsConn, mb []byte) error {
+	if conn.handler != nil {
+		return s.serveAndSendResponse(conn, mb)
+	}
+
 	req, err := http.NewRequest(http.MethodPost, s.rpcAddr, bytes.NewReader(mb))
 	if err != nil {
 		return fmt.Errorf("failed to request; %s", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
+	// the limits of the request have been applied by the websocket server
+	s.limiter.SetInternal(req)
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to write to rest-server; %s", err)
@@ -280,11 +339,40 @@ func (s *Server) tcpGetAndSendResponse(conn *wsConn, mb []byte) error {
 	return conn.WriteJSON(wsSend)
 }
 
-func (s *Server) closeWsConnection(subIds map[rpc.ID]struct{}) {
+// serveAndSendResponse serves a JSON-RPC request by the in-process handler of the connection, and sends the response
+// to the client
+func (s *Server) serveAndSendResponse(conn *wsConn, mb []byte) error {
+	req, err := http.NewRequest(http.MethodPost, "/", bytes.NewReader(mb))
+	if err != nil {
+		return fmt.Errorf("failed to request; %s", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp := newResponseBuffer()
+	conn.handler.ServeHTTP(resp, req)
+	if resp.code != http.StatusOK {
+		return fmt.Errorf("failed to serve the request; %s", strings.TrimSpace(resp.body.String()))
+	}
+
+	body := bytes.TrimSpace(resp.body.Bytes())
+	if len(body) == 0 {
+		// no response to a notification
+		return nil
+	}
+	return conn.WriteJSON(json.RawMessage(body))
+}
+
+func (s *Server) closeWsConnection(conn *wsConn, subIds map[rpc.ID]struct{}) {
 	for id := range subIds {
 		s.api.unsubscribe(id)
 		delete(subIds, id)
 	}
+	if conn.onClose != nil {
+		conn.onClose()
+	}
+}
+
+// releaseConn releases the slot of a closed websocket connection in the connection pool
+func (s *Server) releaseConn() {
 	s.connPoolLock.Lock()
 	defer s.connPoolLock.Unlock()
 	<-s.connPool
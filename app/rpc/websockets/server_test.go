@@ -0,0 +1,22 @@
+package websockets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
+	"github.com/okex/exchain/libs/cosmos-sdk/server"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/spf13/viper"
+)
+
+func TestNewServerTwice(t *testing.T) {
+	viper.Set(server.FlagListenAddr, "tcp://0.0.0.0:8545")
+	defer viper.Set(server.FlagListenAddr, nil)
+
+	require.NotPanics(t, func() {
+		NewServer(context.CLIContext{}, log.NewNopLogger(), "8546", nil)
+		NewServer(context.CLIContext{}, log.NewNopLogger(), "8547", nil)
+	})
+}
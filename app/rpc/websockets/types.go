@@ -38,6 +38,7 @@ type ErrorMessageJSON struct {
 
 type wsSubscription struct {
 	sub          *rpcfilters.Subscription
-	unsubscribed chan struct{} // closed when unsubscribing
+	extraSub     *rpcfilters.Subscription // the subscription of the tx events of the dex subscriptions
+	unsubscribed chan struct{}            // closed when unsubscribing
 	conn         *wsConn
 }
@@ -0,0 +1,217 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/okex/exchain/app"
+	"github.com/okex/exchain/app/rpc"
+	"github.com/okex/exchain/libs/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	flagExec = "exec"
+
+	maxAttachLineBytes = 1024 * 1024
+)
+
+// AttachCommand sends the json-rpc requests to the ipc socket of a running node
+func AttachCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "attach [ipc-path]",
+		Short: "Attach to the IPC socket of a running exchaind and send JSON-RPC requests",
+		Long: `Attach to the IPC socket of a running exchaind, which is exchaind.ipc in the node home by default. The node
+home is the --home directory if it is set, otherwise the default home of exchaind.
+
+Every line is a method with an optional JSON array of params, or a raw JSON-RPC request:
+
+	eth_blockNumber
+	eth_getBalance ["0x5d9cA1eb6c3f7F7Bb4B7F5fDAa8d2C1E1c3D9a6B", "latest"]
+	eth_subscribe ["newHeads"]
+
+The responses and the notifications of the subscriptions are printed as they arrive, "exit" detaches.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			home := app.DefaultNodeHome
+			if cmd.Flags().Changed(flags.FlagHome) {
+				home = viper.GetString(flags.FlagHome)
+			}
+			endpoint := rpc.IPCEndpoint(home, rpc.DefaultIPCPath)
+			if len(args) == 1 {
+				endpoint = args[0]
+			}
+			conn, err := net.Dial("unix", endpoint)
+			if err != nil {
+				return fmt.Errorf("failed to attach to %s: %w", endpoint, err)
+			}
+			defer conn.Close()
+
+			exec, err := cmd.Flags().GetString(flagExec)
+			if err != nil {
+				return err
+			}
+			if exec != "" {
+				return attachExec(conn, exec, cmd.OutOrStdout())
+			}
+			return attachConsole(conn, cmd.InOrStdin(), cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().String(flagExec, "", "Send a single request and print its result")
+	return cmd
+}
+
+type attachRequest struct {
+	Jsonrpc string          `json:"jsonrpc"`
+	ID      int             `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+type attachResponse struct {
+	ID     json.RawMessage `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// parseAttachRequest builds the json-rpc request of a console line
+func parseAttachRequest(line string, id int) ([]byte, error) {
+	if strings.HasPrefix(line, "{") || strings.HasPrefix(line, "[") {
+		if !json.Valid([]byte(line)) {
+			return nil, errors.New("invalid json request")
+		}
+		return []byte(line), nil
+	}
+
+	parts := strings.SplitN(line, " ", 2)
+	params := json.RawMessage("[]")
+	if len(parts) == 2 {
+		params = json.RawMessage(strings.TrimSpace(parts[1]))
+		var array []json.RawMessage
+		if err := json.Unmarshal(params, &array); err != nil {
+			return nil, fmt.Errorf("the params must be a json array: %s", err)
+		}
+	}
+	return json.Marshal(attachRequest{Jsonrpc: "2.0", ID: id, Method: parts[0], Params: params})
+}
+
+// attachExec sends a request and prints the result of its response
+func attachExec(conn net.Conn, line string, out io.Writer) error {
+	req, err := parseAttachRequest(strings.TrimSpace(line), 1)
+	if err != nil {
+		return err
+	}
+	if _, err = conn.Write(req); err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(conn)
+	for {
+		var msg json.RawMessage
+		if err := dec.Decode(&msg); err != nil {
+			return err
+		}
+		// the responses of a batch are printed as they are
+		if bytes.HasPrefix(msg, []byte("[")) {
+			return printJSON(out, msg)
+		}
+		var res attachResponse
+		if err := json.Unmarshal(msg, &res); err != nil {
+			return err
+		}
+		// skip the notifications of the subscriptions
+		if len(res.ID) == 0 {
+			continue
+		}
+		if res.Error != nil {
+			return fmt.Errorf("%s (code %d)", res.Error.Message, res.Error.Code)
+		}
+		return printJSON(out, res.Result)
+	}
+}
+
+// attachConsole sends the requests read from in, and prints the messages from the node until in is closed or the
+// connection is lost
+func attachConsole(conn net.Conn, in io.Reader, out io.Writer) error {
+	var mtx sync.Mutex
+	printLocked := func(f func() error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		if err := f(); err != nil {
+			fmt.Fprintln(out, err)
+		}
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		dec := json.NewDecoder(conn)
+		for {
+			var msg json.RawMessage
+			if err := dec.Decode(&msg); err != nil {
+				done <- err
+				return
+			}
+			printLocked(func() error { return printJSON(out, msg) })
+		}
+	}()
+
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(in)
+		scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxAttachLineBytes)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+
+	for id := 1; ; {
+		select {
+		case err := <-done:
+			if err == io.EOF {
+				return errors.New("the connection is closed by the node")
+			}
+			return err
+		case line, ok := <-lines:
+			if !ok {
+				return nil
+			}
+			line = strings.TrimSpace(line)
+			switch line {
+			case "":
+				continue
+			case "exit", "quit":
+				return nil
+			}
+			req, err := parseAttachRequest(line, id)
+			if err != nil {
+				printLocked(func() error { return err })
+				continue
+			}
+			id++
+			if _, err = conn.Write(req); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func printJSON(out io.Writer, msg json.RawMessage) error {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, msg, "", "  "); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(out, buf.String())
+	return err
+}
@@ -1,6 +1,8 @@
 package client
 
 import (
+	"time"
+
 	"github.com/okex/exchain/app"
 	"github.com/okex/exchain/app/config"
 	"github.com/okex/exchain/app/rpc"
@@ -23,6 +25,7 @@ func RegisterAppFlag(cmd *cobra.Command) {
 	cmd.Flags().Bool(watcher.FlagFastQuery, false, "Enable the fast query mode for rpc queries")
 	cmd.Flags().Int(watcher.FlagFastQueryLru, 1000, "Set the size of LRU cache under fast-query mode")
 	cmd.Flags().Bool(watcher.FlagCheckWd, false, "Enable check watchDB in log")
+	cmd.Flags().Bool(watcher.FlagLogIndex, false, "Index the logs by address and first topic under fast-query mode, which answers eth_getLogs for arbitrary block ranges")
 	cmd.Flags().Bool(rpc.FlagPersonalAPI, true, "Enable the personal_ prefixed set of APIs in the Web3 JSON-RPC spec")
 	cmd.Flags().Bool(rpc.FlagDebugAPI, false, "Enable the debug_ prefixed set of APIs in the Web3 JSON-RPC spec")
 	cmd.Flags().Bool(evmtypes.FlagEnableBloomFilter, false, "Enable bloom filter for event logs")
@@ -47,6 +50,14 @@ func RegisterAppFlag(cmd *cobra.Command) {
 	cmd.Flags().String(rpc.FlagRateLimitAPI, "", "Set the RPC API to be controlled by the rate limit policy, such as \"eth_getLogs,eth_newFilter,eth_newBlockFilter,eth_newPendingTransactionFilter,eth_getFilterChanges\"")
 	cmd.Flags().Int(rpc.FlagRateLimitCount, 0, "Set the count of requests allowed per second of rpc rate limiter")
 	cmd.Flags().Int(rpc.FlagRateLimitBurst, 1, "Set the concurrent count of requests allowed of rpc rate limiter")
+	cmd.Flags().Float64(rpc.FlagRateLimitIPCount, 0, "Set the count of requests allowed per second of every client ip, weighted by the method costs (0 disables the limit)")
+	cmd.Flags().Int(rpc.FlagRateLimitIPBurst, 20, "Set the burst of requests allowed of every client ip")
+	cmd.Flags().String(rpc.FlagRateLimitKeysFile, "", "The json file of the api keys and the rate limits of their tiers, the key is passed by the X-Api-Key header or the apikey query")
+	cmd.Flags().String(rpc.FlagRateLimitMethodCost, "eth_getLogs=10,eth_getLogsPage=10,eth_call=5", "Set the cost weights of the rpc methods of the per-client rate limits, the other methods cost 1")
+	cmd.Flags().Int(rpc.FlagRateLimitProxies, 0, "Set the number of the trusted reverse proxies in front of the rpc server, the client ip of the rate limits is taken from the X-Forwarded-For header appended by them")
+	cmd.Flags().Bool(rpc.FlagGraphQL, false, "Enable the EIP-1767 graphql service on the /graphql route of the rest server")
+	cmd.Flags().String(rpc.FlagIPCPath, rpc.DefaultIPCPath, "Set the path of the IPC socket serving the JSON-RPC and websocket APIs, a relative path is in the home directory, empty to disable it")
+	cmd.Flags().String(rpc.FlagAdminToken, "", "Enable the admin_ prefixed set of APIs, authorized by this bearer token")
 	cmd.Flags().Uint64(config.FlagGasLimitBuffer, 50, "Percentage to increase gas limit")
 	cmd.Flags().String(rpc.FlagDisableAPI, "", "Set the RPC API to be disabled, such as \"eth_getLogs,eth_newFilter,eth_newBlockFilter,eth_newPendingTransactionFilter,eth_getFilterChanges\"")
 	cmd.Flags().Int(config.FlagDynamicGpWeight, 80, "The recommended weight of dynamic gas price [1,100])")
@@ -68,9 +79,20 @@ func RegisterAppFlag(cmd *cobra.Command) {
 
 	cmd.Flags().String(rpc.FlagKafkaAddr, "", "The address of kafka cluster to consume pending txs")
 	cmd.Flags().String(rpc.FlagKafkaTopic, "", "The topic that the kafka writer will produce messages to")
+	cmd.Flags().String(rpc.FlagPendingTxSinks, "", "Comma separated sinks of pending txs: kafka, webhook, file or socket (defaults to kafka if the kafka address and topic are set)")
+	cmd.Flags().String(rpc.FlagWebhookURL, "", "The http endpoint that the webhook sink posts pending txs to")
+	cmd.Flags().Int(rpc.FlagWebhookBatchSize, 100, "The max number of pending txs in one webhook post")
+	cmd.Flags().Duration(rpc.FlagWebhookFlushInterval, time.Second, "The interval to post the batched pending txs to the webhook")
+	cmd.Flags().Int(rpc.FlagWebhookMaxRetries, 3, "The max retries of a failed webhook post")
+	cmd.Flags().String(rpc.FlagPendingTxFilePath, "", "The jsonl file that the file sink appends pending txs to")
+	cmd.Flags().Int64(rpc.FlagPendingTxFileMaxSize, 100, "The size in MB at which the pending tx file is rotated")
+	cmd.Flags().Int(rpc.FlagPendingTxFileBackups, 10, "The number of rotated pending tx files to keep")
+	cmd.Flags().String(rpc.FlagPendingTxSocketPath, "", "The unix socket that the socket sink streams pending txs to")
 
 	cmd.Flags().Bool(config.FlagEnableDynamic, false, "Enable dynamic configuration for nodes")
 	cmd.Flags().String(config.FlagApollo, "", "Apollo connection config(IP|AppID|NamespaceName) for dynamic configuration")
+	cmd.Flags().String(config.FlagDynamicConfigFile, "", "Toml or yaml file of dynamic configuration, which is hot-applied when the file is changed")
+	cmd.Flags().String(config.FlagConfigAuditLog, "", "File to append the audit log of dynamic configuration changes")
 
 	// flags for evm trace
 	cmd.Flags().Bool(evmtypes.FlagEnableTraces, false, "Enable traces db to save evm transaction trace")
@@ -83,6 +105,9 @@ func RegisterAppFlag(cmd *cobra.Command) {
 	cmd.Flags().Bool(evmtypes.FlagTraceDisableReturnData, false, "Disable return data output for evm trace")
 	cmd.Flags().Bool(evmtypes.FlagTraceDebug, false, "Output full trace logs for evm")
 
+	// flags for evm inner tx
+	cmd.Flags().Bool(evmtypes.FlagEnableInnerTx, false, "Enable inner tx db to save the internal transactions of evm and cosmos txs")
+
 	cmd.Flags().Bool(config.FlagPprofAutoDump, false, "Enable auto dump pprof")
 	cmd.Flags().String(config.FlagPprofCollectInterval, "5s", "Interval for pprof dump loop")
 	cmd.Flags().Int(config.FlagPprofCpuTriggerPercentMin, 45, "TriggerPercentMin of cpu to dump pprof")
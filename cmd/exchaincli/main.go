@@ -67,6 +67,7 @@ func main() {
 	// Construct Root Command
 	rootCmd.AddCommand(
 		clientrpc.StatusCommand(),
+		client.AttachCommand(),
 		sdkclient.ConfigCmd(app.DefaultCLIHome),
 		queryCmd(cdc),
 		txCmd(cdc),
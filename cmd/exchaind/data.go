@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/okex/exchain/app"
 	bam "github.com/okex/exchain/libs/cosmos-sdk/baseapp"
 	"github.com/okex/exchain/libs/cosmos-sdk/client/flags"
 	"github.com/okex/exchain/libs/cosmos-sdk/server"
@@ -35,8 +36,10 @@ import (
 	"github.com/okex/exchain/x/ammswap"
 	"github.com/okex/exchain/x/dex"
 	distr "github.com/okex/exchain/x/distribution"
+	"github.com/okex/exchain/x/erc20"
 	"github.com/okex/exchain/x/evidence"
 	"github.com/okex/exchain/x/evm"
+	"github.com/okex/exchain/x/evm/watcher"
 	"github.com/okex/exchain/x/farm"
 	"github.com/okex/exchain/x/gov"
 	"github.com/okex/exchain/x/order"
@@ -51,6 +54,9 @@ const (
 	flagPruning   = "enable_pruning"
 	flagDBBackend = "db_backend"
 
+	flagFromHeight = "from-height"
+	flagToHeight   = "to-height"
+
 	blockDBName = "blockstore"
 	stateDBName = "state"
 	appDBName   = "application"
@@ -68,6 +74,7 @@ func dataCmd(ctx *server.Context) *cobra.Command {
 		pruningCmd(ctx),
 		queryCmd(ctx),
 		dbConvertCmd(ctx),
+		indexLogsCmd(ctx),
 	)
 
 	return cmd
@@ -453,7 +460,7 @@ func initAppStore(appDB dbm.DB) *rootmulti.Store {
 		supply.StoreKey, mint.StoreKey, distr.StoreKey, slashing.StoreKey,
 		gov.StoreKey, params.StoreKey, upgrade.StoreKey, evidence.StoreKey,
 		evm.StoreKey, token.StoreKey, token.KeyLock, dex.StoreKey, dex.TokenPairStoreKey,
-		order.OrderStoreKey, ammswap.StoreKey, farm.StoreKey,
+		order.OrderStoreKey, ammswap.StoreKey, farm.StoreKey, erc20.StoreKey,
 	)
 	tkeys := sdk.NewTransientStoreKeys(params.TStoreKey)
 
@@ -464,6 +471,7 @@ func initAppStore(appDB dbm.DB) *rootmulti.Store {
 	for _, key := range tkeys {
 		cms.MountStoreWithDB(key, sdk.StoreTypeTransient, nil)
 	}
+	cms.SetUpgradeVersion(keys[erc20.StoreKey], app.Erc20StoreUpgradeHeight())
 
 	err := cms.LoadLatestVersion()
 	if err != nil {
@@ -557,6 +565,43 @@ func queryCmd(ctx *server.Context) *cobra.Command {
 	return cmd
 }
 
+func indexLogsCmd(ctx *server.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "index-logs",
+		Short: "Index the logs of the existing blocks in the watcher db by address and first topic",
+		Long: `Index the logs of the existing blocks in the watcher db by address and first topic, which answers
+eth_getLogs for arbitrary block ranges. The node must be stopped, and the blocks must be continuous with
+the indexed blocks. Run the node with --fast-query-log-index to keep the index up to date.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// the watcher db is opened with the backend of the node
+			backend := dbm.BackendType(ctx.Config.DBBackend)
+			if err := checkBackend(backend); err != nil {
+				return err
+			}
+			viper.Set(watcher.FlagDBBackend, string(backend))
+			viper.Set(watcher.FlagFastQuery, true)
+			store := watcher.InstanceOfWatchStore()
+
+			start := time.Now()
+			fromBlock, toBlock := viper.GetUint64(flagFromHeight), viper.GetUint64(flagToHeight)
+			err := watcher.BackfillLogIndex(store, fromBlock, toBlock, func(height uint64) {
+				if height%10000 == 0 {
+					log.Printf("indexed the logs of block %d\n", height)
+				}
+			})
+			if err != nil {
+				return err
+			}
+			log.Printf("indexed the logs in %s\n", time.Since(start))
+			return nil
+		},
+	}
+	cmd.Flags().Uint64(flagFromHeight, 1, "The first block to index")
+	cmd.Flags().Uint64(flagToHeight, 0, "The last block to index, 0 is the latest block in the watcher db")
+	cmd.Flags().String(flagDBBackend, "goleveldb", "Database backend: goleveldb | rocksdb")
+	return cmd
+}
+
 type dbCompactor func(dbm.DB)
 
 var backends = map[dbm.BackendType]dbCompactor{}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/okex/exchain/app/logevents"
 	"io"
+	"path/filepath"
 
 	"github.com/okex/exchain/app/rpc"
 	evmtypes "github.com/okex/exchain/x/evm/types"
@@ -24,6 +25,7 @@ import (
 	clientkeys "github.com/okex/exchain/libs/cosmos-sdk/client/keys"
 	"github.com/okex/exchain/libs/cosmos-sdk/crypto/keys"
 	"github.com/okex/exchain/libs/cosmos-sdk/server"
+	"github.com/okex/exchain/libs/cosmos-sdk/snapshots"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
 
@@ -115,6 +117,7 @@ func closeApp(iApp abci.Application) {
 	app.StopStore()
 	evmtypes.CloseIndexer()
 	evmtypes.CloseTracer()
+	evmtypes.CloseInnerTxDB()
 	rpc.CloseEthBackend()
 }
 
@@ -124,6 +127,16 @@ func newApp(logger log.Logger, db dbm.DB, traceStore io.Writer) abci.Application
 		panic(err)
 	}
 
+	snapshotDir := filepath.Join(viper.GetString(cli.HomeFlag), "data", "snapshots")
+	snapshotDB, err := sdk.NewLevelDB("metadata", snapshotDir)
+	if err != nil {
+		panic(err)
+	}
+	snapshotStore, err := snapshots.NewStore(snapshotDB, snapshotDir)
+	if err != nil {
+		panic(err)
+	}
+
 	return app.NewOKExChainApp(
 		logger,
 		db,
@@ -134,6 +147,9 @@ func newApp(logger log.Logger, db dbm.DB, traceStore io.Writer) abci.Application
 		baseapp.SetPruning(pruningOpts),
 		baseapp.SetMinGasPrices(viper.GetString(server.FlagMinGasPrices)),
 		baseapp.SetHaltHeight(uint64(viper.GetInt(server.FlagHaltHeight))),
+		baseapp.SetSnapshotStore(snapshotStore),
+		baseapp.SetSnapshotInterval(viper.GetUint64(server.FlagStateSyncSnapshotInterval)),
+		baseapp.SetSnapshotKeepRecent(viper.GetUint32(server.FlagStateSyncSnapshotKeepRecent)),
 	)
 }
 
@@ -99,6 +99,11 @@ func replayCmd(ctx *server.Context) *cobra.Command {
 	cmd.Flags().String(types.FlagRedisAuth, "", "redis auth")
 	cmd.Flags().Int(types.FlagRedisExpire, 300, "delta expiration time. unit is second")
 	cmd.Flags().Int(types.FlagRedisDB, 0, "delta db num")
+	cmd.Flags().String(types.FlagDeltaBroker, "redis", "delta broker: redis|file|http")
+	cmd.Flags().String(types.FlagDeltaDir, "", "delta directory of the file delta broker")
+	cmd.Flags().String(types.FlagDeltaHttpUrl, "", "delta server url of the http delta broker, e.g. http://127.0.0.1:26680")
+	cmd.Flags().String(types.FlagDeltaHttpLaddr, "", "listen address of the delta server serving the delta broker of the node, the host defaults to 127.0.0.1, empty means disabled")
+	cmd.Flags().String(types.FlagDeltaHttpToken, "", "token shared by the delta server and the http delta brokers, required by the delta server listening on a non-loopback host")
 	cmd.Flags().Int(types.FlagDeltaVersion, types.DeltaVersion, "Specify delta version")
 	cmd.Flags().Bool(types.FlagFastQuery, false, "enable watch db or not")
 
@@ -2,6 +2,7 @@ package baseapp
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"sort"
@@ -11,6 +12,7 @@ import (
 	"time"
 
 	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	"github.com/okex/exchain/libs/cosmos-sdk/snapshots"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
 	"github.com/okex/exchain/libs/iavl"
@@ -298,6 +300,10 @@ func (app *BaseApp) Commit(req abci.RequestCommit) abci.ResponseCommit {
 		app.halt()
 	}
 
+	if app.snapshotInterval > 0 && uint64(header.Height)%app.snapshotInterval == 0 {
+		go app.snapshot(header.Height)
+	}
+
 	return abci.ResponseCommit{
 		Data:     commitID.Hash,
 		DeltaMap: output,
@@ -326,6 +332,33 @@ func (app *BaseApp) halt() {
 	os.Exit(0)
 }
 
+// snapshot takes a state sync snapshot of the multistore at height and prunes the old ones.
+// It runs asynchronously, so errors are only logged.
+func (app *BaseApp) snapshot(height int64) {
+	if app.snapshotManager == nil {
+		app.logger.Info("snapshot manager not configured")
+		return
+	}
+
+	app.logger.Info("creating state snapshot", "height", height)
+	snapshot, err := app.snapshotManager.Create(uint64(height))
+	if err != nil {
+		app.logger.Error("failed to create state snapshot", "height", height, "err", err)
+		return
+	}
+	app.logger.Info("completed state snapshot", "height", height, "format", snapshot.Format)
+
+	if app.snapshotKeepRecent > 0 {
+		app.logger.Debug("pruning state snapshots")
+		pruned, err := app.snapshotManager.Prune(app.snapshotKeepRecent)
+		if err != nil {
+			app.logger.Error("failed to prune state snapshots", "err", err)
+			return
+		}
+		app.logger.Debug("pruned state snapshots", "pruned", pruned)
+	}
+}
+
 // Query implements the ABCI interface. It delegates to CommitMultiStore if it
 // implements Queryable.
 func (app *BaseApp) Query(req abci.RequestQuery) abci.ResponseQuery {
@@ -388,27 +421,93 @@ func handleQueryApp(app *BaseApp, path []string, req abci.RequestQuery) abci.Res
 				Result:  res,
 			}
 
+			return abci.ResponseQuery{
+				Codespace: sdkerrors.RootCodespace,
+				Height:    req.Height,
+				Value:     codec.Cdc.MustMarshalBinaryBare(simRes),
+			}
+		case "simulateWithOverrides":
+			var queryParams sdk.QuerySimulateWithOverrides
+			if err := json.Unmarshal(req.Data, &queryParams); err != nil {
+				return sdkerrors.QueryResult(sdkerrors.Wrap(err, "invalid simulate params"))
+			}
+			tx, err := app.txDecoder(queryParams.TxBytes)
+			if err != nil {
+				return sdkerrors.QueryResult(sdkerrors.Wrap(err, "failed to decode tx"))
+			}
+			var from string
+			if len(path) > 2 {
+				if addr, err := sdk.AccAddressFromBech32(path[2]); err == nil {
+					if err = sdk.VerifyAddressFormat(addr); err == nil {
+						from = path[2]
+					}
+				}
+			}
+
+			gInfo, res, err := app.SimulateWithOverrides(queryParams.TxBytes, tx, req.Height, from, queryParams.StateOverrides)
+			if err != nil {
+				return sdkerrors.QueryResult(sdkerrors.Wrap(err, "failed to simulate tx"))
+			}
+
+			simRes := sdk.SimulationResponse{
+				GasInfo: gInfo,
+				Result:  res,
+			}
+
 			return abci.ResponseQuery{
 				Codespace: sdkerrors.RootCodespace,
 				Height:    req.Height,
 				Value:     codec.Cdc.MustMarshalBinaryBare(simRes),
 			}
 		case "trace":
-			tmtx, err := GetABCITx(req.Data)
+			return handleQueryTraceTx(app, req, req.Data, nil)
+
+		case "traceTx":
+			var queryParams sdk.QueryTraceTx
+			if err := json.Unmarshal(req.Data, &queryParams); err != nil {
+				return sdkerrors.QueryResult(sdkerrors.Wrap(err, "invalid trace tx params"))
+			}
+			return handleQueryTraceTx(app, req, queryParams.TxHash, queryParams.TraceConfig)
+
+		case "traceBlock":
+			var queryParams sdk.QueryTraceBlock
+			if err := json.Unmarshal(req.Data, &queryParams); err != nil {
+				return sdkerrors.QueryResult(sdkerrors.Wrap(err, "invalid trace block params"))
+			}
+			block, err := GetABCIBlock(queryParams.Height)
 			if err != nil {
-				return sdkerrors.QueryResult(sdkerrors.Wrap(err, "invalid trace tx bytes"))
+				return sdkerrors.QueryResult(sdkerrors.Wrap(err, "invalid trace block height"))
 			}
-			tx, err := app.txDecoder(tmtx.Tx, tmtx.Height)
+			results, err := app.TraceBlock(block.Block, queryParams.TraceConfig)
 			if err != nil {
-				return sdkerrors.QueryResult(sdkerrors.Wrap(err, "failed to decode tx"))
+				return sdkerrors.QueryResult(sdkerrors.Wrap(err, "failed to trace block"))
 			}
-			block, err := GetABCIBlock(tmtx.Height)
+			bz, err := json.Marshal(results)
 			if err != nil {
-				return sdkerrors.QueryResult(sdkerrors.Wrap(err, "invalid trace tx block header"))
+				return sdkerrors.QueryResult(sdkerrors.Wrap(err, "failed to marshal trace results"))
+			}
+			return abci.ResponseQuery{
+				Codespace: sdkerrors.RootCodespace,
+				Height:    req.Height,
+				Value:     bz,
+			}
+
+		case "traceCall":
+			var queryParams sdk.QueryTraceCall
+			if err := json.Unmarshal(req.Data, &queryParams); err != nil {
+				return sdkerrors.QueryResult(sdkerrors.Wrap(err, "invalid trace call params"))
+			}
+			tx, err := app.txDecoder(queryParams.TxBytes)
+			if err != nil {
+				return sdkerrors.QueryResult(sdkerrors.Wrap(err, "failed to decode tx"))
+			}
+			var from string
+			if len(path) > 2 {
+				from = path[2]
 			}
-			res, err := app.TraceTx(req.Data, tx, tmtx.Index, block.Block)
+			res, err := app.TraceCall(queryParams.TxBytes, tx, req.Height, from, queryParams.TraceConfig)
 			if err != nil {
-				return sdkerrors.QueryResult(sdkerrors.Wrap(err, "failed to trace tx"))
+				return sdkerrors.QueryResult(sdkerrors.Wrap(err, "failed to trace call"))
 			}
 			return abci.ResponseQuery{
 				Codespace: sdkerrors.RootCodespace,
@@ -436,6 +535,32 @@ func handleQueryApp(app *BaseApp, path []string, req abci.RequestQuery) abci.Res
 	)
 }
 
+// handleQueryTraceTx traces the tx of txHash with the tracer of traceConfig, the struct logger is used if
+// traceConfig is empty
+func handleQueryTraceTx(app *BaseApp, req abci.RequestQuery, txHash []byte, traceConfig []byte) abci.ResponseQuery {
+	tmtx, err := GetABCITx(txHash)
+	if err != nil {
+		return sdkerrors.QueryResult(sdkerrors.Wrap(err, "invalid trace tx bytes"))
+	}
+	tx, err := app.txDecoder(tmtx.Tx, tmtx.Height)
+	if err != nil {
+		return sdkerrors.QueryResult(sdkerrors.Wrap(err, "failed to decode tx"))
+	}
+	block, err := GetABCIBlock(tmtx.Height)
+	if err != nil {
+		return sdkerrors.QueryResult(sdkerrors.Wrap(err, "invalid trace tx block header"))
+	}
+	res, err := app.TraceTx(tmtx.Tx, tx, tmtx.Index, block.Block, traceConfig)
+	if err != nil {
+		return sdkerrors.QueryResult(sdkerrors.Wrap(err, "failed to trace tx"))
+	}
+	return abci.ResponseQuery{
+		Codespace: sdkerrors.RootCodespace,
+		Height:    req.Height,
+		Value:     codec.Cdc.MustMarshalBinaryBare(res),
+	}
+}
+
 func handleQueryStore(app *BaseApp, path []string, req abci.RequestQuery) abci.ResponseQuery {
 	// "/store" prefix for store queries
 	queryable, ok := app.cms.(sdk.Queryable)
@@ -569,3 +694,115 @@ func splitPath(requestPath string) (path []string) {
 
 	return path
 }
+
+// ListSnapshots implements the ABCI interface. It delegates to app.snapshotManager if set.
+func (app *BaseApp) ListSnapshots(req abci.RequestListSnapshots) abci.ResponseListSnapshots {
+	resp := abci.ResponseListSnapshots{Snapshots: []*abci.Snapshot{}}
+	if app.snapshotManager == nil {
+		return resp
+	}
+
+	snapshots, err := app.snapshotManager.List()
+	if err != nil {
+		app.logger.Error("failed to list snapshots", "err", err)
+		return resp
+	}
+
+	for _, snapshot := range snapshots {
+		abciSnapshot, err := snapshot.ToABCI()
+		if err != nil {
+			app.logger.Error("failed to list snapshots", "err", err)
+			return resp
+		}
+		resp.Snapshots = append(resp.Snapshots, &abciSnapshot)
+	}
+
+	return resp
+}
+
+// LoadSnapshotChunk implements the ABCI interface. It delegates to app.snapshotManager if set.
+func (app *BaseApp) LoadSnapshotChunk(req abci.RequestLoadSnapshotChunk) abci.ResponseLoadSnapshotChunk {
+	if app.snapshotManager == nil {
+		return abci.ResponseLoadSnapshotChunk{}
+	}
+	chunk, err := app.snapshotManager.LoadChunk(req.Height, req.Format, req.Chunk)
+	if err != nil {
+		app.logger.Error("failed to load snapshot chunk", "height", req.Height, "format", req.Format,
+			"chunk", req.Chunk, "err", err)
+		return abci.ResponseLoadSnapshotChunk{}
+	}
+	return abci.ResponseLoadSnapshotChunk{Chunk: chunk}
+}
+
+// OfferSnapshot implements the ABCI interface. It delegates to app.snapshotManager if set.
+func (app *BaseApp) OfferSnapshot(req abci.RequestOfferSnapshot) abci.ResponseOfferSnapshot {
+	if app.snapshotManager == nil {
+		app.logger.Error("snapshot manager not configured")
+		return abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ABORT}
+	}
+
+	if req.Snapshot == nil {
+		app.logger.Error("received nil snapshot")
+		return abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_REJECT}
+	}
+
+	snapshot, err := snapshots.SnapshotFromABCI(req.Snapshot)
+	if err != nil {
+		app.logger.Error("failed to decode snapshot metadata", "err", err)
+		return abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_REJECT}
+	}
+
+	err = app.snapshotManager.Restore(snapshot)
+	switch {
+	case err == nil:
+		return abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ACCEPT}
+
+	case errors.Is(err, snapshots.ErrUnknownFormat):
+		return abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_REJECT_FORMAT}
+
+	case errors.Is(err, snapshots.ErrInvalidMetadata):
+		app.logger.Error("rejecting invalid snapshot", "height", req.Snapshot.Height,
+			"format", req.Snapshot.Format, "err", err)
+		return abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_REJECT}
+
+	default:
+		app.logger.Error("failed to restore snapshot", "height", req.Snapshot.Height,
+			"format", req.Snapshot.Format, "err", err)
+
+		// We currently don't support resetting the IAVL stores and retrying a different snapshot,
+		// so we ask Tendermint to abort all snapshot restoration.
+		return abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ABORT}
+	}
+}
+
+// ApplySnapshotChunk implements the ABCI interface. It delegates to app.snapshotManager if set.
+func (app *BaseApp) ApplySnapshotChunk(req abci.RequestApplySnapshotChunk) abci.ResponseApplySnapshotChunk {
+	if app.snapshotManager == nil {
+		app.logger.Error("snapshot manager not configured")
+		return abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ABORT}
+	}
+
+	done, err := app.snapshotManager.RestoreChunk(req.Chunk)
+	switch {
+	case err == nil:
+		if done {
+			// the restored state replaces the genesis state, which InitChain has never set up
+			app.loadConsensusParams(app.cms.GetKVStore(app.baseKey))
+			app.setCheckState(abci.Header{})
+		}
+		return abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}
+
+	case errors.Is(err, snapshots.ErrChunkHashMismatch):
+		app.logger.Error("chunk checksum mismatch; rejecting sender and requesting refetch",
+			"chunk", req.Index, "sender", req.Sender, "err", err)
+		return abci.ResponseApplySnapshotChunk{
+			Result:        abci.ResponseApplySnapshotChunk_RETRY,
+			RefetchChunks: []uint32{req.Index},
+			RejectSenders: []string{req.Sender},
+		}
+
+	default:
+		app.logger.Error("failed to restore snapshot", "err", err)
+		return abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ABORT}
+	}
+}
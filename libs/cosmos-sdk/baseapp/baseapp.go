@@ -13,6 +13,7 @@ import (
 	"github.com/okex/exchain/libs/tendermint/trace"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/okex/exchain/libs/cosmos-sdk/snapshots"
 	"github.com/okex/exchain/libs/cosmos-sdk/store"
 	"github.com/okex/exchain/libs/cosmos-sdk/store/rootmulti"
 	storetypes "github.com/okex/exchain/libs/cosmos-sdk/store/types"
@@ -134,6 +135,8 @@ type BaseApp struct { // nolint: maligned
 	GasRefundHandler sdk.GasRefundHandler // gas refund handler for gas refund
 	AccHandler       sdk.AccHandler       // account handler for cm tx nonce
 
+	StateOverrideHandler sdk.StateOverrideHandler // state override handler for simulated txs
+
 	initChainer    sdk.InitChainer  // initialize state with validators and state blob
 	beginBlocker   sdk.BeginBlocker // logic to run before any txs
 	endBlocker     sdk.EndBlocker   // logic to run after all txs, and to determine valset changes
@@ -175,6 +178,11 @@ type BaseApp struct { // nolint: maligned
 	// minimum block time (in Unix seconds) at which to halt the chain and gracefully shutdown
 	haltTime uint64
 
+	// manages the state sync snapshots of the multistore
+	snapshotManager    *snapshots.Manager
+	snapshotInterval   uint64 // block interval between state sync snapshots
+	snapshotKeepRecent uint32 // recent state sync snapshots to keep
+
 	// application's version string
 	appVersion string
 
@@ -321,6 +329,15 @@ func (app *BaseApp) MountStore(key sdk.StoreKey, typ sdk.StoreType) {
 	app.cms.MountStoreWithDB(key, typ, nil)
 }
 
+// SetStoreUpgradeVersion sets the height from which the mounted store of the
+// provided key is committed into the BaseApp multistore.
+func (app *BaseApp) SetStoreUpgradeVersion(key sdk.StoreKey, version int64) {
+	if app.sealed {
+		panic("SetStoreUpgradeVersion() on sealed BaseApp")
+	}
+	app.cms.SetUpgradeVersion(key, version)
+}
+
 // LoadLatestVersion loads the latest application version. It will panic if
 // called more than once on a running BaseApp.
 func (app *BaseApp) LoadLatestVersion(baseKey *sdk.KVStoreKey) error {
@@ -434,6 +451,17 @@ func (app *BaseApp) initFromMainStore(baseKey *sdk.KVStoreKey) error {
 	// nil, it will be saved later during InitChain.
 	//
 	// TODO: assert that InitChain hasn't yet been called.
+	app.loadConsensusParams(mainStore)
+
+	// needed for the export command which inits from store but never calls initchain
+	app.setCheckState(abci.Header{})
+	app.Seal()
+
+	return nil
+}
+
+// loadConsensusParams loads the consensus params from the main store, if they are saved
+func (app *BaseApp) loadConsensusParams(mainStore sdk.KVStore) {
 	consensusParamsBz := mainStore.Get(mainConsensusParamsKey)
 	if consensusParamsBz != nil {
 		var consensusParams = &abci.ConsensusParams{}
@@ -445,12 +473,6 @@ func (app *BaseApp) initFromMainStore(baseKey *sdk.KVStoreKey) error {
 
 		app.setConsensusParams(consensusParams)
 	}
-
-	// needed for the export command which inits from store but never calls initchain
-	app.setCheckState(abci.Header{})
-	app.Seal()
-
-	return nil
 }
 
 func (app *BaseApp) setMinGasPrices(gasPrices sdk.DecCoins) {
@@ -465,6 +487,26 @@ func (app *BaseApp) setHaltTime(haltTime uint64) {
 	app.haltTime = haltTime
 }
 
+func (app *BaseApp) setSnapshotStore(snapshotStore *snapshots.Store) {
+	if snapshotStore == nil {
+		app.snapshotManager = nil
+		return
+	}
+	snapshotter, ok := app.cms.(snapshots.Snapshotter)
+	if !ok {
+		panic("state sync snapshots require a snapshotting multistore")
+	}
+	app.snapshotManager = snapshots.NewManager(snapshotStore, snapshotter)
+}
+
+func (app *BaseApp) setSnapshotInterval(snapshotInterval uint64) {
+	app.snapshotInterval = snapshotInterval
+}
+
+func (app *BaseApp) setSnapshotKeepRecent(snapshotKeepRecent uint32) {
+	app.snapshotKeepRecent = snapshotKeepRecent
+}
+
 func (app *BaseApp) setInterBlockCache(cache sdk.MultiStorePersistentCache) {
 	app.interBlockCache = cache
 }
@@ -24,6 +24,13 @@ type runTxInfo struct {
 	result  *sdk.Result
 	txBytes []byte
 	tx      sdk.Tx
+
+	// traceTxLog makes a simulated tx return its evm trace logs, see BaseApp.TraceCall
+	traceTxLog    bool
+	traceTxConfig []byte
+
+	// stateOverrides are applied on the state before a simulated tx runs, see BaseApp.SimulateWithOverrides
+	stateOverrides []byte
 }
 
 func (app *BaseApp) runTx(mode runTxMode,
@@ -59,6 +66,9 @@ func (app *BaseApp) runtxWithInfo(info *runTxInfo, mode runTxMode, txBytes []byt
 		//traceBlockCache was created with different root(chainCache) with app.blockCache in app.BeginBlockForTrace()
 		info.ctx = info.ctx.WithCache(sdk.NewCache(app.blockCache, useCache(mode)))
 	}
+	if info.traceTxLog {
+		info.ctx = info.ctx.WithIsTraceTxLog(true).WithTraceTxConfig(info.traceTxConfig)
+	}
 	for _, addr := range from {
 		// cache from if exist
 		if addr != "" {
@@ -66,6 +76,12 @@ func (app *BaseApp) runtxWithInfo(info *runTxInfo, mode runTxMode, txBytes []byt
 			break
 		}
 	}
+	if len(info.stateOverrides) != 0 {
+		err = app.applyStateOverrides(info)
+		if err != nil {
+			return err
+		}
+	}
 
 	err = handler.handleGasConsumed(info)
 	if err != nil {
@@ -3,9 +3,12 @@ package baseapp
 import (
 	"bytes"
 	"encoding/binary"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"sync"
 	"testing"
@@ -18,9 +21,15 @@ import (
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
 	"github.com/okex/exchain/libs/tendermint/libs/log"
 	"github.com/okex/exchain/libs/tendermint/mempool"
+	ctypes "github.com/okex/exchain/libs/tendermint/rpc/core/types"
+	rpcserver "github.com/okex/exchain/libs/tendermint/rpc/jsonrpc/server"
+	rpctypes "github.com/okex/exchain/libs/tendermint/rpc/jsonrpc/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	dbm "github.com/okex/exchain/libs/tm-db"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	amino "github.com/tendermint/go-amino"
 )
 
 var (
@@ -561,6 +570,7 @@ type txTest struct {
 	Msgs       []sdk.Msg
 	Counter    int64
 	FailOnAnte bool
+	IsEvm      bool
 }
 
 func (tx *txTest) setFailOnAnte(fail bool) {
@@ -598,6 +608,9 @@ func (tx txTest) GetGas() uint64 {
 	return 0
 }
 func (tx txTest) GetType() sdk.TransactionType {
+	if tx.IsEvm {
+		return sdk.EvmTxType
+	}
 	return sdk.UnknownType
 }
 
@@ -635,7 +648,7 @@ func newTxCounter(counter int64, msgCounters ...int64) *txTest {
 		msgs = append(msgs, msgCounter{c, false})
 	}
 
-	return &txTest{msgs, counter, false}
+	return &txTest{msgs, counter, false, false}
 }
 
 // a msg we dont know how to route
@@ -1012,6 +1025,240 @@ func TestSimulateTx(t *testing.T) {
 	}
 }
 
+// the state overrides are seen by the simulated tx only
+func TestSimulateWithOverrides(t *testing.T) {
+	overrideKey := []byte("override")
+
+	anteOpt := func(bapp *BaseApp) {
+		bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (newCtx sdk.Context, err error) {
+			return ctx, nil
+		})
+		bapp.SetStateOverrideHandler(func(ctx sdk.Context, overrides []byte) error {
+			ctx.KVStore(capKey1).Set(overrideKey, overrides)
+			return nil
+		})
+	}
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+			return &sdk.Result{Data: ctx.KVStore(capKey1).Get(overrideKey)}, nil
+		})
+	}
+
+	app := setupBaseApp(t, anteOpt, routerOpt)
+	app.InitChain(abci.RequestInitChain{})
+
+	cdc := codec.New()
+	registerTestCodec(cdc)
+
+	header := abci.Header{Height: 1}
+	app.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	tx := newTxCounter(0, 0)
+	txBytes, err := cdc.MarshalBinaryLengthPrefixed(tx)
+	require.NoError(t, err)
+
+	queryParams, err := json.Marshal(sdk.QuerySimulateWithOverrides{TxBytes: txBytes, StateOverrides: []byte("overridden")})
+	require.NoError(t, err)
+	queryResult := app.Query(abci.RequestQuery{Path: "/app/simulateWithOverrides", Data: queryParams})
+	require.True(t, queryResult.IsOK(), queryResult.Log)
+
+	var simRes sdk.SimulationResponse
+	require.NoError(t, codec.Cdc.UnmarshalBinaryBare(queryResult.Value, &simRes))
+	require.Equal(t, []byte("overridden"), simRes.Result.Data)
+
+	// the overrides are discarded with the simulation
+	_, result, err := app.Simulate(txBytes, tx, 0)
+	require.NoError(t, err)
+	require.Empty(t, result.Data)
+	require.Nil(t, app.deliverState.ctx.KVStore(capKey1).Get(overrideKey))
+}
+
+// the trace logs are returned by the evm txs of the block only, which run on the state of the previous block
+func TestTraceBlock(t *testing.T) {
+	tracedKey := []byte("traced")
+
+	anteOpt := func(bapp *BaseApp) {
+		bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (newCtx sdk.Context, err error) {
+			return ctx, nil
+		})
+	}
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+			if msg.(*msgCounter).FailOnHandler {
+				return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "message handler failure")
+			}
+			store := ctx.KVStore(capKey1)
+			// the txs of the block see the writes of their predecessors
+			traced := append(store.Get(tracedKey), byte(msg.(*msgCounter).Counter))
+			store.Set(tracedKey, traced)
+			if !ctx.IsTraceTxLog() {
+				return &sdk.Result{}, nil
+			}
+			return &sdk.Result{Data: append(ctx.TraceTxConfig(), traced...)}, nil
+		})
+	}
+
+	app := setupBaseApp(t, anteOpt, routerOpt)
+	app.InitChain(abci.RequestInitChain{})
+
+	cdc := codec.New()
+	registerTestCodec(cdc)
+
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit(abci.RequestCommit{})
+
+	var txs tmtypes.Txs
+	for i, isEvm := range []bool{true, false, true, true} {
+		tx := newTxCounter(int64(i), int64(i))
+		tx.IsEvm = isEvm
+		if i == 3 {
+			tx.setFailOnHandler(true)
+		}
+		txBytes, err := cdc.MarshalBinaryLengthPrefixed(tx)
+		require.NoError(t, err)
+		txs = append(txs, txBytes)
+	}
+	block := &tmtypes.Block{Header: tmtypes.Header{Height: 2}, Data: tmtypes.Data{Txs: txs}}
+
+	results, err := app.TraceBlock(block, []byte("config"))
+	require.NoError(t, err)
+	require.Equal(t, 3, len(results))
+	require.Equal(t, txs[0].Hash(2), results[0].TxHash)
+	require.Equal(t, append([]byte("config"), 0), results[0].Result)
+	require.Equal(t, txs[2].Hash(2), results[1].TxHash)
+	require.Equal(t, append([]byte("config"), 0, 1, 2), results[1].Result)
+	require.Equal(t, txs[3].Hash(2), results[2].TxHash)
+	require.Empty(t, results[2].Result)
+	require.Contains(t, results[2].Error, "message handler failure")
+
+	// the traced txs aren't committed
+	require.Nil(t, app.cms.GetKVStore(capKey1).Get(tracedKey))
+
+	results, err = app.TraceBlock(&tmtypes.Block{Header: tmtypes.Header{Height: 2}}, nil)
+	require.NoError(t, err)
+	require.Empty(t, results)
+}
+
+// the trace query keeps accepting the raw tx hash, and the traceTx query takes the tracer options
+func TestTraceTxQuery(t *testing.T) {
+	anteOpt := func(bapp *BaseApp) {
+		bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (newCtx sdk.Context, err error) {
+			return ctx, nil
+		})
+	}
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+			if !ctx.IsTraceTxLog() {
+				return &sdk.Result{}, nil
+			}
+			traced := fmt.Sprintf("%d:%s", msg.(*msgCounter).Counter, ctx.TraceTxConfig())
+			return &sdk.Result{Data: []byte(traced)}, nil
+		})
+	}
+
+	app := setupBaseApp(t, anteOpt, routerOpt)
+	app.InitChain(abci.RequestInitChain{})
+
+	cdc := codec.New()
+	registerTestCodec(cdc)
+
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit(abci.RequestCommit{})
+
+	var txs tmtypes.Txs
+	for i := int64(0); i < 2; i++ {
+		txBytes, err := cdc.MarshalBinaryLengthPrefixed(newTxCounter(i, i))
+		require.NoError(t, err)
+		txs = append(txs, txBytes)
+	}
+	block := &tmtypes.Block{Header: tmtypes.Header{Height: 2}, Data: tmtypes.Data{Txs: txs}}
+	txHash := txs[1].Hash(2)
+
+	// the node serving the tx and the block to be traced
+	rpcCdc := amino.NewCodec()
+	ctypes.RegisterAmino(rpcCdc)
+	mux := http.NewServeMux()
+	rpcserver.RegisterRPCFuncs(mux, map[string]*rpcserver.RPCFunc{
+		"tx": rpcserver.NewRPCFunc(func(ctx *rpctypes.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
+			require.Equal(t, txHash, hash)
+			return &ctypes.ResultTx{Hash: hash, Height: 2, Index: 1, Tx: txs[1]}, nil
+		}, "hash,prove"),
+		"block": rpcserver.NewRPCFunc(func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultBlock, error) {
+			return &ctypes.ResultBlock{Block: block}, nil
+		}, "height"),
+	}, rpcCdc, log.NewNopLogger())
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	viper.Set("rpc.laddr", server.URL)
+	defer viper.Set("rpc.laddr", "")
+
+	queryResult := app.Query(abci.RequestQuery{Path: "/app/trace", Data: txHash})
+	require.True(t, queryResult.IsOK(), queryResult.Log)
+	var res sdk.Result
+	require.NoError(t, codec.Cdc.UnmarshalBinaryBare(queryResult.Value, &res))
+	require.Equal(t, "1:", string(res.Data))
+
+	queryParams, err := json.Marshal(sdk.QueryTraceTx{TxHash: txHash, TraceConfig: []byte("config")})
+	require.NoError(t, err)
+	queryResult = app.Query(abci.RequestQuery{Path: "/app/traceTx", Data: queryParams})
+	require.True(t, queryResult.IsOK(), queryResult.Log)
+	require.NoError(t, codec.Cdc.UnmarshalBinaryBare(queryResult.Value, &res))
+	require.Equal(t, "1:config", string(res.Data))
+
+	queryResult = app.Query(abci.RequestQuery{Path: "/app/traceTx", Data: txHash})
+	require.False(t, queryResult.IsOK())
+}
+
+// the simulated tx of TraceCall returns its trace logs
+func TestTraceCall(t *testing.T) {
+	anteOpt := func(bapp *BaseApp) {
+		bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (newCtx sdk.Context, err error) {
+			return ctx, nil
+		})
+	}
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+			ctx.KVStore(capKey1).Set([]byte("traced"), []byte("traced"))
+			if !ctx.IsTraceTxLog() {
+				return &sdk.Result{}, nil
+			}
+			return &sdk.Result{Data: ctx.TraceTxConfig()}, nil
+		})
+	}
+
+	app := setupBaseApp(t, anteOpt, routerOpt)
+	app.InitChain(abci.RequestInitChain{})
+
+	cdc := codec.New()
+	registerTestCodec(cdc)
+
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+
+	tx := newTxCounter(0, 0)
+	txBytes, err := cdc.MarshalBinaryLengthPrefixed(tx)
+	require.NoError(t, err)
+
+	result, err := app.TraceCall(txBytes, tx, 0, "", []byte("config"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("config"), result.Data)
+
+	queryParams, err := json.Marshal(sdk.QueryTraceCall{TxBytes: txBytes, TraceConfig: []byte("query config")})
+	require.NoError(t, err)
+	queryResult := app.Query(abci.RequestQuery{Path: "/app/traceCall", Data: queryParams})
+	require.True(t, queryResult.IsOK(), queryResult.Log)
+	var res sdk.Result
+	require.NoError(t, codec.Cdc.UnmarshalBinaryBare(queryResult.Value, &res))
+	require.Equal(t, []byte("query config"), res.Data)
+
+	// a simulated tx doesn't return the trace logs
+	_, result, err = app.Simulate(txBytes, tx, 0)
+	require.NoError(t, err)
+	require.Empty(t, result.Data)
+	require.Nil(t, app.deliverState.ctx.KVStore(capKey1).Get([]byte("traced")))
+}
+
 func TestRunInvalidTransaction(t *testing.T) {
 	anteOpt := func(bapp *BaseApp) {
 		bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (newCtx sdk.Context, err error) {
@@ -1076,7 +1323,7 @@ func TestRunInvalidTransaction(t *testing.T) {
 
 	// transaction with no known route
 	{
-		unknownRouteTx := txTest{[]sdk.Msg{msgNoRoute{}}, 0, false}
+		unknownRouteTx := txTest{[]sdk.Msg{msgNoRoute{}}, 0, false, false}
 		_, result, err := app.Deliver(unknownRouteTx)
 		require.Error(t, err)
 		require.Nil(t, result)
@@ -1085,7 +1332,7 @@ func TestRunInvalidTransaction(t *testing.T) {
 		require.EqualValues(t, sdkerrors.ErrUnknownRequest.Codespace(), space, err)
 		require.EqualValues(t, sdkerrors.ErrUnknownRequest.ABCICode(), code, err)
 
-		unknownRouteTx = txTest{[]sdk.Msg{msgCounter{}, msgNoRoute{}}, 0, false}
+		unknownRouteTx = txTest{[]sdk.Msg{msgCounter{}, msgNoRoute{}}, 0, false, false}
 		_, result, err = app.Deliver(unknownRouteTx)
 		require.Error(t, err)
 		require.Nil(t, result)
@@ -20,7 +20,8 @@ func (app *BaseApp) GetDeliverStateCtx() sdk.Context {
 //and the predesessors in the same block must be run before tracing the tx.
 //The runtx procedure for TraceTx is nearly same with that for DeliverTx,  but the
 //state was saved in different Cache in app.
-func (app *BaseApp) TraceTx(targetTxData []byte, targetTx sdk.Tx, txIndex uint32, block *tmtypes.Block) (*sdk.Result, error) {
+func (app *BaseApp) TraceTx(targetTxData []byte, targetTx sdk.Tx, txIndex uint32, block *tmtypes.Block,
+	traceConfig []byte) (*sdk.Result, error) {
 
 	//get first tx
 	var initialTxBytes []byte
@@ -49,13 +50,79 @@ func (app *BaseApp) TraceTx(targetTxData []byte, targetTx sdk.Tx, txIndex uint32
 	}
 
 	//trace tx
-	traceState.ctx = traceState.ctx.WithIsTraceTxLog(true)
+	traceState.ctx = traceState.ctx.WithIsTraceTxLog(true).WithTraceTxConfig(traceConfig)
 	info, err := app.tracetx(targetTxData, targetTx, block.Height, traceState)
 	if info == nil {
 		return nil, err
 	}
 	return info.result, err
 }
+
+//TraceBlock returns the trace logs of all the evm txs in the block
+//The txs are run one by one on the state of the previous block like TraceTx,
+//the results of the non-evm txs are dropped.
+func (app *BaseApp) TraceBlock(block *tmtypes.Block, traceConfig []byte) ([]sdk.TraceTxResult, error) {
+	results := make([]sdk.TraceTxResult, 0, len(block.Txs))
+	if len(block.Txs) == 0 {
+		return results, nil
+	}
+
+	traceState, err := app.beginBlockForTracing(block.Txs[0], block)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "failed to beginblock for tracing")
+	}
+
+	for _, txBytes := range block.Txs {
+		tx, err := app.txDecoder(txBytes, block.Height)
+		if err != nil {
+			return nil, sdkerrors.Wrap(err, "invalid tx in block")
+		}
+		isEvmTx := tx.GetType() == sdk.EvmTxType
+		traceState.ctx = traceState.ctx.WithIsTraceTxLog(isEvmTx).WithTraceTxConfig(traceConfig)
+
+		info, err := app.tracetx(txBytes, tx, block.Height, traceState)
+		if !isEvmTx {
+			//ignore the err of non-evm tx
+			continue
+		}
+		result := sdk.TraceTxResult{TxHash: txBytes.Hash(block.Height)}
+		if err != nil {
+			result.Error = err.Error()
+		} else if info != nil && info.result != nil {
+			result.Result = info.result.Data
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+//TraceCall returns the trace logs of the simulated tx on the state of the height
+func (app *BaseApp) TraceCall(txBytes []byte, tx sdk.Tx, height int64, from string, traceConfig []byte) (*sdk.Result, error) {
+	info := &runTxInfo{
+		traceTxLog:    true,
+		traceTxConfig: traceConfig,
+	}
+	err := app.runtxWithInfo(info, runTxModeSimulate, txBytes, tx, height, from)
+	return info.result, err
+}
+
+// SimulateWithOverrides simulates the tx on the state of the height with the state overrides applied
+func (app *BaseApp) SimulateWithOverrides(txBytes []byte, tx sdk.Tx, height int64, from string, overrides []byte) (sdk.GasInfo, *sdk.Result, error) {
+	info := &runTxInfo{
+		stateOverrides: overrides,
+	}
+	err := app.runtxWithInfo(info, runTxModeSimulate, txBytes, tx, height, from)
+	return info.gInfo, info.result, err
+}
+
+func (app *BaseApp) applyStateOverrides(info *runTxInfo) error {
+	if app.StateOverrideHandler == nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "state overrides are not supported")
+	}
+	// the overrides aren't paid by the tx
+	return app.StateOverrideHandler(info.ctx.WithGasMeter(sdk.NewInfiniteGasMeter()), info.stateOverrides)
+}
+
 func (app *BaseApp) tracetx(txBytes []byte, tx sdk.Tx, height int64, traceState *state) (info *runTxInfo, err error) {
 
 	mode := runTxModeTrace
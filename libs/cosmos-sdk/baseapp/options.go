@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/okex/exchain/libs/cosmos-sdk/snapshots"
 	"github.com/okex/exchain/libs/cosmos-sdk/store"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	dbm "github.com/okex/exchain/libs/tm-db"
@@ -37,6 +38,21 @@ func SetHaltTime(haltTime uint64) func(*BaseApp) {
 	return func(bap *BaseApp) { bap.setHaltTime(haltTime) }
 }
 
+// SetSnapshotStore sets the store of the state sync snapshots.
+func SetSnapshotStore(snapshotStore *snapshots.Store) func(*BaseApp) {
+	return func(app *BaseApp) { app.setSnapshotStore(snapshotStore) }
+}
+
+// SetSnapshotInterval sets the block interval between state sync snapshots, 0 disables them.
+func SetSnapshotInterval(snapshotInterval uint64) func(*BaseApp) {
+	return func(app *BaseApp) { app.setSnapshotInterval(snapshotInterval) }
+}
+
+// SetSnapshotKeepRecent sets the number of recent state sync snapshots to keep, 0 keeps all.
+func SetSnapshotKeepRecent(snapshotKeepRecent uint32) func(*BaseApp) {
+	return func(app *BaseApp) { app.setSnapshotKeepRecent(snapshotKeepRecent) }
+}
+
 // SetInterBlockCache provides a BaseApp option function that sets the
 // inter-block cache.
 func SetInterBlockCache(cache sdk.MultiStorePersistentCache) func(*BaseApp) {
@@ -119,6 +135,13 @@ func (app *BaseApp) SetAccHandler(ah sdk.AccHandler) {
 	app.AccHandler = ah
 }
 
+func (app *BaseApp) SetStateOverrideHandler(sh sdk.StateOverrideHandler) {
+	if app.sealed {
+		panic("SetStateOverrideHandler() on sealed BaseApp")
+	}
+	app.StateOverrideHandler = sh
+}
+
 func (app *BaseApp) SetAddrPeerFilter(pf sdk.PeerFilter) {
 	if app.sealed {
 		panic("SetAddrPeerFilter() on sealed BaseApp")
@@ -68,6 +68,10 @@ func (ms multiStore) MountStoreWithDB(key sdk.StoreKey, typ sdk.StoreType, db db
 	ms.kv[key] = kvStore{store: make(map[string][]byte)}
 }
 
+func (ms multiStore) SetUpgradeVersion(key sdk.StoreKey, version int64) {
+	panic("not implemented")
+}
+
 func (ms multiStore) LoadLatestVersion() error {
 	return nil
 }
@@ -58,8 +58,11 @@ const (
 	FlagGoroutineNum      = "goroutine-num"
 
 	FlagPruningMaxWsNum = "pruning-max-worldstate-num"
-	FlagExportKeystore  = "export-keystore"
-	FlagLogServerUrl    = "log-server"
+
+	FlagStateSyncSnapshotInterval   = "state-sync-snapshot-interval"
+	FlagStateSyncSnapshotKeepRecent = "state-sync-snapshot-keep-recent"
+	FlagExportKeystore              = "export-keystore"
+	FlagLogServerUrl                = "log-server"
 )
 
 // StartCmd runs the service passed in, either stand-alone or in-process with
@@ -142,6 +145,8 @@ which accepts a path for the resulting pprof file.
 	cmd.Flags().Uint64(FlagPruningKeepEvery, 0, "Offset heights to keep on disk after 'keep-every' (ignored if pruning is not 'custom')")
 	cmd.Flags().Uint64(FlagPruningInterval, 0, "Height interval at which pruned heights are removed from disk (ignored if pruning is not 'custom')")
 	cmd.Flags().Uint64(FlagPruningMaxWsNum, 0, "Max number of historic states to keep on disk (ignored if pruning is not 'custom')")
+	cmd.Flags().Uint64(FlagStateSyncSnapshotInterval, 0, "State sync snapshot interval in blocks (0 disables state sync snapshots)")
+	cmd.Flags().Uint32(FlagStateSyncSnapshotKeepRecent, 2, "Number of recent state sync snapshots to keep (0 keeps all)")
 	cmd.Flags().String(FlagLocalRpcPort, "", "Local rpc port for mempool and block monitor on cosmos layer(ignored if mempool/block monitoring is not required)")
 	cmd.Flags().String(FlagPortMonitor, "", "Local target ports for connecting number monitoring(ignored if connecting number monitoring is not required)")
 	cmd.Flags().String(FlagEvmImportMode, "default", "Select import mode for evm state (default|files|db)")
@@ -155,6 +160,11 @@ which accepts a path for the resulting pprof file.
 	cmd.Flags().String(tmtypes.FlagRedisAuth, "", "redis auth")
 	cmd.Flags().Int(tmtypes.FlagRedisExpire, 300, "delta expiration time. unit is second")
 	cmd.Flags().Int(tmtypes.FlagRedisDB, 0, "delta db num")
+	cmd.Flags().String(tmtypes.FlagDeltaBroker, "redis", "delta broker: redis|file|http")
+	cmd.Flags().String(tmtypes.FlagDeltaDir, "", "delta directory of the file delta broker")
+	cmd.Flags().String(tmtypes.FlagDeltaHttpUrl, "", "delta server url of the http delta broker, e.g. http://127.0.0.1:26680")
+	cmd.Flags().String(tmtypes.FlagDeltaHttpLaddr, "", "listen address of the delta server serving the delta broker of the node, the host defaults to 127.0.0.1, empty means disabled")
+	cmd.Flags().String(tmtypes.FlagDeltaHttpToken, "", "token shared by the delta server and the http delta brokers, required by the delta server listening on a non-loopback host")
 	cmd.Flags().Int(tmtypes.FlagDDSCompressType, 0, "delta compress type. 0|1|2|3")
 	cmd.Flags().Int(tmtypes.FlagDDSCompressFlag, 0, "delta compress flag. 0|1|2")
 	cmd.Flags().Int(tmtypes.FlagBufferSize, 10, "delta buffer size")
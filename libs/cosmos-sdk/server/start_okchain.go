@@ -27,6 +27,8 @@ const (
 	FlagWebsocket          = "wsport"
 	FlagWsMaxConnections   = "ws.max_connections"
 	FlagWsSubChannelLength = "ws.sub_channel_length"
+	FlagWsPendingTxBuffer  = "ws.pending_tx_buffer"
+	FlagWsDisconnectSlow   = "ws.disconnect_slow_consumer"
 )
 
 //module hook
@@ -149,6 +151,8 @@ func registerRestServerFlags(cmd *cobra.Command) *cobra.Command {
 	cmd.Flags().String(FlagWebsocket, "8546", "websocket port to listen to")
 	cmd.Flags().Int(FlagWsMaxConnections, 20000, "the max capacity number of websocket client connections")
 	cmd.Flags().Int(FlagWsSubChannelLength, 100, "the length of subscription channel")
+	cmd.Flags().Int(FlagWsPendingTxBuffer, 1000, "the number of pending txs buffered for a slow websocket subscriber")
+	cmd.Flags().Bool(FlagWsDisconnectSlow, false, "disconnect the websocket subscriber whose pending tx buffer is full instead of dropping the txs")
 	cmd.Flags().String(flags.FlagChainID, "", "Chain ID of tendermint node for web3")
 	cmd.Flags().StringP(flags.FlagBroadcastMode, "b", flags.BroadcastSync, "Transaction broadcasting mode (sync|async|block) for web3")
 	return cmd
@@ -0,0 +1,131 @@
+package snapshots
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+)
+
+// ChunkWriter splits a stream written into it into chunks of a fixed size,
+// which are sent to the channel as they fill up.
+type ChunkWriter struct {
+	ch        chan<- io.ReadCloser
+	buf       *bytes.Buffer
+	chunkSize int
+	closed    bool
+}
+
+// NewChunkWriter creates a new ChunkWriter
+func NewChunkWriter(ch chan<- io.ReadCloser, chunkSize int) *ChunkWriter {
+	return &ChunkWriter{
+		ch:        ch,
+		buf:       &bytes.Buffer{},
+		chunkSize: chunkSize,
+	}
+}
+
+// Write implements io.Writer
+func (w *ChunkWriter) Write(data []byte) (int, error) {
+	if w.closed {
+		return 0, io.ErrClosedPipe
+	}
+	n, _ := w.buf.Write(data)
+	for w.buf.Len() >= w.chunkSize {
+		w.flush(w.chunkSize)
+	}
+	return n, nil
+}
+
+// Close sends the remaining data as the last chunk and closes the channel
+func (w *ChunkWriter) Close() error {
+	return w.CloseWithError(nil)
+}
+
+// CloseWithError closes the writer, a non-nil err is returned to the reader of the last chunk
+func (w *ChunkWriter) CloseWithError(err error) error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	if err != nil {
+		w.ch <- ioutil.NopCloser(&errReader{err: err})
+	} else if w.buf.Len() > 0 {
+		w.flush(w.buf.Len())
+	}
+	close(w.ch)
+	return nil
+}
+
+func (w *ChunkWriter) flush(size int) {
+	chunk := make([]byte, size)
+	copy(chunk, w.buf.Next(size))
+	w.ch <- ioutil.NopCloser(bytes.NewReader(chunk))
+}
+
+type errReader struct {
+	err error
+}
+
+func (r *errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+// ChunkReader reads the chunks from a channel as one stream
+type ChunkReader struct {
+	ch     <-chan io.ReadCloser
+	reader io.ReadCloser
+}
+
+// NewChunkReader creates a new ChunkReader
+func NewChunkReader(ch <-chan io.ReadCloser) *ChunkReader {
+	return &ChunkReader{ch: ch}
+}
+
+// Read implements io.Reader
+func (r *ChunkReader) Read(p []byte) (int, error) {
+	if r.reader == nil {
+		if !r.next() {
+			return 0, io.EOF
+		}
+	}
+	n, err := r.reader.Read(p)
+	if err == io.EOF {
+		err = r.reader.Close()
+		r.reader = nil
+		if err != nil {
+			return 0, err
+		}
+		if n == 0 {
+			return r.Read(p)
+		}
+		return n, nil
+	}
+	return n, err
+}
+
+func (r *ChunkReader) next() bool {
+	reader, ok := <-r.ch
+	if !ok {
+		return false
+	}
+	r.reader = reader
+	return true
+}
+
+// Close closes the current chunk and drains the remaining chunks
+func (r *ChunkReader) Close() error {
+	var err error
+	if r.reader != nil {
+		err = r.reader.Close()
+		r.reader = nil
+	}
+	DrainChunks(r.ch)
+	return err
+}
+
+// DrainChunks closes all the chunks of the channel until it is closed
+func DrainChunks(chunks <-chan io.ReadCloser) {
+	for chunk := range chunks {
+		_ = chunk.Close()
+	}
+}
@@ -0,0 +1,267 @@
+package snapshots
+
+import (
+	"bytes"
+	"compress/zlib"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// chunkSize is the size of the snapshot chunks before compression
+	chunkSize = 10 << 20
+	// chunkBufferSize is the number of chunks buffered in memory during a restore
+	chunkBufferSize = 4
+)
+
+type operation string
+
+const (
+	opNone     operation = ""
+	opSnapshot operation = "snapshot"
+	opPrune    operation = "prune"
+	opRestore  operation = "restore"
+)
+
+type restoreDone struct {
+	complete bool
+	err      error
+}
+
+// Manager manages the snapshot and restore operations of a Snapshotter. Only one
+// operation can run at a time, except that chunks can be loaded concurrently.
+type Manager struct {
+	store  *Store
+	target Snapshotter
+
+	mtx                sync.Mutex
+	operation          operation
+	chRestore          chan<- io.ReadCloser
+	chRestoreDone      <-chan restoreDone
+	restoreChunkHashes [][]byte
+	restoreChunkIndex  uint32
+}
+
+// NewManager creates a new snapshot manager
+func NewManager(store *Store, target Snapshotter) *Manager {
+	return &Manager{
+		store:  store,
+		target: target,
+	}
+}
+
+// begin starts an operation, or fails if one is already running
+func (m *Manager) begin(op operation) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.beginLocked(op)
+}
+
+func (m *Manager) beginLocked(op operation) error {
+	if op == opNone {
+		return errors.New("can't begin a none operation")
+	}
+	if m.operation != opNone {
+		return fmt.Errorf("a %v operation is in progress", m.operation)
+	}
+	m.operation = op
+	return nil
+}
+
+func (m *Manager) end() {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.endLocked()
+}
+
+func (m *Manager) endLocked() {
+	m.operation = opNone
+	if m.chRestore != nil {
+		close(m.chRestore)
+		m.chRestore = nil
+	}
+	m.chRestoreDone = nil
+	m.restoreChunkHashes = nil
+	m.restoreChunkIndex = 0
+}
+
+// Create creates a snapshot of the target at height and saves it into the store
+func (m *Manager) Create(height uint64) (*Snapshot, error) {
+	if m == nil {
+		return nil, errors.New("no snapshot store configured")
+	}
+	if err := m.begin(opSnapshot); err != nil {
+		return nil, err
+	}
+	defer m.end()
+
+	latest, err := m.store.GetLatest()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to examine latest snapshot")
+	}
+	if latest != nil && latest.Height >= height {
+		return nil, errors.Wrapf(ErrInvalidSnapshotVersion,
+			"a more recent snapshot already exists at height %v", latest.Height)
+	}
+
+	chunks := make(chan io.ReadCloser)
+	go m.createChunks(height, chunks)
+	return m.store.Save(height, CurrentFormat, chunks)
+}
+
+// createChunks writes the compressed snapshot of the target into chunks
+func (m *Manager) createChunks(height uint64, chunks chan<- io.ReadCloser) {
+	chunkWriter := NewChunkWriter(chunks, chunkSize)
+	zWriter, err := zlib.NewWriterLevel(chunkWriter, 7)
+	if err != nil {
+		chunkWriter.CloseWithError(err)
+		return
+	}
+	err = m.target.Snapshot(height, zWriter)
+	if err == nil {
+		err = zWriter.Close()
+	}
+	chunkWriter.CloseWithError(err)
+}
+
+// List lists the snapshots in the store
+func (m *Manager) List() ([]*Snapshot, error) {
+	return m.store.List()
+}
+
+// LoadChunk loads a chunk from the store, or returns nil if it does not exist
+func (m *Manager) LoadChunk(height uint64, format uint32, chunk uint32) ([]byte, error) {
+	reader, err := m.store.LoadChunk(height, format, chunk)
+	if err != nil {
+		return nil, err
+	}
+	if reader == nil {
+		return nil, nil
+	}
+	defer reader.Close()
+	return ioutil.ReadAll(reader)
+}
+
+// Prune removes all the snapshots but the latest retain heights
+func (m *Manager) Prune(retain uint32) (uint64, error) {
+	if err := m.begin(opPrune); err != nil {
+		return 0, err
+	}
+	defer m.end()
+	return m.store.Prune(retain)
+}
+
+// Restore starts the restore of a snapshot, the chunks must be given with RestoreChunk.
+// A running restore is aborted.
+func (m *Manager) Restore(snapshot Snapshot) error {
+	if snapshot.Chunks == 0 {
+		return errors.Wrap(ErrInvalidMetadata, "no chunks")
+	}
+	if uint32(len(snapshot.Metadata.ChunkHashes)) != snapshot.Chunks {
+		return errors.Wrapf(ErrInvalidMetadata, "snapshot has %v chunk hashes, but %v chunks",
+			len(snapshot.Metadata.ChunkHashes), snapshot.Chunks)
+	}
+	if snapshot.Format != CurrentFormat {
+		return errors.Wrapf(ErrUnknownFormat, "snapshot format %v", snapshot.Format)
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	// abort a previous restore, it is waited for so that the target is no longer written
+	if m.operation == opRestore {
+		done := m.chRestoreDone
+		m.endLocked()
+		<-done
+	}
+	if err := m.beginLocked(opRestore); err != nil {
+		return err
+	}
+
+	chChunks := make(chan io.ReadCloser, chunkBufferSize)
+	chDone := make(chan restoreDone, 1)
+	go func() {
+		err := m.restore(snapshot, chChunks)
+		chDone <- restoreDone{
+			complete: err == nil,
+			err:      err,
+		}
+		close(chDone)
+		// unblock the chunks which are still sent after a failure
+		DrainChunks(chChunks)
+	}()
+
+	m.chRestore = chChunks
+	m.chRestoreDone = chDone
+	m.restoreChunkHashes = snapshot.Metadata.ChunkHashes
+	m.restoreChunkIndex = 0
+	return nil
+}
+
+func (m *Manager) restore(snapshot Snapshot, chChunks <-chan io.ReadCloser) error {
+	chunkReader := NewChunkReader(chChunks)
+	defer chunkReader.Close()
+
+	zReader, err := zlib.NewReader(chunkReader)
+	if err != nil {
+		return errors.Wrap(err, "failed to create zlib reader")
+	}
+	defer zReader.Close()
+
+	return m.target.Restore(snapshot.Height, zReader)
+}
+
+// RestoreChunk adds the next chunk to a running restore. It returns true when the restore
+// has completed successfully.
+func (m *Manager) RestoreChunk(chunk []byte) (bool, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if m.operation != opRestore {
+		return false, errors.New("no restore operation in progress")
+	}
+	if int(m.restoreChunkIndex) >= len(m.restoreChunkHashes) {
+		return false, errors.New("received unexpected chunk")
+	}
+
+	// check if the restore has failed already
+	select {
+	case done := <-m.chRestoreDone:
+		m.endLocked()
+		if done.err != nil {
+			return false, done.err
+		}
+		return false, errors.New("restore ended unexpectedly")
+	default:
+	}
+
+	hash := sha256.Sum256(chunk)
+	expected := m.restoreChunkHashes[m.restoreChunkIndex]
+	if !bytes.Equal(hash[:], expected) {
+		return false, errors.Wrapf(ErrChunkHashMismatch, "expected %X, got %X", expected, hash)
+	}
+
+	m.chRestore <- ioutil.NopCloser(bytes.NewReader(chunk))
+	m.restoreChunkIndex++
+
+	if int(m.restoreChunkIndex) < len(m.restoreChunkHashes) {
+		return false, nil
+	}
+
+	// the last chunk was given, wait for the restore to finish
+	close(m.chRestore)
+	m.chRestore = nil
+	done := <-m.chRestoreDone
+	m.endLocked()
+	if done.err != nil {
+		return false, done.err
+	}
+	if !done.complete {
+		return false, errors.New("restore ended prematurely")
+	}
+	return true, nil
+}
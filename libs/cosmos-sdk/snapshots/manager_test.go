@@ -0,0 +1,143 @@
+package snapshots
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	dbm "github.com/okex/exchain/libs/tm-db"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSnapshotter snapshots a fixed payload per height
+type mockSnapshotter struct {
+	payloads map[uint64][]byte
+	restored map[uint64][]byte
+}
+
+func newMockSnapshotter() *mockSnapshotter {
+	return &mockSnapshotter{
+		payloads: make(map[uint64][]byte),
+		restored: make(map[uint64][]byte),
+	}
+}
+
+func (m *mockSnapshotter) Snapshot(height uint64, w io.Writer) error {
+	payload, ok := m.payloads[height]
+	if !ok {
+		return errors.New("unknown height")
+	}
+	_, err := w.Write(payload)
+	return err
+}
+
+func (m *mockSnapshotter) Restore(height uint64, r io.Reader) error {
+	bz, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	m.restored[height] = bz
+	return nil
+}
+
+func setupManager(t *testing.T) (*Manager, *mockSnapshotter) {
+	store, err := NewStore(dbm.NewMemDB(), t.TempDir())
+	require.NoError(t, err)
+	target := newMockSnapshotter()
+	return NewManager(store, target), target
+}
+
+func TestManagerCreateAndRestore(t *testing.T) {
+	manager, source := setupManager(t)
+	payload := bytes.Repeat([]byte("snapshot payload "), 1000)
+	source.payloads[3] = payload
+
+	snapshot, err := manager.Create(3)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), snapshot.Height)
+	require.Equal(t, CurrentFormat, snapshot.Format)
+	require.Equal(t, uint32(1), snapshot.Chunks)
+	require.Len(t, snapshot.Metadata.ChunkHashes, 1)
+
+	// a snapshot can't be created below the latest one
+	_, err = manager.Create(2)
+	require.Error(t, err)
+
+	list, err := manager.List()
+	require.NoError(t, err)
+	require.Equal(t, []*Snapshot{snapshot}, list)
+
+	abciSnapshot, err := snapshot.ToABCI()
+	require.NoError(t, err)
+	decoded, err := SnapshotFromABCI(&abciSnapshot)
+	require.NoError(t, err)
+	require.Equal(t, *snapshot, decoded)
+
+	chunk, err := manager.LoadChunk(3, CurrentFormat, 0)
+	require.NoError(t, err)
+	require.NotEmpty(t, chunk)
+	missing, err := manager.LoadChunk(3, CurrentFormat, 1)
+	require.NoError(t, err)
+	require.Nil(t, missing)
+
+	target, restored := setupManager(t)
+	require.NoError(t, target.Restore(decoded))
+	_, err = target.RestoreChunk([]byte("bad chunk"))
+	require.True(t, errors.Is(err, ErrChunkHashMismatch))
+	done, err := target.RestoreChunk(chunk)
+	require.NoError(t, err)
+	require.True(t, done)
+	require.Equal(t, payload, restored.restored[3])
+
+	_, err = target.RestoreChunk(chunk)
+	require.Error(t, err)
+}
+
+func TestManagerRestoreInvalid(t *testing.T) {
+	manager, _ := setupManager(t)
+	require.True(t, errors.Is(manager.Restore(Snapshot{Height: 1, Format: CurrentFormat}), ErrInvalidMetadata))
+	require.True(t, errors.Is(manager.Restore(Snapshot{
+		Height:   1,
+		Format:   CurrentFormat + 1,
+		Chunks:   1,
+		Metadata: Metadata{ChunkHashes: [][]byte{{1}}},
+	}), ErrUnknownFormat))
+}
+
+func TestManagerPrune(t *testing.T) {
+	manager, source := setupManager(t)
+	for height := uint64(1); height <= 4; height++ {
+		source.payloads[height] = []byte{byte(height)}
+		_, err := manager.Create(height)
+		require.NoError(t, err)
+	}
+
+	pruned, err := manager.Prune(2)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), pruned)
+
+	list, err := manager.List()
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, uint64(4), list[0].Height)
+	require.Equal(t, uint64(3), list[1].Height)
+
+	chunk, err := manager.LoadChunk(1, CurrentFormat, 0)
+	require.NoError(t, err)
+	require.Nil(t, chunk)
+}
+
+func TestChunkWriterReader(t *testing.T) {
+	ch := make(chan io.ReadCloser, 16)
+	writer := NewChunkWriter(ch, 4)
+	_, err := writer.Write([]byte("0123456789"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	require.Len(t, ch, 3)
+
+	bz, err := ioutil.ReadAll(NewChunkReader(ch))
+	require.NoError(t, err)
+	require.Equal(t, []byte("0123456789"), bz)
+}
@@ -0,0 +1,262 @@
+package snapshots
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	dbm "github.com/okex/exchain/libs/tm-db"
+	"github.com/pkg/errors"
+)
+
+const (
+	// keyPrefixSnapshot is the prefix of the snapshot metadata keys
+	keyPrefixSnapshot byte = 0x01
+)
+
+// Store is a snapshot store, the snapshot metadata is kept in a db and the chunks are
+// stored as files under dir/<height>/<format>/<chunk>.
+type Store struct {
+	db  dbm.DB
+	dir string
+
+	mtx    sync.Mutex
+	saving map[uint64]bool // heights being saved
+}
+
+// NewStore creates a new snapshot store
+func NewStore(db dbm.DB, dir string) (*Store, error) {
+	if dir == "" {
+		return nil, errors.New("snapshot directory not given")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrapf(err, "failed to create snapshot directory %q", dir)
+	}
+	return &Store{
+		db:     db,
+		dir:    dir,
+		saving: make(map[uint64]bool),
+	}, nil
+}
+
+// Delete deletes a snapshot
+func (s *Store) Delete(height uint64, format uint32) error {
+	s.mtx.Lock()
+	saving := s.saving[height]
+	s.mtx.Unlock()
+	if saving {
+		return fmt.Errorf("snapshot for height %v format %v is currently being saved", height, format)
+	}
+	if err := s.db.DeleteSync(encodeKey(height, format)); err != nil {
+		return errors.Wrapf(err, "failed to delete snapshot for height %v format %v", height, format)
+	}
+	if err := os.RemoveAll(s.pathSnapshot(height, format)); err != nil {
+		return errors.Wrapf(err, "failed to delete snapshot chunks for height %v format %v", height, format)
+	}
+	return nil
+}
+
+// Get fetches snapshot info from the store, or returns nil if it does not exist
+func (s *Store) Get(height uint64, format uint32) (*Snapshot, error) {
+	bz, err := s.db.Get(encodeKey(height, format))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch snapshot metadata for height %v format %v", height, format)
+	}
+	if bz == nil {
+		return nil, nil
+	}
+	snapshot := &Snapshot{}
+	if err := cdc.UnmarshalBinaryBare(bz, snapshot); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode snapshot metadata for height %v format %v", height, format)
+	}
+	return snapshot, nil
+}
+
+// GetLatest fetches the latest snapshot from the store, or returns nil if there is none
+func (s *Store) GetLatest() (*Snapshot, error) {
+	snapshots, err := s.List()
+	if err != nil || len(snapshots) == 0 {
+		return nil, err
+	}
+	return snapshots[0], nil
+}
+
+// List lists the snapshots in the store, in descending order of height and format
+func (s *Store) List() ([]*Snapshot, error) {
+	iter, err := s.db.ReverseIterator(encodeKey(0, 0), encodeKey(math.MaxUint64, math.MaxUint32))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list snapshots")
+	}
+	defer iter.Close()
+
+	snapshots := make([]*Snapshot, 0)
+	for ; iter.Valid(); iter.Next() {
+		snapshot := &Snapshot{}
+		if err := cdc.UnmarshalBinaryBare(iter.Value(), snapshot); err != nil {
+			return nil, errors.Wrap(err, "failed to decode snapshot info")
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, nil
+}
+
+// LoadChunk loads a chunk from disk, or returns nil if it does not exist. The caller
+// must close the returned reader.
+func (s *Store) LoadChunk(height uint64, format uint32, chunk uint32) (io.ReadCloser, error) {
+	file, err := os.Open(s.pathChunk(height, format, chunk))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return file, err
+}
+
+// Prune removes all the snapshots but the latest retain heights
+func (s *Store) Prune(retain uint32) (uint64, error) {
+	snapshots, err := s.List()
+	if err != nil {
+		return 0, err
+	}
+
+	pruned := uint64(0)
+	prunedHeights := make(map[uint64]bool)
+	skip := make(map[uint64]bool)
+	for _, snapshot := range snapshots {
+		switch {
+		case skip[snapshot.Height]:
+			continue
+		case uint32(len(skip)) < retain:
+			skip[snapshot.Height] = true
+			continue
+		}
+		if err := s.Delete(snapshot.Height, snapshot.Format); err != nil {
+			return 0, err
+		}
+		pruned++
+		prunedHeights[snapshot.Height] = true
+	}
+	// remove the height directories which are empty now
+	for height := range prunedHeights {
+		if entries, err := os.ReadDir(s.pathHeight(height)); err == nil && len(entries) == 0 {
+			if err := os.Remove(s.pathHeight(height)); err != nil {
+				return 0, errors.Wrapf(err, "failed to remove snapshot directory for height %v", height)
+			}
+		}
+	}
+	return pruned, nil
+}
+
+// Save saves a snapshot to disk, the chunks are read from the channel until it is closed
+func (s *Store) Save(height uint64, format uint32, chunks <-chan io.ReadCloser) (*Snapshot, error) {
+	defer DrainChunks(chunks)
+	if height == 0 {
+		return nil, errors.New("snapshot height cannot be 0")
+	}
+
+	s.mtx.Lock()
+	saving := s.saving[height]
+	s.saving[height] = true
+	s.mtx.Unlock()
+	if saving {
+		return nil, fmt.Errorf("a snapshot for height %v is already being saved", height)
+	}
+	defer func() {
+		s.mtx.Lock()
+		delete(s.saving, height)
+		s.mtx.Unlock()
+	}()
+
+	exists, err := s.db.Has(encodeKey(height, format))
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, fmt.Errorf("snapshot already exists for height %v format %v", height, format)
+	}
+
+	snapshot := &Snapshot{
+		Height: height,
+		Format: format,
+	}
+	index := uint32(0)
+	snapshotHasher := sha256.New()
+	for chunkBody := range chunks {
+		chunkHash, err := s.saveChunk(chunkBody, index, snapshot, snapshotHasher)
+		if err != nil {
+			_ = os.RemoveAll(s.pathSnapshot(height, format))
+			return nil, err
+		}
+		snapshot.Metadata.ChunkHashes = append(snapshot.Metadata.ChunkHashes, chunkHash)
+		index++
+	}
+	snapshot.Chunks = index
+	snapshot.Hash = snapshotHasher.Sum(nil)
+	if err := s.saveSnapshot(snapshot); err != nil {
+		_ = os.RemoveAll(s.pathSnapshot(height, format))
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// saveChunk writes a chunk to disk and returns its hash, the chunk is also added to the snapshot hash
+func (s *Store) saveChunk(chunkBody io.ReadCloser, index uint32, snapshot *Snapshot, snapshotHasher hash.Hash) ([]byte, error) {
+	defer chunkBody.Close()
+
+	dir := s.pathSnapshot(snapshot.Height, snapshot.Format)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrapf(err, "failed to create snapshot directory %q", dir)
+	}
+	path := s.pathChunk(snapshot.Height, snapshot.Format, index)
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create snapshot chunk file %q", path)
+	}
+	defer file.Close()
+
+	chunkHasher := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(file, chunkHasher, snapshotHasher), chunkBody); err != nil {
+		return nil, errors.Wrapf(err, "failed to generate snapshot chunk %v", index)
+	}
+	if err := file.Close(); err != nil {
+		return nil, errors.Wrapf(err, "failed to close snapshot chunk %v", index)
+	}
+	return chunkHasher.Sum(nil), nil
+}
+
+func (s *Store) saveSnapshot(snapshot *Snapshot) error {
+	bz, err := cdc.MarshalBinaryBare(snapshot)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode snapshot metadata")
+	}
+	if err := s.db.SetSync(encodeKey(snapshot.Height, snapshot.Format), bz); err != nil {
+		return errors.Wrap(err, "failed to store snapshot")
+	}
+	return nil
+}
+
+func (s *Store) pathHeight(height uint64) string {
+	return filepath.Join(s.dir, strconv.FormatUint(height, 10))
+}
+
+func (s *Store) pathSnapshot(height uint64, format uint32) string {
+	return filepath.Join(s.pathHeight(height), strconv.FormatUint(uint64(format), 10))
+}
+
+func (s *Store) pathChunk(height uint64, format uint32, chunk uint32) string {
+	return filepath.Join(s.pathSnapshot(height, format), strconv.FormatUint(uint64(chunk), 10))
+}
+
+// encodeKey encodes a snapshot key as prefix | height | format, so that the keys sort by height
+func encodeKey(height uint64, format uint32) []byte {
+	key := make([]byte, 1+8+4)
+	key[0] = keyPrefixSnapshot
+	binary.BigEndian.PutUint64(key[1:], height)
+	binary.BigEndian.PutUint32(key[1+8:], format)
+	return key
+}
@@ -0,0 +1,78 @@
+package snapshots
+
+import (
+	"errors"
+	"io"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+)
+
+// CurrentFormat is the snapshot format produced by the manager. It must be bumped whenever
+// the byte level output of a snapshot changes.
+const CurrentFormat uint32 = 1
+
+var (
+	// ErrUnknownFormat is returned when a snapshot format is not supported
+	ErrUnknownFormat = errors.New("unknown snapshot format")
+	// ErrChunkHashMismatch is returned when a chunk does not match the hash in the snapshot metadata
+	ErrChunkHashMismatch = errors.New("chunk hash verification failed")
+	// ErrInvalidMetadata is returned when the snapshot metadata is invalid
+	ErrInvalidMetadata = errors.New("invalid snapshot metadata")
+	// ErrInvalidSnapshotVersion is returned when a snapshot cannot be taken at the given height
+	ErrInvalidSnapshotVersion = errors.New("invalid snapshot version")
+)
+
+var cdc = codec.New()
+
+// Snapshotter is something that can create and restore snapshots of its state
+type Snapshotter interface {
+	// Snapshot writes the state at height into w
+	Snapshot(height uint64, w io.Writer) error
+
+	// Restore restores the state at height from r
+	Restore(height uint64, r io.Reader) error
+}
+
+// Snapshot is the metadata of a snapshot which is kept in the snapshot store
+type Snapshot struct {
+	Height   uint64   `json:"height"`
+	Format   uint32   `json:"format"`
+	Chunks   uint32   `json:"chunks"`
+	Hash     []byte   `json:"hash"`
+	Metadata Metadata `json:"metadata"`
+}
+
+// Metadata is the application metadata of a snapshot, it is sent to peers along with the snapshot
+type Metadata struct {
+	ChunkHashes [][]byte `json:"chunk_hashes"`
+}
+
+// SnapshotFromABCI converts an abci snapshot into a snapshot
+func SnapshotFromABCI(in *abci.Snapshot) (Snapshot, error) {
+	snapshot := Snapshot{
+		Height: in.Height,
+		Format: in.Format,
+		Chunks: in.Chunks,
+		Hash:   in.Hash,
+	}
+	if err := cdc.UnmarshalBinaryBare(in.Metadata, &snapshot.Metadata); err != nil {
+		return Snapshot{}, ErrInvalidMetadata
+	}
+	return snapshot, nil
+}
+
+// ToABCI converts the snapshot into an abci snapshot
+func (s Snapshot) ToABCI() (abci.Snapshot, error) {
+	metadata, err := cdc.MarshalBinaryBare(s.Metadata)
+	if err != nil {
+		return abci.Snapshot{}, err
+	}
+	return abci.Snapshot{
+		Height:   s.Height,
+		Format:   s.Format,
+		Chunks:   s.Chunks,
+		Hash:     s.Hash,
+		Metadata: metadata,
+	}, nil
+}
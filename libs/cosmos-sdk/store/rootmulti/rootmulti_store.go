@@ -151,6 +151,37 @@ func (rs *Store) MountStoreWithDB(key types.StoreKey, typ types.StoreType, db db
 	rs.keysByName[key.Name()] = key
 }
 
+// SetUpgradeVersion sets the version from which the mounted store of the given
+// key is committed. Before that version the store is neither saved nor part of
+// the commit info, so mounting it does not change the app hash of older blocks.
+func (rs *Store) SetUpgradeVersion(key types.StoreKey, version int64) {
+	params, ok := rs.storesParams[key]
+	if !ok {
+		panic(fmt.Sprintf("SetUpgradeVersion() on unmounted store key %v", key))
+	}
+	params.upgradeVersion = version
+	rs.storesParams[key] = params
+}
+
+// isUpgradePending returns true if the store of the given key is not committed
+// at the given version yet.
+func (rs *Store) isUpgradePending(key types.StoreKey, version int64) bool {
+	params, ok := rs.storesParams[key]
+	return ok && params.upgradeVersion > version
+}
+
+// committedStores returns the stores which are committed at the given version.
+func (rs *Store) committedStores(version int64) map[types.StoreKey]types.CommitKVStore {
+	stores := make(map[types.StoreKey]types.CommitKVStore, len(rs.stores))
+	for key, store := range rs.stores {
+		if rs.isUpgradePending(key, version) {
+			continue
+		}
+		stores[key] = store
+	}
+	return stores
+}
+
 // GetCommitStore returns a mounted CommitStore for a given StoreKey. If the
 // store is wrapped in an inter-block cache, it will be unwrapped before returning.
 func (rs *Store) GetCommitStore(key types.StoreKey) types.CommitStore {
@@ -204,6 +235,10 @@ func (rs *Store) GetCommitVersion() (int64, error) {
 		if storeParams.typ != types.StoreTypeIAVL {
 			continue
 		}
+		// stores added by an upgrade have no versions before it
+		if storeParams.upgradeVersion != 0 {
+			continue
+		}
 		commitVersion, err := rs.getCommitVersionFromParams(storeParams)
 		if err != nil {
 			return 0, err
@@ -245,6 +280,14 @@ func (rs *Store) loadVersion(ver int64, upgrades *types.StoreUpgrades) error {
 			storeParams.initialVersion = uint64(ver) + 1
 		}
 
+		// If it is committed from an upgrade version later than the loaded one,
+		// make its first saved version the upgrade version
+		if rs.isUpgradePending(key, ver) {
+			if _, ok := infos[key.Name()]; !ok {
+				storeParams.initialVersion = uint64(storeParams.upgradeVersion - 1)
+			}
+		}
+
 		// Load it
 		store, err := rs.loadCommitStoreFromParams(key, commitID, storeParams)
 		if err != nil {
@@ -437,7 +480,7 @@ func (rs *Store) CommitterCommitMap(inputDeltaMap iavltree.TreeDeltaMap) (types.
 	version := previousHeight + 1
 
 	var outputDeltaMap iavltree.TreeDeltaMap
-	rs.lastCommitInfo, outputDeltaMap = commitStores(version, rs.stores, inputDeltaMap)
+	rs.lastCommitInfo, outputDeltaMap = commitStores(version, rs.committedStores(version), inputDeltaMap)
 
 	if !iavltree.EnableAsyncCommit {
 		// Determine if pruneHeight height needs to be added to the list of heights to
@@ -499,6 +542,9 @@ func (rs *Store) pruneStores() {
 		}
 	}()
 	for key, store := range rs.stores {
+		if rs.isUpgradePending(key, rs.lastCommitInfo.Version) {
+			continue
+		}
 		if store.GetStoreType() == types.StoreTypeIAVL {
 			// If the store is wrapped with an inter-block cache, we must first unwrap
 			// it to get the underlying IAVL store.
@@ -842,6 +888,7 @@ type storeParams struct {
 	db             dbm.DB
 	typ            types.StoreType
 	initialVersion uint64
+	upgradeVersion int64
 }
 
 //----------------------------------------
@@ -1151,7 +1198,7 @@ func (rs *Store) Export(to *Store, initVersion int64) error {
 
 func (rs *Store) buildCommitInfo(version int64) commitInfo {
 	storeInfos := []storeInfo{}
-	for key, store := range rs.stores {
+	for key, store := range rs.committedStores(version) {
 		if store.GetStoreType() == types.StoreTypeTransient {
 			continue
 		}
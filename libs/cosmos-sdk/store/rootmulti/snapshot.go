@@ -0,0 +1,203 @@
+package rootmulti
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/store/iavl"
+	"github.com/okex/exchain/libs/cosmos-sdk/store/transient"
+	iavltree "github.com/okex/exchain/libs/iavl"
+	"github.com/pkg/errors"
+)
+
+const (
+	// snapshotMaxItemSize limits the size of a single item of a snapshot stream
+	snapshotMaxItemSize = 64 << 20
+	// snapshotBufferSize is the buffer size of the snapshot stream reader and writer
+	snapshotBufferSize = 4 << 20
+)
+
+// snapshotItem is an item of a store snapshot stream. A snapshotStoreItem starts the
+// nodes of a store, and the following snapshotIAVLItems are the nodes of that store.
+type snapshotItem struct {
+	Store *snapshotStoreItem `json:"store"`
+	IAVL  *snapshotIAVLItem  `json:"iavl"`
+}
+
+type snapshotStoreItem struct {
+	Name string `json:"name"`
+}
+
+type snapshotIAVLItem struct {
+	Key     []byte `json:"key"`
+	Value   []byte `json:"value"`
+	Version int64  `json:"version"`
+	Height  int32  `json:"height"`
+}
+
+type namedIAVLStore struct {
+	name  string
+	store *iavl.Store
+}
+
+// snapshotStores returns the iavl stores committed at height sorted by name,
+// transient stores are skipped
+func (rs *Store) snapshotStores(height uint64) ([]namedIAVLStore, error) {
+	stores := make([]namedIAVLStore, 0, len(rs.stores))
+	for key := range rs.committedStores(int64(height)) {
+		switch store := rs.GetCommitKVStore(key).(type) {
+		case *iavl.Store:
+			stores = append(stores, namedIAVLStore{name: key.Name(), store: store})
+		case *transient.Store:
+			continue
+		default:
+			return nil, fmt.Errorf("don't know how to snapshot store %q of type %T", key.Name(), store)
+		}
+	}
+	sort.Slice(stores, func(i, j int) bool {
+		return stores[i].name < stores[j].name
+	})
+	return stores, nil
+}
+
+// Snapshot writes the iavl nodes of all the stores at height into w. The output is
+// identical across nodes, so that chunks from different peers fit together.
+func (rs *Store) Snapshot(height uint64, w io.Writer) error {
+	if height == 0 {
+		return errors.New("cannot snapshot height 0")
+	}
+	if height > uint64(rs.LastCommitID().Version) {
+		return fmt.Errorf("cannot snapshot future height %v", height)
+	}
+	stores, err := rs.snapshotStores(height)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriterSize(w, snapshotBufferSize)
+	for _, store := range stores {
+		if err := writeSnapshotItem(bw, snapshotItem{Store: &snapshotStoreItem{Name: store.name}}); err != nil {
+			return err
+		}
+		if err := exportIAVLStore(bw, store.store, int64(height)); err != nil {
+			return errors.Wrapf(err, "failed to snapshot store %s", store.name)
+		}
+	}
+	return bw.Flush()
+}
+
+func exportIAVLStore(w io.Writer, store *iavl.Store, version int64) error {
+	exporter, err := store.Export(version)
+	if err != nil {
+		return err
+	}
+	defer exporter.Close()
+
+	for {
+		node, err := exporter.Next()
+		if err == iavltree.ExportDone {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		item := snapshotItem{IAVL: &snapshotIAVLItem{
+			Key:     node.Key,
+			Value:   node.Value,
+			Version: node.Version,
+			Height:  int32(node.Height),
+		}}
+		if err := writeSnapshotItem(w, item); err != nil {
+			return err
+		}
+	}
+}
+
+func writeSnapshotItem(w io.Writer, item snapshotItem) error {
+	bz, err := cdc.MarshalBinaryLengthPrefixed(item)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(bz)
+	return err
+}
+
+// Restore imports the stores at height from a stream written by Snapshot. The stores
+// must be empty, the restored version becomes the latest version of the multistore.
+func (rs *Store) Restore(height uint64, r io.Reader) error {
+	if height == 0 {
+		return errors.New("cannot restore snapshot at height 0")
+	}
+	stores, err := rs.snapshotStores(height)
+	if err != nil {
+		return err
+	}
+	storesByName := make(map[string]*iavl.Store, len(stores))
+	for _, store := range stores {
+		storesByName[store.name] = store.store
+	}
+
+	br := bufio.NewReaderSize(r, snapshotBufferSize)
+	var importer *iavltree.Importer
+	defer func() {
+		if importer != nil {
+			importer.Close()
+		}
+	}()
+	for {
+		var item snapshotItem
+		_, err := cdc.UnmarshalBinaryLengthPrefixedReader(br, &item, snapshotMaxItemSize)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return errors.Wrap(err, "invalid snapshot item")
+		}
+
+		switch {
+		case item.Store != nil:
+			if importer != nil {
+				if err := importer.Commit(); err != nil {
+					return errors.Wrap(err, "failed to commit store import")
+				}
+				importer = nil
+			}
+			store, ok := storesByName[item.Store.Name]
+			if !ok {
+				return fmt.Errorf("snapshot contains unknown store %q", item.Store.Name)
+			}
+			importer, err = store.Import(int64(height))
+			if err != nil {
+				return errors.Wrapf(err, "failed to import store %s", item.Store.Name)
+			}
+		case item.IAVL != nil:
+			if importer == nil {
+				return errors.New("received iavl node before store")
+			}
+			if item.IAVL.Height > 127 || item.IAVL.Height < -128 {
+				return fmt.Errorf("node height %v cannot exceed int8", item.IAVL.Height)
+			}
+			node := &iavltree.ExportNode{
+				Key:     item.IAVL.Key,
+				Value:   item.IAVL.Value,
+				Version: item.IAVL.Version,
+				Height:  int8(item.IAVL.Height),
+			}
+			if err := importer.Add(node); err != nil {
+				return errors.Wrap(err, "failed to add iavl node")
+			}
+		default:
+			return errors.New("unknown snapshot item")
+		}
+	}
+	if importer != nil {
+		if err := importer.Commit(); err != nil {
+			return errors.Wrap(err, "failed to commit store import")
+		}
+		importer = nil
+	}
+
+	version := int64(height)
+	flushMetadata(rs.db, version, rs.buildCommitInfo(version), nil, nil)
+	return rs.LoadLatestVersion()
+}
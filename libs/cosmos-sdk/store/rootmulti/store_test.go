@@ -302,6 +302,58 @@ func TestMultistoreLoadWithUpgrade(t *testing.T) {
 	checkContains(t, ci.StoreInfos, []string{"store1", "restore2", "store3", "store4"})
 }
 
+func TestMultistoreLoadWithUpgradeVersion(t *testing.T) {
+	var db dbm.DB = dbm.NewMemDB()
+	newStore := func() *Store {
+		store := newMultiStoreWithMounts(db, types.PruneNothing)
+		key := types.NewKVStoreKey("store4")
+		store.MountStoreWithDB(key, types.StoreTypeIAVL, nil)
+		store.SetUpgradeVersion(key, 3)
+		require.NoError(t, store.LoadLatestVersion())
+		return store
+	}
+	store := newStore()
+
+	// the stores without the pending one make the same hash
+	expected := newMultiStoreWithMounts(dbm.NewMemDB(), types.PruneNothing)
+	require.NoError(t, expected.LoadLatestVersion())
+
+	k1, v1 := []byte("first"), []byte("store")
+	for _, s := range []*Store{store, expected} {
+		s.getStoreByName("store1").(types.KVStore).Set(k1, v1)
+	}
+	commitID, _ := store.CommitterCommitMap(nil)
+	expectedID, _ := expected.CommitterCommitMap(nil)
+	require.Equal(t, expectedID, commitID)
+
+	ci, err := getCommitInfo(db, 1)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(ci.StoreInfos))
+
+	// the pending store is still not committed after a restart
+	store = newStore()
+	require.Equal(t, commitID, store.LastCommitID())
+	commitID, _ = store.CommitterCommitMap(nil)
+	expectedID, _ = expected.CommitterCommitMap(nil)
+	require.Equal(t, expectedID, commitID)
+
+	// the store is committed from the upgrade version
+	k4, v4 := []byte("fourth"), []byte("upgraded")
+	store.getStoreByName("store4").(types.KVStore).Set(k4, v4)
+	commitID, _ = store.CommitterCommitMap(nil)
+	require.Equal(t, getExpectedCommitID(store, 3), commitID)
+	require.Equal(t, int64(3), store.getStoreByName("store4").(types.CommitKVStore).LastCommitID().Version)
+
+	ci, err = getCommitInfo(db, 3)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(ci.StoreInfos))
+	checkHas(t, ci.StoreInfos, "store4")
+
+	store = newStore()
+	require.Equal(t, commitID, store.LastCommitID())
+	require.Equal(t, v4, store.getStoreByName("store4").(types.KVStore).Get(k4))
+}
+
 func TestParsePath(t *testing.T) {
 	_, _, err := parsePath("foo")
 	require.Error(t, err)
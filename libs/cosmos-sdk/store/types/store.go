@@ -156,6 +156,10 @@ type CommitMultiStore interface {
 	// If db == nil, the new store will use the CommitMultiStore db.
 	MountStoreWithDB(key StoreKey, typ StoreType, db dbm.DB)
 
+	// Set the version from which a mounted store is committed. Panics on an
+	// unmounted key.
+	SetUpgradeVersion(key StoreKey, version int64)
+
 	// Panics on a nil key.
 	GetCommitStore(key StoreKey) CommitStore
 
@@ -33,10 +33,11 @@ type Context struct {
 	gasMeter       GasMeter
 	blockGasMeter  GasMeter
 	checkTx        bool
-	recheckTx      bool // if recheckTx == true, then checkTx must also be true
-	wrappedCheckTx bool // if wrappedCheckTx == true, then checkTx must also be true
-	traceTx        bool // traceTx is set true for trace tx and its predesessors , traceTx was set in app.beginBlockForTrace()
-	traceTxLog     bool // traceTxLog is used to create trace logger for evm , traceTxLog is set to true when only tracing target tx (its predesessors will set false), traceTxLog is set before runtx
+	recheckTx      bool   // if recheckTx == true, then checkTx must also be true
+	wrappedCheckTx bool   // if wrappedCheckTx == true, then checkTx must also be true
+	traceTx        bool   // traceTx is set true for trace tx and its predesessors , traceTx was set in app.beginBlockForTrace()
+	traceTxLog     bool   // traceTxLog is used to create trace logger for evm , traceTxLog is set to true when only tracing target tx (its predesessors will set false), traceTxLog is set before runtx
+	traceTxConfig  []byte // traceTxConfig is the json encoded tracer options for the traceTxLog
 	minGasPrice    DecCoins
 	consParams     *abci.ConsensusParams
 	eventManager   *EventManager
@@ -66,6 +67,7 @@ func (c Context) IsCheckTx() bool             { return c.checkTx }
 func (c Context) IsReCheckTx() bool           { return c.recheckTx }
 func (c Context) IsTraceTx() bool             { return c.traceTx }
 func (c Context) IsTraceTxLog() bool          { return c.traceTxLog }
+func (c Context) TraceTxConfig() []byte       { return c.traceTxConfig }
 func (c Context) IsWrappedCheckTx() bool      { return c.wrappedCheckTx }
 func (c Context) MinGasPrices() DecCoins      { return c.minGasPrice }
 func (c Context) EventManager() *EventManager { return c.eventManager }
@@ -203,6 +205,10 @@ func (c Context) WithIsTraceTxLog(isTraceTxLog bool) Context {
 	c.traceTxLog = isTraceTxLog
 	return c
 }
+func (c Context) WithTraceTxConfig(traceTxConfig []byte) Context {
+	c.traceTxConfig = traceTxConfig
+	return c
+}
 func (c Context) WithIsTraceTx(isTraceTx bool) Context {
 	if isTraceTx {
 		c.checkTx = true
@@ -11,6 +11,9 @@ type GasRefundHandler func(ctx Context, tx Tx) (fee Coins, err error)
 
 type AccHandler func(ctx Context, address AccAddress) (nonce uint64)
 
+// StateOverrideHandler applies the encoded state overrides of a simulated tx on the state of ctx
+type StateOverrideHandler func(ctx Context, overrides []byte) error
+
 type UpdateFeeCollectorAccHandler func(ctx Context, balance Coins) error
 
 type LogFix func(isAnteFailed [][]string) (logs [][]byte)
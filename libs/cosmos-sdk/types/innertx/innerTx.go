@@ -2,34 +2,107 @@ package innertx
 
 import (
 	"math/big"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 )
 
 const (
 	CosmosCallType = "cosmos"
+	EvmCallType    = "evm"
 	CosmosDepth    = 0
 
-	SendCallName       = "send"
-	DelegateCallName   = "delegate"
-	MultiCallName      = "multi-send"
-	UndelegateCallName = "undelegate"
-	EvmCallName        = "call"
-	EvmCreateName      = "create"
+	SendCallName         = "send"
+	DelegateCallName     = "delegate"
+	MultiCallName        = "multi-send"
+	UndelegateCallName   = "undelegate"
+	EvmCallName          = "call"
+	EvmCreateName        = "create"
+	EvmCreate2Name       = "create2"
+	EvmCallCodeName      = "callcode"
+	EvmDelegateCallName  = "delegatecall"
+	EvmStaticCallName    = "staticcall"
+	EvmSelfDestructName  = "selfdestruct"
+	evmRevertedErrorText = "execution reverted"
 )
 
 var BIG0 = big.NewInt(0)
 
+// InnerTx is one internal transaction of a tx: a cosmos level coin transfer or an evm call frame
+type InnerTx struct {
+	Depth    int64  `json:"depth"`
+	CallType string `json:"call_type"`
+	Name     string `json:"name"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Value    string `json:"value"`
+	ValueWei string `json:"value_wei"`
+	GasUsed  uint64 `json:"gas_used"`
+	IsError  bool   `json:"is_error"`
+	Error    string `json:"error,omitempty"`
+}
+
+// ERC20Contract is a contract which is created by a tx and exposes the erc20 interface
+type ERC20Contract struct {
+	Address     string `json:"address"`
+	TxHash      string `json:"tx_hash"`
+	BlockHeight int64  `json:"block_height"`
+}
+
+// InnerTxKeeper records the internal transactions of the txs in a block
 type InnerTxKeeper interface {
-	InitInnerBlock(...interface{})
-	UpdateInnerTx(...interface{})
+	InitInnerBlock(height int64, blockHash string)
+	UpdateInnerTx(txBytes []byte, depth int64, from, to sdk.AccAddress, callType, name string, amt sdk.Coins, err error)
+}
+
+// NewCosmosInnerTx creates an inner tx of a cosmos level coin transfer
+func NewCosmosInnerTx(depth int64, from, to sdk.AccAddress, callType, name string, amt sdk.Coins, err error) *InnerTx {
+	innerTx := &InnerTx{
+		Depth:    depth,
+		CallType: callType,
+		Name:     name,
+		Value:    amt.String(),
+		ValueWei: amt.AmountOf(sdk.DefaultBondDenom).BigInt().String(),
+	}
+	if !from.Empty() {
+		innerTx.From = from.String()
+	}
+	if !to.Empty() {
+		innerTx.To = to.String()
+	}
+	SetError(innerTx, err)
+	return innerTx
 }
 
-func AddDefaultInnerTx(...interface{}) interface{} {
-	return nil
+// NewEvmInnerTx creates an inner tx of an evm call frame, the value is in wei
+func NewEvmInnerTx(depth int64, callType, name, from, to string, value *big.Int) *InnerTx {
+	if value == nil {
+		value = BIG0
+	}
+	return &InnerTx{
+		Depth:    depth,
+		CallType: callType,
+		Name:     name,
+		From:     from,
+		To:       to,
+		Value:    sdk.NewDecFromBigIntWithPrec(value, sdk.Precision).String(),
+		ValueWei: value.String(),
+	}
 }
 
-func UpdateDefaultInnerTx(...interface{}) {
+// SetError marks the inner tx as failed with err, a nil err leaves it untouched
+func SetError(innerTx *InnerTx, err error) {
+	if err == nil {
+		return
+	}
+	innerTx.IsError = true
+	innerTx.Error = err.Error()
 }
 
-func ParseInnerTxAndContract(...interface{}) (interface{}, interface{}) {
-	return nil, nil
+// SetReverted marks the inner tx as reverted unless it has already failed
+func SetReverted(innerTx *InnerTx) {
+	if innerTx.IsError {
+		return
+	}
+	innerTx.IsError = true
+	innerTx.Error = evmRevertedErrorText
 }
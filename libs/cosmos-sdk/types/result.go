@@ -44,6 +44,37 @@ type SimulationResponse struct {
 	Result *Result
 }
 
+// QueryTraceTx defines the params of the trace tx query
+type QueryTraceTx struct {
+	TxHash      []byte `json:"tx_hash"`
+	TraceConfig []byte `json:"trace_config"`
+}
+
+// QueryTraceBlock defines the params of the trace block query
+type QueryTraceBlock struct {
+	Height      int64  `json:"height"`
+	TraceConfig []byte `json:"trace_config"`
+}
+
+// QueryTraceCall defines the params of the trace call query
+type QueryTraceCall struct {
+	TxBytes     []byte `json:"tx_bytes"`
+	TraceConfig []byte `json:"trace_config"`
+}
+
+// QuerySimulateWithOverrides defines the params of the simulate query with state overrides
+type QuerySimulateWithOverrides struct {
+	TxBytes        []byte `json:"tx_bytes"`
+	StateOverrides []byte `json:"state_overrides"`
+}
+
+// TraceTxResult defines the trace result of a tx in a traced block
+type TraceTxResult struct {
+	TxHash []byte `json:"tx_hash"`
+	Result []byte `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
 // ABCIMessageLogs represents a slice of ABCIMessageLog.
 type ABCIMessageLogs []ABCIMessageLog
 
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -174,7 +175,6 @@ func (tx StdTx) GetSigners() []sdk.AccAddress {
 	return signers
 }
 
-
 func (tx StdTx) GetType() sdk.TransactionType {
 	return sdk.StdTxType
 }
@@ -440,11 +440,20 @@ func EthereumTxEncoder(_ *codec.Codec) sdk.TxEncoder {
 	}
 }
 
+// EthereumTxEncode encodes the tx with its canonical binary encoding if it has one (e.g. the EIP-2718
+// envelope of a typed ethereum tx), otherwise by RLP
 func EthereumTxEncode(tx sdk.Tx) ([]byte, error) {
+	if m, ok := tx.(encoding.BinaryMarshaler); ok {
+		return m.MarshalBinary()
+	}
 	return rlp.EncodeToBytes(tx)
 }
 
+// EthereumTxDecode is the reverse of EthereumTxEncode
 func EthereumTxDecode(b []byte, tx interface{}) error {
+	if u, ok := tx.(encoding.BinaryUnmarshaler); ok {
+		return u.UnmarshalBinary(b)
+	}
 	return rlp.DecodeBytes(b, tx)
 }
 
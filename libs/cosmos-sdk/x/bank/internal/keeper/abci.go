@@ -12,6 +12,6 @@ import (
 func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, ik innertx.InnerTxKeeper) {
 	currentHash := req.Hash
 	if ik != nil {
-		ik.InitInnerBlock(common.BytesToHash(currentHash).Hex())
+		ik.InitInnerBlock(ctx.BlockHeight(), common.BytesToHash(currentHash).Hex())
 	}
 }
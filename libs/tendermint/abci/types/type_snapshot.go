@@ -0,0 +1,96 @@
+package types
+
+// Snapshot is a snapshot of the application state at a height, which is split into chunks
+// and is offered to the nodes doing state sync.
+type Snapshot struct {
+	Height   uint64 `json:"height"`   // The height at which the snapshot was taken
+	Format   uint32 `json:"format"`   // The application-specific snapshot format
+	Chunks   uint32 `json:"chunks"`   // Number of chunks in the snapshot
+	Hash     []byte `json:"hash"`     // Arbitrary snapshot hash, equal only if identical
+	Metadata []byte `json:"metadata"` // Arbitrary application metadata
+}
+
+type RequestListSnapshots struct{}
+
+type ResponseListSnapshots struct {
+	Snapshots []*Snapshot `json:"snapshots"`
+}
+
+type RequestOfferSnapshot struct {
+	Snapshot *Snapshot `json:"snapshot"` // snapshot offered by peers
+	AppHash  []byte    `json:"app_hash"` // light client-verified app hash for snapshot height
+}
+
+type ResponseOfferSnapshot_Result int32
+
+const (
+	ResponseOfferSnapshot_UNKNOWN       ResponseOfferSnapshot_Result = 0 // Unknown result, abort all snapshot restoration
+	ResponseOfferSnapshot_ACCEPT        ResponseOfferSnapshot_Result = 1 // Snapshot accepted, apply chunks
+	ResponseOfferSnapshot_ABORT         ResponseOfferSnapshot_Result = 2 // Abort all snapshot restoration
+	ResponseOfferSnapshot_REJECT        ResponseOfferSnapshot_Result = 3 // Reject this specific snapshot, try others
+	ResponseOfferSnapshot_REJECT_FORMAT ResponseOfferSnapshot_Result = 4 // Reject all snapshots of this format, try others
+	ResponseOfferSnapshot_REJECT_SENDER ResponseOfferSnapshot_Result = 5 // Reject all snapshots from the sender(s), try others
+)
+
+var ResponseOfferSnapshot_Result_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "ACCEPT",
+	2: "ABORT",
+	3: "REJECT",
+	4: "REJECT_FORMAT",
+	5: "REJECT_SENDER",
+}
+
+func (x ResponseOfferSnapshot_Result) String() string {
+	return ResponseOfferSnapshot_Result_name[int32(x)]
+}
+
+type ResponseOfferSnapshot struct {
+	Result ResponseOfferSnapshot_Result `json:"result"`
+}
+
+type RequestLoadSnapshotChunk struct {
+	Height uint64 `json:"height"`
+	Format uint32 `json:"format"`
+	Chunk  uint32 `json:"chunk"`
+}
+
+type ResponseLoadSnapshotChunk struct {
+	Chunk []byte `json:"chunk"`
+}
+
+type RequestApplySnapshotChunk struct {
+	Index  uint32 `json:"index"`
+	Chunk  []byte `json:"chunk"`
+	Sender string `json:"sender"`
+}
+
+type ResponseApplySnapshotChunk_Result int32
+
+const (
+	ResponseApplySnapshotChunk_UNKNOWN         ResponseApplySnapshotChunk_Result = 0 // Unknown result, abort all snapshot restoration
+	ResponseApplySnapshotChunk_ACCEPT          ResponseApplySnapshotChunk_Result = 1 // Chunk successfully accepted
+	ResponseApplySnapshotChunk_ABORT           ResponseApplySnapshotChunk_Result = 2 // Abort all snapshot restoration
+	ResponseApplySnapshotChunk_RETRY           ResponseApplySnapshotChunk_Result = 3 // Retry chunk (combine with refetch and reject)
+	ResponseApplySnapshotChunk_RETRY_SNAPSHOT  ResponseApplySnapshotChunk_Result = 4 // Retry snapshot (combine with refetch and reject)
+	ResponseApplySnapshotChunk_REJECT_SNAPSHOT ResponseApplySnapshotChunk_Result = 5 // Reject this snapshot, try others
+)
+
+var ResponseApplySnapshotChunk_Result_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "ACCEPT",
+	2: "ABORT",
+	3: "RETRY",
+	4: "RETRY_SNAPSHOT",
+	5: "REJECT_SNAPSHOT",
+}
+
+func (x ResponseApplySnapshotChunk_Result) String() string {
+	return ResponseApplySnapshotChunk_Result_name[int32(x)]
+}
+
+type ResponseApplySnapshotChunk struct {
+	Result        ResponseApplySnapshotChunk_Result `json:"result"`
+	RefetchChunks []uint32                          `json:"refetch_chunks"` // Chunks to refetch and reapply
+	RejectSenders []string                          `json:"reject_senders"` // Chunk senders to reject and ban
+}
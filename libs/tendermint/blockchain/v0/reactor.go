@@ -74,6 +74,7 @@ type BlockchainReactor struct {
 	fastSync     bool
 	autoFastSync bool
 	isSyncing    bool
+	stateSyncing bool // the state isn't bootstrapped until the state sync completes
 	mtx          sync.RWMutex
 
 	requestsCh <-chan BlockRequest
@@ -132,6 +133,38 @@ func (bcR *BlockchainReactor) OnStart() error {
 	return nil
 }
 
+// SwitchToFastSync is called by the state sync reactor when switching to fast sync.
+func (bcR *BlockchainReactor) SwitchToFastSync(state sm.State) error {
+	bcR.mtx.Lock()
+	bcR.fastSync = true
+	bcR.stateSyncing = false
+	bcR.curState = state
+	bcR.mtx.Unlock()
+
+	// the pool wasn't started in OnStart as fast sync was disabled during state sync
+	bcR.pool.SetHeight(state.LastBlockHeight + 1)
+	if err := bcR.pool.Start(); err != nil {
+		return err
+	}
+
+	go bcR.poolRoutine()
+	return nil
+}
+
+// SetStateSyncing makes the reactor wait for the state sync, it doesn't switch to fast sync by
+// the peer status until the state sync completes.
+func (bcR *BlockchainReactor) SetStateSyncing(stateSyncing bool) {
+	bcR.mtx.Lock()
+	bcR.stateSyncing = stateSyncing
+	bcR.mtx.Unlock()
+}
+
+func (bcR *BlockchainReactor) isStateSyncing() bool {
+	bcR.mtx.RLock()
+	defer bcR.mtx.RUnlock()
+	return bcR.stateSyncing
+}
+
 // OnStop implements service.Service.
 func (bcR *BlockchainReactor) OnStop() {
 	bcR.pool.Stop()
@@ -218,7 +251,7 @@ func (bcR *BlockchainReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte)
 		// Got a peer status. Unverified. TODO: should verify before SetPeerRange
 		shouldSync := bcR.pool.SetPeerRange(src.ID(), msg.Base, msg.Height, bcR.store.Height())
 		// should switch to fast-sync when more than XX peers' height is greater than store.Height
-		if shouldSync {
+		if shouldSync && !bcR.isStateSyncing() {
 			bcR.Logger.Info("ShouldSync.", "Status peer", msg.Height, "now", bcR.store.Height())
 			go bcR.poolRoutine()
 		}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	dbm "github.com/okex/exchain/libs/tm-db"
 
@@ -183,6 +184,44 @@ func TestNoBlockResponse(t *testing.T) {
 	}
 }
 
+// a node that state-synced switches to fast sync and catches up from the restored height
+func TestSwitchToFastSync(t *testing.T) {
+	config = cfg.ResetTestRoot("blockchain_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	genDoc, privVals := randGenesisDoc(1, false, 30)
+
+	maxBlockHeight := int64(20)
+
+	reactorPairs := make([]BlockchainReactorPair, 2)
+	reactorPairs[0] = newBlockchainReactor(log.TestingLogger(), genDoc, privVals, maxBlockHeight)
+	reactorPairs[1] = newBlockchainReactor(log.TestingLogger(), genDoc, privVals, 0)
+	// fast sync is disabled while the node state-syncs
+	reactorPairs[1].reactor.fastSync = false
+
+	p2p.MakeConnectedSwitches(config.P2P, 2, func(i int, s *p2p.Switch) *p2p.Switch {
+		s.AddReactor("BLOCKCHAIN", reactorPairs[i].reactor)
+		return s
+
+	}, p2p.Connect2Switches)
+
+	defer func() {
+		for _, r := range reactorPairs {
+			r.reactor.Stop()
+			r.app.Stop()
+		}
+	}()
+
+	require.False(t, reactorPairs[1].reactor.pool.IsRunning())
+	require.NoError(t, reactorPairs[1].reactor.SwitchToFastSync(reactorPairs[1].reactor.curState))
+
+	deadline := time.Now().Add(10 * time.Second)
+	for !reactorPairs[1].reactor.pool.IsCaughtUp() {
+		require.True(t, time.Now().Before(deadline), "fast sync stalled")
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.NotNil(t, reactorPairs[1].reactor.store.LoadBlock(maxBlockHeight-1))
+}
+
 // NOTE: This is too hard to test without
 // an easy way to add test peer to switch
 // or without significant refactoring of the module.
@@ -1,5 +1,13 @@
 package delta
 
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+)
+
 type DeltaBroker interface {
 	GetLocker() bool
 	ReleaseLocker()
@@ -7,3 +15,48 @@ type DeltaBroker interface {
 	SetDeltas(height int64, bytes []byte) error
 	GetDeltas(height int64) ([]byte, error, int64)
 }
+
+// BrokerCreator creates a DeltaBroker, the broker reads its own configuration
+type BrokerCreator func(logger log.Logger) (DeltaBroker, error)
+
+var (
+	brokersMtx sync.RWMutex
+	brokers    = make(map[string]BrokerCreator)
+)
+
+// RegisterBroker makes a DeltaBroker available by the given name.
+// It panics if the name is registered twice or the creator is nil.
+func RegisterBroker(name string, creator BrokerCreator) {
+	brokersMtx.Lock()
+	defer brokersMtx.Unlock()
+	if creator == nil {
+		panic(fmt.Sprintf("delta broker %s: nil creator", name))
+	}
+	if _, ok := brokers[name]; ok {
+		panic(fmt.Sprintf("delta broker %s is already registered", name))
+	}
+	brokers[name] = creator
+}
+
+// NewBroker creates the DeltaBroker registered by the given name
+func NewBroker(name string, logger log.Logger) (DeltaBroker, error) {
+	brokersMtx.RLock()
+	creator, ok := brokers[name]
+	brokersMtx.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown delta broker %s, available: %v", name, Brokers())
+	}
+	return creator(logger)
+}
+
+// Brokers returns the sorted names of the registered DeltaBrokers
+func Brokers() []string {
+	brokersMtx.RLock()
+	defer brokersMtx.RUnlock()
+	names := make([]string, 0, len(brokers))
+	for name := range brokers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,255 @@
+package file_cgi
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/okex/exchain/libs/tendermint/types"
+)
+
+const (
+	lockerExpire = 4 * time.Second
+
+	mostRecentHeightFile = "MostRecentHeight"
+	deltaLockerFile      = "DeltaLocker"
+	deltaFilePrefix      = "DH-"
+)
+
+// FileClient is a DeltaBroker storing the deltas in a local directory,
+// which can be shared by several nodes through NFS or any other means.
+type FileClient struct {
+	dir    string
+	ttl    time.Duration
+	owner  []byte
+	logger log.Logger
+
+	mtx       sync.Mutex
+	lastPrune time.Time
+}
+
+// NewFileClient creates the directory of the current delta version under dir.
+// Deltas older than ttl are removed, ttl <= 0 means deltas never expire.
+func NewFileClient(dir string, ttl time.Duration, l log.Logger) (*FileClient, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("delta dir is empty")
+	}
+	dir = filepath.Join(dir, fmt.Sprintf("dds-%d", types.DeltaVersion))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	owner, err := newLockerOwner()
+	if err != nil {
+		return nil, err
+	}
+	return &FileClient{dir: dir, ttl: ttl, owner: owner, logger: l, lastPrune: time.Now()}, nil
+}
+
+// newLockerOwner returns the identity written into the locker file by the client,
+// so that the client only releases the locker it holds
+func newLockerOwner() ([]byte, error) {
+	nonce := make([]byte, 8)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	host, _ := os.Hostname()
+	return []byte(fmt.Sprintf("%s-%d-%s", host, os.Getpid(), hex.EncodeToString(nonce))), nil
+}
+
+func (f *FileClient) GetLocker() bool {
+	path := filepath.Join(f.dir, deltaLockerFile)
+	for i := 0; i < 2; i++ {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			_, err = file.Write(f.owner)
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				f.logger.Error("GetLocker err", "err", err)
+				os.Remove(path)
+				return false
+			}
+			return true
+		}
+		if !os.IsExist(err) {
+			f.logger.Error("GetLocker err", "err", err)
+			return false
+		}
+		// the locker releases itself once it expires
+		owner, err := ioutil.ReadFile(path)
+		if err != nil {
+			return false
+		}
+		info, err := os.Stat(path)
+		if err != nil || time.Since(info.ModTime()) < lockerExpire {
+			return false
+		}
+		// another client may have taken the expired locker in the meantime
+		if current, err := ioutil.ReadFile(path); err != nil || !bytes.Equal(current, owner) {
+			return false
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return false
+		}
+	}
+	return false
+}
+
+// ReleaseLocker removes the locker only if it is held by the client,
+// the locker may have expired and been taken by another client
+func (f *FileClient) ReleaseLocker() {
+	path := filepath.Join(f.dir, deltaLockerFile)
+	owner, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return
+	}
+	if err != nil {
+		f.logger.Error("Failed to Release Locker", "err", err)
+		return
+	}
+	if !bytes.Equal(owner, f.owner) {
+		f.logger.Info("The locker is held by another client", "owner", string(owner))
+		return
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		f.logger.Error("Failed to Release Locker", "err", err)
+	}
+}
+
+// return bool: if change the value of latest_height, need to upload
+func (f *FileClient) ResetMostRecentHeightAfterUpload(targetHeight int64, upload func(int64) bool) (bool, int64, error) {
+	var res bool
+	mrh, err := f.readMostRecentHeight()
+	if err != nil {
+		return res, mrh, err
+	}
+
+	if mrh < targetHeight && upload(mrh) {
+		err = f.writeFile(mostRecentHeightFile, []byte(strconv.FormatInt(targetHeight, 10)))
+		if err == nil {
+			res = true
+			f.logger.Info("Reset most recent height", "new-mrh", targetHeight, "old-mrh", mrh)
+		} else {
+			f.logger.Error("Failed to reset most recent height",
+				"target-mrh", targetHeight,
+				"existing-mrh", mrh, "err", err)
+		}
+	}
+	return res, mrh, err
+}
+
+func (f *FileClient) SetDeltas(height int64, bytes []byte) error {
+	if len(bytes) == 0 {
+		return fmt.Errorf("delta is empty")
+	}
+	f.pruneExpired()
+
+	name := genDeltaFile(height)
+	// keep the existing delta, the same as SETNX
+	if _, err := os.Stat(filepath.Join(f.dir, name)); err == nil {
+		return nil
+	}
+	return f.writeFile(name, bytes)
+}
+
+func (f *FileClient) GetDeltas(height int64) ([]byte, error, int64) {
+	mrh := f.getMostRecentHeight()
+	path := filepath.Join(f.dir, genDeltaFile(height))
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) || (err == nil && f.expired(info)) {
+		return nil, fmt.Errorf("get empty delta"), mrh
+	}
+	if err != nil {
+		return nil, err, mrh
+	}
+	bytes, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("get empty delta"), mrh
+	}
+	return bytes, err, mrh
+}
+
+func (f *FileClient) getMostRecentHeight() int64 {
+	mrh, err := f.readMostRecentHeight()
+	if err != nil {
+		return -1
+	}
+	return mrh
+}
+
+// readMostRecentHeight returns 0 if the most recent height has never been set
+func (f *FileClient) readMostRecentHeight() (int64, error) {
+	bytes, err := ioutil.ReadFile(filepath.Join(f.dir, mostRecentHeightFile))
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(bytes)), 10, 64)
+}
+
+// writeFile writes to a temp file first and then renames it,
+// so that the readers never see a partially written file
+func (f *FileClient) writeFile(name string, bytes []byte) error {
+	tmp, err := ioutil.TempFile(f.dir, name+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(bytes); err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), filepath.Join(f.dir, name))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+func (f *FileClient) expired(info os.FileInfo) bool {
+	return f.ttl > 0 && time.Since(info.ModTime()) > f.ttl
+}
+
+// pruneExpired removes the expired deltas, at most once per ttl
+func (f *FileClient) pruneExpired() {
+	if f.ttl <= 0 {
+		return
+	}
+	f.mtx.Lock()
+	if time.Since(f.lastPrune) < f.ttl {
+		f.mtx.Unlock()
+		return
+	}
+	f.lastPrune = time.Now()
+	f.mtx.Unlock()
+
+	files, err := ioutil.ReadDir(f.dir)
+	if err != nil {
+		f.logger.Error("Failed to prune deltas", "err", err)
+		return
+	}
+	for _, info := range files {
+		if strings.HasPrefix(info.Name(), deltaFilePrefix) && f.expired(info) {
+			os.Remove(filepath.Join(f.dir, info.Name()))
+		}
+	}
+}
+
+func genDeltaFile(height int64) string {
+	return fmt.Sprintf("%s%d", deltaFilePrefix, height)
+}
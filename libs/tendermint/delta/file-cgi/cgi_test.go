@@ -0,0 +1,144 @@
+package file_cgi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ConstDeltaBytes = "delta-bytes"
+	ConstTestHeight = 1
+)
+
+func getFileClient(t *testing.T, ttl time.Duration) *FileClient {
+	f, err := NewFileClient(t.TempDir(), ttl, log.TestingLogger())
+	require.NoError(t, err)
+	return f
+}
+
+func TestFileClient_SetGetDeltas(t *testing.T) {
+	f := getFileClient(t, time.Minute)
+
+	height := int64(ConstTestHeight)
+	// delta is empty
+	re, err, mrh := f.GetDeltas(height)
+	require.Nil(t, re)
+	require.Error(t, err)
+	require.Equal(t, int64(0), mrh)
+
+	// set empty delta
+	require.Error(t, f.SetDeltas(height, nil))
+
+	// set delta
+	require.NoError(t, f.SetDeltas(height, []byte(ConstDeltaBytes)))
+	re, err, _ = f.GetDeltas(height)
+	require.NoError(t, err)
+	require.Equal(t, []byte(ConstDeltaBytes), re)
+
+	// the existing delta is kept
+	require.NoError(t, f.SetDeltas(height, []byte("other-bytes")))
+	re, err, _ = f.GetDeltas(height)
+	require.NoError(t, err)
+	require.Equal(t, []byte(ConstDeltaBytes), re)
+
+	// get wrong height
+	re, err, _ = f.GetDeltas(height + 1)
+	require.Nil(t, re)
+	require.Error(t, err)
+}
+
+func TestFileClient_ExpiredDeltas(t *testing.T) {
+	f := getFileClient(t, time.Minute)
+
+	height := int64(ConstTestHeight)
+	require.NoError(t, f.SetDeltas(height, []byte(ConstDeltaBytes)))
+	expired := time.Now().Add(-2 * time.Minute)
+	path := filepath.Join(f.dir, genDeltaFile(height))
+	require.NoError(t, os.Chtimes(path, expired, expired))
+
+	re, err, _ := f.GetDeltas(height)
+	require.Nil(t, re)
+	require.Error(t, err)
+
+	// expired deltas are pruned
+	f.lastPrune = expired
+	require.NoError(t, f.SetDeltas(height+1, []byte(ConstDeltaBytes)))
+	_, err = os.Stat(path)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestFileClient_ResetLatestHeightAfterUpload(t *testing.T) {
+	f := getFileClient(t, time.Minute)
+	uploadSuccess := func(int64) bool { return true }
+	uploadFailed := func(int64) bool { return false }
+	h := int64(ConstTestHeight)
+	tests := []struct {
+		name   string
+		height int64
+		upload func(int64) bool
+		want   bool
+	}{
+		{"upload failed", h, uploadFailed, false},
+		{"first time set", h, uploadSuccess, true},
+		{"height<latestHeight", h - 1, uploadSuccess, false},
+		{"height==latestHeight", h, uploadSuccess, false},
+		{"height>latestHeight", h + 1, uploadSuccess, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := f.ResetMostRecentHeightAfterUpload(tt.height, tt.upload)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+	_, _, mrh := f.GetDeltas(h)
+	require.Equal(t, h+1, mrh)
+}
+
+func TestFileClient_GetReleaseLocker(t *testing.T) {
+	f := getFileClient(t, time.Minute)
+
+	// first time lock
+	require.True(t, f.GetLocker())
+	// already locked
+	require.False(t, f.GetLocker())
+
+	// release locker
+	f.ReleaseLocker()
+	require.True(t, f.GetLocker())
+
+	// when locker expire time, locker release itself
+	expired := time.Now().Add(-lockerExpire)
+	require.NoError(t, os.Chtimes(filepath.Join(f.dir, deltaLockerFile), expired, expired))
+	require.True(t, f.GetLocker())
+}
+
+func TestFileClient_ReleaseLockerOfOthers(t *testing.T) {
+	dir := t.TempDir()
+	f1, err := NewFileClient(dir, time.Minute, log.TestingLogger())
+	require.NoError(t, err)
+	f2, err := NewFileClient(dir, time.Minute, log.TestingLogger())
+	require.NoError(t, err)
+
+	require.True(t, f1.GetLocker())
+	require.False(t, f2.GetLocker())
+
+	// the locker of f1 can't be released by f2
+	f2.ReleaseLocker()
+	require.False(t, f2.GetLocker())
+
+	// f2 takes the expired locker, which can't be released by f1 any more
+	expired := time.Now().Add(-lockerExpire)
+	require.NoError(t, os.Chtimes(filepath.Join(f1.dir, deltaLockerFile), expired, expired))
+	require.True(t, f2.GetLocker())
+	f1.ReleaseLocker()
+	require.False(t, f1.GetLocker())
+
+	f2.ReleaseLocker()
+	require.True(t, f1.GetLocker())
+}
@@ -0,0 +1,178 @@
+package http_cgi
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+)
+
+// HTTPClient is a DeltaBroker talking to the delta Server of another node
+type HTTPClient struct {
+	url    string
+	token  string
+	owner  string
+	client *http.Client
+	logger log.Logger
+}
+
+// NewHTTPClient returns a client of the delta Server listening on url, e.g. http://127.0.0.1:26680,
+// which presents the token to the server if it is not empty
+func NewHTTPClient(url, token string, timeout time.Duration, l log.Logger) *HTTPClient {
+	return &HTTPClient{
+		url:    strings.TrimSuffix(url, "/"),
+		token:  token,
+		owner:  newLockerOwner(),
+		client: &http.Client{Timeout: timeout},
+		logger: l,
+	}
+}
+
+// newLockerOwner returns the id presented by the client to the server when it gets or releases the locker,
+// so that the client only releases the locker it holds
+func newLockerOwner() string {
+	nonce := make([]byte, 8)
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Sprintf("%d-%d", os.Getpid(), time.Now().UnixNano())
+	}
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), hex.EncodeToString(nonce))
+}
+
+func (c *HTTPClient) lockerPath() string {
+	return fmt.Sprintf("%s?%s=%s", lockerPath, lockerOwnerParam, url.QueryEscape(c.owner))
+}
+
+func (c *HTTPClient) GetLocker() bool {
+	var locked bool
+	if err := c.doJSON(http.MethodPost, c.lockerPath(), &locked); err != nil {
+		c.logger.Error("GetLocker err", "err", err)
+		return false
+	}
+	return locked
+}
+
+func (c *HTTPClient) ReleaseLocker() {
+	if err := c.doJSON(http.MethodDelete, c.lockerPath(), nil); err != nil {
+		c.logger.Error("Failed to Release Locker", "err", err)
+	}
+}
+
+// return bool: if change the value of latest_height, need to upload
+func (c *HTTPClient) ResetMostRecentHeightAfterUpload(targetHeight int64, upload func(int64) bool) (bool, int64, error) {
+	var mrh int64
+	if err := c.doJSON(http.MethodGet, mostRecentHeightPath, &mrh); err != nil {
+		return false, mrh, err
+	}
+	if mrh >= targetHeight || !upload(mrh) {
+		return false, mrh, nil
+	}
+
+	var res resetResult
+	path := fmt.Sprintf("%s?height=%d", mostRecentHeightPath, targetHeight)
+	if err := c.doJSON(http.MethodPut, path, &res); err != nil {
+		c.logger.Error("Failed to reset most recent height",
+			"target-mrh", targetHeight,
+			"existing-mrh", mrh, "err", err)
+		return false, mrh, err
+	}
+	if res.Reset {
+		c.logger.Info("Reset most recent height", "new-mrh", targetHeight, "old-mrh", res.MRH)
+	}
+	return res.Reset, res.MRH, nil
+}
+
+func (c *HTTPClient) SetDeltas(height int64, bz []byte) error {
+	if len(bz) == 0 {
+		return fmt.Errorf("delta is empty")
+	}
+	req, err := c.newRequest(http.MethodPut, c.deltasURL(height), bytes.NewReader(bz))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return checkResponse(resp)
+}
+
+func (c *HTTPClient) GetDeltas(height int64) ([]byte, error, int64) {
+	req, err := c.newRequest(http.MethodGet, c.deltasURL(height), nil)
+	if err != nil {
+		return nil, err, -1
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err, -1
+	}
+	defer resp.Body.Close()
+
+	mrh, err := strconv.ParseInt(resp.Header.Get(mostRecentHeightHeader), 10, 64)
+	if err != nil {
+		mrh = -1
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("get empty delta"), mrh
+	}
+	if err := checkResponse(resp); err != nil {
+		return nil, err, mrh
+	}
+	bz, err := ioutil.ReadAll(resp.Body)
+	return bz, err, mrh
+}
+
+func (c *HTTPClient) deltasURL(height int64) string {
+	return fmt.Sprintf("%s%s%d", c.url, deltasPath, height)
+}
+
+func (c *HTTPClient) doJSON(method, path string, result interface{}) error {
+	req, err := c.newRequest(method, c.url+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+func (c *HTTPClient) newRequest(method, url string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", authorizationPrefix+c.token)
+	}
+	return req, nil
+}
+
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	msg, _ := ioutil.ReadAll(resp.Body)
+	return fmt.Errorf("delta server responded %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+}
@@ -0,0 +1,157 @@
+package http_cgi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	file_cgi "github.com/okex/exchain/libs/tendermint/delta/file-cgi"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ConstDeltaBytes = "delta-bytes"
+	ConstTestHeight = 1
+	ConstTestToken  = "delta-token"
+)
+
+func getHTTPClient(t *testing.T) *HTTPClient {
+	return getHTTPClientWithToken(t, ConstTestToken)
+}
+
+func getHTTPClientWithToken(t *testing.T, token string) *HTTPClient {
+	logger := log.TestingLogger()
+	broker, err := file_cgi.NewFileClient(t.TempDir(), time.Minute, logger)
+	require.NoError(t, err)
+	server := httptest.NewServer(NewServer(broker, ConstTestToken, logger))
+	t.Cleanup(server.Close)
+	return NewHTTPClient(server.URL, token, time.Second, logger)
+}
+
+func TestHTTPClient_SetGetDeltas(t *testing.T) {
+	c := getHTTPClient(t)
+
+	height := int64(ConstTestHeight)
+	// delta is empty
+	re, err, mrh := c.GetDeltas(height)
+	require.Nil(t, re)
+	require.Error(t, err)
+	require.Equal(t, int64(0), mrh)
+
+	// set empty delta
+	require.Error(t, c.SetDeltas(height, nil))
+
+	// set delta
+	require.NoError(t, c.SetDeltas(height, []byte(ConstDeltaBytes)))
+	re, err, _ = c.GetDeltas(height)
+	require.NoError(t, err)
+	require.Equal(t, []byte(ConstDeltaBytes), re)
+
+	// get wrong height
+	re, err, _ = c.GetDeltas(height + 1)
+	require.Nil(t, re)
+	require.Error(t, err)
+}
+
+func TestHTTPClient_ResetLatestHeightAfterUpload(t *testing.T) {
+	c := getHTTPClient(t)
+	uploadSuccess := func(int64) bool { return true }
+	uploadFailed := func(int64) bool { return false }
+	h := int64(ConstTestHeight)
+	tests := []struct {
+		name   string
+		height int64
+		upload func(int64) bool
+		want   bool
+	}{
+		{"upload failed", h, uploadFailed, false},
+		{"first time set", h, uploadSuccess, true},
+		{"height<latestHeight", h - 1, uploadSuccess, false},
+		{"height==latestHeight", h, uploadSuccess, false},
+		{"height>latestHeight", h + 1, uploadSuccess, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := c.ResetMostRecentHeightAfterUpload(tt.height, tt.upload)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+	_, _, mrh := c.GetDeltas(h)
+	require.Equal(t, h+1, mrh)
+}
+
+func TestHTTPClient_GetReleaseLocker(t *testing.T) {
+	c := getHTTPClient(t)
+
+	// first time lock
+	require.True(t, c.GetLocker())
+	// already locked
+	require.False(t, c.GetLocker())
+
+	// release locker
+	c.ReleaseLocker()
+	require.True(t, c.GetLocker())
+
+	// another client can't release the locker held by c
+	other := NewHTTPClient(c.url, ConstTestToken, time.Second, log.TestingLogger())
+	require.False(t, other.GetLocker())
+	other.ReleaseLocker()
+	require.False(t, other.GetLocker())
+	c.ReleaseLocker()
+	require.True(t, other.GetLocker())
+	c.ReleaseLocker()
+	require.False(t, c.GetLocker())
+
+	// the owner is required
+	req, err := c.newRequest(http.MethodDelete, c.url+lockerPath, nil)
+	require.NoError(t, err)
+	resp, err := c.client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	require.False(t, c.GetLocker())
+
+	// the locker can't be got if the server is unreachable
+	unreachable := NewHTTPClient("http://127.0.0.1:1", ConstTestToken, time.Second, log.TestingLogger())
+	require.False(t, unreachable.GetLocker())
+}
+
+func TestHTTPClient_Unauthorized(t *testing.T) {
+	for _, token := range []string{"", "wrong-token"} {
+		c := getHTTPClientWithToken(t, token)
+		require.False(t, c.GetLocker())
+		require.Error(t, c.SetDeltas(ConstTestHeight, []byte(ConstDeltaBytes)))
+		_, err, _ := c.GetDeltas(ConstTestHeight)
+		require.Error(t, err)
+		_, _, err = c.ResetMostRecentHeightAfterUpload(ConstTestHeight, func(int64) bool { return true })
+		require.Error(t, err)
+	}
+}
+
+func TestListenAddr(t *testing.T) {
+	tests := []struct {
+		laddr string
+		token string
+		want  string
+	}{
+		{"26680", "", "127.0.0.1:26680"},
+		{":26680", "", "127.0.0.1:26680"},
+		{"localhost:26680", "", "localhost:26680"},
+		{"[::1]:26680", "", "[::1]:26680"},
+		{"0.0.0.0:26680", "", ""},
+		{"0.0.0.0:26680", ConstTestToken, "0.0.0.0:26680"},
+		{"a:b:c", ConstTestToken, ""},
+	}
+	for _, tt := range tests {
+		got, err := ListenAddr(tt.laddr, tt.token)
+		if tt.want == "" {
+			require.Error(t, err, tt.laddr)
+			continue
+		}
+		require.NoError(t, err, tt.laddr)
+		require.Equal(t, tt.want, got)
+	}
+}
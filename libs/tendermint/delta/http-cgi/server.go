@@ -0,0 +1,206 @@
+package http_cgi
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/okex/exchain/libs/tendermint/delta"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+)
+
+const (
+	lockerPath           = "/delta/locker"
+	mostRecentHeightPath = "/delta/mrh"
+	deltasPath           = "/delta/deltas/"
+
+	// lockerOwnerParam carries the id of the client getting or releasing the locker
+	lockerOwnerParam = "owner"
+
+	// mostRecentHeightHeader carries the most recent height along with the deltas
+	mostRecentHeightHeader = "X-Delta-Most-Recent-Height"
+	// authorizationPrefix prefixes the shared token in the Authorization header
+	authorizationPrefix = "Bearer "
+	// defaultServerHost is the host the server listens on if the listen address has none
+	defaultServerHost = "127.0.0.1"
+
+	maxDeltaSize = 512 << 20
+
+	// lockerExpire is the expiry of the lockers of the redis and file brokers,
+	// the locker may have been taken by another client once it expires
+	lockerExpire = 4 * time.Second
+)
+
+type resetResult struct {
+	Reset bool  `json:"reset"`
+	MRH   int64 `json:"mrh"`
+}
+
+// Server exposes a DeltaBroker to the HTTP clients
+type Server struct {
+	broker delta.DeltaBroker
+	token  string
+	logger log.Logger
+	mux    *http.ServeMux
+
+	// the client holding the locker, only the holder can release it before it expires
+	lockerMtx    sync.Mutex
+	lockerHolder string
+	lockedAt     time.Time
+}
+
+// NewServer returns the http.Handler serving the broker,
+// the clients must present the token if it is not empty
+func NewServer(broker delta.DeltaBroker, token string, l log.Logger) *Server {
+	s := &Server{broker: broker, token: token, logger: l, mux: http.NewServeMux()}
+	s.mux.HandleFunc(lockerPath, s.handleLocker)
+	s.mux.HandleFunc(mostRecentHeightPath, s.handleMostRecentHeight)
+	s.mux.HandleFunc(deltasPath, s.handleDeltas)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	if s.token == "" {
+		return true
+	}
+	got := []byte(r.Header.Get("Authorization"))
+	return subtle.ConstantTimeCompare(got, []byte(authorizationPrefix+s.token)) == 1
+}
+
+// ListenAddr returns the address the server listens on, the host defaults to the localhost.
+// A token is required to listen on the other hosts.
+func ListenAddr(laddr, token string) (string, error) {
+	host, port, err := net.SplitHostPort(laddr)
+	if err != nil {
+		// the port only
+		if host, port, err = net.SplitHostPort(":" + laddr); err != nil {
+			return "", fmt.Errorf("invalid delta server address %s: %v", laddr, err)
+		}
+	}
+	if host == "" {
+		host = defaultServerHost
+	}
+	if token == "" && !isLoopback(host) {
+		return "", fmt.Errorf("a token is required by the delta server listening on %s", host)
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// handleLocker: POST ?owner=ID gets the locker, DELETE ?owner=ID releases it if the client holds it
+func (s *Server) handleLocker(w http.ResponseWriter, r *http.Request) {
+	owner := r.URL.Query().Get(lockerOwnerParam)
+	if owner == "" {
+		http.Error(w, "the locker owner is required", http.StatusBadRequest)
+		return
+	}
+
+	s.lockerMtx.Lock()
+	defer s.lockerMtx.Unlock()
+	switch r.Method {
+	case http.MethodPost:
+		locked := s.broker.GetLocker()
+		if locked {
+			s.lockerHolder, s.lockedAt = owner, time.Now()
+		}
+		s.writeJSON(w, locked)
+	case http.MethodDelete:
+		if owner != s.lockerHolder || time.Since(s.lockedAt) >= lockerExpire {
+			http.Error(w, "the locker isn't held by the client", http.StatusConflict)
+			return
+		}
+		s.broker.ReleaseLocker()
+		s.lockerHolder = ""
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleMostRecentHeight: GET gets the most recent height,
+// PUT ?height=N resets it once the client has uploaded the delta
+func (s *Server) handleMostRecentHeight(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		// the broker reports the most recent height along with any deltas
+		_, _, mrh := s.broker.GetDeltas(0)
+		s.writeJSON(w, mrh)
+	case http.MethodPut:
+		height, err := strconv.ParseInt(r.URL.Query().Get("height"), 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		reset, mrh, err := s.broker.ResetMostRecentHeightAfterUpload(height, func(int64) bool { return true })
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		s.writeJSON(w, resetResult{Reset: reset, MRH: mrh})
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleDeltas: GET /delta/deltas/{height} gets the deltas, PUT sets them
+func (s *Server) handleDeltas(w http.ResponseWriter, r *http.Request) {
+	height, err := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, deltasPath), 10, 64)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		bytes, err, mrh := s.broker.GetDeltas(height)
+		w.Header().Set(mostRecentHeightHeader, strconv.FormatInt(mrh, 10))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write(bytes)
+	case http.MethodPut:
+		bytes, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxDeltaSize))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := s.broker.SetDeltas(height, bytes); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		s.logger.Error("Failed to write delta response", "err", err)
+	}
+}
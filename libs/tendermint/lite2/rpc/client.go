@@ -167,6 +167,18 @@ func (c *Client) GetPendingNonce(address string) (*ctypes.ResultPendingNonce, er
 	return c.next.GetPendingNonce(address)
 }
 
+func (c *Client) UserQueuedTxs(address string, limit int) (*ctypes.ResultUserUnconfirmedTxs, error) {
+	return c.next.UserQueuedTxs(address, limit)
+}
+
+func (c *Client) NumQueuedTxs() (*ctypes.ResultUnconfirmedTxs, error) {
+	return c.next.NumQueuedTxs()
+}
+
+func (c *Client) GetQueuedAddressList() (*ctypes.ResultUnconfirmedAddresses, error) {
+	return c.next.GetQueuedAddressList()
+}
+
 func (c *Client) NetInfo() (*ctypes.ResultNetInfo, error) {
 	return c.next.NetInfo()
 }
@@ -375,6 +387,11 @@ func (c *Client) TxSearch(query string, prove bool, page, perPage int, orderBy s
 	return c.next.TxSearch(query, prove, page, perPage, orderBy)
 }
 
+func (c *Client) BlockSearch(query string, page, perPage int, orderBy string) (
+	*ctypes.ResultBlockSearch, error) {
+	return c.next.BlockSearch(query, page, perPage, orderBy)
+}
+
 // Validators fetches and verifies validators.
 //
 // WARNING: only full validator sets are verified (when length of validators is
@@ -45,7 +45,10 @@ func (ar *AddressRecord) AddItem(address string, cElement *clist.CElement) {
 	}
 }
 
-func (ar *AddressRecord) checkRepeatedAndAddItem(memTx *mempoolTx, info ExTxInfo, txPriceBump int64) *clist.CElement {
+// checkRepeatedAndAddItem adds the element of the tx, the tx with the same nonce is replaced
+// only if the gas price is bumped by txPriceBump percent at least.
+// The replaced tx is returned as well.
+func (ar *AddressRecord) checkRepeatedAndAddItem(memTx *mempoolTx, info ExTxInfo, txPriceBump int64) (*clist.CElement, *mempoolTx, error) {
 	newElement := clist.NewCElement(memTx, info.Sender, info.GasPrice, info.Nonce)
 
 	v, ok := ar.addrTxs.Load(info.Sender)
@@ -59,32 +62,50 @@ func (ar *AddressRecord) checkRepeatedAndAddItem(memTx *mempoolTx, info ExTxInfo
 	if newElement.Nonce > am.maxNonce {
 		am.maxNonce = newElement.Nonce
 		am.items[newElement.Nonce] = newElement
-		return newElement
-	}
-
-	for _, e := range am.items {
-		if e.Nonce == info.Nonce {
-			// only replace tx for bigger gas price
-			expectedGasPrice := MultiPriceBump(e.GasPrice, txPriceBump)
-			if info.GasPrice.Cmp(expectedGasPrice) <= 0 {
-				return nil
+		return newElement, nil, nil
+	}
+
+	var replaced *mempoolTx
+	if e, ok := am.items[info.Nonce]; ok {
+		// only replace tx for bigger gas price
+		expectedGasPrice := MultiPriceBump(e.GasPrice, txPriceBump)
+		if info.GasPrice.Cmp(expectedGasPrice) < 0 {
+			return nil, nil, ErrTxReplaceUnderpriced{
+				address:  info.Sender,
+				nonce:    info.Nonce,
+				gasPrice: info.GasPrice,
+				expected: expectedGasPrice,
 			}
+		}
 
-			// delete the old element and reorganize the elements whose nonce is greater the the new element
-			ar.removeElement(e)
-			var items []*clist.CElement
-			for _, item := range am.items {
-				if item.Nonce > info.Nonce {
-					items = append(items, item)
-				}
+		// delete the old element and reorganize the elements whose nonce is greater the the new element
+		replaced = e.Value.(*mempoolTx)
+		ar.removeElement(e)
+		var items []*clist.CElement
+		for _, item := range am.items {
+			if item.Nonce > info.Nonce {
+				items = append(items, item)
 			}
-			ar.reorganizeElements(items)
 		}
+		ar.reorganizeElements(items)
 	}
 
 	am.items[newElement.Nonce] = newElement
 
-	return newElement
+	return newElement, replaced, nil
+}
+
+// HasNonce reports whether the address has the tx of the nonce
+func (ar *AddressRecord) HasNonce(address string, nonce uint64) bool {
+	v, ok := ar.addrTxs.Load(address)
+	if !ok {
+		return false
+	}
+	am := v.(*addrMap)
+	am.RLock()
+	defer am.RUnlock()
+	_, ok = am.items[nonce]
+	return ok
 }
 
 func (ar *AddressRecord) CleanItems(address string, nonce uint64) []*clist.CElement {
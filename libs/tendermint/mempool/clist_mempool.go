@@ -23,7 +23,6 @@ import (
 	"github.com/okex/exchain/libs/tendermint/proxy"
 	"github.com/okex/exchain/libs/tendermint/trace"
 	"github.com/okex/exchain/libs/tendermint/types"
-	"github.com/pkg/errors"
 )
 
 type TxInfoParser interface {
@@ -228,6 +227,7 @@ func (mem *CListMempool) Flush() {
 
 	for e := mem.txs.Front(); e != nil; e = e.Next() {
 		mem.removeTx(e)
+		mem.notifyRmPendingTx(e, types.Flushed)
 	}
 
 	_ = atomic.SwapInt64(&mem.txsBytes, 0)
@@ -414,10 +414,18 @@ func (mem *CListMempool) reqResCb(
 func (mem *CListMempool) addAndSortTx(memTx *mempoolTx, info ExTxInfo) error {
 
 	// Replace the same Nonce transaction from the same account
-	elem := mem.addressRecord.checkRepeatedAndAddItem(memTx, info, int64(mem.config.TxPriceBump))
-	if elem == nil {
-		return errors.New(fmt.Sprintf("Failed to replace tx for acccount %s with nonce %d, "+
-			"the provided gas price %d is not bigger enough", info.Sender, info.Nonce, info.GasPrice))
+	elem, replaced, err := mem.addressRecord.checkRepeatedAndAddItem(memTx, info, int64(mem.config.TxPriceBump))
+	if err != nil {
+		return err
+	}
+	if replaced != nil {
+		mem.onTxReplaced(replaced, memTx)
+		mem.eventBus.PublishEventRmPendingTx(types.EventDataRmPendingTx{
+			Hash:   replaced.tx.Hash(replaced.height),
+			From:   info.Sender,
+			Nonce:  info.Nonce,
+			Reason: types.Replaced,
+		})
 	}
 
 	mem.txs.InsertElement(elem)
@@ -507,6 +515,17 @@ func (mem *CListMempool) removeTx(elem *clist.CElement, ignoreAddressRecord ...b
 	atomic.AddInt64(&mem.txsBytes, int64(-len(tx)))
 }
 
+// notifyRmPendingTx publishes the event of a tx leaving the mempool without being included in a block
+func (mem *CListMempool) notifyRmPendingTx(elem *clist.CElement, reason int) {
+	memTx := elem.Value.(*mempoolTx)
+	mem.eventBus.PublishEventRmPendingTx(types.EventDataRmPendingTx{
+		Hash:   memTx.tx.Hash(memTx.height),
+		From:   elem.Address,
+		Nonce:  elem.Nonce,
+		Reason: reason,
+	})
+}
+
 func (mem *CListMempool) isFull(txSize int) error {
 	var (
 		memSize  = mem.Size()
@@ -523,29 +542,60 @@ func (mem *CListMempool) isFull(txSize int) error {
 }
 
 func (mem *CListMempool) addPendingTx(memTx *mempoolTx, exTxInfo ExTxInfo) error {
-	// nonce is continuous
-	if exTxInfo.Nonce == exTxInfo.SenderNonce {
-		err := mem.addTx(memTx, exTxInfo)
+	// nonce is continuous, or the tx replaces the executable one with the same nonce
+	if exTxInfo.Nonce == exTxInfo.SenderNonce || mem.addressRecord.HasNonce(exTxInfo.Sender, exTxInfo.Nonce) {
+		// the queued tx with the same nonce is replaced as well
+		queued, err := mem.pendingPool.checkReplacement(exTxInfo, int64(mem.config.TxPriceBump))
+		if err != nil {
+			return err
+		}
+		err = mem.addTx(memTx, exTxInfo)
 		if err == nil {
+			if queued != nil {
+				mem.pendingPool.removeTx(exTxInfo.Sender, exTxInfo.Nonce)
+				mem.onTxReplaced(queued.mempoolTx, memTx)
+			}
 			go mem.consumePendingTx(exTxInfo.Sender, exTxInfo.Nonce+1)
 		}
 		return err
 	}
 
-	// add tx to PendingPool
-	if err := mem.pendingPool.validate(exTxInfo.Sender, memTx.tx, memTx.height); err != nil {
+	// replace the queued tx with the same nonce in PendingPool
+	replaced, err := mem.pendingPool.checkReplacement(exTxInfo, int64(mem.config.TxPriceBump))
+	if err != nil {
 		return err
 	}
+	if replaced == nil {
+		// add tx to PendingPool
+		if err := mem.pendingPool.validate(exTxInfo.Sender, memTx.tx, memTx.height); err != nil {
+			return err
+		}
+	}
 	pendingTx := &PendingTx{
 		mempoolTx: memTx,
 		exTxInfo:  exTxInfo,
 	}
 	mem.pendingPool.addTx(pendingTx)
 	mem.logger.Debug("pending pool addTx", "tx", pendingTx)
+	if replaced != nil {
+		mem.onTxReplaced(replaced.mempoolTx, memTx)
+	}
 
 	return nil
 }
 
+// onTxReplaced is called after the tx is evicted by the tx with the same sender and nonce
+// but a higher gas price
+func (mem *CListMempool) onTxReplaced(replaced *mempoolTx, memTx *mempoolTx) {
+	// the replaced tx can be submitted again
+	mem.cache.Remove(replaced.tx)
+	mem.logger.Info("Replaced transaction",
+		"tx", txID(replaced.tx, replaced.height),
+		"by", txID(memTx.tx, memTx.height),
+		"sender", memTx.from,
+	)
+}
+
 func (mem *CListMempool) consumePendingTx(address string, nonce uint64) {
 	for {
 		pendingTx := mem.pendingPool.getTx(address, nonce)
@@ -690,6 +740,7 @@ func (mem *CListMempool) resCbRecheck(req *abci.Request, res *abci.Response) {
 			// NOTE: we remove tx from the cache because it might be good later
 			mem.cache.Remove(tx)
 			mem.removeTx(mem.recheckCursor)
+			mem.notifyRmPendingTx(mem.recheckCursor, types.Recheck)
 		}
 		if mem.recheckCursor == mem.recheckEnd {
 			mem.recheckCursor = nil
@@ -821,6 +872,30 @@ func (mem *CListMempool) GetPendingNonce(address string) uint64 {
 	return mem.addressRecord.GetAddressNonce(address)
 }
 
+// ReapUserQueuedTxs returns the txs of the address waiting in PendingPool for the nonce gap to be filled
+func (mem *CListMempool) ReapUserQueuedTxs(address string, max int) types.Txs {
+	if mem.pendingPool == nil {
+		return nil
+	}
+	return mem.pendingPool.getAddressTxs(address, max)
+}
+
+// GetQueuedAddressList returns the addresses having txs in PendingPool
+func (mem *CListMempool) GetQueuedAddressList() []string {
+	if mem.pendingPool == nil {
+		return nil
+	}
+	return mem.pendingPool.getAddressList()
+}
+
+// QueuedSize returns the number of txs in PendingPool
+func (mem *CListMempool) QueuedSize() int {
+	if mem.pendingPool == nil {
+		return 0
+	}
+	return mem.pendingPool.Size()
+}
+
 // Lock() must be help by the caller during execution.
 func (mem *CListMempool) Update(
 	height int64,
@@ -901,6 +976,7 @@ func (mem *CListMempool) Update(
 		items := mem.addressRecord.CleanItems(accAddr, accMaxNonce)
 		for _, ele := range items {
 			mem.removeTx(ele, true)
+			mem.notifyRmPendingTx(ele, types.Stale)
 		}
 	}
 
@@ -1,6 +1,7 @@
 package mempool
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/binary"
@@ -690,6 +691,58 @@ func TestReplaceTx(t *testing.T) {
 	require.Equal(t, 5, mempool.txs.Len(), fmt.Sprintf("Expected to txs length %v but got %v", 5, mempool.txs.Len()))
 }
 
+func TestReplaceTxUnderpriced(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	config := cfg.ResetTestRoot("mempool_test")
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+
+	require.NoError(t, mempool.addAndSortTx(&mempoolTx{height: 1, gasWanted: 1, tx: []byte("10000")}, newExTxInfo("1", 0, big.NewInt(1000), 0)))
+	require.NoError(t, mempool.addAndSortTx(&mempoolTx{height: 1, gasWanted: 1, tx: []byte("10001")}, newExTxInfo("1", 0, big.NewInt(1000), 1)))
+
+	// the gas price must be bumped by TxPriceBump percent at least
+	err := mempool.addAndSortTx(&mempoolTx{height: 1, gasWanted: 1, tx: []byte("20000")}, newExTxInfo("1", 0, big.NewInt(1099), 0))
+	require.IsType(t, ErrTxReplaceUnderpriced{}, err)
+	require.Equal(t, 2, mempool.txs.Len())
+
+	require.NoError(t, mempool.addAndSortTx(&mempoolTx{height: 1, gasWanted: 1, tx: []byte("20000")}, newExTxInfo("1", 0, big.NewInt(1100), 0)))
+	require.Equal(t, 2, mempool.txs.Len())
+	require.Equal(t, []byte("20000"), []byte(mempool.txs.Front().Value.(*mempoolTx).tx))
+}
+
+func TestRmPendingTxEvent(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	config := cfg.ResetTestRoot("mempool_test")
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	defer eventBus.Stop()
+	mempool.SetEventBus(eventBus)
+	sub, err := eventBus.Subscribe(context.Background(), "test", types.EventQueryRmPendingTx, 10)
+	require.NoError(t, err)
+
+	replaced := &mempoolTx{height: 1, gasWanted: 1, tx: []byte("10000")}
+	require.NoError(t, mempool.addAndSortTx(replaced, newExTxInfo("1", 0, big.NewInt(1000), 0)))
+	require.NoError(t, mempool.addAndSortTx(&mempoolTx{height: 1, gasWanted: 1, tx: []byte("20000")}, newExTxInfo("1", 0, big.NewInt(1100), 0)))
+
+	msg := <-sub.Out()
+	data := msg.Data().(types.EventDataRmPendingTx)
+	require.Equal(t, replaced.tx.Hash(replaced.height), data.Hash)
+	require.Equal(t, "1", data.From)
+	require.Equal(t, uint64(0), data.Nonce)
+	require.Equal(t, types.Replaced, data.Reason)
+
+	mempool.Flush()
+	msg = <-sub.Out()
+	data = msg.Data().(types.EventDataRmPendingTx)
+	require.Equal(t, types.Tx("20000").Hash(1), data.Hash)
+	require.Equal(t, types.Flushed, data.Reason)
+}
+
 func TestAddAndSortTxByRandom(t *testing.T) {
 	app := kvstore.NewApplication()
 	cc := proxy.NewLocalClientCreator(app)
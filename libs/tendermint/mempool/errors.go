@@ -2,6 +2,7 @@ package mempool
 
 import (
 	"fmt"
+	"math/big"
 
 	"github.com/pkg/errors"
 )
@@ -39,6 +40,21 @@ func (e ErrMempoolIsFull) Error() string {
 		e.txsBytes, e.maxTxsBytes)
 }
 
+// ErrTxReplaceUnderpriced means the tx can't replace the tx with the same sender and nonce
+// for its gas price isn't bumped enough
+type ErrTxReplaceUnderpriced struct {
+	address  string
+	nonce    uint64
+	gasPrice *big.Int
+	expected *big.Int
+}
+
+func (e ErrTxReplaceUnderpriced) Error() string {
+	return fmt.Sprintf(
+		"Failed to replace tx for account %s with nonce %d, the provided gas price %s is less than %s",
+		e.address, e.nonce, e.gasPrice, e.expected)
+}
+
 // ErrPreCheck is returned when tx is too big
 type ErrPreCheck struct {
 	Reason error
@@ -1,6 +1,7 @@
 package mempool
 
 import (
+	"sort"
 	"sync"
 
 	"github.com/okex/exchain/libs/tendermint/types"
@@ -64,12 +65,70 @@ func (p *PendingPool) hasTx(tx types.Tx, height int64) bool {
 	return exist
 }
 
+// getAddressTxs returns the txs of the address ordered by nonce, max <= 0 means no limit
+func (p *PendingPool) getAddressTxs(address string, max int) types.Txs {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	txsMap, ok := p.addressTxsMap[address]
+	if !ok {
+		return nil
+	}
+	nonces := make([]uint64, 0, len(txsMap))
+	for nonce := range txsMap {
+		nonces = append(nonces, nonce)
+	}
+	sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+	if max <= 0 || max > len(nonces) {
+		max = len(nonces)
+	}
+	txs := make(types.Txs, 0, max)
+	for _, nonce := range nonces[:max] {
+		txs = append(txs, txsMap[nonce].mempoolTx.tx)
+	}
+	return txs
+}
+
+func (p *PendingPool) getAddressList() []string {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	addrList := make([]string, 0, len(p.addressTxsMap))
+	for addr := range p.addressTxsMap {
+		addrList = append(addrList, addr)
+	}
+	return addrList
+}
+
+// checkReplacement returns the tx with the same sender and nonce, which can be replaced
+// only if the gas price is bumped by priceBump percent at least
+func (p *PendingPool) checkReplacement(info ExTxInfo, priceBump int64) (*PendingTx, error) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	pendingTx, ok := p.addressTxsMap[info.Sender][info.Nonce]
+	if !ok {
+		return nil, nil
+	}
+	expectedGasPrice := MultiPriceBump(pendingTx.exTxInfo.GasPrice, priceBump)
+	if info.GasPrice.Cmp(expectedGasPrice) < 0 {
+		return nil, ErrTxReplaceUnderpriced{
+			address:  info.Sender,
+			nonce:    info.Nonce,
+			gasPrice: info.GasPrice,
+			expected: expectedGasPrice,
+		}
+	}
+	return pendingTx, nil
+}
+
+// addTx adds the tx to the pool, the tx with the same sender and nonce is replaced
 func (p *PendingPool) addTx(pendingTx *PendingTx) {
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
 	if _, ok := p.addressTxsMap[pendingTx.exTxInfo.Sender]; !ok {
 		p.addressTxsMap[pendingTx.exTxInfo.Sender] = make(map[uint64]*PendingTx)
 	}
+	if replaced, ok := p.addressTxsMap[pendingTx.exTxInfo.Sender][pendingTx.exTxInfo.Nonce]; ok {
+		delete(p.txsMap, txID(replaced.mempoolTx.tx, replaced.mempoolTx.height))
+	}
 	p.addressTxsMap[pendingTx.exTxInfo.Sender][pendingTx.exTxInfo.Nonce] = pendingTx
 	p.txsMap[txID(pendingTx.mempoolTx.tx, pendingTx.mempoolTx.height)] = pendingTx
 }
@@ -1,7 +1,53 @@
 package mempool
 
-import "testing"
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
 
 func TestPendingPool(t *testing.T) {
 
 }
+
+func newPendingTx(tx string, info ExTxInfo) *PendingTx {
+	return &PendingTx{
+		mempoolTx: &mempoolTx{height: 1, gasWanted: 1, tx: []byte(tx)},
+		exTxInfo:  info,
+	}
+}
+
+func TestPendingPoolReplacement(t *testing.T) {
+	pool := newPendingPool(100, 3, 10, 10)
+	pool.addTx(newPendingTx("10003", newExTxInfo("1", 0, big.NewInt(1000), 3)))
+	pool.addTx(newPendingTx("10002", newExTxInfo("1", 0, big.NewInt(1000), 2)))
+
+	// no tx with the nonce
+	replaced, err := pool.checkReplacement(newExTxInfo("1", 0, big.NewInt(1), 5), 10)
+	require.NoError(t, err)
+	require.Nil(t, replaced)
+
+	// gas price is not bumped enough
+	_, err = pool.checkReplacement(newExTxInfo("1", 0, big.NewInt(1099), 3), 10)
+	require.Error(t, err)
+	require.IsType(t, ErrTxReplaceUnderpriced{}, err)
+
+	// gas price is bumped
+	info := newExTxInfo("1", 0, big.NewInt(1100), 3)
+	replaced, err = pool.checkReplacement(info, 10)
+	require.NoError(t, err)
+	require.Equal(t, []byte("10003"), []byte(replaced.mempoolTx.tx))
+	pool.addTx(newPendingTx("20003", info))
+	require.Equal(t, 2, pool.Size())
+	require.False(t, pool.hasTx([]byte("10003"), 1))
+
+	// txs are ordered by nonce
+	txs := pool.getAddressTxs("1", 0)
+	require.Equal(t, 2, len(txs))
+	require.Equal(t, []byte("10002"), []byte(txs[0]))
+	require.Equal(t, []byte("20003"), []byte(txs[1]))
+	require.Equal(t, 1, len(pool.getAddressTxs("1", 1)))
+	require.Nil(t, pool.getAddressTxs("2", 0))
+	require.Equal(t, []string{"1"}, pool.getAddressList())
+}
@@ -39,6 +39,13 @@ type Mempool interface {
 	ReapUserTxs(address string, max int) types.Txs
 	GetPendingNonce(address string) uint64
 
+	// ReapUserQueuedTxs returns the txs of the address queued for the nonce gap to be filled
+	ReapUserQueuedTxs(address string, max int) types.Txs
+	// GetQueuedAddressList returns the addresses having queued txs
+	GetQueuedAddressList() []string
+	// QueuedSize returns the number of queued txs
+	QueuedSize() int
+
 	// Lock locks the mempool. The consensus must be able to hold lock to safely update.
 	Lock()
 
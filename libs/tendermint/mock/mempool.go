@@ -37,6 +37,11 @@ func (Mempool) ReapUserTxsCnt(address string) int             { return 0 }
 func (Mempool) GetUserPendingTxsCnt(address string) int       { return 0 }
 func (Mempool) ReapUserTxs(address string, max int) types.Txs { return types.Txs{} }
 func (Mempool) GetPendingNonce(address string) uint64         { return 0 }
+func (Mempool) ReapUserQueuedTxs(address string, max int) types.Txs {
+	return types.Txs{}
+}
+func (Mempool) GetQueuedAddressList() []string { return nil }
+func (Mempool) QueuedSize() int                { return 0 }
 func (Mempool) Update(
 	_ int64,
 	txs types.Txs,
@@ -2,9 +2,11 @@ package node
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
 	"net"
 	"os"
+	"sync"
 	"syscall"
 	"testing"
 	"time"
@@ -15,10 +17,12 @@ import (
 	dbm "github.com/okex/exchain/libs/tm-db"
 
 	"github.com/okex/exchain/libs/tendermint/abci/example/kvstore"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
 	cfg "github.com/okex/exchain/libs/tendermint/config"
 	"github.com/okex/exchain/libs/tendermint/crypto/ed25519"
 	"github.com/okex/exchain/libs/tendermint/evidence"
 	"github.com/okex/exchain/libs/tendermint/libs/log"
+	tmnet "github.com/okex/exchain/libs/tendermint/libs/net"
 	tmrand "github.com/okex/exchain/libs/tendermint/libs/rand"
 	mempl "github.com/okex/exchain/libs/tendermint/mempool"
 	"github.com/okex/exchain/libs/tendermint/p2p"
@@ -359,3 +363,189 @@ func state(nVals int, height int64) (sm.State, dbm.DB) {
 	}
 	return s, stateDB
 }
+
+// snapshotApp is an app whose state is the height only, it serves a single snapshot of snapshotHeight
+type snapshotApp struct {
+	abci.BaseApplication
+
+	mtx      sync.Mutex
+	height   int64
+	restored int64
+}
+
+const snapshotHeight = 2
+
+func snapshotAppHash(height int64) []byte {
+	hash := make([]byte, 8)
+	binary.BigEndian.PutUint64(hash, uint64(height))
+	return hash
+}
+
+func (app *snapshotApp) Info(abci.RequestInfo) abci.ResponseInfo {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	if app.height == 0 {
+		return abci.ResponseInfo{}
+	}
+	return abci.ResponseInfo{LastBlockHeight: app.height, LastBlockAppHash: snapshotAppHash(app.height)}
+}
+
+func (app *snapshotApp) Commit(abci.RequestCommit) abci.ResponseCommit {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	app.height++
+	return abci.ResponseCommit{Data: snapshotAppHash(app.height)}
+}
+
+func (app *snapshotApp) ListSnapshots(abci.RequestListSnapshots) abci.ResponseListSnapshots {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	if app.height < snapshotHeight {
+		return abci.ResponseListSnapshots{}
+	}
+	return abci.ResponseListSnapshots{Snapshots: []*abci.Snapshot{{
+		Height: snapshotHeight,
+		Format: 1,
+		Chunks: 1,
+		Hash:   snapshotAppHash(snapshotHeight),
+	}}}
+}
+
+func (app *snapshotApp) LoadSnapshotChunk(req abci.RequestLoadSnapshotChunk) abci.ResponseLoadSnapshotChunk {
+	return abci.ResponseLoadSnapshotChunk{Chunk: snapshotAppHash(int64(req.Height))}
+}
+
+func (app *snapshotApp) OfferSnapshot(abci.RequestOfferSnapshot) abci.ResponseOfferSnapshot {
+	return abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ACCEPT}
+}
+
+func (app *snapshotApp) ApplySnapshotChunk(req abci.RequestApplySnapshotChunk) abci.ResponseApplySnapshotChunk {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	app.height = int64(binary.BigEndian.Uint64(req.Chunk))
+	app.restored = app.height
+	return abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}
+}
+
+func (app *snapshotApp) restoredHeight() int64 {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	return app.restored
+}
+
+// nodeStateProvider serves the trusted state data from the stores of a running node
+type nodeStateProvider struct {
+	n *Node
+}
+
+func (p nodeStateProvider) AppHash(height uint64) ([]byte, error) {
+	meta := p.n.blockStore.LoadBlockMeta(int64(height + 1))
+	if meta == nil {
+		return nil, fmt.Errorf("no block at height %d", height+1)
+	}
+	return meta.Header.AppHash, nil
+}
+
+func (p nodeStateProvider) Commit(height uint64) (*types.Commit, error) {
+	commit := p.n.blockStore.LoadBlockCommit(int64(height))
+	if commit == nil {
+		return nil, fmt.Errorf("no commit at height %d", height)
+	}
+	return commit, nil
+}
+
+func (p nodeStateProvider) State(height uint64) (sm.State, error) {
+	state := sm.LoadState(p.n.stateDB)
+	meta := p.n.blockStore.LoadBlockMeta(int64(height))
+	nextMeta := p.n.blockStore.LoadBlockMeta(int64(height + 1))
+	if meta == nil || nextMeta == nil {
+		return sm.State{}, fmt.Errorf("no blocks at height %d", height)
+	}
+
+	state.Version.Consensus = meta.Header.Version
+	state.LastBlockHeight = meta.Header.Height
+	state.LastBlockTime = meta.Header.Time
+	state.LastBlockID = meta.BlockID
+	state.AppHash = nextMeta.Header.AppHash
+	state.LastResultsHash = nextMeta.Header.LastResultsHash
+
+	var err error
+	if state.LastValidators, err = sm.LoadValidators(p.n.stateDB, int64(height)); err != nil {
+		return sm.State{}, err
+	}
+	if state.Validators, err = sm.LoadValidators(p.n.stateDB, int64(height+1)); err != nil {
+		return sm.State{}, err
+	}
+	if state.NextValidators, err = sm.LoadValidators(p.n.stateDB, int64(height+2)); err != nil {
+		return sm.State{}, err
+	}
+	state.LastHeightValidatorsChanged = nextMeta.Header.Height
+	state.LastHeightConsensusParamsChanged = nextMeta.Header.Height
+	return state, nil
+}
+
+func newSnapshotTestNode(t *testing.T, config *cfg.Config, pv types.PrivValidator, app abci.Application,
+	options ...Option) *Node {
+	p2pPort, err := tmnet.GetFreePort()
+	require.NoError(t, err)
+	rpcPort, err := tmnet.GetFreePort()
+	require.NoError(t, err)
+	config.P2P.ListenAddress = fmt.Sprintf("tcp://127.0.0.1:%d", p2pPort)
+	config.RPC.ListenAddress = fmt.Sprintf("tcp://127.0.0.1:%d", rpcPort)
+	config.RPC.GRPCListenAddress = ""
+	config.Instrumentation.Prometheus = false
+
+	nodeKey, err := p2p.LoadOrGenNodeKey(config.NodeKeyFile())
+	require.NoError(t, err)
+	n, err := NewNode(config, pv, nodeKey,
+		proxy.NewLocalClientCreator(app),
+		DefaultGenesisDocProviderFunc(config),
+		DefaultDBProvider,
+		DefaultMetricsProvider(config.Instrumentation),
+		log.TestingLogger(),
+		options...,
+	)
+	require.NoError(t, err)
+	return n
+}
+
+// a new node restores the snapshot of its peer over p2p and fast syncs the following blocks
+func TestNodeStateSync(t *testing.T) {
+	configA := cfg.ResetTestRoot("node_state_sync_test_a")
+	defer os.RemoveAll(configA.RootDir)
+	appA := &snapshotApp{}
+	nodeA := newSnapshotTestNode(t, configA,
+		privval.LoadOrGenFilePV(configA.PrivValidatorKeyFile(), configA.PrivValidatorStateFile()), appA)
+	require.NoError(t, nodeA.Start())
+	defer nodeA.Stop()
+
+	// the state provider needs the blocks up to the snapshot height + 2
+	deadline := time.Now().Add(30 * time.Second)
+	for nodeA.blockStore.Height() < snapshotHeight+3 {
+		require.True(t, time.Now().Before(deadline), "timed out waiting for the blocks of the snapshot")
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	configB := cfg.ResetTestRoot("node_state_sync_test_b")
+	defer os.RemoveAll(configB.RootDir)
+	configB.StateSync.Enable = true
+	configB.StateSync.DiscoveryTime = 5 * time.Second
+	configB.P2P.PersistentPeers = p2p.IDAddressString(nodeA.nodeKey.ID(), nodeA.config.P2P.ListenAddress[len("tcp://"):])
+	configB.P2P.AllowDuplicateIP = true
+	configA.P2P.AllowDuplicateIP = true
+	// a fresh validator key, the node doesn't state sync if it's the only validator
+	pvB := privval.GenFilePV(configB.PrivValidatorKeyFile(), configB.PrivValidatorStateFile())
+	appB := &snapshotApp{}
+	nodeB := newSnapshotTestNode(t, configB, pvB, appB, StateProvider(nodeStateProvider{nodeA}))
+	require.NoError(t, nodeB.Start())
+	defer nodeB.Stop()
+
+	deadline = time.Now().Add(60 * time.Second)
+	for appB.restoredHeight() == 0 || nodeB.blockStore.Height() <= snapshotHeight+1 {
+		require.True(t, time.Now().Before(deadline), "timed out waiting for the state sync")
+		time.Sleep(50 * time.Millisecond)
+	}
+	assert.EqualValues(t, snapshotHeight, appB.restoredHeight())
+	// the blocks before the snapshot are never downloaded
+	assert.Nil(t, nodeB.blockStore.LoadBlock(1))
+}
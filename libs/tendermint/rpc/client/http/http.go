@@ -327,6 +327,33 @@ func (c *baseRPCClient) GetPendingNonce(address string) (*ctypes.ResultPendingNo
 	return result, nil
 }
 
+func (c *baseRPCClient) UserQueuedTxs(address string, limit int) (*ctypes.ResultUserUnconfirmedTxs, error) {
+	result := new(ctypes.ResultUserUnconfirmedTxs)
+	_, err := c.caller.Call("user_queued_txs", map[string]interface{}{"address": address, "limit": limit}, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "user_queued_txs")
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) NumQueuedTxs() (*ctypes.ResultUnconfirmedTxs, error) {
+	result := new(ctypes.ResultUnconfirmedTxs)
+	_, err := c.caller.Call("num_queued_txs", map[string]interface{}{}, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "num_queued_txs")
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) GetQueuedAddressList() (*ctypes.ResultUnconfirmedAddresses, error) {
+	result := new(ctypes.ResultUnconfirmedAddresses)
+	_, err := c.caller.Call("get_queued_address_list", map[string]interface{}{}, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "get_queued_address_list")
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) NetInfo() (*ctypes.ResultNetInfo, error) {
 	result := new(ctypes.ResultNetInfo)
 	_, err := c.caller.Call("net_info", map[string]interface{}{}, result)
@@ -449,6 +476,22 @@ func (c *baseRPCClient) TxSearch(query string, prove bool, page, perPage int, or
 	return result, nil
 }
 
+func (c *baseRPCClient) BlockSearch(query string, page, perPage int, orderBy string) (
+	*ctypes.ResultBlockSearch, error) {
+	result := new(ctypes.ResultBlockSearch)
+	params := map[string]interface{}{
+		"query":    query,
+		"page":     page,
+		"per_page": perPage,
+		"order_by": orderBy,
+	}
+	_, err := c.caller.Call("block_search", params, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "BlockSearch")
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) Validators(height *int64, page, perPage int) (*ctypes.ResultValidators, error) {
 	result := new(ctypes.ResultValidators)
 	_, err := c.caller.Call("validators", map[string]interface{}{
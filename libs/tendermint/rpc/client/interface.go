@@ -71,6 +71,7 @@ type SignClient interface {
 	Validators(height *int64, page, perPage int) (*ctypes.ResultValidators, error)
 	Tx(hash []byte, prove bool) (*ctypes.ResultTx, error)
 	TxSearch(query string, prove bool, page, perPage int, orderBy string) (*ctypes.ResultTxSearch, error)
+	BlockSearch(query string, page, perPage int, orderBy string) (*ctypes.ResultBlockSearch, error)
 }
 
 // HistoryClient provides access to data from genesis to now in large chunks.
@@ -120,6 +121,9 @@ type MempoolClient interface {
 	GetUnconfirmedTxByHash(hash [sha256.Size]byte) (types.Tx, error)
 	GetAddressList() (*ctypes.ResultUnconfirmedAddresses, error)
 	GetPendingNonce(address string) (*ctypes.ResultPendingNonce, error)
+	UserQueuedTxs(address string, limit int) (*ctypes.ResultUserUnconfirmedTxs, error)
+	NumQueuedTxs() (*ctypes.ResultUnconfirmedTxs, error)
+	GetQueuedAddressList() (*ctypes.ResultUnconfirmedAddresses, error)
 }
 
 // EvidenceClient is used for submitting an evidence of the malicious
@@ -126,6 +126,18 @@ func (c *Local) GetPendingNonce(address string) (*ctypes.ResultPendingNonce, err
 	return core.GetPendingNonce(address)
 }
 
+func (c *Local) UserQueuedTxs(address string, limit int) (*ctypes.ResultUserUnconfirmedTxs, error) {
+	return core.UserQueuedTxs(address, limit)
+}
+
+func (c *Local) NumQueuedTxs() (*ctypes.ResultUnconfirmedTxs, error) {
+	return core.NumQueuedTxs()
+}
+
+func (c *Local) GetQueuedAddressList() (*ctypes.ResultUnconfirmedAddresses, error) {
+	return core.GetQueuedAddressList()
+}
+
 func (c *Local) NetInfo() (*ctypes.ResultNetInfo, error) {
 	return core.NetInfo(c.ctx)
 }
@@ -187,6 +199,11 @@ func (c *Local) TxSearch(query string, prove bool, page, perPage int, orderBy st
 	return core.TxSearch(c.ctx, query, prove, page, perPage, orderBy)
 }
 
+func (c *Local) BlockSearch(query string, page, perPage int, orderBy string) (
+	*ctypes.ResultBlockSearch, error) {
+	return core.BlockSearch(c.ctx, query, page, perPage, orderBy)
+}
+
 func (c *Local) BroadcastEvidence(ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
 	return core.BroadcastEvidence(c.ctx, ev)
 }
@@ -10,9 +10,11 @@ import (
 	dbm "github.com/okex/exchain/libs/tm-db"
 
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/libs/tendermint/libs/kv"
 	ctypes "github.com/okex/exchain/libs/tendermint/rpc/core/types"
 	rpctypes "github.com/okex/exchain/libs/tendermint/rpc/jsonrpc/types"
 	sm "github.com/okex/exchain/libs/tendermint/state"
+	blockidxkv "github.com/okex/exchain/libs/tendermint/state/blockindex/kv"
 	"github.com/okex/exchain/libs/tendermint/types"
 )
 
@@ -113,6 +115,53 @@ func TestBlockResults(t *testing.T) {
 	}
 }
 
+func TestBlockSearch(t *testing.T) {
+	env = &Environment{}
+	env.BlockStore = blockSearchStore{mockBlockStore{height: 10}}
+	env.BlockIndexer = blockidxkv.New(dbm.NewMemDB(), blockidxkv.IndexAllEvents())
+	for h := int64(1); h <= 10; h++ {
+		var events []abci.Event
+		if h%3 == 0 {
+			events = append(events, abci.Event{
+				Type:       "slash",
+				Attributes: []kv.Pair{{Key: []byte("address"), Value: []byte("val")}},
+			})
+		}
+		require.NoError(t, env.BlockIndexer.Index(types.EventDataNewBlockHeader{
+			Header:         types.Header{Height: h},
+			ResultEndBlock: abci.ResponseEndBlock{Events: events},
+		}))
+	}
+
+	res, err := BlockSearch(&rpctypes.Context{}, "slash.address = 'val'", 1, 2, "desc")
+	require.NoError(t, err)
+	require.Equal(t, 3, res.TotalCount)
+	require.Len(t, res.Blocks, 2)
+	require.Equal(t, int64(9), res.Blocks[0].Block.Height)
+	require.Equal(t, int64(6), res.Blocks[1].Block.Height)
+
+	res, err = BlockSearch(&rpctypes.Context{}, "slash.address = 'val'", 2, 2, "desc")
+	require.NoError(t, err)
+	require.Len(t, res.Blocks, 1)
+	require.Equal(t, int64(3), res.Blocks[0].Block.Height)
+
+	_, err = BlockSearch(&rpctypes.Context{}, "slash.address = 'val'", 1, 2, "random")
+	require.Error(t, err)
+}
+
+// blockSearchStore returns an empty block for any height
+type blockSearchStore struct {
+	mockBlockStore
+}
+
+func (blockSearchStore) LoadBlock(height int64) *types.Block {
+	return &types.Block{Header: types.Header{Height: height}}
+}
+
+func (blockSearchStore) LoadBlockMeta(height int64) *types.BlockMeta {
+	return &types.BlockMeta{Header: types.Header{Height: height}}
+}
+
 type mockBlockStore struct {
 	height int64
 }
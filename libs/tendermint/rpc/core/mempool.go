@@ -175,6 +175,28 @@ func GetAddressList() (*ctypes.ResultUnconfirmedAddresses, error) {
 	}, nil
 }
 
+// UserQueuedTxs gets the txs of the address queued for the nonce gap to be filled
+func UserQueuedTxs(address string, limit int) (*ctypes.ResultUserUnconfirmedTxs, error) {
+	txs := env.Mempool.ReapUserQueuedTxs(address, limit)
+	return &ctypes.ResultUserUnconfirmedTxs{
+		Count: len(txs),
+		Txs:   txs}, nil
+}
+
+// NumQueuedTxs gets number of the queued txs
+func NumQueuedTxs() (*ctypes.ResultUnconfirmedTxs, error) {
+	return &ctypes.ResultUnconfirmedTxs{
+		Count: env.Mempool.QueuedSize(),
+		Total: env.Mempool.QueuedSize()}, nil
+}
+
+// GetQueuedAddressList gets the addresses having queued txs
+func GetQueuedAddressList() (*ctypes.ResultUnconfirmedAddresses, error) {
+	return &ctypes.ResultUnconfirmedAddresses{
+		Addresses: env.Mempool.GetQueuedAddressList(),
+	}, nil
+}
+
 func GetPendingNonce(address string) (*ctypes.ResultPendingNonce, error) {
 	nonce := env.Mempool.GetPendingNonce(address)
 	return &ctypes.ResultPendingNonce{
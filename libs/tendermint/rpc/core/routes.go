@@ -25,6 +25,7 @@ var Routes = map[string]*rpc.RPCFunc{
 	"commit":                   rpc.NewRPCFunc(Commit, "height"),
 	"tx":                       rpc.NewRPCFunc(Tx, "hash,prove"),
 	"tx_search":                rpc.NewRPCFunc(TxSearch, "query,prove,page,per_page,order_by"),
+	"block_search":             rpc.NewRPCFunc(BlockSearch, "query,page,per_page,order_by"),
 	"validators":               rpc.NewRPCFunc(Validators, "height,page,per_page"),
 	"dump_consensus_state":     rpc.NewRPCFunc(DumpConsensusState, ""),
 	"consensus_state":          rpc.NewRPCFunc(ConsensusState, ""),
@@ -34,6 +35,9 @@ var Routes = map[string]*rpc.RPCFunc{
 	"user_unconfirmed_txs":     rpc.NewRPCFunc(UserUnconfirmedTxs, "address,limit"),
 	"user_num_unconfirmed_txs": rpc.NewRPCFunc(UserNumUnconfirmedTxs, "address"),
 	"get_address_list":         rpc.NewRPCFunc(GetAddressList, ""),
+	"user_queued_txs":          rpc.NewRPCFunc(UserQueuedTxs, "address,limit"),
+	"num_queued_txs":           rpc.NewRPCFunc(NumQueuedTxs, ""),
+	"get_queued_address_list":  rpc.NewRPCFunc(GetQueuedAddressList, ""),
 
 	// tx broadcast API
 	"broadcast_tx_commit": rpc.NewRPCFunc(BroadcastTxCommit, "tx"),
@@ -0,0 +1,176 @@
+package kv_test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/okex/exchain/libs/tm-db"
+
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/libs/tendermint/libs/kv"
+	"github.com/okex/exchain/libs/tendermint/libs/pubsub/query"
+	blockidxkv "github.com/okex/exchain/libs/tendermint/state/blockindex/kv"
+	"github.com/okex/exchain/libs/tendermint/types"
+)
+
+func TestBlockIndexer(t *testing.T) {
+	store := dbm.NewPrefixDB(dbm.NewMemDB(), []byte("block_events"))
+	indexer := blockidxkv.New(store, blockidxkv.IndexAllEvents())
+
+	require.NoError(t, indexer.Index(types.EventDataNewBlockHeader{
+		Header: types.Header{Height: 1},
+		ResultBeginBlock: abci.ResponseBeginBlock{
+			Events: []abci.Event{
+				{
+					Type: "begin_event",
+					Attributes: []kv.Pair{
+						{Key: []byte("proposer"), Value: []byte("FCAA001")},
+					},
+				},
+			},
+		},
+		ResultEndBlock: abci.ResponseEndBlock{
+			Events: []abci.Event{
+				{
+					Type: "end_event",
+					Attributes: []kv.Pair{
+						{Key: []byte("foo"), Value: []byte("100")},
+					},
+				},
+			},
+		},
+	}))
+
+	for i := 2; i < 12; i++ {
+		var index bool
+		if i%2 == 0 {
+			index = true
+		}
+
+		events := []abci.Event{
+			{
+				Type: "end_event",
+				Attributes: []kv.Pair{
+					{Key: []byte("foo"), Value: []byte(fmt.Sprintf("%d", i))},
+				},
+			},
+		}
+		if index {
+			events = append(events, abci.Event{
+				Type: "slash",
+				Attributes: []kv.Pair{
+					{Key: []byte("address"), Value: []byte(fmt.Sprintf("val%d", i))},
+				},
+			})
+		}
+
+		require.NoError(t, indexer.Index(types.EventDataNewBlockHeader{
+			Header: types.Header{Height: int64(i)},
+			ResultBeginBlock: abci.ResponseBeginBlock{
+				Events: []abci.Event{
+					{
+						Type: "begin_event",
+						Attributes: []kv.Pair{
+							{Key: []byte("proposer"), Value: []byte("FCAA001")},
+						},
+					},
+				},
+			},
+			ResultEndBlock: abci.ResponseEndBlock{Events: events},
+		}))
+	}
+
+	has, err := indexer.Has(11)
+	require.NoError(t, err)
+	require.True(t, has)
+	has, err = indexer.Has(12)
+	require.NoError(t, err)
+	require.False(t, has)
+
+	testCases := map[string]struct {
+		q       *query.Query
+		results []int64
+	}{
+		"block.height = 100": {
+			q:       query.MustParse("block.height = 100"),
+			results: []int64{},
+		},
+		"block.height = 5": {
+			q:       query.MustParse("block.height = 5"),
+			results: []int64{5},
+		},
+		"begin_event.proposer = 'FCAA001'": {
+			q:       query.MustParse("begin_event.proposer = 'FCAA001'"),
+			results: []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+		},
+		"end_event.foo <= 5": {
+			q:       query.MustParse("end_event.foo <= 5"),
+			results: []int64{2, 3, 4, 5},
+		},
+		"end_event.foo >= 100": {
+			q:       query.MustParse("end_event.foo >= 100"),
+			results: []int64{1},
+		},
+		"block.height > 2 AND end_event.foo <= 8": {
+			q:       query.MustParse("block.height > 2 AND end_event.foo <= 8"),
+			results: []int64{3, 4, 5, 6, 7, 8},
+		},
+		"block.height = 6 AND slash.address = 'val6'": {
+			q:       query.MustParse("block.height = 6 AND slash.address = 'val6'"),
+			results: []int64{6},
+		},
+		"block.height = 7 AND slash.address = 'val6'": {
+			q:       query.MustParse("block.height = 7 AND slash.address = 'val6'"),
+			results: []int64{},
+		},
+		"slash.address CONTAINS 'val1'": {
+			q:       query.MustParse("slash.address CONTAINS 'val1'"),
+			results: []int64{10},
+		},
+		"begin_event.proposer = 'FCAA001' AND slash.address CONTAINS 'val'": {
+			q:       query.MustParse("begin_event.proposer = 'FCAA001' AND slash.address CONTAINS 'val'"),
+			results: []int64{2, 4, 6, 8, 10},
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			results, err := indexer.Search(context.Background(), tc.q)
+			require.NoError(t, err)
+			sort.Slice(results, func(i, j int) bool { return results[i] < results[j] })
+			require.Equal(t, tc.results, results)
+		})
+	}
+}
+
+func TestBlockIndexerIndexEvents(t *testing.T) {
+	indexer := blockidxkv.New(dbm.NewMemDB(), blockidxkv.IndexEvents([]string{"slash.address"}))
+
+	require.NoError(t, indexer.Index(types.EventDataNewBlockHeader{
+		Header: types.Header{Height: 1},
+		ResultEndBlock: abci.ResponseEndBlock{
+			Events: []abci.Event{
+				{
+					Type: "slash",
+					Attributes: []kv.Pair{
+						{Key: []byte("address"), Value: []byte("val1")},
+						{Key: []byte("power"), Value: []byte("10")},
+					},
+				},
+			},
+		},
+	}))
+
+	results, err := indexer.Search(context.Background(), query.MustParse("slash.address = 'val1'"))
+	require.NoError(t, err)
+	require.Equal(t, []int64{1}, results)
+
+	results, err = indexer.Search(context.Background(), query.MustParse("slash.power = 10"))
+	require.NoError(t, err)
+	require.Empty(t, results)
+}
@@ -0,0 +1,122 @@
+package kv
+
+import (
+	"time"
+
+	"github.com/okex/exchain/libs/tendermint/libs/pubsub/query"
+	"github.com/okex/exchain/libs/tendermint/types"
+)
+
+// intInSlice returns true if a is found in the list.
+func intInSlice(a int, list []int) bool {
+	for _, b := range list {
+		if b == a {
+			return true
+		}
+	}
+	return false
+}
+
+// lookForHeight returns a height if there is an "block.height=X" condition.
+func lookForHeight(conditions []query.Condition) (int64, bool) {
+	for _, c := range conditions {
+		if c.CompositeKey == types.BlockHeightKey && c.Op == query.OpEqual {
+			return c.Operand.(int64), true
+		}
+	}
+	return 0, false
+}
+
+// special map to hold range conditions
+// Example: slash.power => queryRange{lowerBound: 1, upperBound: 5}
+type queryRanges map[string]queryRange
+
+type queryRange struct {
+	lowerBound        interface{} // int || time.Time
+	upperBound        interface{} // int || time.Time
+	key               string
+	includeLowerBound bool
+	includeUpperBound bool
+}
+
+func (r queryRange) lowerBoundValue() interface{} {
+	if r.lowerBound == nil {
+		return nil
+	}
+
+	if r.includeLowerBound {
+		return r.lowerBound
+	}
+
+	switch t := r.lowerBound.(type) {
+	case int64:
+		return t + 1
+	case time.Time:
+		return t.Unix() + 1
+	default:
+		panic("not implemented")
+	}
+}
+
+func (r queryRange) AnyBound() interface{} {
+	if r.lowerBound != nil {
+		return r.lowerBound
+	}
+
+	return r.upperBound
+}
+
+func (r queryRange) upperBoundValue() interface{} {
+	if r.upperBound == nil {
+		return nil
+	}
+
+	if r.includeUpperBound {
+		return r.upperBound
+	}
+
+	switch t := r.upperBound.(type) {
+	case int64:
+		return t - 1
+	case time.Time:
+		return t.Unix() - 1
+	default:
+		panic("not implemented")
+	}
+}
+
+func lookForRanges(conditions []query.Condition) (ranges queryRanges, indexes []int) {
+	ranges = make(queryRanges)
+	for i, c := range conditions {
+		if isRangeOperation(c.Op) {
+			r, ok := ranges[c.CompositeKey]
+			if !ok {
+				r = queryRange{key: c.CompositeKey}
+			}
+			switch c.Op {
+			case query.OpGreater:
+				r.lowerBound = c.Operand
+			case query.OpGreaterEqual:
+				r.includeLowerBound = true
+				r.lowerBound = c.Operand
+			case query.OpLess:
+				r.upperBound = c.Operand
+			case query.OpLessEqual:
+				r.includeUpperBound = true
+				r.upperBound = c.Operand
+			}
+			ranges[c.CompositeKey] = r
+			indexes = append(indexes, i)
+		}
+	}
+	return ranges, indexes
+}
+
+func isRangeOperation(op query.Operator) bool {
+	switch op {
+	case query.OpGreater, query.OpGreaterEqual, query.OpLess, query.OpLessEqual:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,29 @@
+package null
+
+import (
+	"context"
+	"errors"
+
+	"github.com/okex/exchain/libs/tendermint/libs/pubsub/query"
+	"github.com/okex/exchain/libs/tendermint/state/blockindex"
+	"github.com/okex/exchain/libs/tendermint/types"
+)
+
+var _ blockindex.BlockIndexer = (*BlockerIndexer)(nil)
+
+// BlockerIndexer implements a no-op block indexer.
+type BlockerIndexer struct{}
+
+// Has on a BlockerIndexer is disabled and returns an error when invoked.
+func (idx *BlockerIndexer) Has(height int64) (bool, error) {
+	return false, errors.New(`indexing is disabled (set 'tx_index = "kv"' in config)`)
+}
+
+// Index is a noop and always returns nil.
+func (idx *BlockerIndexer) Index(types.EventDataNewBlockHeader) error {
+	return nil
+}
+
+func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64, error) {
+	return []int64{}, nil
+}
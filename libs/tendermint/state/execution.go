@@ -11,6 +11,7 @@ import (
 
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
 	cfg "github.com/okex/exchain/libs/tendermint/config"
+	"github.com/okex/exchain/libs/tendermint/delta"
 	"github.com/okex/exchain/libs/tendermint/libs/fail"
 	"github.com/okex/exchain/libs/tendermint/libs/log"
 	mempl "github.com/okex/exchain/libs/tendermint/mempool"
@@ -56,6 +57,13 @@ type BlockExecutor struct {
 
 type BlockExecutorOption func(executor *BlockExecutor)
 
+// BlockExecutorWithDeltaBroker makes the block executor upload or download the deltas with the broker
+func BlockExecutorWithDeltaBroker(broker delta.DeltaBroker) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.deltaContext.deltaBroker = broker
+	}
+}
+
 func BlockExecutorWithMetrics(metrics *Metrics) BlockExecutorOption {
 	return func(blockExec *BlockExecutor) {
 		blockExec.metrics = metrics
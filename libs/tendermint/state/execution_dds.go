@@ -8,7 +8,6 @@ import (
 	"github.com/okex/exchain/libs/iavl"
 	"github.com/okex/exchain/libs/system"
 	"github.com/okex/exchain/libs/tendermint/delta"
-	redis_cgi "github.com/okex/exchain/libs/tendermint/delta/redis-cgi"
 	"github.com/okex/exchain/libs/tendermint/libs/log"
 	"github.com/okex/exchain/libs/tendermint/trace"
 	"github.com/spf13/viper"
@@ -93,15 +92,18 @@ func (dc *DeltaContext) init() {
 		if dc.bufferSize < 5 {
 			dc.bufferSize = 5
 		}
-		url := viper.GetString(types.FlagRedisUrl)
-		auth := viper.GetString(types.FlagRedisAuth)
-		expire := time.Duration(viper.GetInt(types.FlagRedisExpire)) * time.Second
-		dbNum := viper.GetInt(types.FlagRedisDB)
-		if dbNum < 0 || dbNum > 15 {
-			panic("delta-redis-db only support 0~15")
+		if dc.deltaBroker == nil {
+			broker, err := NewDeltaBroker(dc.logger)
+			if err != nil {
+				dc.logger.Error("Failed to init delta broker, the deltas are disabled", "err", err)
+				dc.uploadDelta, dc.downloadDelta = false, false
+			}
+			dc.deltaBroker = broker
+		}
+
+		if laddr := viper.GetString(types.FlagDeltaHttpLaddr); laddr != "" && dc.deltaBroker != nil {
+			go serveDeltaBroker(laddr, dc.deltaBroker, dc.logger)
 		}
-		dc.deltaBroker = redis_cgi.NewRedisClient(url, auth, expire, dbNum, dc.logger)
-		dc.logger.Info("Init delta broker", "url", url)
 	}
 
 	// control if iavl produce delta or not
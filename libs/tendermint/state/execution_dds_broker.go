@@ -0,0 +1,102 @@
+package state
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/okex/exchain/libs/tendermint/delta"
+	file_cgi "github.com/okex/exchain/libs/tendermint/delta/file-cgi"
+	http_cgi "github.com/okex/exchain/libs/tendermint/delta/http-cgi"
+	redis_cgi "github.com/okex/exchain/libs/tendermint/delta/redis-cgi"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/okex/exchain/libs/tendermint/types"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+const (
+	RedisDeltaBroker = "redis"
+	FileDeltaBroker  = "file"
+	HttpDeltaBroker  = "http"
+
+	httpDeltaBrokerTimeout = 5 * time.Second
+
+	// the timeouts of the delta server, the deltas are up to hundreds of megabytes
+	deltaServerReadTimeout  = 30 * time.Second
+	deltaServerWriteTimeout = 30 * time.Second
+	deltaServerIdleTimeout  = 60 * time.Second
+)
+
+func init() {
+	delta.RegisterBroker(RedisDeltaBroker, newRedisDeltaBroker)
+	delta.RegisterBroker(FileDeltaBroker, newFileDeltaBroker)
+	delta.RegisterBroker(HttpDeltaBroker, newHttpDeltaBroker)
+}
+
+// NewDeltaBroker creates the delta broker of the configuration, it returns nil if the deltas are disabled
+func NewDeltaBroker(logger log.Logger) (delta.DeltaBroker, error) {
+	if !types.UploadDelta && !types.DownloadDelta {
+		return nil, nil
+	}
+	brokerName := viper.GetString(types.FlagDeltaBroker)
+	if brokerName == "" {
+		brokerName = RedisDeltaBroker
+	}
+	broker, err := delta.NewBroker(brokerName, logger)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s", types.FlagDeltaBroker)
+	}
+	return broker, nil
+}
+
+func deltaExpire() time.Duration {
+	return time.Duration(viper.GetInt(types.FlagRedisExpire)) * time.Second
+}
+
+func newRedisDeltaBroker(logger log.Logger) (delta.DeltaBroker, error) {
+	url := viper.GetString(types.FlagRedisUrl)
+	auth := viper.GetString(types.FlagRedisAuth)
+	dbNum := viper.GetInt(types.FlagRedisDB)
+	if dbNum < 0 || dbNum > 15 {
+		return nil, errors.New("delta-redis-db only support 0~15")
+	}
+	logger.Info("Init delta broker", "url", url)
+	return redis_cgi.NewRedisClient(url, auth, deltaExpire(), dbNum, logger), nil
+}
+
+func newFileDeltaBroker(logger log.Logger) (delta.DeltaBroker, error) {
+	dir := viper.GetString(types.FlagDeltaDir)
+	logger.Info("Init delta broker", "dir", dir)
+	return file_cgi.NewFileClient(dir, deltaExpire(), logger)
+}
+
+func newHttpDeltaBroker(logger log.Logger) (delta.DeltaBroker, error) {
+	url := viper.GetString(types.FlagDeltaHttpUrl)
+	if url == "" {
+		return nil, errors.Errorf("%s is required by the http delta broker", types.FlagDeltaHttpUrl)
+	}
+	logger.Info("Init delta broker", "url", url)
+	token := viper.GetString(types.FlagDeltaHttpToken)
+	return http_cgi.NewHTTPClient(url, token, httpDeltaBrokerTimeout, logger), nil
+}
+
+// serveDeltaBroker exposes the delta broker of the node to the http delta brokers of other nodes
+func serveDeltaBroker(laddr string, broker delta.DeltaBroker, logger log.Logger) {
+	token := viper.GetString(types.FlagDeltaHttpToken)
+	laddr, err := http_cgi.ListenAddr(laddr, token)
+	if err != nil {
+		logger.Error("Failed to start delta server", "err", err)
+		return
+	}
+	server := &http.Server{
+		Addr:         laddr,
+		Handler:      http_cgi.NewServer(broker, token, logger),
+		ReadTimeout:  deltaServerReadTimeout,
+		WriteTimeout: deltaServerWriteTimeout,
+		IdleTimeout:  deltaServerIdleTimeout,
+	}
+	logger.Info("Start delta server", "laddr", laddr)
+	if err := server.ListenAndServe(); err != nil {
+		logger.Error("Delta server stopped", "laddr", laddr, "err", err)
+	}
+}
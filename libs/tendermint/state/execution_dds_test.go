@@ -13,6 +13,7 @@ import (
 	"github.com/okex/exchain/libs/tendermint/types"
 	tmtime "github.com/okex/exchain/libs/tendermint/types/time"
 	dbm "github.com/okex/exchain/libs/tm-db"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/go-amino"
 )
@@ -292,3 +293,32 @@ func BenchmarkUnmarshalFromCustom(b *testing.B) {
 		ar.UnmarshalFromAmino(nil, data)
 	}
 }
+
+func TestNewDeltaBroker(t *testing.T) {
+	defer viper.Set(types.FlagDeltaBroker, "")
+	defer func(upload bool) { types.UploadDelta = upload }(types.UploadDelta)
+
+	types.UploadDelta = false
+	viper.Set(types.FlagDeltaBroker, "unknown")
+	broker, err := NewDeltaBroker(log.TestingLogger())
+	require.NoError(t, err)
+	require.Nil(t, broker)
+
+	types.UploadDelta = true
+	_, err = NewDeltaBroker(log.TestingLogger())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), types.FlagDeltaBroker)
+
+	// the deltas are disabled instead of panicking without a valid broker
+	dc := newDeltaContext(log.TestingLogger())
+	require.NotPanics(t, dc.init)
+	require.False(t, dc.uploadDelta)
+	require.Nil(t, dc.deltaBroker)
+
+	viper.Set(types.FlagDeltaBroker, FileDeltaBroker)
+	viper.Set(types.FlagDeltaDir, t.TempDir())
+	defer viper.Set(types.FlagDeltaDir, "")
+	broker, err = NewDeltaBroker(log.TestingLogger())
+	require.NoError(t, err)
+	require.NotNil(t, broker)
+}
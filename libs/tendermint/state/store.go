@@ -117,6 +117,20 @@ func saveState(db dbm.DB, state State, key []byte) {
 	db.SetSync(key, state.Bytes())
 }
 
+// BootstrapState saves a new state, used e.g. by state sync when starting from non-zero height.
+// Unlike SaveState it persists the validator sets and consensus params for the bootstrap heights
+// directly, since no earlier heights exist in the database.
+func BootstrapState(db dbm.DB, state State) {
+	height := state.LastBlockHeight + 1
+	if height > 1 && !state.LastValidators.IsNilOrEmpty() {
+		saveValidatorsInfo(db, height-1, height-1, state.LastValidators)
+	}
+	saveValidatorsInfo(db, height, height, state.Validators)
+	saveValidatorsInfo(db, height+1, height+1, state.NextValidators)
+	saveConsensusParamsInfo(db, height, height, state.ConsensusParams)
+	db.SetSync(stateKey, state.Bytes())
+}
+
 //------------------------------------------------------------------------
 
 // ABCIResponses retains the responses
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/okex/exchain/libs/tendermint/libs/service"
+	"github.com/okex/exchain/libs/tendermint/state/blockindex"
 
 	"github.com/okex/exchain/libs/tendermint/types"
 )
@@ -12,24 +13,25 @@ const (
 	subscriber = "IndexerService"
 )
 
-// IndexerService connects event bus and transaction indexer together in order
-// to index transactions coming from event bus.
+// IndexerService connects event bus, transaction and block indexers together in
+// order to index transactions and blocks coming from the event bus.
 type IndexerService struct {
 	service.BaseService
 
-	idr      TxIndexer
-	eventBus *types.EventBus
+	idr       TxIndexer
+	blockIdxr blockindex.BlockIndexer
+	eventBus  *types.EventBus
 }
 
 // NewIndexerService returns a new service instance.
-func NewIndexerService(idr TxIndexer, eventBus *types.EventBus) *IndexerService {
-	is := &IndexerService{idr: idr, eventBus: eventBus}
+func NewIndexerService(idr TxIndexer, blockIdxr blockindex.BlockIndexer, eventBus *types.EventBus) *IndexerService {
+	is := &IndexerService{idr: idr, blockIdxr: blockIdxr, eventBus: eventBus}
 	is.BaseService = *service.NewBaseService(nil, "IndexerService", is)
 	return is
 }
 
 // OnStart implements service.Service by subscribing for all transactions
-// and indexing them by events.
+// and blocks and indexing them by events.
 func (is *IndexerService) OnStart() error {
 	// Use SubscribeUnbuffered here to ensure both subscriptions does not get
 	// cancelled due to not pulling messages fast enough. Cause this might
@@ -53,6 +55,9 @@ func (is *IndexerService) OnStart() error {
 			msg := <-blockHeadersSub.Out()
 			eventDataHeader := msg.Data().(types.EventDataNewBlockHeader)
 			height := eventDataHeader.Header.Height
+			if err := is.blockIdxr.Index(eventDataHeader); err != nil {
+				is.Logger.Error("Failed to index block events", "height", height, "err", err)
+			}
 			batch := NewBatch(eventDataHeader.NumTxs)
 			for i := int64(0); i < eventDataHeader.NumTxs; i++ {
 				msg2 := <-txsSub.Out()
@@ -11,6 +11,7 @@ import (
 
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
 	"github.com/okex/exchain/libs/tendermint/libs/log"
+	blockidxkv "github.com/okex/exchain/libs/tendermint/state/blockindex/kv"
 	"github.com/okex/exchain/libs/tendermint/state/txindex"
 	"github.com/okex/exchain/libs/tendermint/state/txindex/kv"
 	"github.com/okex/exchain/libs/tendermint/types"
@@ -27,8 +28,9 @@ func TestIndexerServiceIndexesBlocks(t *testing.T) {
 	// tx indexer
 	store := db.NewMemDB()
 	txIndexer := kv.NewTxIndex(store, kv.IndexAllEvents())
+	blockIndexer := blockidxkv.New(db.NewPrefixDB(store, []byte("block_events")))
 
-	service := txindex.NewIndexerService(txIndexer, eventBus)
+	service := txindex.NewIndexerService(txIndexer, blockIndexer, eventBus)
 	service.SetLogger(log.TestingLogger())
 	err = service.Start()
 	require.NoError(t, err)
@@ -57,6 +59,10 @@ func TestIndexerServiceIndexesBlocks(t *testing.T) {
 	time.Sleep(100 * time.Millisecond)
 
 	// check the result
+	has, err := blockIndexer.Has(1)
+	assert.NoError(t, err)
+	assert.True(t, has)
+
 	res, err := txIndexer.Get(types.Tx("foo").Hash(txResult1.Height))
 	assert.NoError(t, err)
 	assert.Equal(t, txResult1, res)
@@ -24,7 +24,18 @@ const (
 	// expire unit: second
 	FlagRedisExpire = "delta-redis-expire"
 	FlagRedisDB     = "delta-redis-db"
-	FlagFastQuery   = "fast-query"
+
+	// FlagDeltaBroker specify the delta broker, redis|file|http
+	FlagDeltaBroker = "delta-broker"
+	// FlagDeltaDir is the directory of the file delta broker
+	FlagDeltaDir = "delta-dir"
+	// FlagDeltaHttpUrl is the url of the delta server used by the http delta broker
+	FlagDeltaHttpUrl = "delta-http-url"
+	// FlagDeltaHttpLaddr is the address the delta server of the node listens on, empty means disabled
+	FlagDeltaHttpLaddr = "delta-http-laddr"
+	// FlagDeltaHttpToken is the token shared by the delta server and the http delta brokers
+	FlagDeltaHttpToken = "delta-http-token"
+	FlagFastQuery      = "fast-query"
 
 	// FlagDeltaVersion specify the DeltaVersion
 	FlagDeltaVersion = "delta-version"
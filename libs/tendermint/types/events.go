@@ -21,6 +21,7 @@ const (
 	EventNewBlockHeader      = "NewBlockHeader"
 	EventTx                  = "Tx"
 	EventPendingTx           = "PendingTx"
+	EventRmPendingTx         = "RmPendingTx"
 	EventValidatorSetUpdates = "ValidatorSetUpdates"
 
 	// Internal consensus events.
@@ -53,6 +54,7 @@ func RegisterEventDatas(cdc *amino.Codec) {
 	cdc.RegisterConcrete(EventDataNewBlock{}, "tendermint/event/NewBlock", nil)
 	cdc.RegisterConcrete(EventDataNewBlockHeader{}, "tendermint/event/NewBlockHeader", nil)
 	cdc.RegisterConcrete(EventDataTx{}, "tendermint/event/Tx", nil)
+	cdc.RegisterConcrete(EventDataRmPendingTx{}, "tendermint/event/RmPendingTx", nil)
 	cdc.RegisterConcrete(EventDataRoundState{}, "tendermint/event/RoundState", nil)
 	cdc.RegisterConcrete(EventDataNewRound{}, "tendermint/event/NewRound", nil)
 	cdc.RegisterConcrete(EventDataCompleteProposal{}, "tendermint/event/CompleteProposal", nil)
@@ -84,6 +86,26 @@ type EventDataTx struct {
 	TxResult
 }
 
+// Reasons for a tx leaving the mempool without being included in a block
+const (
+	// Recheck means the tx became invalid when rechecked after a new block
+	Recheck = iota
+	// Replaced means the tx was replaced by a tx with the same sender and nonce but a higher gas price
+	Replaced
+	// Stale means the nonce of the tx was consumed by another tx included in a block
+	Stale
+	// Flushed means the mempool was flushed
+	Flushed
+)
+
+// EventDataRmPendingTx is fired when a tx leaves the mempool without being included in a block
+type EventDataRmPendingTx struct {
+	Hash   []byte `json:"hash"`
+	From   string `json:"from"`
+	Nonce  uint64 `json:"nonce"`
+	Reason int    `json:"reason"`
+}
+
 // NOTE: This goes into the replay WAL
 type EventDataRoundState struct {
 	Height int64  `json:"height"`
@@ -135,6 +157,9 @@ const (
 	// TxHeightKey is a reserved key, used to specify transaction block's height.
 	// see EventBus#PublishEventTx
 	TxHeightKey = "tx.height"
+	// BlockHeightKey is a reserved key used for indexing BeginBlock and EndBlock
+	// events.
+	BlockHeightKey = "block.height"
 )
 
 var (
@@ -145,6 +170,7 @@ var (
 	EventQueryNewRound            = QueryForEvent(EventNewRound)
 	EventQueryNewRoundStep        = QueryForEvent(EventNewRoundStep)
 	EventQueryPolka               = QueryForEvent(EventPolka)
+	EventQueryRmPendingTx         = QueryForEvent(EventRmPendingTx)
 	EventQueryRelock              = QueryForEvent(EventRelock)
 	EventQueryTimeoutPropose      = QueryForEvent(EventTimeoutPropose)
 	EventQueryTimeoutWait         = QueryForEvent(EventTimeoutWait)
@@ -169,10 +195,12 @@ type BlockEventPublisher interface {
 	PublishEventNewBlockHeader(header EventDataNewBlockHeader) error
 	PublishEventTx(EventDataTx) error
 	PublishEventPendingTx(EventDataTx) error
+	PublishEventRmPendingTx(EventDataRmPendingTx) error
 	PublishEventValidatorSetUpdates(EventDataValidatorSetUpdates) error
 }
 
 type TxEventPublisher interface {
 	PublishEventTx(EventDataTx) error
 	PublishEventPendingTx(EventDataTx) error
+	PublishEventRmPendingTx(EventDataRmPendingTx) error
 }
@@ -20,6 +20,9 @@ var (
 	MILESTONE_VENUS_HEIGHT string
 	milestoneVenusHeight   int64
 
+	MILESTONE_EARTH_HEIGHT string
+	milestoneEarthHeight   int64
+
 	once sync.Once
 )
 
@@ -28,6 +31,7 @@ func init() {
 		genesisHeight = string2number(MILESTONE_GENESIS_HEIGHT)
 		milestoneMercuryHeight = string2number(MILESTONE_MERCURY_HEIGHT)
 		milestoneVenusHeight = string2number(MILESTONE_VENUS_HEIGHT)
+		milestoneEarthHeight = string2number(MILESTONE_EARTH_HEIGHT)
 	})
 }
 
@@ -58,6 +62,14 @@ func HigherThanVenus(height int64) bool {
 	return height >= milestoneVenusHeight
 }
 
+// HigherThanEarth returns true if height reaches the earth milestone, which is not enabled if it is not set
+func HigherThanEarth(height int64) bool {
+	if milestoneEarthHeight == 0 {
+		return false
+	}
+	return height >= milestoneEarthHeight
+}
+
 // GetMilestoneVenusHeight returns milestoneVenusHeight
 func GetMilestoneVenusHeight() int64 {
 	return milestoneVenusHeight
@@ -85,7 +97,16 @@ func GetMercuryHeight() int64 {
 	return milestoneMercuryHeight
 }
 
+func GetEarthHeight() int64 {
+	return milestoneEarthHeight
+}
+
 // can be used in unit test only
 func UnittestOnlySetMilestoneVenusHeight(height int64) {
 	milestoneVenusHeight = height
 }
+
+// can be used in unit test only
+func UnittestOnlySetMilestoneEarthHeight(height int64) {
+	milestoneEarthHeight = height
+}
@@ -35,4 +35,7 @@ type (
 
 	// nolint
 	SwapTokenPair = types.SwapTokenPair
+
+	// nolint
+	MsgTokenToToken = types.MsgTokenToToken
 )
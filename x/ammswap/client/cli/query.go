@@ -11,6 +11,7 @@ import (
 	"github.com/okex/exchain/libs/cosmos-sdk/version"
 	"github.com/okex/exchain/x/ammswap/types"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"strings"
 )
 
@@ -32,6 +33,7 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 			GetCmdAllSwapTokenPairs(queryRoute, cdc),
 			GetCmdRedeemableAssets(queryRoute, cdc),
 			GetCmdQueryBuyAmount(queryRoute, cdc),
+			GetCmdQuerySwapRoute(queryRoute, cdc),
 		)...,
 	)
 
@@ -109,6 +111,46 @@ $ %s query swap amount 100eth-245 xxb`, version.ClientName,
 	}
 }
 
+// GetCmdQuerySwapRoute queries the best route to swap the given amount of token, or the quote of the given path
+func GetCmdQuerySwapRoute(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "route [token-to-sell] [token-name-to-buy]",
+		Short: "Query the best route to swap token and the amount returned",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(
+				`Query the route which returns the most token by the given amount of token to sell.
+The route through the given intermediate tokens is quoted if the path flag is set.
+
+Example:
+$ %s query swap route 100eth-245 xxb
+$ %s query swap route 100eth-245 xxb --path okt,usdk-017`, version.ClientName, version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			var path []string
+			if pathStr := viper.GetString(flagPath); pathStr != "" {
+				path = strings.Split(pathStr, ",")
+			}
+			params := types.NewQuerySwapRouteParams(args[0], args[1], path)
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QuerySwapRoute), bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+	cmd.Flags().String(flagPath, "", "Comma separated intermediate tokens of the route to quote")
+	return cmd
+}
+
 // GetCmdQueryParams queries the parameters of the AMM swap system
 func GetCmdQueryParams(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
@@ -28,6 +28,7 @@ const (
 	flagSellAmount       = "sell-amount"
 	flagMinBuyAmount     = "min-buy-amount"
 	flagRecipient        = "recipient"
+	flagPath             = "path"
 	flagToken0           = "token0"
 	flagToken1           = "token1"
 )
@@ -198,6 +199,7 @@ func getCmdTokenSwap(cdc *codec.Codec) *cobra.Command {
 	var minBoughtTokenAmount string
 	var deadline string
 	var recipient string
+	var path string
 	cmd := &cobra.Command{
 		Use:   "token",
 		Short: "swap token",
@@ -206,6 +208,7 @@ func getCmdTokenSwap(cdc *codec.Codec) *cobra.Command {
 
 Example:
 $ exchaincli tx swap token --sell-amount 1eth-355 --min-buy-amount 60btc-366
+$ exchaincli tx swap token --sell-amount 1eth-355 --min-buy-amount 60btc-366 --path okt,usdk-017
 
 `),
 		),
@@ -237,7 +240,11 @@ $ exchaincli tx swap token --sell-amount 1eth-355 --min-buy-amount 60btc-366
 				}
 			}
 
-			msg := types.NewMsgTokenToToken(soldTokenAmount, minBoughtTokenAmount,
+			var swapPath []string
+			if path != "" {
+				swapPath = strings.Split(path, ",")
+			}
+			msg := types.NewMsgTokenToTokenWithPath(soldTokenAmount, minBoughtTokenAmount, swapPath,
 				deadline, recip, cliCtx.FromAddress)
 
 			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
@@ -250,6 +257,8 @@ $ exchaincli tx swap token --sell-amount 1eth-355 --min-buy-amount 60btc-366
 		"Minimum amount expected to buy")
 	cmd.Flags().StringVarP(&recipient, flagRecipient, "", "",
 		"The address to receive the amount bought")
+	cmd.Flags().StringVarP(&path, flagPath, "", "",
+		"Comma separated intermediate tokens to swap through, such as \"okt,usdk-017\"")
 	cmd.Flags().StringVarP(&deadline, flagDeadlineDuration, "", "100s",
 		"Duration after which this transaction can no longer be executed. such as \"300ms\", \"1.5h\" or \"2h45m\". Valid time units are \"ns\", \"us\" (or \"µs\"), \"ms\", \"s\", \"m\", \"h\".")
 	cmd.MarkFlagRequired(flagSellAmount)
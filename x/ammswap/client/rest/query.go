@@ -20,6 +20,7 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc("/liquidity/add_quote/{token}", swapAddQuoteHandler(cliCtx)).Methods("GET")
 	r.HandleFunc("/liquidity/remove_quote/{token_pair}", queryRedeemableAssetsHandler(cliCtx)).Methods("GET")
 	r.HandleFunc("/quote/{token}", swapQuoteHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/route/{token}", swapRouteHandler(cliCtx)).Methods("GET")
 }
 
 func querySwapTokenPairHandler(cliContext context.CLIContext) func(http.ResponseWriter, *http.Request) {
@@ -128,3 +129,31 @@ func swapAddQuoteHandler(cliCtx context.CLIContext) http.HandlerFunc {
 		rest.PostProcessResponse(w, cliCtx, res)
 	}
 }
+
+func swapRouteHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		buyToken := vars["token"]
+		sellTokenAmount := r.URL.Query().Get("sell_token_amount")
+		var path []string
+		if pathStr := r.URL.Query().Get("path"); pathStr != "" {
+			path = strings.Split(pathStr, ",")
+		}
+
+		params := types.NewQuerySwapRouteParams(sellTokenAmount, buyToken, path)
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, common.CodeMarshalJSONFailed, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QuerySwapRoute), bz)
+		if err != nil {
+			sdkErr := common.ParseSDKError(err.Error())
+			common.HandleErrorMsg(w, cliCtx, sdkErr.Code, sdkErr.Message)
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
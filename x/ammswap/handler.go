@@ -1,6 +1,8 @@
 package ammswap
 
 import (
+	"strings"
+
 	"github.com/okex/exchain/x/ammswap/keeper"
 	"github.com/okex/exchain/x/ammswap/types"
 	"github.com/okex/exchain/x/common"
@@ -49,6 +51,12 @@ func NewHandler(k Keeper) sdk.Handler {
 }
 
 func handleMsgTokenToToken(ctx sdk.Context, k Keeper, msg types.MsgTokenToToken) (*sdk.Result, error) {
+	if err := msg.ValidateSwapPath(ctx.BlockHeight()); err != nil {
+		return nil, err
+	}
+	if len(msg.Path) > 0 {
+		return swapTokenByPath(ctx, k, msg)
+	}
 	_, err := k.GetSwapTokenPair(ctx, msg.GetSwapTokenPairName())
 	if err != nil {
 		return swapTokenByRouter(ctx, k, msg)
@@ -328,6 +336,57 @@ func swapTokenByRouter(ctx sdk.Context, k Keeper, msg types.MsgTokenToToken) (*s
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
 
+func swapTokenByPath(ctx sdk.Context, k Keeper, msg types.MsgTokenToToken) (*sdk.Result, error) {
+	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
+
+	if msg.Deadline < ctx.BlockTime().Unix() {
+		return types.ErrBlockTimeBigThanDeadline().Result()
+	}
+	if err := common.HasSufficientCoins(msg.Sender, k.GetTokenKeeper().GetCoins(ctx, msg.Sender),
+		sdk.SysCoins{msg.SoldTokenAmount}); err != nil {
+		return common.ErrInsufficientCoins(DefaultParamspace, err.Error()).Result()
+	}
+	swapTokenPairs, amounts, err := k.CalculateRouteToBuy(ctx, msg.SoldTokenAmount, msg.GetRoute())
+	if err != nil {
+		return nil, err
+	}
+	// the min bought token amount protects the whole route
+	tokenBuy := amounts[len(amounts)-1]
+	if tokenBuy.Amount.LT(msg.MinBoughtTokenAmount.Amount) {
+		return types.ErrLessThan("token buy amount", "min bought token amount").Result()
+	}
+
+	// the intermediate tokens stay in the pool, only the sold and the bought tokens are transferred
+	err = k.SendCoinsToPool(ctx, sdk.SysCoins{msg.SoldTokenAmount}, msg.Sender)
+	if err != nil {
+		return types.ErrSendCoinsToPoolFailed(err.Error()).Result()
+	}
+	err = k.SendCoinsFromPoolToAccount(ctx, sdk.SysCoins{tokenBuy}, msg.Recipient)
+	if err != nil {
+		return types.ErrSendCoinsFromPoolToAccountFailed(err.Error()).Result()
+	}
+
+	sellToken := msg.SoldTokenAmount
+	for i, swapTokenPair := range swapTokenPairs {
+		if amounts[i].Denom < sellToken.Denom {
+			swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Add(sellToken)
+			swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Sub(amounts[i])
+		} else {
+			swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Sub(amounts[i])
+			swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Add(sellToken)
+		}
+		k.SetSwapTokenPair(ctx, swapTokenPair.TokenPairName(), swapTokenPair)
+		k.OnSwapToken(ctx, msg.Recipient, swapTokenPair, sellToken, amounts[i])
+		sellToken = amounts[i]
+	}
+
+	event = event.AppendAttributes(sdk.NewAttribute("bought_token_amount", tokenBuy.String()))
+	event = event.AppendAttributes(sdk.NewAttribute("recipient", msg.Recipient.String()))
+	event = event.AppendAttributes(sdk.NewAttribute("route", strings.Join(msg.GetRoute(), ",")))
+	ctx.EventManager().EmitEvent(event)
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
 func swapTokenNativeToken(
 	ctx sdk.Context, k Keeper, swapTokenPair SwapTokenPair, tokenBuy sdk.SysCoin,
 	msg types.MsgTokenToToken,
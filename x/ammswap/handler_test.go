@@ -9,6 +9,7 @@ import (
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/supply"
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	"github.com/okex/exchain/x/ammswap/keeper"
 	"github.com/okex/exchain/x/ammswap/types"
 	"github.com/okex/exchain/x/token"
@@ -454,6 +455,99 @@ func TestHandleMsgTokenToTokenDirectly(t *testing.T) {
 	}
 }
 
+func TestHandleMsgTokenToTokenByPath(t *testing.T) {
+	mapp, addrKeysSlice := getMockAppWithBalance(t, 1, 100000)
+	keeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10).WithBlockTime(time.Now())
+	mapp.swapKeeper.SetParams(ctx, types.DefaultParams())
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+	handler := NewHandler(keeper)
+	addr := addrKeysSlice[0].Address
+	deadLine := time.Now().Unix()
+
+	// aab_okt, ccb_okt and ccb_ddb pools
+	for _, symbol := range []string{types.TestBasePooledToken, types.TestBasePooledToken2,
+		types.TestBasePooledToken3, types.TestQuotePooledToken} {
+		mapp.tokenKeeper.NewToken(ctx, token.InitTestToken(symbol))
+	}
+	for _, pair := range [][2]string{
+		{types.TestBasePooledToken, types.TestQuotePooledToken},
+		{types.TestBasePooledToken2, types.TestQuotePooledToken},
+		{types.TestBasePooledToken2, types.TestBasePooledToken3},
+	} {
+		_, err := handler(ctx, types.NewMsgCreateExchange(pair[0], pair[1], addr))
+		require.Nil(t, err)
+		_, err = handler(ctx, types.NewMsgAddLiquidity(sdk.NewDec(1), sdk.NewDecCoinFromDec(pair[0], sdk.NewDec(10000)),
+			sdk.NewDecCoinFromDec(pair[1], sdk.NewDec(10000)), deadLine, addr))
+		require.Nil(t, err)
+	}
+
+	soldTokenAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(2))
+	path := []string{types.TestQuotePooledToken, types.TestBasePooledToken2}
+	route := append(append([]string{types.TestBasePooledToken}, path...), types.TestBasePooledToken3)
+	_, amounts, err := keeper.CalculateRouteToBuy(ctx, soldTokenAmount, route)
+	require.Nil(t, err)
+	tokenBuy := amounts[len(amounts)-1]
+
+	minBoughtTokenAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken3, sdk.NewDec(1))
+	invalidMinBoughtTokenAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken3, sdk.NewDec(2))
+
+	// the multi-hop swap is enabled since the earth height
+	tmtypes.UnittestOnlySetMilestoneEarthHeight(11)
+	defer tmtypes.UnittestOnlySetMilestoneEarthHeight(0)
+	msg := types.NewMsgTokenToTokenWithPath(soldTokenAmount, minBoughtTokenAmount, path, deadLine, addr, addr)
+	_, err = handler(ctx, msg)
+	require.Error(t, err)
+	require.Error(t, msg.ValidateSwapPath(ctx.BlockHeight()))
+	require.Nil(t, msg.ValidateSwapPath(11))
+	ctx = ctx.WithBlockHeight(11)
+
+	tests := []struct {
+		testCase             string
+		minBoughtTokenAmount sdk.SysCoin
+		path                 []string
+		deadLine             int64
+		exceptResultCode     uint32
+	}{
+		{"(tokenToTokenByPath) blockTime exceeded deadline", minBoughtTokenAmount, path, 0, sdk.CodeInternal},
+		{"(tokenToTokenByPath) unknown swapTokenPair", minBoughtTokenAmount, []string{types.TestQuotePooledToken}, deadLine, sdk.CodeInternal},
+		{"(tokenToTokenByPath) The available BoughtTokenAmount of the route are less than minBoughtTokenAmount", invalidMinBoughtTokenAmount, path, deadLine, sdk.CodeInternal},
+		{"(tokenToTokenByPath) success", minBoughtTokenAmount, path, deadLine, sdk.CodeOK},
+	}
+	for _, testCase := range tests {
+		fmt.Println(testCase.testCase)
+		msg := types.NewMsgTokenToTokenWithPath(soldTokenAmount, testCase.minBoughtTokenAmount, testCase.path,
+			testCase.deadLine, addr, addr)
+		_, err := handler(ctx, msg)
+		testCode(t, err, testCase.exceptResultCode)
+	}
+
+	// only the sold and the bought tokens of the account change
+	acc := mapp.AccountKeeper.GetAccount(ctx, addr)
+	require.Equal(t, sdk.NewDec(89998), acc.GetCoins().AmountOf(types.TestBasePooledToken))
+	require.Equal(t, sdk.NewDec(80000), acc.GetCoins().AmountOf(types.TestQuotePooledToken))
+	require.Equal(t, sdk.NewDec(80000), acc.GetCoins().AmountOf(types.TestBasePooledToken2))
+	require.Equal(t, sdk.NewDec(90000).Add(tokenBuy.Amount), acc.GetCoins().AmountOf(types.TestBasePooledToken3))
+
+	// the pools of every hop are updated
+	for i := 1; i < len(route); i++ {
+		swapTokenPair, err := keeper.GetSwapTokenPair(ctx, types.GetSwapTokenPairName(route[i-1], route[i]))
+		require.Nil(t, err)
+		sold := soldTokenAmount
+		if i > 1 {
+			sold = amounts[i-2]
+		}
+		if swapTokenPair.BasePooledCoin.Denom == sold.Denom {
+			require.Equal(t, sdk.NewDec(10000).Add(sold.Amount), swapTokenPair.BasePooledCoin.Amount)
+			require.Equal(t, sdk.NewDec(10000).Sub(amounts[i-1].Amount), swapTokenPair.QuotePooledCoin.Amount)
+		} else {
+			require.Equal(t, sdk.NewDec(10000).Add(sold.Amount), swapTokenPair.QuotePooledCoin.Amount)
+			require.Equal(t, sdk.NewDec(10000).Sub(amounts[i-1].Amount), swapTokenPair.BasePooledCoin.Amount)
+		}
+	}
+}
+
 func TestGetInputPrice(t *testing.T) {
 	tests := []struct {
 		testCase           string
@@ -200,7 +200,17 @@ func (k *Keeper) SetObserverKeeper(bk types.BackendKeeper) {
 	k.ObserverKeeper = append(k.ObserverKeeper, bk)
 }
 
+// OnSwapToken emits the event of the swap through the token pair and notifies the observers
 func (k Keeper) OnSwapToken(ctx sdk.Context, address sdk.AccAddress, swapTokenPair types.SwapTokenPair, sellAmount sdk.SysCoin, buyAmount sdk.SysCoin) {
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeSwap,
+		sdk.NewAttribute(types.AttributeKeyPool, swapTokenPair.TokenPairName()),
+		sdk.NewAttribute(types.AttributeKeyAddress, address.String()),
+		sdk.NewAttribute(types.AttributeKeySoldToken, sellAmount.String()),
+		sdk.NewAttribute(types.AttributeKeyBoughtToken, buyAmount.String()),
+		sdk.NewAttribute(types.AttributeKeyBasePooled, swapTokenPair.BasePooledCoin.String()),
+		sdk.NewAttribute(types.AttributeKeyQuotePooled, swapTokenPair.QuotePooledCoin.String()),
+	))
 	for _, observer := range k.ObserverKeeper {
 		observer.OnSwapToken(ctx, address, swapTokenPair, sellAmount, buyAmount)
 	}
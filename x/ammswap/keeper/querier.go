@@ -28,6 +28,8 @@ func NewQuerier(k Keeper) sdk.Querier {
 			res, err = querySwapQuoteInfo(ctx, req, k)
 		case types.QuerySwapAddLiquidityQuote:
 			res, err = querySwapAddLiquidityQuote(ctx, req, k)
+		case types.QuerySwapRoute:
+			res, err = querySwapRoute(ctx, req, k)
 
 		default:
 			return nil, types.ErrSwapUnknownQueryType()
@@ -273,6 +275,62 @@ func querySwapQuoteInfo(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (
 
 }
 
+// querySwapRoute returns the quote of swapping through the given path or the best route
+func querySwapRoute(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var queryParams types.QuerySwapRouteParams
+	err := keeper.cdc.UnmarshalJSON(req.Data, &queryParams)
+	if err != nil {
+		return nil, common.ErrUnMarshalJSONFailed(err.Error())
+	}
+	if queryParams.SellTokenAmount == "" || queryParams.BuyToken == "" {
+		return nil, types.ErrSellAmountOrBuyTokenIsEmpty()
+	}
+
+	sellAmount, err := sdk.ParseDecCoin(queryParams.SellTokenAmount)
+	if err != nil {
+		return nil, types.ErrConvertSellTokenAmount(queryParams.SellTokenAmount, err)
+	}
+	if sellAmount.Denom == queryParams.BuyToken {
+		return nil, types.ErrSellAmountEqualBuyToken()
+	}
+
+	var route []string
+	var amounts []sdk.SysCoin
+	if len(queryParams.Path) > 0 {
+		route = append([]string{sellAmount.Denom}, queryParams.Path...)
+		route = append(route, queryParams.BuyToken)
+		if err := types.ValidateSwapRoute(route); err != nil {
+			return nil, err
+		}
+		_, amounts, err = keeper.CalculateRouteToBuy(ctx, sellAmount, route)
+	} else {
+		route, amounts, err = keeper.FindBestSwapRoute(ctx, sellAmount, queryParams.BuyToken)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	buyAmount := amounts[len(amounts)-1].Amount
+	price := sdk.ZeroDec()
+	if sellAmount.Amount.IsPositive() {
+		price = buyAmount.Quo(sellAmount.Amount)
+	}
+	routeInfo := types.SwapRouteInfo{
+		Route:     route,
+		Path:      route[1 : len(route)-1],
+		Amounts:   amounts,
+		BuyAmount: buyAmount,
+		Price:     price,
+	}
+
+	response := common.GetBaseResponse(routeInfo)
+	bz, err := json.Marshal(response)
+	if err != nil {
+		return nil, common.ErrMarshalJSONFailed(err.Error())
+	}
+	return bz, nil
+}
+
 // querySwapAddLiquidityQuote returns swap information of adding liquidity
 func querySwapAddLiquidityQuote(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
 	var queryParams types.QuerySwapAddInfoParams
@@ -0,0 +1,105 @@
+package keeper
+
+import (
+	"sort"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/ammswap/types"
+)
+
+// CalculateRouteToBuy quotes swapping the sold token through the route hop by hop,
+// returns the token pairs of the hops and the amount bought by every hop
+func (k Keeper) CalculateRouteToBuy(ctx sdk.Context, soldToken sdk.SysCoin, route []string) (
+	[]types.SwapTokenPair, []sdk.SysCoin, error) {
+	if len(route) < 2 || route[0] != soldToken.Denom {
+		return nil, nil, types.ErrInvalidSwapPath("the route must start with the sold token")
+	}
+	params := k.GetParams(ctx)
+	swapTokenPairs := make([]types.SwapTokenPair, 0, len(route)-1)
+	amounts := make([]sdk.SysCoin, 0, len(route)-1)
+	sellToken := soldToken
+	for i := 1; i < len(route); i++ {
+		swapTokenPair, err := k.GetSwapTokenPair(ctx, types.GetSwapTokenPairName(route[i-1], route[i]))
+		if err != nil {
+			return nil, nil, err
+		}
+		if swapTokenPair.BasePooledCoin.IsZero() || swapTokenPair.QuotePooledCoin.IsZero() {
+			return nil, nil, types.ErrIsZeroValue("base pooled coin or quote pooled coin")
+		}
+		tokenBuy := CalculateTokenToBuy(swapTokenPair, sellToken, route[i], params)
+		if tokenBuy.IsZero() {
+			return nil, nil, types.ErrIsZeroValue("token buy")
+		}
+		swapTokenPairs = append(swapTokenPairs, swapTokenPair)
+		amounts = append(amounts, tokenBuy)
+		sellToken = tokenBuy
+	}
+	return swapTokenPairs, amounts, nil
+}
+
+// FindBestSwapRoute searches the route through at most MaxSwapRouteHops token pairs which buys the most
+// of buyToken with the sold token, returns the route and the amount bought by every hop
+func (k Keeper) FindBestSwapRoute(ctx sdk.Context, soldToken sdk.SysCoin, buyToken string) (
+	[]string, []sdk.SysCoin, error) {
+	// build the graph of the swap token pairs with liquidity
+	swapTokenPairs := make(map[string]types.SwapTokenPair)
+	neighbors := make(map[string][]string)
+	for _, swapTokenPair := range k.GetSwapTokenPairs(ctx) {
+		if swapTokenPair.BasePooledCoin.IsZero() || swapTokenPair.QuotePooledCoin.IsZero() {
+			continue
+		}
+		base, quote := swapTokenPair.BasePooledCoin.Denom, swapTokenPair.QuotePooledCoin.Denom
+		swapTokenPairs[types.GetSwapTokenPairName(base, quote)] = swapTokenPair
+		neighbors[base] = append(neighbors[base], quote)
+		neighbors[quote] = append(neighbors[quote], base)
+	}
+	for token := range neighbors {
+		sort.Strings(neighbors[token])
+	}
+
+	params := k.GetParams(ctx)
+	var bestRoute []string
+	var bestAmounts []sdk.SysCoin
+	route := []string{soldToken.Denom}
+	var amounts []sdk.SysCoin
+	visited := map[string]bool{soldToken.Denom: true}
+
+	var search func(sellToken sdk.SysCoin)
+	search = func(sellToken sdk.SysCoin) {
+		if len(route)-1 >= types.MaxSwapRouteHops {
+			return
+		}
+		for _, token := range neighbors[sellToken.Denom] {
+			if visited[token] {
+				continue
+			}
+			swapTokenPair := swapTokenPairs[types.GetSwapTokenPairName(sellToken.Denom, token)]
+			tokenBuy := CalculateTokenToBuy(swapTokenPair, sellToken, token, params)
+			if tokenBuy.IsZero() {
+				continue
+			}
+			route = append(route, token)
+			amounts = append(amounts, tokenBuy)
+			if token == buyToken {
+				// the shorter route is kept when the amounts are equal
+				if bestAmounts == nil || tokenBuy.Amount.GT(bestAmounts[len(bestAmounts)-1].Amount) ||
+					(tokenBuy.Amount.Equal(bestAmounts[len(bestAmounts)-1].Amount) && len(route) < len(bestRoute)) {
+					bestRoute = append([]string{}, route...)
+					bestAmounts = append([]sdk.SysCoin{}, amounts...)
+				}
+			} else {
+				visited[token] = true
+				search(tokenBuy)
+				visited[token] = false
+			}
+			route = route[:len(route)-1]
+			amounts = amounts[:len(amounts)-1]
+		}
+	}
+	search(soldToken)
+
+	if bestRoute == nil {
+		return nil, nil, types.ErrNoSwapRoute(soldToken.Denom, buyToken)
+	}
+	return bestRoute, bestAmounts, nil
+}
@@ -0,0 +1,69 @@
+package keeper
+
+import (
+	"encoding/json"
+	"testing"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/exchain/x/ammswap/types"
+	"github.com/okex/exchain/x/common"
+)
+
+func setTestSwapTokenPair(ctx sdk.Context, keeper Keeper, token0, token1 string, amount0, amount1 int64) {
+	base, quote := types.GetBaseQuoteTokenName(token0, token1)
+	baseAmount, quoteAmount := amount0, amount1
+	if base != token0 {
+		baseAmount, quoteAmount = amount1, amount0
+	}
+	swapTokenPair := types.NewSwapTokenPair(sdk.NewDecCoinFromDec(quote, sdk.NewDec(quoteAmount)),
+		sdk.NewDecCoinFromDec(base, sdk.NewDec(baseAmount)), types.GetPoolTokenName(base, quote))
+	keeper.SetSwapTokenPair(ctx, types.GetSwapTokenPairName(base, quote), *swapTokenPair)
+}
+
+func TestFindBestSwapRoute(t *testing.T) {
+	_, _, ctx, keeper, querier := initQurierTest(t)
+	// the direct pool is too shallow, swapping through the native token buys more
+	setTestSwapTokenPair(ctx, keeper, types.TestBasePooledToken, types.TestQuotePooledToken, 1000, 1000)
+	setTestSwapTokenPair(ctx, keeper, types.TestBasePooledToken2, types.TestQuotePooledToken, 1000, 1000)
+	setTestSwapTokenPair(ctx, keeper, types.TestBasePooledToken, types.TestBasePooledToken2, 10, 10)
+
+	soldToken := sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(5))
+	route, amounts, err := keeper.FindBestSwapRoute(ctx, soldToken, types.TestBasePooledToken2)
+	require.Nil(t, err)
+	require.Equal(t, []string{types.TestBasePooledToken, types.TestQuotePooledToken, types.TestBasePooledToken2}, route)
+	require.Equal(t, 2, len(amounts))
+
+	_, quotedAmounts, err := keeper.CalculateRouteToBuy(ctx, soldToken, route)
+	require.Nil(t, err)
+	require.Equal(t, amounts, quotedAmounts)
+
+	_, directAmounts, err := keeper.CalculateRouteToBuy(ctx, soldToken,
+		[]string{types.TestBasePooledToken, types.TestBasePooledToken2})
+	require.Nil(t, err)
+	require.True(t, directAmounts[0].Amount.LT(amounts[1].Amount))
+
+	// no pool of the token to buy
+	_, _, err = keeper.FindBestSwapRoute(ctx, soldToken, types.TestBasePooledToken3)
+	require.NotNil(t, err)
+	_, _, err = keeper.CalculateRouteToBuy(ctx, soldToken,
+		[]string{types.TestBasePooledToken, types.TestBasePooledToken3})
+	require.NotNil(t, err)
+
+	// query the best route and the given path
+	params := types.NewQuerySwapRouteParams(soldToken.String(), types.TestBasePooledToken2, nil)
+	bz, err := querier(ctx, []string{types.QuerySwapRoute}, abci.RequestQuery{Data: keeper.cdc.MustMarshalJSON(params)})
+	require.Nil(t, err)
+	var routeInfo types.SwapRouteInfo
+	response := common.BaseResponse{Data: &routeInfo}
+	require.Nil(t, json.Unmarshal(bz, &response))
+	require.Equal(t, route, routeInfo.Route)
+	require.Equal(t, []string{types.TestQuotePooledToken}, routeInfo.Path)
+	require.Equal(t, amounts[1].Amount, routeInfo.BuyAmount)
+
+	params = types.NewQuerySwapRouteParams(soldToken.String(), types.TestBasePooledToken2, []string{types.TestBasePooledToken})
+	_, err = querier(ctx, []string{types.QuerySwapRoute}, abci.RequestQuery{Data: keeper.cdc.MustMarshalJSON(params)})
+	require.NotNil(t, err)
+}
@@ -56,6 +56,8 @@ const (
 	CodeIsSwapTokenPairExist                    uint32 = 65043
 	CodeIsPoolTokenPairExist                    uint32 = 65044
 	CodeInternalError                           uint32 = 65045
+	CodeInvalidSwapPath                         uint32 = 65046
+	CodeNoSwapRoute                             uint32 = 65047
 )
 
 func ErrNonExistSwapTokenPair(tokenPairName string) sdk.EnvelopedErr {
@@ -239,3 +241,11 @@ func ErrSwapTokenPairExist() sdk.EnvelopedErr {
 func ErrPoolTokenPairExist() sdk.EnvelopedErr {
 	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeIsPoolTokenPairExist, "the pool token pair already exists")}
 }
+
+func ErrInvalidSwapPath(msg string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeInvalidSwapPath, fmt.Sprintf("invalid swap path: %s", msg))}
+}
+
+func ErrNoSwapRoute(soldToken, boughtToken string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeNoSwapRoute, fmt.Sprintf("no swap route from %s to %s", soldToken, boughtToken))}
+}
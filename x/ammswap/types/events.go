@@ -2,5 +2,13 @@ package types
 
 // ammswap module event types
 const (
-	AttributeValueCategory = ModuleName
+	EventTypeSwap = "swap_token"
+
+	AttributeKeyPool        = "pool"
+	AttributeKeyAddress     = "address"
+	AttributeKeySoldToken   = "sold_token_amount"
+	AttributeKeyBoughtToken = "bought_token_amount"
+	AttributeKeyBasePooled  = "base_pooled_coin"
+	AttributeKeyQuotePooled = "quote_pooled_coin"
+	AttributeValueCategory  = ModuleName
 )
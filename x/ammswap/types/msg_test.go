@@ -260,3 +260,30 @@ func TestMsgTokenToTokenInvalid(t *testing.T) {
 		testCode(t, err, testCase.exceptResultCode)
 	}
 }
+
+func TestMsgTokenToTokenWithPath(t *testing.T) {
+	addr, err := hex.DecodeString(addrStr)
+	require.Nil(t, err)
+	minBoughtTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken, sdk.NewDec(1))
+	deadLine := time.Now().Unix()
+	soldTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken3, sdk.NewDec(2))
+
+	tests := []struct {
+		testCase         string
+		path             []string
+		exceptResultCode uint32
+	}{
+		{"success", []string{TestQuotePooledToken, TestBasePooledToken2}, sdk.CodeOK},
+		{"repeated token", []string{TestQuotePooledToken, TestBasePooledToken3}, sdk.CodeUnknownRequest},
+		{"invalid token", []string{"1aaa"}, sdk.CodeUnknownRequest},
+		{"too many hops", []string{TestQuotePooledToken, TestBasePooledToken2, "eeb", "ffb"}, sdk.CodeUnknownRequest},
+	}
+	for _, testCase := range tests {
+		msg := NewMsgTokenToTokenWithPath(soldTokenAmount, minBoughtTokenAmount, testCase.path, deadLine, addr, addr)
+		err := msg.ValidateBasic()
+		testCode(t, err, testCase.exceptResultCode)
+	}
+
+	msg := NewMsgTokenToTokenWithPath(soldTokenAmount, minBoughtTokenAmount, []string{TestQuotePooledToken}, deadLine, addr, addr)
+	require.Equal(t, []string{TestBasePooledToken3, TestQuotePooledToken, TestBasePooledToken}, msg.GetRoute())
+}
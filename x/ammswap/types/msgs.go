@@ -2,6 +2,7 @@ package types
 
 import (
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 )
 
 // PoolSwap message types and routes
@@ -201,6 +202,7 @@ type MsgTokenToToken struct {
 	Deadline             int64          `json:"deadline"`                // Time after which this transaction can no longer be executed.
 	Recipient            sdk.AccAddress `json:"recipient"`               // Recipient address,transfer Tokens to recipient.default recipient is sender.
 	Sender               sdk.AccAddress `json:"sender"`                  // Sender
+	Path                 []string       `json:"path,omitempty"`          // Intermediate tokens of the route, empty means swapping directly or via the native token
 }
 
 // NewMsgTokenToToken is a constructor function for MsgTokenOKTSwap
@@ -216,6 +218,15 @@ func NewMsgTokenToToken(
 	}
 }
 
+// NewMsgTokenToTokenWithPath is a constructor function for MsgTokenToToken routed through the intermediate tokens of path
+func NewMsgTokenToTokenWithPath(
+	soldTokenAmount, minBoughtTokenAmount sdk.SysCoin, path []string, deadline int64, recipient, sender sdk.AccAddress,
+) MsgTokenToToken {
+	msg := NewMsgTokenToToken(soldTokenAmount, minBoughtTokenAmount, deadline, recipient, sender)
+	msg.Path = path
+	return msg
+}
+
 // Route should return the name of the module
 func (msg MsgTokenToToken) Route() string { return RouterKey }
 
@@ -248,6 +259,17 @@ func (msg MsgTokenToToken) ValidateBasic() sdk.Error {
 	if err != nil {
 		return err
 	}
+	if len(msg.Path) == 0 {
+		return nil
+	}
+	return ValidateSwapRoute(msg.GetRoute())
+}
+
+// ValidateSwapPath rejects the multi-hop swap path, which is enabled since the earth height
+func (msg MsgTokenToToken) ValidateSwapPath(height int64) sdk.Error {
+	if len(msg.Path) > 0 && !tmtypes.HigherThanEarth(height) {
+		return ErrInvalidSwapPath("multi-hop swap is not supported before the earth height")
+	}
 	return nil
 }
 
@@ -261,6 +283,14 @@ func (msg MsgTokenToToken) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Sender}
 }
 
+// GetRoute returns all the tokens the sold token is swapped through, from the sold token to the bought token
+func (msg MsgTokenToToken) GetRoute() []string {
+	route := make([]string, 0, len(msg.Path)+2)
+	route = append(route, msg.SoldTokenAmount.Denom)
+	route = append(route, msg.Path...)
+	return append(route, msg.MinBoughtTokenAmount.Denom)
+}
+
 // GetSwapTokenPair defines token pair
 func (msg MsgTokenToToken) GetSwapTokenPairName() string {
 	return GetSwapTokenPairName(msg.MinBoughtTokenAmount.Denom, msg.SoldTokenAmount.Denom)
@@ -40,6 +40,31 @@ type SwapBuyInfo struct {
 	Route       string  `json:"route"`
 }
 
+// nolint
+type QuerySwapRouteParams struct {
+	SellTokenAmount string   `json:"sell_token_amount"`
+	BuyToken        string   `json:"buy_token"`
+	Path            []string `json:"path,omitempty"` // quote the given intermediate tokens instead of searching the best route
+}
+
+// NewQuerySwapRouteParams creates a new instance of QuerySwapRouteParams
+func NewQuerySwapRouteParams(sellTokenAmount string, buyToken string, path []string) QuerySwapRouteParams {
+	return QuerySwapRouteParams{
+		SellTokenAmount: sellTokenAmount,
+		BuyToken:        buyToken,
+		Path:            path,
+	}
+}
+
+// SwapRouteInfo is the quote of swapping through the route
+type SwapRouteInfo struct {
+	Route     []string      `json:"route"`      // tokens from the sold token to the bought token
+	Path      []string      `json:"path"`       // intermediate tokens to be set in MsgTokenToToken
+	Amounts   []sdk.SysCoin `json:"amounts"`    // amount bought by every hop
+	BuyAmount sdk.Dec       `json:"buy_amount"` // amount bought by the last hop
+	Price     sdk.Dec       `json:"price"`
+}
+
 type SwapAddInfo struct {
 	BaseTokenAmount sdk.Dec `json:"base_token_amount"`
 	PoolShare       sdk.Dec `json:"pool_share"`
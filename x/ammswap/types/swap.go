@@ -12,6 +12,9 @@ import (
 // PoolTokenPrefix defines pool token prefix name
 const PoolTokenPrefix = "ammswap_"
 
+// MaxSwapRouteHops defines the max number of swap token pairs a route goes through
+const MaxSwapRouteHops = 4
+
 // SwapTokenPair defines token pair exchange
 type SwapTokenPair struct {
 	QuotePooledCoin sdk.SysCoin `json:"quote_pooled_coin"` // The volume of quote token in the token pair exchange pool
@@ -104,6 +107,27 @@ func ValidateSwapAmountName(amountName string) error {
 	return nil
 }
 
+// ValidateSwapRoute checks that the route goes through distinct valid tokens within MaxSwapRouteHops
+func ValidateSwapRoute(route []string) error {
+	if len(route) < 2 {
+		return ErrInvalidSwapPath("at least two tokens are required")
+	}
+	if len(route)-1 > MaxSwapRouteHops {
+		return ErrInvalidSwapPath(fmt.Sprintf("the route goes through more than %d token pairs", MaxSwapRouteHops))
+	}
+	visited := make(map[string]bool, len(route))
+	for _, token := range route {
+		if err := ValidateSwapAmountName(token); err != nil {
+			return err
+		}
+		if visited[token] {
+			return ErrInvalidSwapPath(fmt.Sprintf("token %s is repeated", token))
+		}
+		visited[token] = true
+	}
+	return nil
+}
+
 func GetPoolTokenName(token1, token2 string) string {
 	return PoolTokenPrefix + GetSwapTokenPairName(token1, token2)
 }
@@ -17,6 +17,12 @@ func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k keeper.Keeper)
 		previousTotalPower += voteInfo.Validator.Power
 	}
 
+	// the rewards records of the validators and the shares created before are initialized at the earth milestone,
+	// before the delegators are rewarded
+	if earthHeight := tmtypes.GetEarthHeight(); earthHeight != 0 && ctx.BlockHeight() == earthHeight {
+		k.InitializeLegacyRewards(ctx)
+	}
+
 	// TODO this is Tendermint-dependent
 	// ref https://github.com/cosmos/cosmos-sdk/issues/3095
 	if ctx.BlockHeight() > tmtypes.GetStartBlockHeight()+1 {
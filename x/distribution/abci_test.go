@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	"github.com/okex/exchain/x/distribution/keeper"
 	"github.com/stretchr/testify/require"
 )
@@ -24,3 +25,18 @@ func TestBeginBlocker(t *testing.T) {
 		require.Equal(t, k.GetPreviousProposerConsAddr(ctx), valConsAddrs[index])
 	}
 }
+
+func TestBeginBlockerAtEarth(t *testing.T) {
+	valOpAddrs, _, valConsAddrs := keeper.GetTestAddrs()
+	ctx, _, k, _, _ := keeper.CreateTestInputDefault(t, false, 1000)
+	tmtypes.UnittestOnlySetMilestoneEarthHeight(2)
+	defer tmtypes.UnittestOnlySetMilestoneEarthHeight(0)
+
+	// the rewards records of the validators created before are initialized at the earth milestone
+	for i := int64(1); i <= 2; i++ {
+		ctx = ctx.WithBlockHeight(i)
+		req := abci.RequestBeginBlock{Header: abci.Header{Height: i, ProposerAddress: valConsAddrs[0].Bytes()}}
+		BeginBlocker(ctx, req, k)
+		require.Equal(t, i == 2, k.HasValidatorCurrentRewards(ctx, valOpAddrs[0]))
+	}
+}
@@ -20,6 +20,8 @@ const (
 	QueryParams              = types.QueryParams
 	QueryValidatorCommission = types.QueryValidatorCommission
 	QueryWithdrawAddr        = types.QueryWithdrawAddr
+	QueryDelegationRewards   = types.QueryDelegationRewards
+	QueryDelegatorRewards    = types.QueryDelegatorRewards
 	ParamWithdrawAddrEnabled = types.ParamWithdrawAddrEnabled
 	DefaultParamspace        = types.DefaultParamspace
 )
@@ -43,8 +45,12 @@ var (
 	ValidateGenesis                          = types.ValidateGenesis
 	NewMsgSetWithdrawAddress                 = types.NewMsgSetWithdrawAddress
 	NewMsgWithdrawValidatorCommission        = types.NewMsgWithdrawValidatorCommission
+	NewMsgWithdrawDelegatorReward            = types.NewMsgWithdrawDelegatorReward
+	NewMsgWithdrawDelegatorAllRewards        = types.NewMsgWithdrawDelegatorAllRewards
 	NewQueryValidatorCommissionParams        = types.NewQueryValidatorCommissionParams
 	NewQueryDelegatorWithdrawAddrParams      = types.NewQueryDelegatorWithdrawAddrParams
+	NewQueryDelegationRewardsParams          = types.NewQueryDelegationRewardsParams
+	NewQueryDelegatorParams                  = types.NewQueryDelegatorParams
 	InitialValidatorAccumulatedCommission    = types.InitialValidatorAccumulatedCommission
 
 	// variable aliases
@@ -71,7 +77,13 @@ type (
 	GenesisState                         = types.GenesisState
 	MsgSetWithdrawAddress                = types.MsgSetWithdrawAddress
 	MsgWithdrawValidatorCommission       = types.MsgWithdrawValidatorCommission
+	MsgWithdrawDelegatorReward           = types.MsgWithdrawDelegatorReward
+	MsgWithdrawDelegatorAllRewards       = types.MsgWithdrawDelegatorAllRewards
 	QueryValidatorCommissionParams       = types.QueryValidatorCommissionParams
 	QueryDelegatorWithdrawAddrParams     = types.QueryDelegatorWithdrawAddrParams
 	ValidatorAccumulatedCommission       = types.ValidatorAccumulatedCommission
+	ValidatorOutstandingRewards          = types.ValidatorOutstandingRewards
+	ValidatorHistoricalRewards           = types.ValidatorHistoricalRewards
+	ValidatorCurrentRewards              = types.ValidatorCurrentRewards
+	DelegatorStartingInfo                = types.DelegatorStartingInfo
 )
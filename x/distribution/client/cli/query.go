@@ -32,6 +32,7 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 		GetCmdQueryParams(queryRoute, cdc),
 		GetCmdQueryValidatorCommission(queryRoute, cdc),
 		GetCmdQueryCommunityPool(queryRoute, cdc),
+		GetCmdQueryDelegatorRewards(queryRoute, cdc),
 	)...)
 
 	return distQueryCmd
@@ -120,3 +121,61 @@ $ %s query distr community-pool
 		},
 	}
 }
+
+// GetCmdQueryDelegatorRewards implements the query delegator rewards command.
+func GetCmdQueryDelegatorRewards(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rewards [delegator-addr] [validator-addr]",
+		Args:  cobra.RangeArgs(1, 2),
+		Short: "Query all distribution delegator rewards or rewards from a particular validator",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query all rewards earned by a delegator, optionally restrict to rewards from a single validator.
+
+Example:
+$ %s query distr rewards ex1cftp8q8g4aa65nw9s5trwexe77d9t6cr8ndu02
+$ %s query distr rewards ex1cftp8q8g4aa65nw9s5trwexe77d9t6cr8ndu02 exvaloper1alq9na49n9yycysh889rl90g9nhe58lcqkfpfg
+`,
+				version.ClientName, version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			delAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			// query for rewards from a particular delegation
+			if len(args) == 2 {
+				valAddr, err := sdk.ValAddressFromBech32(args[1])
+				if err != nil {
+					return err
+				}
+
+				res, _, err := common.QueryDelegationRewards(cliCtx, queryRoute, delAddr, valAddr)
+				if err != nil {
+					return err
+				}
+
+				var result sdk.SysCoins
+				if err := cdc.UnmarshalJSON(res, &result); err != nil {
+					return fmt.Errorf("failed to unmarshal response: %w", err)
+				}
+				return cliCtx.PrintOutput(result)
+			}
+
+			// query for delegator total rewards
+			res, _, err := common.QueryDelegatorTotalRewards(cliCtx, queryRoute, delAddr)
+			if err != nil {
+				return err
+			}
+
+			var result types.QueryDelegatorTotalRewardsResponse
+			if err := cdc.UnmarshalJSON(res, &result); err != nil {
+				return fmt.Errorf("failed to unmarshal response: %w", err)
+			}
+			return cliCtx.PrintOutput(result)
+		},
+	}
+}
@@ -34,6 +34,8 @@ func GetTxCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 	distTxCmd.AddCommand(flags.PostCommands(
 		GetCmdWithdrawRewards(cdc),
 		GetCmdSetWithdrawAddr(cdc),
+		GetCmdWithdrawDelegatorReward(cdc),
+		GetCmdWithdrawDelegatorAllRewards(cdc),
 	)...)
 
 	return distTxCmd
@@ -104,6 +106,63 @@ $ %s tx distr withdraw-rewards exvaloper1alq9na49n9yycysh889rl90g9nhe58lcqkfpfg
 	return cmd
 }
 
+// GetCmdWithdrawDelegatorReward command to withdraw the rewards of the shares added to a validator
+func GetCmdWithdrawDelegatorReward(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "withdraw-delegator-reward [validator-addr]",
+		Short: "withdraw the rewards of the shares added to a validator",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Withdraw the rewards of the shares added by the delegator to a validator.
+
+Example:
+$ %s tx distr withdraw-delegator-reward exvaloper1alq9na49n9yycysh889rl90g9nhe58lcqkfpfg --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			valAddr, err := sdk.ValAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgWithdrawDelegatorReward(cliCtx.GetFromAddress(), valAddr)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdWithdrawDelegatorAllRewards command to withdraw the rewards of the shares added to all the validators
+func GetCmdWithdrawDelegatorAllRewards(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "withdraw-all-rewards",
+		Short: "withdraw the rewards of the shares added to all the validators",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Withdraw the rewards of the shares added by the delegator to all the validators.
+
+Example:
+$ %s tx distr withdraw-all-rewards --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgWithdrawDelegatorAllRewards(cliCtx.GetFromAddress())
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
 // GetCmdSubmitProposal implements the command to submit a community-pool-spend proposal
 func GetCmdSubmitProposal(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -14,6 +14,7 @@ func QueryParams(cliCtx context.CLIContext, queryRoute string) (params types.Par
 	route := fmt.Sprintf("custom/%s/params/%s", queryRoute, types.ParamCommunityTax)
 	var communityTax sdk.Dec
 	var withdrawAddrEnabled bool
+	var commissionRate sdk.Dec
 	bytes, _, err := cliCtx.QueryWithData(route, []byte{})
 	if err != nil {
 		return
@@ -27,7 +28,14 @@ func QueryParams(cliCtx context.CLIContext, queryRoute string) (params types.Par
 	}
 	cliCtx.Codec.MustUnmarshalJSON(bytes, &withdrawAddrEnabled)
 
-	return types.NewParams(communityTax, withdrawAddrEnabled), err
+	route = fmt.Sprintf("custom/%s/params/%s", queryRoute, types.ParamCommissionRate)
+	bytes, _, err = cliCtx.QueryWithData(route, []byte{})
+	if err != nil {
+		return
+	}
+	cliCtx.Codec.MustUnmarshalJSON(bytes, &commissionRate)
+
+	return types.NewParams(communityTax, withdrawAddrEnabled, commissionRate), err
 }
 
 // QueryValidatorCommission returns a validator's commission.
@@ -40,6 +48,24 @@ func QueryValidatorCommission(cliCtx context.CLIContext, queryRoute string, vali
 	return res, err
 }
 
+// QueryDelegationRewards returns the rewards of the shares added by a delegator to a validator
+func QueryDelegationRewards(cliCtx context.CLIContext, queryRoute string, delAddr sdk.AccAddress,
+	valAddr sdk.ValAddress) ([]byte, int64, error) {
+	return cliCtx.QueryWithData(
+		fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryDelegationRewards),
+		cliCtx.Codec.MustMarshalJSON(types.NewQueryDelegationRewardsParams(delAddr, valAddr)),
+	)
+}
+
+// QueryDelegatorTotalRewards returns the rewards of the shares added by a delegator to all the validators
+func QueryDelegatorTotalRewards(cliCtx context.CLIContext, queryRoute string, delAddr sdk.AccAddress) (
+	[]byte, int64, error) {
+	return cliCtx.QueryWithData(
+		fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryDelegatorRewards),
+		cliCtx.Codec.MustMarshalJSON(types.NewQueryDelegatorParams(delAddr)),
+	)
+}
+
 // WithdrawValidatorRewardsAndCommission builds a two-message message slice to be
 // used to withdraw both validation's commission and self-delegation reward.
 func WithdrawValidatorRewardsAndCommission(validatorAddr sdk.ValAddress) ([]sdk.Msg, error) {
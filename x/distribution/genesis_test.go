@@ -37,7 +37,8 @@ func TestInitGenesis(t *testing.T) {
 		dwis[i].DelegatorAddress, dwis[i].WithdrawAddress = keeper.TestAddrs[i*2], keeper.TestAddrs[i*2+1]
 	}
 
-	genesisState := NewGenesisState(types.DefaultParams(), types.InitialFeePool(), dwis, valConsAddrs[0], accs)
+	genesisState := NewGenesisState(types.DefaultParams(), types.InitialFeePool(), dwis, valConsAddrs[0], accs,
+		nil, nil, nil, nil)
 	InitGenesis(ctx, k, supplyKeeper, genesisState)
 	require.True(t, k.GetFeePoolCommunityCoins(ctx).IsZero())
 	require.Equal(t, genesisState.Params.CommunityTax, k.GetCommunityTax(ctx))
@@ -5,6 +5,7 @@ import (
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 
 	"github.com/okex/exchain/x/distribution/types"
 	"github.com/okex/exchain/x/staking/exported"
@@ -144,16 +145,60 @@ func (k Keeper) allocateByShares(ctx sdk.Context, rewards sdk.SysCoins) sdk.SysC
 
 // AllocateTokensToValidator allocate tokens to a particular validator, splitting according to commissions
 func (k Keeper) AllocateTokensToValidator(ctx sdk.Context, val exported.ValidatorI, tokens sdk.SysCoins) {
-	// split tokens between validator and delegators according to commissions
-	// commissions is always 1.0, so tokens.MulDec(val.GetCommission()) = tokens
-	// only update current commissions
-	commission := k.GetValidatorAccumulatedCommission(ctx, val.GetOperator())
-	commission = commission.Add(tokens...)
-	k.SetValidatorAccumulatedCommission(ctx, val.GetOperator(), commission)
+	if !tmtypes.HigherThanEarth(ctx.BlockHeight()) {
+		// the delegators are rewarded from the earth milestone, all the tokens are the commission before
+		commission := k.GetValidatorAccumulatedCommission(ctx, val.GetOperator())
+		commission = commission.Add(tokens...)
+		k.SetValidatorAccumulatedCommission(ctx, val.GetOperator(), commission)
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeCommission,
+				sdk.NewAttribute(sdk.AttributeKeyAmount, tokens.String()),
+				sdk.NewAttribute(types.AttributeKeyValidator, val.GetOperator().String()),
+			),
+		)
+		return
+	}
+
+	// split tokens between validator and delegators according to the commission rate,
+	// the part of the share of the min self delegation belongs to the validator as well
+	var shared sdk.SysCoins
+	if shares := delegatorShares(val); shares.IsPositive() {
+		fraction := sdk.OneDec().Sub(k.GetCommissionRate(ctx)).Mul(shares).QuoTruncate(val.GetDelegatorShares())
+		shared = tokens.MulDecTruncate(fraction)
+	}
+	commission := tokens.Sub(shared)
+
+	// update current commissions
+	if !commission.IsZero() {
+		currentCommission := k.GetValidatorAccumulatedCommission(ctx, val.GetOperator())
+		currentCommission = currentCommission.Add(commission...)
+		k.SetValidatorAccumulatedCommission(ctx, val.GetOperator(), currentCommission)
+	}
 	ctx.EventManager().EmitEvent(
 		sdk.NewEvent(
 			types.EventTypeCommission,
-			sdk.NewAttribute(sdk.AttributeKeyAmount, tokens.String()),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, commission.String()),
+			sdk.NewAttribute(types.AttributeKeyValidator, val.GetOperator().String()),
+		),
+	)
+	if shared.IsZero() {
+		return
+	}
+
+	// update current rewards of the delegators
+	currentRewards := k.GetValidatorCurrentRewards(ctx, val.GetOperator())
+	currentRewards.Rewards = currentRewards.Rewards.Add(shared...)
+	k.SetValidatorCurrentRewards(ctx, val.GetOperator(), currentRewards)
+
+	// update outstanding rewards
+	outstanding := k.GetValidatorOutstandingRewards(ctx, val.GetOperator())
+	outstanding = outstanding.Add(shared...)
+	k.SetValidatorOutstandingRewards(ctx, val.GetOperator(), outstanding)
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeRewards,
+			sdk.NewAttribute(sdk.AttributeKeyAmount, shared.String()),
 			sdk.NewAttribute(types.AttributeKeyValidator, val.GetOperator().String()),
 		),
 	)
@@ -0,0 +1,162 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+
+	"github.com/okex/exchain/x/distribution/types"
+	"github.com/okex/exchain/x/staking/exported"
+)
+
+// initialize starting info for the shares added by a delegator to a validator
+func (k Keeper) initializeDelegation(ctx sdk.Context, val sdk.ValAddress, del sdk.AccAddress) {
+	// period has already been incremented - we want to store the period ended by this delegation action
+	previousPeriod := k.GetValidatorCurrentRewards(ctx, val).Period - 1
+
+	// increment reference count for the period we're going to track
+	k.incrementReferenceCount(ctx, val, previousPeriod)
+
+	// the shares recorded in the staking module are the stake of the delegator
+	shares, found := k.stakingKeeper.GetShares(ctx, del, val)
+	if !found {
+		panic(fmt.Sprintf("no shares added by %s to validator %s", del, val))
+	}
+	k.SetDelegatorStartingInfo(ctx, val, del,
+		types.NewDelegatorStartingInfo(previousPeriod, shares, uint64(ctx.BlockHeight())))
+}
+
+// calculate the rewards accrued by the shares between two periods
+func (k Keeper) calculateDelegationRewardsBetween(ctx sdk.Context, val sdk.ValAddress,
+	startingPeriod, endingPeriod uint64, stake sdk.Dec) (rewards sdk.SysCoins) {
+	// sanity check
+	if startingPeriod > endingPeriod {
+		panic("startingPeriod cannot be greater than endingPeriod")
+	}
+
+	// sanity check
+	if stake.IsNegative() {
+		panic("stake should not be negative")
+	}
+
+	// return stake * (ending - starting)
+	starting := k.GetValidatorHistoricalRewards(ctx, val, startingPeriod)
+	ending := k.GetValidatorHistoricalRewards(ctx, val, endingPeriod)
+	difference := ending.CumulativeRewardRatio.Sub(starting.CumulativeRewardRatio)
+	if difference.IsAnyNegative() {
+		panic("negative rewards should not be possible")
+	}
+	// note: necessary to truncate so we don't allow withdrawing more rewards than owed
+	return difference.MulDecTruncate(stake)
+}
+
+// calculateDelegationRewards calculates the total rewards accrued by the shares added by a delegator to a validator
+// until the ending period
+func (k Keeper) calculateDelegationRewards(ctx sdk.Context, val exported.ValidatorI, del sdk.AccAddress,
+	endingPeriod uint64) (rewards sdk.SysCoins) {
+	// fetch starting info for delegation
+	startingInfo, found := k.GetDelegatorStartingInfo(ctx, val.GetOperator(), del)
+	if !found {
+		return sdk.SysCoins{}
+	}
+
+	// the shares are recorded again every time they change, so they stay the same within the periods
+	return k.calculateDelegationRewardsBetween(ctx, val.GetOperator(), startingInfo.PreviousPeriod, endingPeriod,
+		startingInfo.Stake)
+}
+
+// withdrawDelegationRewards sends the rewards of the delegator on the validator to its withdraw address,
+// and removes the starting info of the delegation
+func (k Keeper) withdrawDelegationRewards(ctx sdk.Context, val exported.ValidatorI, del sdk.AccAddress) (
+	sdk.SysCoins, error) {
+	// check existence of delegator starting info
+	if !k.HasDelegatorStartingInfo(ctx, val.GetOperator(), del) {
+		return nil, types.ErrEmptyDelegationDistInfo()
+	}
+
+	// end current period and calculate rewards
+	endingPeriod := k.IncrementValidatorPeriod(ctx, val)
+	rewards := k.calculateDelegationRewards(ctx, val, del, endingPeriod)
+	outstanding := k.GetValidatorOutstandingRewards(ctx, val.GetOperator())
+
+	// defensive edge case may happen on the very final digits
+	// of the decCoins due to operation order of the distribution mechanism.
+	rewards = rewards.Intersect(outstanding)
+
+	// add coins to user account
+	if !rewards.IsZero() {
+		withdrawAddr := k.GetDelegatorWithdrawAddr(ctx, del)
+		err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, withdrawAddr, rewards)
+		if err != nil {
+			return nil, types.ErrSendCoinsFromModuleToAccountFailed()
+		}
+	}
+
+	// update the outstanding rewards
+	k.SetValidatorOutstandingRewards(ctx, val.GetOperator(), outstanding.Sub(rewards))
+
+	// decrement reference count of starting period
+	startingInfo, _ := k.GetDelegatorStartingInfo(ctx, val.GetOperator(), del)
+	startingPeriod := startingInfo.PreviousPeriod
+	k.decrementReferenceCount(ctx, val.GetOperator(), startingPeriod)
+
+	// remove delegator starting info
+	k.DeleteDelegatorStartingInfo(ctx, val.GetOperator(), del)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeWithdrawRewards,
+			sdk.NewAttribute(sdk.AttributeKeyAmount, rewards.String()),
+			sdk.NewAttribute(types.AttributeKeyValidator, val.GetOperator().String()),
+			sdk.NewAttribute(types.AttributeKeyDelegator, del.String()),
+		),
+	)
+
+	return rewards, nil
+}
+
+// WithdrawDelegationRewards withdraws the rewards of the shares added by the delegator to the validator
+func (k Keeper) WithdrawDelegationRewards(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) (
+	sdk.SysCoins, error) {
+	val := k.stakingKeeper.Validator(ctx, valAddr)
+	if val == nil {
+		return nil, types.ErrEmptyDelegationDistInfo()
+	}
+
+	rewards, err := k.withdrawDelegationRewards(ctx, val, delAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	// reinitialize the delegation
+	k.initializeDelegation(ctx, valAddr, delAddr)
+	return rewards, nil
+}
+
+// WithdrawDelegationAllRewards withdraws the rewards of the shares added by the delegator to all the validators
+func (k Keeper) WithdrawDelegationAllRewards(ctx sdk.Context, delAddr sdk.AccAddress) (sdk.SysCoins, error) {
+	delegator := k.stakingKeeper.Delegator(ctx, delAddr)
+	if delegator == nil {
+		return nil, types.ErrEmptyDelegationDistInfo()
+	}
+
+	var total sdk.SysCoins
+	withdrawn := false
+	for _, valAddr := range delegator.GetShareAddedValidatorAddresses() {
+		if !k.HasDelegatorStartingInfo(ctx, valAddr, delAddr) {
+			// the validator has been removed
+			continue
+		}
+		rewards, err := k.WithdrawDelegationRewards(ctx, delAddr, valAddr)
+		if err != nil {
+			return nil, err
+		}
+		total = total.Add(rewards...)
+		withdrawn = true
+	}
+
+	if !withdrawn {
+		return nil, types.ErrEmptyDelegationDistInfo()
+	}
+	return total, nil
+}
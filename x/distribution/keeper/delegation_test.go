@@ -0,0 +1,195 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+
+	"github.com/okex/exchain/x/distribution/types"
+	"github.com/okex/exchain/x/staking"
+)
+
+// the shares are weighted by the block time
+var testBlockTime = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// addTestShares deposits the tokens of the delegators and adds their shares to the validator
+func addTestShares(t *testing.T, ctx sdk.Context, sk staking.Keeper, valAddr sdk.ValAddress,
+	delAddrs []sdk.AccAddress, amounts []int64) {
+	h := staking.NewHandler(sk)
+	for i, delAddr := range delAddrs {
+		_, err := h(ctx, staking.NewMsgDeposit(delAddr, NewTestSysCoin(amounts[i], 0)))
+		require.NoError(t, err)
+		_, err = h(ctx, staking.NewMsgAddShares(delAddr, []sdk.ValAddress{valAddr}))
+		require.NoError(t, err)
+	}
+}
+
+// enterTestEarth moves the context to the earth milestone and initializes the rewards records of the validators
+// created before, as the begin blocker does
+func enterTestEarth(t *testing.T, ctx sdk.Context, k Keeper) sdk.Context {
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	tmtypes.UnittestOnlySetMilestoneEarthHeight(ctx.BlockHeight())
+	t.Cleanup(func() { tmtypes.UnittestOnlySetMilestoneEarthHeight(0) })
+	k.InitializeLegacyRewards(ctx)
+	return ctx
+}
+
+// allocateTestTokens allocates the tokens to the validator, which are held by the module account
+func allocateTestTokens(t *testing.T, ctx sdk.Context, ak auth.AccountKeeper, k Keeper, valAddr sdk.ValAddress,
+	tokens sdk.SysCoins) {
+	acc := ak.GetAccount(ctx, k.supplyKeeper.GetModuleAddress(types.ModuleName))
+	require.NoError(t, acc.SetCoins(acc.GetCoins().Add(tokens...)))
+	ak.SetAccount(ctx, acc)
+	k.AllocateTokensToValidator(ctx, k.stakingKeeper.Validator(ctx, valAddr), tokens)
+}
+
+func TestDelegationRewards(t *testing.T) {
+	ctx, ak, k, sk, _ := CreateTestInputDefault(t, false, 1000)
+	ctx = enterTestEarth(t, ctx.WithBlockTime(testBlockTime), k)
+	k.SetCommissionRate(ctx, sdk.NewDecWithPrec(5, 1))
+	addTestShares(t, ctx, sk, valOpAddr1, []sdk.AccAddress{delAddr1, delAddr2}, []int64{100, 300})
+	shares1, found := sk.GetShares(ctx, delAddr1, valOpAddr1)
+	require.True(t, found)
+	shares2, found := sk.GetShares(ctx, delAddr2, valOpAddr1)
+	require.True(t, found)
+
+	// the validator keeps the commission and the part of the share of its min self delegation
+	tokens := NewTestSysCoins(10, 0)
+	allocateTestTokens(t, ctx, ak, k, valOpAddr1, tokens)
+	val := sk.Validator(ctx, valOpAddr1)
+	delShares := shares1.Add(shares2)
+	require.Equal(t, val.GetDelegatorShares(), delShares.Add(sdk.OneDec()))
+	shared := tokens.MulDecTruncate(sdk.NewDecWithPrec(5, 1).Mul(delShares).QuoTruncate(val.GetDelegatorShares()))
+	require.Equal(t, tokens.Sub(shared), k.GetValidatorAccumulatedCommission(ctx, valOpAddr1))
+	require.Equal(t, shared, k.GetValidatorOutstandingRewards(ctx, valOpAddr1))
+
+	// query the rewards
+	querier := NewQuerier(k)
+	bz, err := querier(ctx, []string{types.QueryDelegationRewards}, abci.RequestQuery{
+		Data: k.cdc.MustMarshalJSON(types.NewQueryDelegationRewardsParams(delAddr1, valOpAddr1)),
+	})
+	require.NoError(t, err)
+	var queried sdk.SysCoins
+	k.cdc.MustUnmarshalJSON(bz, &queried)
+	expected := shared.QuoDecTruncate(delShares).MulDecTruncate(shares1)
+	require.Equal(t, expected, queried)
+
+	// withdraw the rewards from the validator
+	balance := ak.GetAccount(ctx, delAddr1).GetCoins()
+	rewards, err := k.WithdrawDelegationRewards(ctx, delAddr1, valOpAddr1)
+	require.NoError(t, err)
+	require.Equal(t, expected, rewards)
+	require.Equal(t, balance.Add(rewards...), ak.GetAccount(ctx, delAddr1).GetCoins())
+
+	// nothing more to withdraw
+	rewards, err = k.WithdrawDelegationRewards(ctx, delAddr1, valOpAddr1)
+	require.NoError(t, err)
+	require.True(t, rewards.IsZero())
+
+	// withdraw all the rewards of the other delegator
+	rewards, err = k.WithdrawDelegationAllRewards(ctx, delAddr2)
+	require.NoError(t, err)
+	require.Equal(t, shared.QuoDecTruncate(delShares).MulDecTruncate(shares2), rewards)
+
+	// no shares were added by the delegator
+	_, err = k.WithdrawDelegationAllRewards(ctx, delAddr3)
+	require.Error(t, err)
+	_, err = k.WithdrawDelegationRewards(ctx, delAddr1, valOpAddr2)
+	require.Error(t, err)
+
+	_, broken := ModuleAccountInvariant(k)(ctx)
+	require.False(t, broken)
+}
+
+func TestDelegationRewardsSharesModified(t *testing.T) {
+	ctx, ak, k, sk, _ := CreateTestInputDefault(t, false, 1000)
+	ctx = enterTestEarth(t, ctx.WithBlockTime(testBlockTime), k)
+	k.SetCommissionRate(ctx, sdk.ZeroDec())
+	addTestShares(t, ctx, sk, valOpAddr1, []sdk.AccAddress{delAddr1}, []int64{100})
+	shares, found := sk.GetShares(ctx, delAddr1, valOpAddr1)
+	require.True(t, found)
+	allocateTestTokens(t, ctx, ak, k, valOpAddr1, NewTestSysCoins(10, 0))
+	// the rewards are truncated by the ratio to the shares
+	expected := k.GetValidatorOutstandingRewards(ctx, valOpAddr1).QuoDecTruncate(shares).MulDecTruncate(shares)
+	require.False(t, expected.IsZero())
+
+	// the rewards are withdrawn before the shares change
+	balance := ak.GetAccount(ctx, delAddr1).GetCoins()
+	_, err := staking.NewHandler(sk)(ctx, staking.NewMsgDeposit(delAddr1, NewTestSysCoin(100, 0)))
+	require.NoError(t, err)
+	require.Equal(t, balance.Sub(NewTestSysCoins(100, 0)).Add(expected...), ak.GetAccount(ctx, delAddr1).GetCoins())
+
+	// the starting info is reset with the new shares
+	shares, found = sk.GetShares(ctx, delAddr1, valOpAddr1)
+	require.True(t, found)
+	info, found := k.GetDelegatorStartingInfo(ctx, valOpAddr1, delAddr1)
+	require.True(t, found)
+	require.Equal(t, shares, info.Stake)
+
+	// the rewards are withdrawn before the shares are withdrawn from the validator
+	outstanding := k.GetValidatorOutstandingRewards(ctx, valOpAddr1)
+	allocateTestTokens(t, ctx, ak, k, valOpAddr1, NewTestSysCoins(10, 0))
+	expected = k.GetValidatorOutstandingRewards(ctx, valOpAddr1).Sub(outstanding).QuoDecTruncate(shares).MulDecTruncate(shares)
+	balance = ak.GetAccount(ctx, delAddr1).GetCoins()
+	_, err = staking.NewHandler(sk)(ctx, staking.NewMsgAddShares(delAddr1, []sdk.ValAddress{valOpAddr2}))
+	require.NoError(t, err)
+	require.Equal(t, balance.Add(expected...), ak.GetAccount(ctx, delAddr1).GetCoins())
+	require.False(t, k.HasDelegatorStartingInfo(ctx, valOpAddr1, delAddr1))
+	require.True(t, k.HasDelegatorStartingInfo(ctx, valOpAddr2, delAddr1))
+
+	_, broken := ModuleAccountInvariant(k)(ctx)
+	require.False(t, broken)
+}
+
+func TestInitializeLegacyRewards(t *testing.T) {
+	ctx, ak, k, sk, _ := CreateTestInputDefault(t, false, 1000)
+	ctx = ctx.WithBlockTime(testBlockTime)
+	tmtypes.UnittestOnlySetMilestoneEarthHeight(10)
+	defer tmtypes.UnittestOnlySetMilestoneEarthHeight(0)
+
+	// the shares added before the earth milestone have no rewards records, all the tokens are the commission
+	addTestShares(t, ctx, sk, valOpAddr1, []sdk.AccAddress{delAddr1, delAddr2}, []int64{100, 300})
+	allocateTestTokens(t, ctx, ak, k, valOpAddr1, NewTestSysCoins(1, 0))
+	require.False(t, k.HasValidatorCurrentRewards(ctx, valOpAddr1))
+	require.False(t, k.HasDelegatorStartingInfo(ctx, valOpAddr1, delAddr1))
+	require.Equal(t, NewTestSysCoins(1, 0), k.GetValidatorAccumulatedCommission(ctx, valOpAddr1))
+	_, err := k.WithdrawDelegationRewards(ctx, delAddr1, valOpAddr1)
+	require.Error(t, err)
+	// the shares change without the hooks
+	addTestShares(t, ctx, sk, valOpAddr1, []sdk.AccAddress{delAddr1}, []int64{100})
+	require.False(t, k.HasDelegatorStartingInfo(ctx, valOpAddr1, delAddr1))
+
+	ctx = ctx.WithBlockHeight(10)
+	k.InitializeLegacyRewards(ctx)
+	require.True(t, k.HasValidatorCurrentRewards(ctx, valOpAddr1))
+	require.True(t, k.HasDelegatorStartingInfo(ctx, valOpAddr1, delAddr1))
+	require.True(t, k.HasDelegatorStartingInfo(ctx, valOpAddr1, delAddr2))
+	require.Equal(t, NewTestSysCoins(1, 0), k.GetValidatorAccumulatedCommission(ctx, valOpAddr1))
+
+	// the migrated delegations are rewarded
+	k.SetCommissionRate(ctx, sdk.ZeroDec())
+	allocateTestTokens(t, ctx, ak, k, valOpAddr1, NewTestSysCoins(10, 0))
+	rewards1, err := k.WithdrawDelegationRewards(ctx, delAddr1, valOpAddr1)
+	require.NoError(t, err)
+	require.False(t, rewards1.IsZero())
+
+	// the migrated shares are modified, added and withdrawn with the hooks
+	balance := ak.GetAccount(ctx, delAddr2).GetCoins()
+	addTestShares(t, ctx, sk, valOpAddr1, []sdk.AccAddress{delAddr2}, []int64{100})
+	rewards2 := ak.GetAccount(ctx, delAddr2).GetCoins().Sub(balance.Sub(NewTestSysCoins(100, 0)))
+	require.True(t, rewards1.IsAllLT(rewards2))
+	addTestShares(t, ctx, sk, valOpAddr2, []sdk.AccAddress{delAddr3}, []int64{100})
+	_, err = staking.NewHandler(sk)(ctx, staking.NewMsgAddShares(delAddr1, []sdk.ValAddress{valOpAddr2}))
+	require.NoError(t, err)
+	require.False(t, k.HasDelegatorStartingInfo(ctx, valOpAddr1, delAddr1))
+	require.True(t, k.HasDelegatorStartingInfo(ctx, valOpAddr2, delAddr1))
+
+	_, broken := ModuleAccountInvariant(k)(ctx)
+	require.False(t, broken)
+}
@@ -2,6 +2,7 @@ package keeper
 
 import (
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 
 	"github.com/okex/exchain/x/distribution/types"
 	stakingtypes "github.com/okex/exchain/x/staking/types"
@@ -49,6 +50,24 @@ func (h Hooks) AfterValidatorRemoved(ctx sdk.Context, _ sdk.ConsAddress, valAddr
 
 	// remove commission record
 	h.k.deleteValidatorAccumulatedCommission(ctx, valAddr)
+
+	// the rewards of the delegators are recorded from the earth milestone
+	if !tmtypes.HigherThanEarth(ctx.BlockHeight()) {
+		return
+	}
+
+	// the shares have all been withdrawn, the rest of the outstanding rewards goes to the community pool
+	outstanding := h.k.GetValidatorOutstandingRewards(ctx, valAddr)
+	if !outstanding.IsZero() {
+		feePool := h.k.GetFeePool(ctx)
+		feePool.CommunityPool = feePool.CommunityPool.Add(outstanding...)
+		h.k.SetFeePool(ctx, feePool)
+	}
+
+	// remove the records of the rewards of the delegators
+	h.k.deleteValidatorOutstandingRewards(ctx, valAddr)
+	h.k.DeleteValidatorHistoricalRewards(ctx, valAddr)
+	h.k.deleteValidatorCurrentRewards(ctx, valAddr)
 }
 
 // AfterValidatorDestroyed nothing to do
@@ -56,6 +75,34 @@ func (h Hooks) AfterValidatorDestroyed(ctx sdk.Context, consAddr sdk.ConsAddress
 
 }
 
+// BeforeDelegationCreated increments the period of the validator before the shares are added
+func (h Hooks) BeforeDelegationCreated(ctx sdk.Context, _ sdk.AccAddress, valAddr sdk.ValAddress) {
+	if !tmtypes.HigherThanEarth(ctx.BlockHeight()) {
+		return
+	}
+	val := h.k.stakingKeeper.Validator(ctx, valAddr)
+	h.k.IncrementValidatorPeriod(ctx, val)
+}
+
+// BeforeDelegationSharesModified withdraws the rewards of the delegator before its shares change
+func (h Hooks) BeforeDelegationSharesModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	if !tmtypes.HigherThanEarth(ctx.BlockHeight()) {
+		return
+	}
+	val := h.k.stakingKeeper.Validator(ctx, valAddr)
+	if _, err := h.k.withdrawDelegationRewards(ctx, val, delAddr); err != nil {
+		panic(err)
+	}
+}
+
+// AfterDelegationModified initializes the starting info of the delegator with its new shares
+func (h Hooks) AfterDelegationModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	if !tmtypes.HigherThanEarth(ctx.BlockHeight()) {
+		return
+	}
+	h.k.initializeDelegation(ctx, valAddr, delAddr)
+}
+
 // nolint - unused hooks
 func (h Hooks) BeforeValidatorModified(_ sdk.Context, _ sdk.ValAddress)                         {}
 func (h Hooks) AfterValidatorBonded(_ sdk.Context, _ sdk.ConsAddress, _ sdk.ValAddress)         {}
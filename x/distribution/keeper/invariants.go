@@ -69,7 +69,7 @@ func CanWithdrawInvariant(k Keeper) sdk.Invariant {
 }
 
 // ModuleAccountInvariant checks that the coins held by the distr ModuleAccount
-// is consistent with the sum of accumulated commissions
+// is consistent with the sum of accumulated commissions and outstanding rewards
 func ModuleAccountInvariant(k Keeper) sdk.Invariant {
 	return func(ctx sdk.Context) (string, bool) {
 		var accumulatedCommission sdk.SysCoins
@@ -78,12 +78,19 @@ func ModuleAccountInvariant(k Keeper) sdk.Invariant {
 				accumulatedCommission = accumulatedCommission.Add(commission...)
 				return false
 			})
+		var outstanding sdk.SysCoins
+		k.IterateValidatorOutstandingRewards(ctx,
+			func(_ sdk.ValAddress, rewards types.ValidatorOutstandingRewards) (stop bool) {
+				outstanding = outstanding.Add(rewards...)
+				return false
+			})
 		communityPool := k.GetFeePoolCommunityCoins(ctx)
+		expectedCoins := communityPool.Add(accumulatedCommission...).Add(outstanding...)
 		macc := k.GetDistributionAccount(ctx)
-		broken := !macc.GetCoins().IsEqual(communityPool.Add(accumulatedCommission...))
+		broken := !macc.GetCoins().IsEqual(expectedCoins)
 		return sdk.FormatInvariant(types.ModuleName, "ModuleAccount coins",
 			fmt.Sprintf("\texpected distribution ModuleAccount coins:     %s\n"+
 				"\tacutal distribution ModuleAccount coins: %s\n",
-				accumulatedCommission, macc.GetCoins())), broken
+				expectedCoins, macc.GetCoins())), broken
 	}
 }
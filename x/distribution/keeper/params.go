@@ -7,9 +7,10 @@ import (
 )
 
 // GetParams returns the total set of distribution parameters.
+// The params are read one by one, since the commission rate may not have been set on the chains started
+// before the rewards of the delegators
 func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
-	k.paramSpace.GetParamSet(ctx, &params)
-	return params
+	return types.NewParams(k.GetCommunityTax(ctx), k.GetWithdrawAddrEnabled(ctx), k.GetCommissionRate(ctx))
 }
 
 // SetParams sets the distribution parameters to the param space.
@@ -42,3 +43,17 @@ func (k Keeper) GetWithdrawAddrEnabled(ctx sdk.Context) (enabled bool) {
 func (k Keeper) SetWithdrawAddrEnabled(ctx sdk.Context, enabled bool) {
 	k.paramSpace.Set(ctx, types.ParamStoreKeyWithdrawAddrEnabled, &enabled)
 }
+
+// GetCommissionRate returns the current CommissionRate from the global param store,
+// all the rewards belong to the validators if it hasn't been set
+func (k Keeper) GetCommissionRate(ctx sdk.Context) (rate sdk.Dec) {
+	rate = sdk.OneDec()
+	k.paramSpace.GetIfExists(ctx, types.ParamStoreKeyCommissionRate, &rate)
+	return rate
+}
+
+// SetCommissionRate sets the value of commission rate
+// nolint: errcheck
+func (k Keeper) SetCommissionRate(ctx sdk.Context, rate sdk.Dec) {
+	k.paramSpace.Set(ctx, types.ParamStoreKeyCommissionRate, &rate)
+}
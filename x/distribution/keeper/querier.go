@@ -25,6 +25,12 @@ func NewQuerier(k Keeper) sdk.Querier {
 		case types.QueryCommunityPool:
 			return queryCommunityPool(ctx, path[1:], req, k)
 
+		case types.QueryDelegationRewards:
+			return queryDelegationRewards(ctx, path[1:], req, k)
+
+		case types.QueryDelegatorRewards:
+			return queryDelegatorTotalRewards(ctx, path[1:], req, k)
+
 		default:
 			return nil, types.ErrUnknownDistributionQueryType()
 		}
@@ -45,6 +51,12 @@ func queryParams(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper
 			return nil, comm.ErrMarshalJSONFailed(err.Error())
 		}
 		return bz, nil
+	case types.ParamCommissionRate:
+		bz, err := codec.MarshalJSONIndent(k.cdc, k.GetCommissionRate(ctx))
+		if err != nil {
+			return nil, comm.ErrMarshalJSONFailed(err.Error())
+		}
+		return bz, nil
 
 	default:
 		return nil, types.ErrUnknownDistributionParamType()
@@ -103,3 +115,72 @@ func queryCommunityPool(ctx sdk.Context, _ []string, req abci.RequestQuery, k Ke
 
 	return bz, nil
 }
+
+func queryDelegationRewards(ctx sdk.Context, _ []string, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryDelegationRewardsParams
+	err := k.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, comm.ErrUnMarshalJSONFailed(err.Error())
+	}
+
+	// cache-wrap context as to not persist state changes during querying
+	ctx, _ = ctx.CacheContext()
+
+	val := k.stakingKeeper.Validator(ctx, params.ValidatorAddress)
+	if val == nil || !k.HasDelegatorStartingInfo(ctx, params.ValidatorAddress, params.DelegatorAddress) {
+		return nil, types.ErrEmptyDelegationDistInfo()
+	}
+
+	endingPeriod := k.IncrementValidatorPeriod(ctx, val)
+	rewards := k.calculateDelegationRewards(ctx, val, params.DelegatorAddress, endingPeriod)
+	if rewards == nil {
+		rewards = sdk.SysCoins{}
+	}
+
+	bz, err := codec.MarshalJSONIndent(k.cdc, rewards)
+	if err != nil {
+		return nil, comm.ErrMarshalJSONFailed(err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryDelegatorTotalRewards(ctx sdk.Context, _ []string, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryDelegatorParams
+	err := k.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, comm.ErrUnMarshalJSONFailed(err.Error())
+	}
+
+	// cache-wrap context as to not persist state changes during querying
+	ctx, _ = ctx.CacheContext()
+
+	delegator := k.stakingKeeper.Delegator(ctx, params.DelegatorAddress)
+	if delegator == nil {
+		return nil, types.ErrEmptyDelegationDistInfo()
+	}
+
+	total := sdk.SysCoins{}
+	delRewards := make([]types.DelegationDelegatorReward, 0)
+	for _, valAddr := range delegator.GetShareAddedValidatorAddresses() {
+		val := k.stakingKeeper.Validator(ctx, valAddr)
+		if val == nil || !k.HasDelegatorStartingInfo(ctx, valAddr, params.DelegatorAddress) {
+			continue
+		}
+
+		endingPeriod := k.IncrementValidatorPeriod(ctx, val)
+		delReward := k.calculateDelegationRewards(ctx, val, params.DelegatorAddress, endingPeriod)
+		if delReward == nil {
+			delReward = sdk.SysCoins{}
+		}
+		delRewards = append(delRewards, types.NewDelegationDelegatorReward(valAddr, delReward))
+		total = total.Add(delReward...)
+	}
+
+	bz, err := codec.MarshalJSONIndent(k.cdc, types.NewQueryDelegatorTotalRewardsResponse(delRewards, total))
+	if err != nil {
+		return nil, comm.ErrMarshalJSONFailed(err.Error())
+	}
+
+	return bz, nil
+}
@@ -127,3 +127,197 @@ func (k Keeper) IterateValidatorAccumulatedCommissions(ctx sdk.Context,
 		}
 	}
 }
+
+// GetValidatorOutstandingRewards returns the outstanding rewards of the delegators of a validator
+func (k Keeper) GetValidatorOutstandingRewards(ctx sdk.Context, val sdk.ValAddress) (
+	rewards types.ValidatorOutstandingRewards) {
+
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(types.GetValidatorOutstandingRewardsKey(val))
+	if b == nil {
+		return types.ValidatorOutstandingRewards{}
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(b, &rewards)
+	return rewards
+}
+
+// SetValidatorOutstandingRewards sets the outstanding rewards of the delegators of a validator
+func (k Keeper) SetValidatorOutstandingRewards(ctx sdk.Context, val sdk.ValAddress,
+	rewards types.ValidatorOutstandingRewards) {
+
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinaryLengthPrefixed(rewards)
+	store.Set(types.GetValidatorOutstandingRewardsKey(val), b)
+}
+
+// deleteValidatorOutstandingRewards deletes the outstanding rewards of a validator
+func (k Keeper) deleteValidatorOutstandingRewards(ctx sdk.Context, val sdk.ValAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetValidatorOutstandingRewardsKey(val))
+}
+
+// IterateValidatorOutstandingRewards iterates over the outstanding rewards of the validators
+func (k Keeper) IterateValidatorOutstandingRewards(ctx sdk.Context,
+	handler func(val sdk.ValAddress, rewards types.ValidatorOutstandingRewards) (stop bool)) {
+
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.ValidatorOutstandingRewardsPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var rewards types.ValidatorOutstandingRewards
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &rewards)
+		addr := types.GetValidatorOutstandingRewardsAddress(iter.Key())
+		if handler(addr, rewards) {
+			break
+		}
+	}
+}
+
+// GetDelegatorStartingInfo returns the starting info of the rewards of a delegator on a validator
+func (k Keeper) GetDelegatorStartingInfo(ctx sdk.Context, val sdk.ValAddress, del sdk.AccAddress) (
+	period types.DelegatorStartingInfo, found bool) {
+
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(types.GetDelegatorStartingInfoKey(val, del))
+	if b == nil {
+		return period, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(b, &period)
+	return period, true
+}
+
+// SetDelegatorStartingInfo sets the starting info of the rewards of a delegator on a validator
+func (k Keeper) SetDelegatorStartingInfo(ctx sdk.Context, val sdk.ValAddress, del sdk.AccAddress,
+	period types.DelegatorStartingInfo) {
+
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinaryLengthPrefixed(period)
+	store.Set(types.GetDelegatorStartingInfoKey(val, del), b)
+}
+
+// HasDelegatorStartingInfo checks the existence of the starting info of a delegator on a validator
+func (k Keeper) HasDelegatorStartingInfo(ctx sdk.Context, val sdk.ValAddress, del sdk.AccAddress) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.GetDelegatorStartingInfoKey(val, del))
+}
+
+// DeleteDelegatorStartingInfo deletes the starting info of the rewards of a delegator on a validator
+func (k Keeper) DeleteDelegatorStartingInfo(ctx sdk.Context, val sdk.ValAddress, del sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetDelegatorStartingInfoKey(val, del))
+}
+
+// IterateDelegatorStartingInfos iterates over the starting infos of the delegators
+func (k Keeper) IterateDelegatorStartingInfos(ctx sdk.Context,
+	handler func(val sdk.ValAddress, del sdk.AccAddress, info types.DelegatorStartingInfo) (stop bool)) {
+
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.DelegatorStartingInfoPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var info types.DelegatorStartingInfo
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &info)
+		val, del := types.GetDelegatorStartingInfoAddresses(iter.Key())
+		if handler(val, del, info) {
+			break
+		}
+	}
+}
+
+// GetValidatorHistoricalRewards returns the historical rewards of a validator at a period
+func (k Keeper) GetValidatorHistoricalRewards(ctx sdk.Context, val sdk.ValAddress, period uint64) (
+	rewards types.ValidatorHistoricalRewards) {
+
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(types.GetValidatorHistoricalRewardsKey(val, period))
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(b, &rewards)
+	return rewards
+}
+
+// SetValidatorHistoricalRewards sets the historical rewards of a validator at a period
+func (k Keeper) SetValidatorHistoricalRewards(ctx sdk.Context, val sdk.ValAddress, period uint64,
+	rewards types.ValidatorHistoricalRewards) {
+
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinaryLengthPrefixed(rewards)
+	store.Set(types.GetValidatorHistoricalRewardsKey(val, period), b)
+}
+
+// DeleteValidatorHistoricalReward deletes the historical rewards of a validator at a period
+func (k Keeper) DeleteValidatorHistoricalReward(ctx sdk.Context, val sdk.ValAddress, period uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetValidatorHistoricalRewardsKey(val, period))
+}
+
+// DeleteValidatorHistoricalRewards deletes all the historical rewards of a validator
+func (k Keeper) DeleteValidatorHistoricalRewards(ctx sdk.Context, val sdk.ValAddress) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.GetValidatorHistoricalRewardsPrefix(val))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		store.Delete(iter.Key())
+	}
+}
+
+// IterateValidatorHistoricalRewards iterates over the historical rewards of the validators
+func (k Keeper) IterateValidatorHistoricalRewards(ctx sdk.Context,
+	handler func(val sdk.ValAddress, period uint64, rewards types.ValidatorHistoricalRewards) (stop bool)) {
+
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.ValidatorHistoricalRewardsPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var rewards types.ValidatorHistoricalRewards
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &rewards)
+		addr, period := types.GetValidatorHistoricalRewardsAddressPeriod(iter.Key())
+		if handler(addr, period, rewards) {
+			break
+		}
+	}
+}
+
+// GetValidatorCurrentRewards returns the current rewards of a validator
+func (k Keeper) GetValidatorCurrentRewards(ctx sdk.Context, val sdk.ValAddress) (
+	rewards types.ValidatorCurrentRewards) {
+
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(types.GetValidatorCurrentRewardsKey(val))
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(b, &rewards)
+	return rewards
+}
+
+// SetValidatorCurrentRewards sets the current rewards of a validator
+func (k Keeper) SetValidatorCurrentRewards(ctx sdk.Context, val sdk.ValAddress, rewards types.ValidatorCurrentRewards) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinaryLengthPrefixed(rewards)
+	store.Set(types.GetValidatorCurrentRewardsKey(val), b)
+}
+
+// HasValidatorCurrentRewards checks the existence of the current rewards of a validator
+func (k Keeper) HasValidatorCurrentRewards(ctx sdk.Context, val sdk.ValAddress) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.GetValidatorCurrentRewardsKey(val))
+}
+
+// deleteValidatorCurrentRewards deletes the current rewards of a validator
+func (k Keeper) deleteValidatorCurrentRewards(ctx sdk.Context, val sdk.ValAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetValidatorCurrentRewardsKey(val))
+}
+
+// IterateValidatorCurrentRewards iterates over the current rewards of the validators
+func (k Keeper) IterateValidatorCurrentRewards(ctx sdk.Context,
+	handler func(val sdk.ValAddress, rewards types.ValidatorCurrentRewards) (stop bool)) {
+
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.ValidatorCurrentRewardsPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var rewards types.ValidatorCurrentRewards
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &rewards)
+		addr := types.GetValidatorCurrentRewardsAddress(iter.Key())
+		if handler(addr, rewards) {
+			break
+		}
+	}
+}
@@ -1,7 +1,10 @@
 package keeper
 
 import (
+	"fmt"
+
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 
 	"github.com/okex/exchain/x/distribution/types"
 	"github.com/okex/exchain/x/staking/exported"
@@ -11,4 +14,125 @@ import (
 func (k Keeper) initializeValidator(ctx sdk.Context, val exported.ValidatorI) {
 	// set accumulated commissions
 	k.SetValidatorAccumulatedCommission(ctx, val.GetOperator(), types.InitialValidatorAccumulatedCommission())
+
+	// the rewards of the delegators are recorded from the earth milestone
+	if !tmtypes.HigherThanEarth(ctx.BlockHeight()) {
+		return
+	}
+
+	// set initial historical rewards (period 0) with reference count of 1
+	k.SetValidatorHistoricalRewards(ctx, val.GetOperator(), 0, types.NewValidatorHistoricalRewards(sdk.SysCoins{}, 1))
+
+	// set current rewards (starting at period 1)
+	k.SetValidatorCurrentRewards(ctx, val.GetOperator(), types.NewValidatorCurrentRewards(sdk.SysCoins{}, 1))
+
+	// set outstanding rewards
+	k.SetValidatorOutstandingRewards(ctx, val.GetOperator(), sdk.SysCoins{})
+}
+
+// IncrementValidatorPeriod increments validator period, returning the period just ended
+func (k Keeper) IncrementValidatorPeriod(ctx sdk.Context, val exported.ValidatorI) uint64 {
+	// fetch current rewards
+	rewards := k.GetValidatorCurrentRewards(ctx, val.GetOperator())
+
+	// calculate current ratio
+	var current sdk.SysCoins
+	shares := delegatorShares(val)
+	if shares.IsZero() {
+		// can't calculate ratio for zero-shares validators
+		// ergo we instead add to the community pool
+		if !rewards.Rewards.IsZero() {
+			feePool := k.GetFeePool(ctx)
+			outstanding := k.GetValidatorOutstandingRewards(ctx, val.GetOperator())
+			feePool.CommunityPool = feePool.CommunityPool.Add(rewards.Rewards...)
+			outstanding = outstanding.Sub(rewards.Rewards)
+			k.SetFeePool(ctx, feePool)
+			k.SetValidatorOutstandingRewards(ctx, val.GetOperator(), outstanding)
+		}
+		current = sdk.SysCoins{}
+	} else {
+		// note: necessary to truncate so we don't allow withdrawing more rewards than owed
+		current = rewards.Rewards.QuoDecTruncate(shares)
+	}
+
+	// fetch historical rewards for last period
+	historical := k.GetValidatorHistoricalRewards(ctx, val.GetOperator(), rewards.Period-1).CumulativeRewardRatio
+
+	// decrement reference count
+	k.decrementReferenceCount(ctx, val.GetOperator(), rewards.Period-1)
+
+	// set new historical rewards with reference count of 1
+	k.SetValidatorHistoricalRewards(ctx, val.GetOperator(), rewards.Period,
+		types.NewValidatorHistoricalRewards(historical.Add(current...), 1))
+
+	// set current rewards, incrementing period by 1
+	k.SetValidatorCurrentRewards(ctx, val.GetOperator(), types.NewValidatorCurrentRewards(sdk.SysCoins{}, rewards.Period+1))
+
+	return rewards.Period
+}
+
+// increment the reference count for a historical rewards value
+func (k Keeper) incrementReferenceCount(ctx sdk.Context, valAddr sdk.ValAddress, period uint64) {
+	historical := k.GetValidatorHistoricalRewards(ctx, valAddr, period)
+	if historical.ReferenceCount > 2 {
+		panic("reference count should never exceed 2")
+	}
+	historical.ReferenceCount++
+	k.SetValidatorHistoricalRewards(ctx, valAddr, period, historical)
+}
+
+// decrement the reference count for a historical rewards value, and delete if zero references remain
+func (k Keeper) decrementReferenceCount(ctx sdk.Context, valAddr sdk.ValAddress, period uint64) {
+	historical := k.GetValidatorHistoricalRewards(ctx, valAddr, period)
+	if historical.ReferenceCount == 0 {
+		panic(fmt.Sprintf("cannot set negative reference count of validator %s at period %d", valAddr, period))
+	}
+	historical.ReferenceCount--
+	if historical.ReferenceCount == 0 {
+		k.DeleteValidatorHistoricalReward(ctx, valAddr, period)
+	} else {
+		k.SetValidatorHistoricalRewards(ctx, valAddr, period, historical)
+	}
+}
+
+// delegatorShares returns the shares added to the validator by the delegators. The validator holds one more share
+// for its min self delegation, which isn't recorded as the shares of a delegator in the staking module
+func delegatorShares(val exported.ValidatorI) sdk.Dec {
+	shares := val.GetDelegatorShares()
+	if !val.GetMinSelfDelegation().IsZero() {
+		shares = shares.Sub(sdk.OneDec())
+	}
+	if shares.IsNegative() {
+		return sdk.ZeroDec()
+	}
+	return shares
+}
+
+// InitializeLegacyRewards initializes the rewards records of the validators and the shares which were created before
+// the delegators are rewarded, it migrates the state at the earth milestone
+func (k Keeper) InitializeLegacyRewards(ctx sdk.Context) {
+	k.stakingKeeper.IterateValidators(ctx, func(_ int64, val exported.ValidatorI) (stop bool) {
+		if !k.HasValidatorCurrentRewards(ctx, val.GetOperator()) {
+			// keep the accumulated commission
+			commission := k.GetValidatorAccumulatedCommission(ctx, val.GetOperator())
+			k.initializeValidator(ctx, val)
+			k.SetValidatorAccumulatedCommission(ctx, val.GetOperator(), commission)
+		}
+		return false
+	})
+
+	k.stakingKeeper.IterateShares(ctx,
+		func(_ int64, delAddr sdk.AccAddress, valAddr sdk.ValAddress, _ sdk.Dec) (stop bool) {
+			if k.HasDelegatorStartingInfo(ctx, valAddr, delAddr) {
+				return false
+			}
+			val := k.stakingKeeper.Validator(ctx, valAddr)
+			if val == nil {
+				return false
+			}
+			// every delegation refers to its own period as the shares added later do
+			k.IncrementValidatorPeriod(ctx, val)
+			k.initializeDelegation(ctx, valAddr, delAddr)
+			return false
+		})
 }
@@ -8,6 +8,8 @@ import (
 func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgWithdrawValidatorCommission{}, "okexchain/distribution/MsgWithdrawReward", nil)
 	cdc.RegisterConcrete(MsgSetWithdrawAddress{}, "okexchain/distribution/MsgModifyWithdrawAddress", nil)
+	cdc.RegisterConcrete(MsgWithdrawDelegatorReward{}, "okexchain/distribution/MsgWithdrawDelegatorReward", nil)
+	cdc.RegisterConcrete(MsgWithdrawDelegatorAllRewards{}, "okexchain/distribution/MsgWithdrawDelegatorAllRewards", nil)
 	cdc.RegisterConcrete(CommunityPoolSpendProposal{}, "okexchain/distribution/CommunityPoolSpendProposal", nil)
 }
 
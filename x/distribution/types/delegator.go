@@ -0,0 +1,23 @@
+package types
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+// DelegatorStartingInfo represents the starting info of the rewards of a delegator on a validator, it's reset
+// every time the shares added by the delegator to the validator change.
+// Stake is the amount of the shares recorded in the staking module.
+type DelegatorStartingInfo struct {
+	PreviousPeriod uint64  `json:"previous_period" yaml:"previous_period"` // period at which the shares were added
+	Stake          sdk.Dec `json:"stake" yaml:"stake"`                     // amount of the shares added
+	Height         uint64  `json:"creation_height" yaml:"creation_height"` // height at which the shares were added
+}
+
+// NewDelegatorStartingInfo creates a new DelegatorStartingInfo
+func NewDelegatorStartingInfo(previousPeriod uint64, stake sdk.Dec, height uint64) DelegatorStartingInfo {
+	return DelegatorStartingInfo{
+		PreviousPeriod: previousPeriod,
+		Stake:          stake,
+		Height:         height,
+	}
+}
@@ -28,6 +28,7 @@ const (
 	CodeBadDistribution                             uint32 = 67816
 	CodeInvalidProposalAmount                       uint32 = 67817
 	CodeEmptyProposalRecipient                      uint32 = 67818
+	CodeEmptyDelegationDistInfo                     uint32 = 67819
 )
 
 func ErrNilDelegatorAddr() sdk.Error {
@@ -89,3 +90,7 @@ func ErrInvalidProposalAmount() sdk.Error {
 func ErrEmptyProposalRecipient() sdk.Error {
 	return sdkerrors.New(DefaultCodespace, CodeEmptyProposalRecipient, "invalid community pool spend proposal recipient")
 }
+
+func ErrEmptyDelegationDistInfo() sdk.Error {
+	return sdkerrors.New(DefaultCodespace, CodeEmptyDelegationDistInfo, "no delegation distribution info")
+}
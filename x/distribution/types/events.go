@@ -4,11 +4,14 @@ package types
 const (
 	EventTypeSetWithdrawAddress = "set_withdraw_address"
 	EventTypeCommission         = "commission"
+	EventTypeRewards            = "rewards"
 	EventTypeWithdrawCommission = "withdraw_commission"
 	EventTypeProposerReward     = "proposer_reward"
+	EventTypeWithdrawRewards    = "withdraw_rewards"
 
 	AttributeKeyWithdrawAddress = "withdraw_address"
 	AttributeKeyValidator       = "validator"
+	AttributeKeyDelegator       = "delegator"
 
 	AttributeValueCategory = ModuleName
 )
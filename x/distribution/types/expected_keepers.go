@@ -35,6 +35,14 @@ type StakingKeeper interface {
 
 	GetLastTotalPower(ctx sdk.Context) sdk.Int
 	GetLastValidatorPower(ctx sdk.Context, valAddr sdk.ValAddress) int64
+
+	// get a particular delegator by delegator address
+	Delegator(ctx sdk.Context, delAddr sdk.AccAddress) stakingexported.DelegatorI
+	// get the shares added by a delegator to a validator
+	GetShares(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) (sdk.Dec, bool)
+	// iterate through all the shares added by the delegators to the validators
+	IterateShares(ctx sdk.Context,
+		fn func(index int64, delAddr sdk.AccAddress, valAddr sdk.ValAddress, shares sdk.Dec) (stop bool))
 }
 
 // StakingHooks event hooks for staking validator object (noalias)
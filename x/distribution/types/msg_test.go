@@ -85,3 +85,44 @@ func TestMsgWithdrawValidatorCommission(t *testing.T) {
 		}
 	}
 }
+
+// TestMsgWithdrawDelegatorReward test ValidateBasic for MsgWithdrawDelegatorReward
+func TestMsgWithdrawDelegatorReward(t *testing.T) {
+	msg := NewMsgWithdrawDelegatorReward(delAddr1, valAddr1)
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	require.Equal(t, ModuleName, msg.Route())
+	require.Equal(t, "withdraw_delegator_reward", msg.Type())
+	require.Equal(t, []sdk.AccAddress{delAddr1}, msg.GetSigners())
+	require.Equal(t, sdk.MustSortJSON(bz), msg.GetSignBytes())
+
+	tests := []struct {
+		delegatorAddr sdk.AccAddress
+		validatorAddr sdk.ValAddress
+		expectPass    bool
+	}{
+		{delAddr1, valAddr1, true},
+		{emptyDelAddr, valAddr1, false},
+		{delAddr1, emptyValAddr, false},
+		{emptyDelAddr, emptyValAddr, false},
+	}
+	for i, tc := range tests {
+		msg := NewMsgWithdrawDelegatorReward(tc.delegatorAddr, tc.validatorAddr)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test index: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test index: %v", i)
+		}
+	}
+}
+
+// TestMsgWithdrawDelegatorAllRewards test ValidateBasic for MsgWithdrawDelegatorAllRewards
+func TestMsgWithdrawDelegatorAllRewards(t *testing.T) {
+	msg := NewMsgWithdrawDelegatorAllRewards(delAddr1)
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	require.Equal(t, ModuleName, msg.Route())
+	require.Equal(t, "withdraw_delegator_all_rewards", msg.Type())
+	require.Equal(t, []sdk.AccAddress{delAddr1}, msg.GetSigners())
+	require.Equal(t, sdk.MustSortJSON(bz), msg.GetSignBytes())
+	require.NoError(t, msg.ValidateBasic())
+	require.Error(t, NewMsgWithdrawDelegatorAllRewards(emptyDelAddr).ValidateBasic())
+}
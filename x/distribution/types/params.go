@@ -16,12 +16,16 @@ const (
 var (
 	ParamStoreKeyCommunityTax        = []byte("communitytax")
 	ParamStoreKeyWithdrawAddrEnabled = []byte("withdrawaddrenabled")
+	ParamStoreKeyCommissionRate      = []byte("commissionrate")
 )
 
 // Params defines the set of distribution parameters.
 type Params struct {
 	CommunityTax        sdk.Dec `json:"community_tax" yaml:"community_tax"`
 	WithdrawAddrEnabled bool    `json:"withdraw_addr_enabled" yaml:"withdraw_addr_enabled"`
+	// CommissionRate is the part of the rewards of a validator kept as its commission,
+	// the rest is distributed to the delegators by their shares
+	CommissionRate sdk.Dec `json:"commission_rate" yaml:"commission_rate"`
 }
 
 // ParamKeyTable returns the parameter key table.
@@ -34,6 +38,7 @@ func DefaultParams() Params {
 	return Params{
 		CommunityTax:        sdk.NewDecWithPrec(2, 2), // 2%
 		WithdrawAddrEnabled: true,
+		CommissionRate:      sdk.OneDec(), // 100%, all the rewards belong to the validators
 	}
 }
 
@@ -41,8 +46,9 @@ func DefaultParams() Params {
 func (p Params) String() string {
 	return fmt.Sprintf(`Distribution Params:
   Community Tax:          %s
-  Withdraw Addr Enabled:  %t`,
-		p.CommunityTax, p.WithdrawAddrEnabled)
+  Withdraw Addr Enabled:  %t
+  Commission Rate:        %s`,
+		p.CommunityTax, p.WithdrawAddrEnabled, p.CommissionRate)
 }
 
 // ParamSetPairs returns the parameter set pairs.
@@ -50,6 +56,7 @@ func (p *Params) ParamSetPairs() params.ParamSetPairs {
 	return params.ParamSetPairs{
 		params.NewParamSetPair(ParamStoreKeyCommunityTax, &p.CommunityTax, validateCommunityTax),
 		params.NewParamSetPair(ParamStoreKeyWithdrawAddrEnabled, &p.WithdrawAddrEnabled, validateWithdrawAddrEnabled),
+		params.NewParamSetPair(ParamStoreKeyCommissionRate, &p.CommissionRate, validateCommissionRate),
 	}
 }
 
@@ -60,6 +67,11 @@ func (p Params) ValidateBasic() error {
 			"community tax should non-negative and less than one: %s", p.CommunityTax,
 		)
 	}
+	if p.CommissionRate.IsNil() || p.CommissionRate.IsNegative() || p.CommissionRate.GT(sdk.OneDec()) {
+		return fmt.Errorf(
+			"commission rate should non-negative and less than one: %s", p.CommissionRate,
+		)
+	}
 
 	return nil
 }
@@ -92,11 +104,31 @@ func validateWithdrawAddrEnabled(i interface{}) error {
 	return nil
 }
 
+func validateCommissionRate(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.IsNil() {
+		return fmt.Errorf("commission rate must be not nil")
+	}
+	if v.IsNegative() {
+		return fmt.Errorf("commission rate must be positive: %s", v)
+	}
+	if v.GT(sdk.OneDec()) {
+		return fmt.Errorf("commission rate too large: %s", v)
+	}
+
+	return nil
+}
+
 // NewParams creates a new instance of Params
-func NewParams(communityTax sdk.Dec, withdrawAddrEnabled bool) Params {
+func NewParams(communityTax sdk.Dec, withdrawAddrEnabled bool, commissionRate sdk.Dec) Params {
 	return Params{
 		CommunityTax:        communityTax,
 		WithdrawAddrEnabled: withdrawAddrEnabled,
+		CommissionRate:      commissionRate,
 	}
 }
 
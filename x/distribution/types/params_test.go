@@ -10,7 +10,8 @@ import (
 const (
 	strExpected = `Distribution Params:
   Community Tax:          0.020000000000000000
-  Withdraw Addr Enabled:  true`
+  Withdraw Addr Enabled:  true
+  Commission Rate:        1.000000000000000000`
 )
 
 func TestParams(t *testing.T) {
@@ -18,6 +19,7 @@ func TestParams(t *testing.T) {
 	defaultParams := defaultState.Params
 	require.Equal(t, sdk.NewDecWithPrec(2, 2), defaultParams.CommunityTax)
 	require.Equal(t, true, defaultParams.WithdrawAddrEnabled)
+	require.Equal(t, sdk.OneDec(), defaultParams.CommissionRate)
 
 	require.Equal(t, strExpected, defaultParams.String())
 	yamlStr, err := defaultParams.MarshalYAML()
@@ -47,6 +49,7 @@ func Test_validateAuxFuncs(t *testing.T) {
 
 		t.Run(stc.name, func(t *testing.T) {
 			require.Equal(t, stc.wantErr, validateCommunityTax(stc.args.i) != nil)
+			require.Equal(t, stc.wantErr, validateCommissionRate(stc.args.i) != nil)
 		})
 	}
 }
@@ -1,6 +1,11 @@
 package types
 
-import sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
 
 // querier keys
 const (
@@ -8,9 +13,12 @@ const (
 	QueryValidatorCommission = "validator_commission"
 	QueryWithdrawAddr        = "withdraw_addr"
 	QueryCommunityPool       = "community_pool"
+	QueryDelegationRewards   = "delegation_rewards"
+	QueryDelegatorRewards    = "delegator_total_rewards"
 
 	ParamCommunityTax        = "community_tax"
 	ParamWithdrawAddrEnabled = "withdraw_addr_enabled"
+	ParamCommissionRate      = "commission_rate"
 )
 
 // QueryValidatorCommissionParams is the struct of params for query 'custom/distr/validator_commission'
@@ -34,3 +42,64 @@ type QueryDelegatorWithdrawAddrParams struct {
 func NewQueryDelegatorWithdrawAddrParams(delegatorAddr sdk.AccAddress) QueryDelegatorWithdrawAddrParams {
 	return QueryDelegatorWithdrawAddrParams{DelegatorAddress: delegatorAddr}
 }
+
+// QueryDelegationRewardsParams is the struct of params for query 'custom/distr/delegation_rewards'
+type QueryDelegationRewardsParams struct {
+	DelegatorAddress sdk.AccAddress `json:"delegator_address" yaml:"delegator_address"`
+	ValidatorAddress sdk.ValAddress `json:"validator_address" yaml:"validator_address"`
+}
+
+// NewQueryDelegationRewardsParams creates a new instance of QueryDelegationRewardsParams
+func NewQueryDelegationRewardsParams(delegatorAddr sdk.AccAddress, validatorAddr sdk.ValAddress) QueryDelegationRewardsParams {
+	return QueryDelegationRewardsParams{
+		DelegatorAddress: delegatorAddr,
+		ValidatorAddress: validatorAddr,
+	}
+}
+
+// QueryDelegatorParams is the struct of params for query 'custom/distr/delegator_total_rewards'
+type QueryDelegatorParams struct {
+	DelegatorAddress sdk.AccAddress `json:"delegator_address" yaml:"delegator_address"`
+}
+
+// NewQueryDelegatorParams creates a new instance of QueryDelegatorParams
+func NewQueryDelegatorParams(delegatorAddr sdk.AccAddress) QueryDelegatorParams {
+	return QueryDelegatorParams{DelegatorAddress: delegatorAddr}
+}
+
+// DelegationDelegatorReward is the rewards of a delegator on a validator
+type DelegationDelegatorReward struct {
+	ValidatorAddress sdk.ValAddress `json:"validator_address" yaml:"validator_address"`
+	Reward           sdk.SysCoins   `json:"reward" yaml:"reward"`
+}
+
+// NewDelegationDelegatorReward creates a new instance of DelegationDelegatorReward
+func NewDelegationDelegatorReward(valAddr sdk.ValAddress, reward sdk.SysCoins) DelegationDelegatorReward {
+	return DelegationDelegatorReward{ValidatorAddress: valAddr, Reward: reward}
+}
+
+// QueryDelegatorTotalRewardsResponse is the response of query 'custom/distr/delegator_total_rewards'
+type QueryDelegatorTotalRewardsResponse struct {
+	Rewards []DelegationDelegatorReward `json:"rewards" yaml:"rewards"`
+	Total   sdk.SysCoins                `json:"total" yaml:"total"`
+}
+
+// NewQueryDelegatorTotalRewardsResponse creates a new instance of QueryDelegatorTotalRewardsResponse
+func NewQueryDelegatorTotalRewardsResponse(rewards []DelegationDelegatorReward,
+	total sdk.SysCoins) QueryDelegatorTotalRewardsResponse {
+	return QueryDelegatorTotalRewardsResponse{Rewards: rewards, Total: total}
+}
+
+// String returns a human readable string representation of QueryDelegatorTotalRewardsResponse
+func (res QueryDelegatorTotalRewardsResponse) String() string {
+	var b strings.Builder
+	b.WriteString("Delegator Total Rewards:\n")
+	b.WriteString("  Rewards:")
+	for _, r := range res.Rewards {
+		b.WriteString(fmt.Sprintf(`
+    ValidatorAddress: %s
+    Reward: %s`, r.ValidatorAddress, r.Reward))
+	}
+	b.WriteString(fmt.Sprintf("\n  Total: %s\n", res.Total))
+	return b.String()
+}
@@ -12,3 +12,40 @@ type ValidatorAccumulatedCommission = sdk.SysCoins
 func InitialValidatorAccumulatedCommission() ValidatorAccumulatedCommission {
 	return ValidatorAccumulatedCommission{}
 }
+
+// ValidatorHistoricalRewards is the cumulative rewards ratio of a validator at the end of a period,
+// the height is implicit within the store key.
+// CumulativeRewardRatio is the sum from the zeroeth period until this period of rewards / shares.
+// ReferenceCount is the number of the delegators whose starting info refers to this period, plus one
+// for the current rewards refer to it while it's the previous period.
+type ValidatorHistoricalRewards struct {
+	CumulativeRewardRatio sdk.SysCoins `json:"cumulative_reward_ratio" yaml:"cumulative_reward_ratio"`
+	ReferenceCount        uint16       `json:"reference_count" yaml:"reference_count"`
+}
+
+// NewValidatorHistoricalRewards creates a new ValidatorHistoricalRewards
+func NewValidatorHistoricalRewards(cumulativeRewardRatio sdk.SysCoins, referenceCount uint16) ValidatorHistoricalRewards {
+	return ValidatorHistoricalRewards{
+		CumulativeRewardRatio: cumulativeRewardRatio,
+		ReferenceCount:        referenceCount,
+	}
+}
+
+// ValidatorCurrentRewards is the rewards of the delegators accumulated by a validator during the current period,
+// which are moved to the historical rewards once the shares added to the validator change
+type ValidatorCurrentRewards struct {
+	Rewards sdk.SysCoins `json:"rewards" yaml:"rewards"` // current rewards
+	Period  uint64       `json:"period" yaml:"period"`   // current period
+}
+
+// NewValidatorCurrentRewards creates a new ValidatorCurrentRewards
+func NewValidatorCurrentRewards(rewards sdk.SysCoins, period uint64) ValidatorCurrentRewards {
+	return ValidatorCurrentRewards{
+		Rewards: rewards,
+		Period:  period,
+	}
+}
+
+// ValidatorOutstandingRewards is the rewards of the delegators held by the module for a validator,
+// which haven't been withdrawn yet
+type ValidatorOutstandingRewards = sdk.SysCoins
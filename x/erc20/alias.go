@@ -0,0 +1,26 @@
+package erc20
+
+import (
+	"github.com/okex/exchain/x/erc20/keeper"
+	"github.com/okex/exchain/x/erc20/types"
+)
+
+const (
+	ModuleName       = types.ModuleName
+	StoreKey         = types.StoreKey
+	RouterKey        = types.RouterKey
+	QuerierRoute     = types.QuerierRoute
+	DefaultCodespace = types.DefaultCodespace
+)
+
+var (
+	NewKeeper        = keeper.NewKeeper
+	ModuleCdc        = types.ModuleCdc
+	ModuleEvmAddress = types.ModuleEvmAddress
+)
+
+type (
+	Keeper       = keeper.Keeper
+	GenesisState = types.GenesisState
+	TokenMapping = types.TokenMapping
+)
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
+	client "github.com/okex/exchain/libs/cosmos-sdk/client/flags"
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	"github.com/okex/exchain/libs/cosmos-sdk/version"
+	"github.com/okex/exchain/x/erc20/types"
+	"github.com/spf13/cobra"
+)
+
+// GetQueryCmd returns the cli query commands for this module
+func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	// Group erc20 queries under a subcommand
+	erc20QueryCmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      fmt.Sprintf("Querying commands for the %s module", types.ModuleName),
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+	}
+
+	erc20QueryCmd.AddCommand(
+		client.GetCommands(
+			GetCmdQueryTokenMapping(queryRoute, cdc),
+			GetCmdQueryTokenMappings(queryRoute, cdc),
+		)...,
+	)
+
+	return erc20QueryCmd
+}
+
+// GetCmdQueryTokenMapping gets the token mapping query command.
+func GetCmdQueryTokenMapping(storeName string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "token-mapping [denom-or-contract]",
+		Short: "query the token mapping of a denom or a contract",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the erc20 contract mapped to a native denom, or the denom mapped to a contract.
+
+Example:
+$ %s query erc20 token-mapping xxb
+$ %s query erc20 token-mapping 0x1033796B018B2bf0Fc9CB88c0793b2F275eDB624
+`,
+				version.ClientName, version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			bytes, err := cdc.MarshalJSON(types.NewQueryTokenMappingParams(args[0]))
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", storeName, types.QueryTokenMapping)
+			resp, _, err := cliCtx.QueryWithData(route, bytes)
+			if err != nil {
+				return err
+			}
+
+			var mapping types.TokenMapping
+			cdc.MustUnmarshalJSON(resp, &mapping)
+			return cliCtx.PrintOutput(mapping)
+		},
+	}
+}
+
+// GetCmdQueryTokenMappings gets the token mappings query command.
+func GetCmdQueryTokenMappings(storeName string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "token-mappings",
+		Short: "query all the token mappings",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query all the mappings between the native denoms and the erc20 contracts.
+
+Example:
+$ %s query erc20 token-mappings
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			route := fmt.Sprintf("custom/%s/%s", storeName, types.QueryTokenMappings)
+			bz, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+
+			var mappings []types.TokenMapping
+			cdc.MustUnmarshalJSON(bz, &mappings)
+			return cliCtx.PrintOutput(mappings)
+		},
+	}
+}
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
+	client "github.com/okex/exchain/libs/cosmos-sdk/client/flags"
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/version"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth/client/utils"
+	erc20utils "github.com/okex/exchain/x/erc20/client/utils"
+	"github.com/okex/exchain/x/erc20/types"
+	"github.com/okex/exchain/x/gov"
+	"github.com/spf13/cobra"
+)
+
+// GetTxCmd returns the transaction commands for this module
+func GetTxCmd(cdc *codec.Codec) *cobra.Command {
+	erc20TxCmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      fmt.Sprintf("%s transactions subcommands", types.ModuleName),
+		SuggestionsMinimumDistance: 2,
+	}
+
+	erc20TxCmd.AddCommand(client.PostCommands(
+		GetCmdConvertNative(cdc),
+	)...)
+	return erc20TxCmd
+}
+
+// GetCmdConvertNative implements the command to convert the native tokens to the mapped erc20 tokens
+func GetCmdConvertNative(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "convert [amount]",
+		Short: "convert native tokens to the mapped erc20 tokens",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Convert native tokens to the erc20 tokens of the mapped contract.
+The erc20 tokens are sent to the evm address of the sender. To convert them back, transfer the erc20
+tokens to the evm address of the erc20 module, %s.
+
+Example:
+$ %s tx erc20 convert 10xxb --from mykey
+`, types.ModuleEvmAddress.Hex(), version.ClientName),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			amount, err := sdk.ParseDecCoin(args[0])
+			if err != nil {
+				return err
+			}
+			msg := types.NewMsgConvertNative(cliCtx.GetFromAddress(), amount)
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdTokenMappingProposal implements a command handler for submitting a token mapping proposal transaction
+func GetCmdTokenMappingProposal(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "token-mapping [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a token mapping proposal",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal to map a native denom to an erc20 contract along with an initial deposit.
+The canonical erc20 wrapper of the denom is deployed if the contract is empty. Otherwise the contract must be
+an erc20 contract with 18 decimals, and a native denom is created for it.
+The proposal details must be supplied via a JSON file.
+
+Example:
+$ %s tx gov submit-proposal token-mapping <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+ "title": "map xxb",
+ "description": "deploy the erc20 wrapper of xxb",
+ "denom": "xxb",
+ "contract": "",
+ "deposit": [
+   {
+     "denom": "%s",
+     "amount": "100"
+   }
+ ]
+}
+`, version.ClientName, sdk.DefaultBondDenom,
+			)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			proposal, err := erc20utils.ParseTokenMappingProposalJSON(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			from := cliCtx.GetFromAddress()
+			content := types.NewTokenMappingProposal(proposal.Title, proposal.Description, proposal.Denom, proposal.Contract)
+			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, from)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
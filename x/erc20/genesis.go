@@ -0,0 +1,27 @@
+package erc20
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/erc20/keeper"
+	"github.com/okex/exchain/x/erc20/types"
+)
+
+// InitGenesis initializes the token mappings of the erc20 module
+func InitGenesis(ctx sdk.Context, k keeper.Keeper, data types.GenesisState) {
+	// the erc20 store is committed from the earth milestone, so the mappings
+	// would be lost if it is not reached at the first block
+	if len(data.TokenMappings) != 0 && !tmtypes.HigherThanEarth(tmtypes.GetStartBlockHeight()+1) {
+		panic(types.ErrNotEnabled)
+	}
+	for _, mapping := range data.TokenMappings {
+		k.SetTokenMapping(ctx, mapping)
+	}
+}
+
+// ExportGenesis writes the current token mappings to a genesis state
+func ExportGenesis(ctx sdk.Context, k keeper.Keeper) types.GenesisState {
+	return types.GenesisState{
+		TokenMappings: k.GetTokenMappings(ctx),
+	}
+}
@@ -0,0 +1,52 @@
+package erc20
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/common"
+	"github.com/okex/exchain/x/common/perf"
+	"github.com/okex/exchain/x/erc20/keeper"
+	"github.com/okex/exchain/x/erc20/types"
+)
+
+// NewHandler creates an sdk.Handler for all the erc20 type messages, which are handled from the earth milestone
+func NewHandler(k keeper.Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		if !tmtypes.HigherThanEarth(ctx.BlockHeight()) {
+			return nil, types.ErrNotEnabled
+		}
+		ctx = ctx.WithEventManager(sdk.NewEventManager())
+		var handlerFun func() (*sdk.Result, error)
+		var name string
+		switch msg := msg.(type) {
+		case types.MsgConvertNative:
+			name = "handleMsgConvertNative"
+			handlerFun = func() (*sdk.Result, error) {
+				return handleMsgConvertNative(ctx, k, msg)
+			}
+		default:
+			return nil, sdkerrors.Wrapf(types.ErrUnknownMsgType, "%T", msg)
+		}
+
+		seq := perf.GetPerf().OnDeliverTxEnter(ctx, types.ModuleName, name)
+		defer perf.GetPerf().OnDeliverTxExit(ctx, types.ModuleName, name, seq)
+
+		res, err := handlerFun()
+		common.SanityCheckHandler(res, err)
+		return res, err
+	}
+}
+
+func handleMsgConvertNative(ctx sdk.Context, k keeper.Keeper, msg types.MsgConvertNative) (*sdk.Result, error) {
+	if err := k.ConvertNativeToERC20(ctx, msg.Sender, msg.Amount); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+	))
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
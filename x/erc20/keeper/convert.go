@@ -0,0 +1,83 @@
+package keeper
+
+import (
+	"math/big"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	"github.com/okex/exchain/x/erc20/types"
+)
+
+// ConvertNativeToERC20 burns the native tokens of the sender, and mints the same amount of the wrapper tokens,
+// or releases the escrowed erc20 tokens, to the evm address of the sender
+func (k Keeper) ConvertNativeToERC20(ctx sdk.Context, sender sdk.AccAddress, amount sdk.SysCoin) error {
+	mapping, found := k.GetTokenMapping(ctx, amount.Denom)
+	if !found {
+		return sdkerrors.Wrapf(types.ErrMappingNotFound, "denom %s", amount.Denom)
+	}
+
+	coins := sdk.NewCoins(amount)
+	if err := k.supplyKeeper.SendCoinsFromAccountToModule(ctx, sender, types.ModuleName, coins); err != nil {
+		return err
+	}
+	if err := k.supplyKeeper.BurnCoins(ctx, types.ModuleName, coins); err != nil {
+		return err
+	}
+
+	// the erc20 tokens have 18 decimals, the same as the precision of the native tokens
+	receiver := ethcmn.BytesToAddress(sender)
+	value := amount.Amount.BigInt()
+	method := "transfer"
+	if mapping.Wrapper {
+		method = "mint"
+	}
+	if err := k.callContractSucceeded(ctx, mapping.ContractAddress(), method, receiver, value); err != nil {
+		return err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeConvertNative,
+		sdk.NewAttribute(types.AttributeKeySender, sender.String()),
+		sdk.NewAttribute(types.AttributeKeyReceiver, receiver.Hex()),
+		sdk.NewAttribute(types.AttributeKeyContract, mapping.Contract),
+		sdk.NewAttribute(types.AttributeKeyAmount, amount.String()),
+	))
+	return nil
+}
+
+// ConvertERC20ToNative mints the native tokens to the sender of the erc20 tokens, which have been transferred to
+// the evm account of the module. The wrapper tokens are burnt, while the other erc20 tokens are kept in escrow.
+func (k Keeper) ConvertERC20ToNative(ctx sdk.Context, mapping types.TokenMapping, sender ethcmn.Address, value *big.Int) error {
+	if value.Sign() <= 0 {
+		return nil
+	}
+
+	if mapping.Wrapper {
+		if err := k.callContractSucceeded(ctx, mapping.ContractAddress(), "burn", types.ModuleEvmAddress, value); err != nil {
+			return err
+		}
+	}
+
+	amount := sdk.NewDecCoinFromDec(mapping.Denom, sdk.NewDecFromBigIntWithPrec(value, sdk.Precision))
+	if !amount.IsValid() {
+		return sdkerrors.Wrap(types.ErrInvalidAmount, amount.String())
+	}
+	coins := sdk.NewCoins(amount)
+	if err := k.supplyKeeper.MintCoins(ctx, types.ModuleName, coins); err != nil {
+		return err
+	}
+	receiver := sdk.AccAddress(sender.Bytes())
+	if err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, receiver, coins); err != nil {
+		return err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeConvertERC20,
+		sdk.NewAttribute(types.AttributeKeySender, sender.Hex()),
+		sdk.NewAttribute(types.AttributeKeyReceiver, receiver.String()),
+		sdk.NewAttribute(types.AttributeKeyContract, mapping.Contract),
+		sdk.NewAttribute(types.AttributeKeyAmount, amount.String()),
+	))
+	return nil
+}
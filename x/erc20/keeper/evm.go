@@ -0,0 +1,55 @@
+package keeper
+
+import (
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	"github.com/okex/exchain/x/erc20/types"
+)
+
+// callContract calls a method of the erc20 contract from the evm account of the module
+func (k Keeper) callContract(ctx sdk.Context, contract ethcmn.Address, method string, args ...interface{}) ([]interface{}, error) {
+	data, err := types.ERC20ABI.Pack(method, args...)
+	if err != nil {
+		return nil, sdkerrors.Wrap(types.ErrEvmCallFailed, err.Error())
+	}
+	ret, _, err := k.evmKeeper.CallEvm(ctx, types.ModuleEvmAddress, &contract, data)
+	if err != nil {
+		return nil, sdkerrors.Wrapf(types.ErrEvmCallFailed, "%s of %s: %s", method, contract.Hex(), err.Error())
+	}
+	out, err := types.ERC20ABI.Unpack(method, ret)
+	if err != nil {
+		return nil, sdkerrors.Wrapf(types.ErrEvmCallFailed, "%s of %s: %s", method, contract.Hex(), err.Error())
+	}
+	return out, nil
+}
+
+// callContractSucceeded calls a method of the erc20 contract which returns a bool of the success
+func (k Keeper) callContractSucceeded(ctx sdk.Context, contract ethcmn.Address, method string, args ...interface{}) error {
+	out, err := k.callContract(ctx, contract, method, args...)
+	if err != nil {
+		return err
+	}
+	if succeeded, ok := out[0].(bool); !ok || !succeeded {
+		return sdkerrors.Wrapf(types.ErrEvmCallFailed, "%s of %s returned false", method, contract.Hex())
+	}
+	return nil
+}
+
+// deployWrapper deploys the canonical erc20 wrapper of the native token, owned by the evm account of the module
+func (k Keeper) deployWrapper(ctx sdk.Context, denom string) (ethcmn.Address, error) {
+	token := k.tokenKeeper.GetTokenInfo(ctx, denom)
+	name := token.WholeName
+	if len(name) == 0 {
+		name = denom
+	}
+	code, err := types.WrapperDeployCode(types.WrapperName(name), denom)
+	if err != nil {
+		return ethcmn.Address{}, sdkerrors.Wrap(types.ErrInvalidMapping, err.Error())
+	}
+	_, contract, err := k.evmKeeper.CallEvm(ctx, types.ModuleEvmAddress, nil, code)
+	if err != nil {
+		return ethcmn.Address{}, sdkerrors.Wrapf(types.ErrEvmCallFailed, "deploy the wrapper of %s: %s", denom, err.Error())
+	}
+	return contract, nil
+}
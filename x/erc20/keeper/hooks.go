@@ -0,0 +1,56 @@
+package keeper
+
+import (
+	"math/big"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/erc20/types"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+)
+
+var _ evmtypes.EvmHooks = Hooks{}
+
+// Hooks wrapper struct for the erc20 keeper
+type Hooks struct {
+	k Keeper
+}
+
+// Hooks returns the evm hooks of the erc20 keeper
+func (k Keeper) Hooks() Hooks {
+	return Hooks{k}
+}
+
+// PostTxProcessing converts the erc20 tokens of the mapped contracts, which are transferred to the evm account of
+// the module in the tx, to the native tokens. The tokens are converted from the earth milestone.
+func (h Hooks) PostTxProcessing(ctx sdk.Context, _ ethcmn.Address, _ *ethcmn.Address, logs []*ethtypes.Log) error {
+	if !tmtypes.HigherThanEarth(ctx.BlockHeight()) {
+		return nil
+	}
+	for _, log := range logs {
+		if len(log.Topics) != 3 || log.Topics[0] != types.TransferEventID ||
+			ethcmn.BytesToAddress(log.Topics[2].Bytes()) != types.ModuleEvmAddress {
+			continue
+		}
+		mapping, found := h.k.GetTokenMappingByContract(ctx, log.Address)
+		if !found {
+			continue
+		}
+
+		out, err := types.ERC20ABI.Unpack("Transfer", log.Data)
+		if err != nil || len(out) != 1 {
+			continue
+		}
+		value, ok := out[0].(*big.Int)
+		if !ok {
+			continue
+		}
+		sender := ethcmn.BytesToAddress(log.Topics[1].Bytes())
+		if err := h.k.ConvertERC20ToNative(ctx, mapping, sender, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
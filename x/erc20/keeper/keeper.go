@@ -0,0 +1,40 @@
+package keeper
+
+import (
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/okex/exchain/x/erc20/types"
+)
+
+// Keeper of the erc20 store
+type Keeper struct {
+	storeKey     sdk.StoreKey
+	cdc          *codec.Codec
+	supplyKeeper types.SupplyKeeper
+	tokenKeeper  types.TokenKeeper
+	evmKeeper    types.EvmKeeper
+	govKeeper    GovKeeper
+}
+
+// NewKeeper creates an erc20 keeper
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, supplyKeeper types.SupplyKeeper, tokenKeeper types.TokenKeeper,
+	evmKeeper types.EvmKeeper) Keeper {
+	return Keeper{
+		storeKey:     key,
+		cdc:          cdc,
+		supplyKeeper: supplyKeeper,
+		tokenKeeper:  tokenKeeper,
+		evmKeeper:    evmKeeper,
+	}
+}
+
+// Logger returns a module-specific logger
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", types.ModuleName)
+}
+
+// SetGovKeeper sets keeper of gov
+func (k *Keeper) SetGovKeeper(gk GovKeeper) {
+	k.govKeeper = gk
+}
@@ -0,0 +1,206 @@
+package keeper_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/okex/exchain/app"
+	"github.com/okex/exchain/app/crypto/ethsecp256k1"
+	ethermint "github.com/okex/exchain/app/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/erc20/types"
+	"github.com/okex/exchain/x/evm"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+	tokentypes "github.com/okex/exchain/x/token/types"
+)
+
+const testDenom = "xxb"
+
+type KeeperTestSuite struct {
+	suite.Suite
+
+	ctx  sdk.Context
+	app  *app.OKExChainApp
+	addr sdk.AccAddress
+}
+
+func (suite *KeeperTestSuite) SetupTest() {
+	tmtypes.UnittestOnlySetMilestoneEarthHeight(1)
+	suite.app = app.Setup(false)
+	suite.ctx = suite.app.BaseApp.NewContext(false, abci.Header{Height: 1, ChainID: "ethermint-3", Time: time.Now().UTC()})
+	suite.addr = sdk.AccAddress(ethcmn.HexToAddress("0x756F45E3FA69347A9A973A725E3C98bC4db0b4c1").Bytes())
+
+	suite.app.TokenKeeper.NewToken(suite.ctx, tokentypes.Token{
+		Symbol:              testDenom,
+		OriginalSymbol:      "XXB",
+		WholeName:           "XXB Token",
+		OriginalTotalSupply: sdk.NewDec(100),
+		Owner:               suite.addr,
+	})
+	coins := sdk.NewCoins(sdk.NewDecCoinFromDec(testDenom, sdk.NewDec(100)))
+	suite.Require().NoError(suite.app.SupplyKeeper.MintCoins(suite.ctx, types.ModuleName, coins))
+	suite.Require().NoError(suite.app.SupplyKeeper.SendCoinsFromModuleToAccount(suite.ctx, types.ModuleName, suite.addr, coins))
+}
+
+func (suite *KeeperTestSuite) TearDownTest() {
+	tmtypes.UnittestOnlySetMilestoneEarthHeight(0)
+}
+
+func TestKeeperTestSuite(t *testing.T) {
+	suite.Run(t, new(KeeperTestSuite))
+}
+
+func (suite *KeeperTestSuite) callContract(from ethcmn.Address, contract ethcmn.Address, method string,
+	args ...interface{}) []interface{} {
+	data, err := types.ERC20ABI.Pack(method, args...)
+	suite.Require().NoError(err)
+	ret, _, err := suite.app.EvmKeeper.CallEvm(suite.ctx, from, &contract, data)
+	suite.Require().NoError(err)
+	out, err := types.ERC20ABI.Unpack(method, ret)
+	suite.Require().NoError(err)
+	return out
+}
+
+func (suite *KeeperTestSuite) balance() sdk.Dec {
+	return suite.app.BankKeeper.GetCoins(suite.ctx, suite.addr).AmountOf(testDenom)
+}
+
+func (suite *KeeperTestSuite) TestWrapperMapping() {
+	k := suite.app.Erc20Keeper
+	proposal := types.NewTokenMappingProposal("title", "description", testDenom, "")
+	suite.Require().NoError(k.RegisterTokenMapping(suite.ctx, proposal))
+	// a denom is mapped once
+	suite.Require().Error(k.CheckTokenMappingProposal(suite.ctx, proposal))
+
+	mapping, found := k.GetTokenMapping(suite.ctx, testDenom)
+	suite.Require().True(found)
+	suite.Require().True(mapping.Wrapper)
+	byContract, found := k.GetTokenMappingByContract(suite.ctx, mapping.ContractAddress())
+	suite.Require().True(found)
+	suite.Require().Equal(mapping, byContract)
+	suite.Require().Equal([]types.TokenMapping{mapping}, k.GetTokenMappings(suite.ctx))
+
+	contract := mapping.ContractAddress()
+	holder := ethcmn.BytesToAddress(suite.addr)
+	suite.Require().Equal("XXB Token", suite.callContract(holder, contract, "name")[0])
+	suite.Require().Equal(types.ModuleEvmAddress, suite.callContract(holder, contract, "owner")[0])
+
+	// native -> erc20
+	amount := sdk.NewDecCoinFromDec(testDenom, sdk.NewDec(40))
+	suite.Require().NoError(k.ConvertNativeToERC20(suite.ctx, suite.addr, amount))
+	suite.Require().Equal(sdk.NewDec(60), suite.balance())
+	suite.Require().Equal(amount.Amount.BigInt(), suite.callContract(holder, contract, "balanceOf", holder)[0])
+	suite.Require().Error(k.ConvertNativeToERC20(suite.ctx, suite.addr, sdk.NewDecCoinFromDec(testDenom, sdk.NewDec(61))))
+
+	// erc20 -> native, the hook reacts on the transfer to the evm account of the module
+	value := sdk.NewDec(15).BigInt()
+	suite.callContract(holder, contract, "transfer", types.ModuleEvmAddress, value)
+	log := &ethtypes.Log{
+		Address: contract,
+		Topics:  []ethcmn.Hash{types.TransferEventID, holder.Hash(), types.ModuleEvmAddress.Hash()},
+		Data:    ethcmn.LeftPadBytes(value.Bytes(), 32),
+	}
+	suite.Require().NoError(k.Hooks().PostTxProcessing(suite.ctx, holder, &contract, []*ethtypes.Log{log}))
+	suite.Require().Equal(sdk.NewDec(75), suite.balance())
+	suite.Require().Equal(sdk.NewDec(25).BigInt(), suite.callContract(holder, contract, "balanceOf", holder)[0])
+	suite.Require().Zero(suite.callContract(holder, contract, "balanceOf", types.ModuleEvmAddress)[0].(*big.Int).Sign())
+	suite.Require().Equal(sdk.NewDec(25).BigInt(), suite.callContract(holder, contract, "totalSupply")[0])
+
+	// the transfers of the unmapped contracts are ignored
+	log.Address = ethcmn.HexToAddress("0x01")
+	suite.Require().NoError(k.Hooks().PostTxProcessing(suite.ctx, holder, &contract, []*ethtypes.Log{log}))
+	suite.Require().Equal(sdk.NewDec(75), suite.balance())
+}
+
+func (suite *KeeperTestSuite) TestHooksBeforeEarth() {
+	k := suite.app.Erc20Keeper
+	proposal := types.NewTokenMappingProposal("title", "description", testDenom, "")
+	suite.Require().NoError(k.RegisterTokenMapping(suite.ctx, proposal))
+	mapping, found := k.GetTokenMapping(suite.ctx, testDenom)
+	suite.Require().True(found)
+	contract := mapping.ContractAddress()
+	holder := ethcmn.BytesToAddress(suite.addr)
+	amount := sdk.NewDecCoinFromDec(testDenom, sdk.NewDec(40))
+	suite.Require().NoError(k.ConvertNativeToERC20(suite.ctx, suite.addr, amount))
+	value := sdk.NewDec(15).BigInt()
+	suite.callContract(holder, contract, "transfer", types.ModuleEvmAddress, value)
+	log := &ethtypes.Log{
+		Address: contract,
+		Topics:  []ethcmn.Hash{types.TransferEventID, holder.Hash(), types.ModuleEvmAddress.Hash()},
+		Data:    ethcmn.LeftPadBytes(value.Bytes(), 32),
+	}
+
+	// the tokens are not converted before the earth milestone
+	tmtypes.UnittestOnlySetMilestoneEarthHeight(2)
+	suite.Require().NoError(k.Hooks().PostTxProcessing(suite.ctx, holder, &contract, []*ethtypes.Log{log}))
+	suite.Require().Equal(sdk.NewDec(60), suite.balance())
+	suite.Require().Equal(value, suite.callContract(holder, contract, "balanceOf", types.ModuleEvmAddress)[0])
+}
+
+func (suite *KeeperTestSuite) TestHooksInSimulatedTx() {
+	k := suite.app.Erc20Keeper
+	proposal := types.NewTokenMappingProposal("title", "description", testDenom, "")
+	suite.Require().NoError(k.RegisterTokenMapping(suite.ctx, proposal))
+	mapping, found := k.GetTokenMapping(suite.ctx, testDenom)
+	suite.Require().True(found)
+	contract := mapping.ContractAddress()
+
+	priv, err := ethsecp256k1.GenerateKey()
+	suite.Require().NoError(err)
+	sender := ethcrypto.PubkeyToAddress(priv.ToECDSA().PublicKey)
+	value := sdk.NewDec(15).BigInt()
+	suite.Require().NoError(k.ConvertNativeToERC20(suite.ctx, suite.addr, sdk.NewDecCoinFromDec(testDenom, sdk.NewDec(40))))
+	suite.callContract(ethcmn.BytesToAddress(suite.addr), contract, "transfer", sender, value)
+
+	params := evmtypes.DefaultParams()
+	params.EnableCall = true
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+	suite.Require().NoError(ethermint.SetChainId(suite.ctx.ChainID()))
+	data, err := types.ERC20ABI.Pack("transfer", types.ModuleEvmAddress, value)
+	suite.Require().NoError(err)
+	tx := evmtypes.NewMsgEthereumTx(0, &contract, big.NewInt(0), 1000000, big.NewInt(1), data)
+	suite.Require().NoError(tx.Sign(big.NewInt(3), priv.ToECDSA()))
+
+	// the hooks convert the tokens in the simulated tx as well, so that their gas is estimated
+	ctx := suite.ctx.WithIsCheckTx(true).WithGasMeter(sdk.NewInfiniteGasMeter())
+	_, err = evm.NewHandler(suite.app.EvmKeeper)(ctx, tx)
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewDec(15), suite.app.BankKeeper.GetCoins(ctx, sdk.AccAddress(sender.Bytes())).AmountOf(testDenom))
+}
+
+func (suite *KeeperTestSuite) TestCheckTokenMappingProposal() {
+	k := suite.app.Erc20Keeper
+	// the wrapper of a denom which is not issued
+	suite.Require().Error(k.CheckTokenMappingProposal(suite.ctx,
+		types.NewTokenMappingProposal("title", "description", "yyb", "")))
+	// an issued denom is only mapped to its wrapper
+	suite.Require().Error(k.CheckTokenMappingProposal(suite.ctx,
+		types.NewTokenMappingProposal("title", "description", testDenom, "0x1033796B018B2bf0Fc9CB88c0793b2F275eDB624")))
+	// the contract must be an erc20 contract with 18 decimals
+	suite.Require().Error(k.CheckTokenMappingProposal(suite.ctx,
+		types.NewTokenMappingProposal("title", "description", "yyb", "0x1033796B018B2bf0Fc9CB88c0793b2F275eDB624")))
+
+	// an external erc20 contract, deployed by the module for the test
+	code, err := types.WrapperDeployCode("External", "ext")
+	suite.Require().NoError(err)
+	_, contract, err := suite.app.EvmKeeper.CallEvm(suite.ctx, types.ModuleEvmAddress, nil, code)
+	suite.Require().NoError(err)
+	proposal := types.NewTokenMappingProposal("title", "description", "yyb", contract.Hex())
+	suite.Require().NoError(k.RegisterTokenMapping(suite.ctx, proposal))
+	suite.Require().True(suite.app.TokenKeeper.TokenExist(suite.ctx, "yyb"))
+	mapping, found := k.GetTokenMapping(suite.ctx, "yyb")
+	suite.Require().True(found)
+	suite.Require().False(mapping.Wrapper)
+
+	// the contract is mapped once
+	suite.Require().Error(k.CheckTokenMappingProposal(suite.ctx,
+		types.NewTokenMappingProposal("title", "description", "zzb", contract.Hex())))
+}
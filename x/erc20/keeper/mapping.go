@@ -0,0 +1,55 @@
+package keeper
+
+import (
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/erc20/types"
+)
+
+// GetTokenMapping gets the token mapping of a native denom
+func (k Keeper) GetTokenMapping(ctx sdk.Context, denom string) (mapping types.TokenMapping, found bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetDenomToMappingKey(denom))
+	if bz == nil {
+		return mapping, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &mapping)
+	return mapping, true
+}
+
+// GetTokenMappingByContract gets the token mapping of an erc20 contract
+func (k Keeper) GetTokenMappingByContract(ctx sdk.Context, contract ethcmn.Address) (mapping types.TokenMapping, found bool) {
+	denom := ctx.KVStore(k.storeKey).Get(types.GetContractToMappingKey(contract))
+	if denom == nil {
+		return mapping, false
+	}
+	return k.GetTokenMapping(ctx, string(denom))
+}
+
+// SetTokenMapping sets the token mapping and the index of its contract
+func (k Keeper) SetTokenMapping(ctx sdk.Context, mapping types.TokenMapping) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetDenomToMappingKey(mapping.Denom), k.cdc.MustMarshalBinaryLengthPrefixed(mapping))
+	store.Set(types.GetContractToMappingKey(mapping.ContractAddress()), []byte(mapping.Denom))
+}
+
+// IsDenomMapped returns true if the native denom is mapped
+func (k Keeper) IsDenomMapped(ctx sdk.Context, denom string) bool {
+	return ctx.KVStore(k.storeKey).Has(types.GetDenomToMappingKey(denom))
+}
+
+// IsContractMapped returns true if the erc20 contract is mapped
+func (k Keeper) IsContractMapped(ctx sdk.Context, contract ethcmn.Address) bool {
+	return ctx.KVStore(k.storeKey).Has(types.GetContractToMappingKey(contract))
+}
+
+// GetTokenMappings gets all the token mappings
+func (k Keeper) GetTokenMappings(ctx sdk.Context) (mappings []types.TokenMapping) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.DenomToMappingPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var mapping types.TokenMapping
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &mapping)
+		mappings = append(mappings, mapping)
+	}
+	return mappings
+}
@@ -0,0 +1,138 @@
+package keeper
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/erc20/types"
+	sdkGov "github.com/okex/exchain/x/gov"
+	govKeeper "github.com/okex/exchain/x/gov/keeper"
+	govTypes "github.com/okex/exchain/x/gov/types"
+	tokentypes "github.com/okex/exchain/x/token/types"
+)
+
+var _ govKeeper.ProposalHandler = (*Keeper)(nil)
+
+// GetMinDeposit returns min deposit
+func (k Keeper) GetMinDeposit(ctx sdk.Context, content sdkGov.Content) (minDeposit sdk.SysCoins) {
+	if _, ok := content.(types.TokenMappingProposal); ok {
+		minDeposit = k.govKeeper.GetDepositParams(ctx).MinDeposit
+	}
+
+	return
+}
+
+// GetMaxDepositPeriod returns max deposit period
+func (k Keeper) GetMaxDepositPeriod(ctx sdk.Context, content sdkGov.Content) (maxDepositPeriod time.Duration) {
+	if _, ok := content.(types.TokenMappingProposal); ok {
+		maxDepositPeriod = k.govKeeper.GetDepositParams(ctx).MaxDepositPeriod
+	}
+
+	return
+}
+
+// GetVotingPeriod returns voting period
+func (k Keeper) GetVotingPeriod(ctx sdk.Context, content sdkGov.Content) (votingPeriod time.Duration) {
+	if _, ok := content.(types.TokenMappingProposal); ok {
+		votingPeriod = k.govKeeper.GetVotingParams(ctx).VotingPeriod
+	}
+
+	return
+}
+
+// CheckMsgSubmitProposal validates MsgSubmitProposal, the proposals are submitted from the earth milestone
+func (k Keeper) CheckMsgSubmitProposal(ctx sdk.Context, msg govTypes.MsgSubmitProposal) sdk.Error {
+	if !tmtypes.HigherThanEarth(ctx.BlockHeight()) {
+		return types.ErrNotEnabled
+	}
+	switch content := msg.Content.(type) {
+	case types.TokenMappingProposal:
+		return k.CheckTokenMappingProposal(ctx, content)
+	default:
+		return sdk.ErrUnknownRequest(fmt.Sprintf("unrecognized %s proposal content type: %T", types.DefaultCodespace, content))
+	}
+}
+
+// nolint
+func (k Keeper) AfterSubmitProposalHandler(_ sdk.Context, _ govTypes.Proposal) {}
+func (k Keeper) AfterDepositPeriodPassed(_ sdk.Context, _ govTypes.Proposal)   {}
+func (k Keeper) RejectedHandler(_ sdk.Context, _ govTypes.Content)             {}
+func (k Keeper) VoteHandler(_ sdk.Context, _ govTypes.Proposal, _ govTypes.Vote) (string, sdk.Error) {
+	return "", nil
+}
+
+// CheckTokenMappingProposal checks whether the denom and the contract of the proposal can be mapped.
+// The denom of a wrapper must be an issued token, while the denom of an external erc20 contract must not be,
+// and the external contract must have 18 decimals.
+func (k Keeper) CheckTokenMappingProposal(ctx sdk.Context, proposal types.TokenMappingProposal) sdk.Error {
+	if k.IsDenomMapped(ctx, proposal.Denom) {
+		return sdkerrors.Wrapf(types.ErrMappingExists, "denom %s", proposal.Denom)
+	}
+
+	if proposal.IsWrapper() {
+		if !k.tokenKeeper.TokenExist(ctx, proposal.Denom) {
+			return sdkerrors.Wrapf(types.ErrTokenNotFound, "denom %s", proposal.Denom)
+		}
+		return nil
+	}
+
+	if k.tokenKeeper.TokenExist(ctx, proposal.Denom) {
+		return sdkerrors.Wrapf(types.ErrInvalidMapping, "the issued token %s can only be mapped to its wrapper", proposal.Denom)
+	}
+	contract := ethcmn.HexToAddress(proposal.Contract)
+	if k.IsContractMapped(ctx, contract) {
+		return sdkerrors.Wrapf(types.ErrMappingExists, "contract %s", contract.Hex())
+	}
+	// the call is run on a cached context, so that the check leaves nothing in the state
+	cacheCtx, _ := ctx.CacheContext()
+	out, err := k.callContract(cacheCtx, contract, "decimals")
+	if err != nil {
+		return err
+	}
+	if decimals, ok := out[0].(uint8); !ok || decimals != sdk.Precision {
+		return sdkerrors.Wrapf(types.ErrInvalidMapping, "the decimals of contract %s must be %d", contract.Hex(), sdk.Precision)
+	}
+	return nil
+}
+
+// RegisterTokenMapping registers the mapping of the proposal, deploying the canonical wrapper of the denom
+// or issuing the native token of the external erc20 contract
+func (k Keeper) RegisterTokenMapping(ctx sdk.Context, proposal types.TokenMappingProposal) sdk.Error {
+	if err := k.CheckTokenMappingProposal(ctx, proposal); err != nil {
+		return err
+	}
+
+	var mapping types.TokenMapping
+	if proposal.IsWrapper() {
+		contract, err := k.deployWrapper(ctx, proposal.Denom)
+		if err != nil {
+			return err
+		}
+		mapping = types.NewTokenMapping(proposal.Denom, contract, true)
+	} else {
+		k.tokenKeeper.NewToken(ctx, tokentypes.Token{
+			Description:         fmt.Sprintf("mapped from the erc20 contract %s", ethcmn.HexToAddress(proposal.Contract).Hex()),
+			Symbol:              proposal.Denom,
+			OriginalSymbol:      strings.ToUpper(proposal.Denom),
+			WholeName:           proposal.Denom,
+			OriginalTotalSupply: sdk.ZeroDec(),
+			Owner:               k.supplyKeeper.GetModuleAddress(types.ModuleName),
+			Mintable:            false,
+		})
+		mapping = types.NewTokenMapping(proposal.Denom, ethcmn.HexToAddress(proposal.Contract), false)
+	}
+	k.SetTokenMapping(ctx, mapping)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeRegisterMapping,
+		sdk.NewAttribute(types.AttributeKeyDenom, mapping.Denom),
+		sdk.NewAttribute(types.AttributeKeyContract, mapping.Contract),
+		sdk.NewAttribute(types.AttributeKeyWrapper, fmt.Sprintf("%t", mapping.Wrapper)),
+	))
+	return nil
+}
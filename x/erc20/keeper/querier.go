@@ -0,0 +1,63 @@
+package keeper
+
+import (
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/x/erc20/types"
+)
+
+// NewQuerier creates a new querier for erc20 clients.
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		switch path[0] {
+		case types.QueryTokenMapping:
+			return queryTokenMapping(ctx, req, k)
+		case types.QueryTokenMappings:
+			return queryTokenMappings(ctx, k)
+		default:
+			return nil, sdkerrors.Wrap(types.ErrUnknownQueryType, path[0])
+		}
+	}
+}
+
+func queryTokenMapping(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	var params types.QueryTokenMappingParams
+	if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	var (
+		mapping types.TokenMapping
+		found   bool
+	)
+	if ethcmn.IsHexAddress(params.Key) {
+		mapping, found = k.GetTokenMappingByContract(ctx, ethcmn.HexToAddress(params.Key))
+	} else {
+		mapping, found = k.GetTokenMapping(ctx, params.Key)
+	}
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrMappingNotFound, params.Key)
+	}
+
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, mapping)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func queryTokenMappings(ctx sdk.Context, k Keeper) ([]byte, sdk.Error) {
+	mappings := k.GetTokenMappings(ctx)
+	if mappings == nil {
+		mappings = []types.TokenMapping{}
+	}
+
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, mappings)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
@@ -0,0 +1,20 @@
+package erc20
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/common"
+	"github.com/okex/exchain/x/erc20/types"
+	govTypes "github.com/okex/exchain/x/gov/types"
+)
+
+// NewTokenMappingProposalHandler handles "gov" type message in "erc20"
+func NewTokenMappingProposalHandler(k *Keeper) govTypes.Handler {
+	return func(ctx sdk.Context, proposal *govTypes.Proposal) (err sdk.Error) {
+		switch content := proposal.Content.(type) {
+		case types.TokenMappingProposal:
+			return k.RegisterTokenMapping(ctx, content)
+		default:
+			return common.ErrUnknownProposalType(DefaultCodespace, content.ProposalType())
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// the code of the canonical erc20 wrapper, compiled from contracts/wrapper_constructor.easm and contracts/wrapper.easm
+// by the go-ethereum assembler. The code hash of the deployed wrappers is pinned by TestWrapperCodeHash.
+const (
+	wrapperConstructorCode = "336000556080803803600039600051600455602051600555604051600655606051600755630000003a60010180608001380380916000396000f35b"
+	wrapperRuntimeCode     = "3463000000b3576004361063000000b35760003560e01c806370a082311463000000e7578063a9059cbb1463000001b057806323b872dd1463000001d7578063095ea7b314630000014a578063dd62ed3e14630000011257806318160ddd1463000000d3578063313ce5671463000000ca57806306fdde031463000000b857806395d89b411463000000c15780638da5cb5b1463000000dd57806340c10f1914630000022c5780639dc29fac1463000002b2575b600080fd5b600463000003da565b600663000003da565b601263000003d1565b60015463000003d1565b60005463000003d1565b6024361063000000b357630000010a6004358060a01c63000000b357630000039c565b5463000003d1565b6044361063000000b35763000001426004358060a01c63000000b3576024358060a01c63000000b35763000003ac565b5463000003d1565b6044361063000000b3576300000176336004358060a01c63000000b357801563000000b35763000003ac565b602435806080529055600435337f8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b92560206080a363000003c8565b6044361063000000b35763000003c86024356004358060a01c63000000b357336300000333565b6064361063000000b35763000001fb6004358060a01c63000000b3573363000003ac565b805460443581811163000000b3579003905563000003c86044356024358060a01c63000000b3576004356300000333565b6044361063000000b35760005433141563000000b357602435600154810181811063000000b35760015563000002776004358060a01c63000000b357801563000000b357630000039c565b80548201905560805260043560007fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef60206080a363000003c8565b6044361063000000b35760005433141563000000b35763000002e16004358060a01c63000000b357630000039c565b805460243580821063000000b35780600154036001559003905560243560805260006004357fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef60206080a363000003c8565b811563000000b357630000034881630000039c565b805484811063000000b3578490039055630000036582630000039c565b8054840190558260805281817fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef60206080a3505050565b6000526002602052604060002090565b9060005260036020526040600020602052600052604060002090565b600163000003d1565b60805260206080f35b6020608052806001015460a0525460c05260606080f3"
+
+	// maxWrapperStringLength is the max length of the name and the symbol, which are stored in a single word
+	maxWrapperStringLength = 32
+)
+
+const erc20ABIJSON = `[
+{"type":"function","name":"name","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"string"}]},
+{"type":"function","name":"symbol","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"string"}]},
+{"type":"function","name":"decimals","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint8"}]},
+{"type":"function","name":"totalSupply","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+{"type":"function","name":"owner","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"address"}]},
+{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"account","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+{"type":"function","name":"allowance","stateMutability":"view","inputs":[{"name":"owner","type":"address"},{"name":"spender","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+{"type":"function","name":"approve","stateMutability":"nonpayable","inputs":[{"name":"spender","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+{"type":"function","name":"transfer","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+{"type":"function","name":"transferFrom","stateMutability":"nonpayable","inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+{"type":"function","name":"mint","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+{"type":"function","name":"burn","stateMutability":"nonpayable","inputs":[{"name":"from","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+{"type":"event","name":"Transfer","anonymous":false,"inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}]},
+{"type":"event","name":"Approval","anonymous":false,"inputs":[{"name":"owner","type":"address","indexed":true},{"name":"spender","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}]}
+]`
+
+var (
+	// ERC20ABI is the abi of the erc20 standard, with the mint and burn methods of the canonical wrapper
+	ERC20ABI abi.ABI
+
+	// TransferEventID is the topic of the erc20 Transfer event
+	TransferEventID ethcmn.Hash
+)
+
+func init() {
+	var err error
+	ERC20ABI, err = abi.JSON(strings.NewReader(erc20ABIJSON))
+	if err != nil {
+		panic(err)
+	}
+	TransferEventID = ERC20ABI.Events["Transfer"].ID
+}
+
+// WrapperDeployCode returns the code to deploy the canonical erc20 wrapper with the name and the symbol
+func WrapperDeployCode(name, symbol string) ([]byte, error) {
+	if len(name) == 0 || len(name) > maxWrapperStringLength {
+		return nil, fmt.Errorf("the length of the wrapper name must be between 1 and %d: %s", maxWrapperStringLength, name)
+	}
+	if len(symbol) == 0 || len(symbol) > maxWrapperStringLength {
+		return nil, fmt.Errorf("the length of the wrapper symbol must be between 1 and %d: %s", maxWrapperStringLength, symbol)
+	}
+
+	constructor, err := hex.DecodeString(wrapperConstructorCode)
+	if err != nil {
+		return nil, err
+	}
+	runtime, err := hex.DecodeString(wrapperRuntimeCode)
+	if err != nil {
+		return nil, err
+	}
+
+	code := append(constructor, runtime...)
+	for _, s := range []string{name, symbol} {
+		code = append(code, ethcmn.RightPadBytes([]byte(s), 32)...)
+		code = append(code, ethcmn.LeftPadBytes(big.NewInt(int64(len(s))).Bytes(), 32)...)
+	}
+	return code, nil
+}
+
+// WrapperName returns the name of the canonical wrapper of a native token, truncated to fit in a word
+func WrapperName(wholeName string) string {
+	if len(wholeName) > maxWrapperStringLength {
+		return wholeName[:maxWrapperStringLength]
+	}
+	return wholeName
+}
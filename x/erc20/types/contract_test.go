@@ -0,0 +1,148 @@
+package types
+
+import (
+	"io/ioutil"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/asm"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func compileEasm(t *testing.T, path string) string {
+	src, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	compiler := asm.NewCompiler(false)
+	compiler.Feed(asm.Lex(src, false))
+	bin, errs := compiler.Compile()
+	require.Empty(t, errs)
+	return bin
+}
+
+func TestWrapperCodeCompiled(t *testing.T) {
+	require.Equal(t, wrapperConstructorCode, compileEasm(t, "contracts/wrapper_constructor.easm"))
+	require.Equal(t, wrapperRuntimeCode, compileEasm(t, "contracts/wrapper.easm"))
+}
+
+// the code hashes of the canonical wrapper, a change of them changes the code of the wrappers deployed from then on
+const (
+	wrapperConstructorCodeHash = "0xd0e4d9dad7a0510f80e317d9fe3de59f272e5afb87b9deb3acf8ff670607b5b3"
+	wrapperRuntimeCodeHash     = "0x72ec6fcf4ca1e68ee7b82f0bdea6e047c2be016e04d981798b2229449535adae"
+)
+
+func TestWrapperCodeHash(t *testing.T) {
+	require.Equal(t, wrapperConstructorCodeHash, crypto.Keccak256Hash(common.FromHex(wrapperConstructorCode)).Hex())
+	require.Equal(t, wrapperRuntimeCodeHash, crypto.Keccak256Hash(common.FromHex(wrapperRuntimeCode)).Hex())
+
+	// the deployed code is the runtime code
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	cfg := &runtime.Config{State: statedb, GasLimit: 10000000}
+	code, err := WrapperDeployCode("Test Token", "test")
+	require.NoError(t, err)
+	_, contract, _, err := runtime.Create(code, cfg)
+	require.NoError(t, err)
+	require.Equal(t, wrapperRuntimeCodeHash, statedb.GetCodeHash(contract).Hex())
+}
+
+type wrapperTester struct {
+	t        *testing.T
+	cfg      *runtime.Config
+	contract common.Address
+}
+
+func (wt wrapperTester) call(from common.Address, method string, args ...interface{}) ([]interface{}, error) {
+	input, err := ERC20ABI.Pack(method, args...)
+	require.NoError(wt.t, err)
+	wt.cfg.Origin = from
+	ret, _, err := runtime.Call(wt.contract, input, wt.cfg)
+	if err != nil {
+		return nil, err
+	}
+	return ERC20ABI.Unpack(method, ret)
+}
+
+func (wt wrapperTester) mustCall(from common.Address, method string, args ...interface{}) interface{} {
+	out, err := wt.call(from, method, args...)
+	require.NoError(wt.t, err)
+	require.Len(wt.t, out, 1)
+	return out[0]
+}
+
+func TestWrapperContract(t *testing.T) {
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	owner := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	alice := common.HexToAddress("0x2000000000000000000000000000000000000002")
+	bob := common.HexToAddress("0x3000000000000000000000000000000000000003")
+	cfg := &runtime.Config{State: statedb, Origin: owner, GasLimit: 10000000}
+
+	code, err := WrapperDeployCode("Test Token", "test")
+	require.NoError(t, err)
+	_, contract, _, err := runtime.Create(code, cfg)
+	require.NoError(t, err)
+	wt := wrapperTester{t: t, cfg: cfg, contract: contract}
+
+	require.Equal(t, "Test Token", wt.mustCall(alice, "name"))
+	require.Equal(t, "test", wt.mustCall(alice, "symbol"))
+	require.Equal(t, uint8(18), wt.mustCall(alice, "decimals"))
+	require.Equal(t, owner, wt.mustCall(alice, "owner"))
+
+	// only the owner mints and burns
+	_, err = wt.call(alice, "mint", alice, big.NewInt(100))
+	require.Error(t, err)
+	require.Equal(t, true, wt.mustCall(owner, "mint", alice, big.NewInt(100)))
+	require.Equal(t, big.NewInt(100), wt.mustCall(bob, "totalSupply"))
+	require.Equal(t, big.NewInt(100), wt.mustCall(bob, "balanceOf", alice))
+	require.Len(t, statedb.Logs(), 1)
+	require.Equal(t, TransferEventID, statedb.Logs()[0].Topics[0])
+
+	// transfer
+	require.Equal(t, true, wt.mustCall(alice, "transfer", bob, big.NewInt(30)))
+	require.Equal(t, big.NewInt(70), wt.mustCall(bob, "balanceOf", alice))
+	require.Equal(t, big.NewInt(30), wt.mustCall(bob, "balanceOf", bob))
+	_, err = wt.call(alice, "transfer", bob, big.NewInt(71))
+	require.Error(t, err)
+	_, err = wt.call(alice, "transfer", common.Address{}, big.NewInt(1))
+	require.Error(t, err)
+
+	// approve and transferFrom
+	require.Equal(t, true, wt.mustCall(alice, "approve", bob, big.NewInt(50)))
+	require.Equal(t, big.NewInt(50), wt.mustCall(bob, "allowance", alice, bob))
+	_, err = wt.call(bob, "transferFrom", alice, bob, big.NewInt(51))
+	require.Error(t, err)
+	require.Equal(t, true, wt.mustCall(bob, "transferFrom", alice, owner, big.NewInt(20)))
+	require.Equal(t, big.NewInt(30), wt.mustCall(bob, "allowance", alice, bob))
+	require.Equal(t, big.NewInt(50), wt.mustCall(bob, "balanceOf", alice))
+	require.Equal(t, big.NewInt(20), wt.mustCall(bob, "balanceOf", owner))
+
+	// burn
+	_, err = wt.call(bob, "burn", owner, big.NewInt(20))
+	require.Error(t, err)
+	_, err = wt.call(owner, "burn", owner, big.NewInt(21))
+	require.Error(t, err)
+	require.Equal(t, true, wt.mustCall(owner, "burn", owner, big.NewInt(20)))
+	require.Zero(t, wt.mustCall(bob, "balanceOf", owner).(*big.Int).Sign())
+	require.Equal(t, big.NewInt(80), wt.mustCall(bob, "totalSupply"))
+
+	// unknown methods and calls with value revert
+	cfg.Origin = alice
+	_, _, err = runtime.Call(contract, []byte{1, 2, 3, 4}, cfg)
+	require.Error(t, err)
+	cfg.Value = big.NewInt(1)
+	_, err = wt.call(alice, "totalSupply")
+	require.Error(t, err)
+}
+
+func TestWrapperDeployCode(t *testing.T) {
+	_, err := WrapperDeployCode("", "test")
+	require.Error(t, err)
+	_, err = WrapperDeployCode("name", "a-symbol-which-is-longer-than-32-bytes")
+	require.Error(t, err)
+	require.Equal(t, 32, len(WrapperName("a-name-which-is-longer-than-32-bytes")))
+}
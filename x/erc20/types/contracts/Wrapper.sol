@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+pragma solidity 0.8.11;
+
+/**
+ * @title Wrapper
+ * @dev The canonical erc20 wrapper of the native tokens. It is an erc20 token with 18 decimals,
+ * whose tokens are only minted and burnt by the owner, the erc20 module account which deploys it.
+ *
+ * Compile with compile.sh: solc 0.8.11, optimizer enabled with 200 runs, evm version istanbul.
+ * The code deployed by the erc20 module is still assembled from wrapper_constructor.easm and wrapper.easm,
+ * whose constructor takes the name and the symbol as trailing words instead of the abi encoded strings.
+ */
+contract Wrapper {
+    address public owner;
+    uint256 public totalSupply;
+    mapping(address => uint256) public balanceOf;
+    mapping(address => mapping(address => uint256)) public allowance;
+    string public name;
+    string public symbol;
+
+    event Transfer(address indexed from, address indexed to, uint256 value);
+    event Approval(address indexed owner, address indexed spender, uint256 value);
+
+    modifier onlyOwner() {
+        require(msg.sender == owner, "caller is not the owner");
+        _;
+    }
+
+    constructor(string memory name_, string memory symbol_) {
+        owner = msg.sender;
+        name = name_;
+        symbol = symbol_;
+    }
+
+    function decimals() external pure returns (uint8) {
+        return 18;
+    }
+
+    function approve(address spender, uint256 amount) external returns (bool) {
+        require(spender != address(0), "approve to the zero address");
+        allowance[msg.sender][spender] = amount;
+        emit Approval(msg.sender, spender, amount);
+        return true;
+    }
+
+    function transfer(address to, uint256 amount) external returns (bool) {
+        _transfer(msg.sender, to, amount);
+        return true;
+    }
+
+    function transferFrom(address from, address to, uint256 amount) external returns (bool) {
+        // reverts on underflow if the allowance is insufficient
+        allowance[from][msg.sender] -= amount;
+        _transfer(from, to, amount);
+        return true;
+    }
+
+    function mint(address to, uint256 amount) external onlyOwner returns (bool) {
+        require(to != address(0), "mint to the zero address");
+        totalSupply += amount;
+        balanceOf[to] += amount;
+        emit Transfer(address(0), to, amount);
+        return true;
+    }
+
+    function burn(address from, uint256 amount) external onlyOwner returns (bool) {
+        balanceOf[from] -= amount;
+        totalSupply -= amount;
+        emit Transfer(from, address(0), amount);
+        return true;
+    }
+
+    function _transfer(address from, address to, uint256 amount) private {
+        require(to != address(0), "transfer to the zero address");
+        balanceOf[from] -= amount;
+        balanceOf[to] += amount;
+        emit Transfer(from, to, amount);
+    }
+}
@@ -0,0 +1,18 @@
+#!/usr/bin/env bash
+# Compiles the canonical erc20 wrapper into wrapper.bin and wrapper.abi, with the settings
+# the deployed code must be reproduced with.
+set -euo pipefail
+
+SOLC_VERSION="0.8.11"
+cd "$(dirname "$0")"
+
+if ! solc --version | grep -q "Version: ${SOLC_VERSION}+"; then
+    echo "solc ${SOLC_VERSION} is required" >&2
+    exit 1
+fi
+
+solc --optimize --optimize-runs 200 --evm-version istanbul --metadata-hash none \
+    --bin --abi --overwrite -o build Wrapper.sol
+mv build/Wrapper.bin wrapper.bin
+mv build/Wrapper.abi wrapper.abi
+rm -rf build
@@ -0,0 +1,41 @@
+package types
+
+import (
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+)
+
+const (
+	DefaultCodespace string = ModuleName
+)
+
+var (
+	// ErrUnexpectedProposalType returns an error when the proposal type is not supported in erc20 module
+	ErrUnexpectedProposalType = sdkerrors.Register(ModuleName, 2, "Unsupported proposal type of erc20 module")
+
+	// ErrMappingNotFound returns an error if the denom or the contract is not mapped
+	ErrMappingNotFound = sdkerrors.Register(ModuleName, 3, "Token mapping not found")
+
+	// ErrMappingExists returns an error if the denom or the contract is already mapped
+	ErrMappingExists = sdkerrors.Register(ModuleName, 4, "Token mapping already exists")
+
+	// ErrInvalidMapping returns an error if the mapping can not be registered
+	ErrInvalidMapping = sdkerrors.Register(ModuleName, 5, "Invalid token mapping")
+
+	// ErrTokenNotFound returns an error if the native token to wrap is not issued
+	ErrTokenNotFound = sdkerrors.Register(ModuleName, 6, "Native token not found")
+
+	// ErrEvmCallFailed returns an error if the call to the erc20 contract failed
+	ErrEvmCallFailed = sdkerrors.Register(ModuleName, 7, "Call to the erc20 contract failed")
+
+	// ErrInvalidAmount returns an error if the amount to convert is invalid
+	ErrInvalidAmount = sdkerrors.Register(ModuleName, 8, "Invalid amount to convert")
+
+	// ErrUnknownMsgType returns an error if the msg type is not supported in erc20 module
+	ErrUnknownMsgType = sdkerrors.Register(ModuleName, 9, "Unknown erc20 msg type")
+
+	// ErrUnknownQueryType returns an error if the query endpoint is not supported in erc20 module
+	ErrUnknownQueryType = sdkerrors.Register(ModuleName, 10, "Unknown erc20 query endpoint")
+
+	// ErrNotEnabled returns an error if the erc20 module is used before the earth milestone
+	ErrNotEnabled = sdkerrors.Register(ModuleName, 11, "The erc20 module is not enabled before the earth milestone")
+)
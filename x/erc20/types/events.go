@@ -0,0 +1,17 @@
+package types
+
+// erc20 module event types
+const (
+	EventTypeRegisterMapping = "register_token_mapping"
+	EventTypeConvertNative   = "convert_native"
+	EventTypeConvertERC20    = "convert_erc20"
+
+	AttributeKeyDenom    = "denom"
+	AttributeKeyContract = "contract"
+	AttributeKeyWrapper  = "wrapper"
+	AttributeKeySender   = "sender"
+	AttributeKeyReceiver = "receiver"
+	AttributeKeyAmount   = "amount"
+
+	AttributeValueCategory = ModuleName
+)
@@ -0,0 +1,28 @@
+package types
+
+import (
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	token "github.com/okex/exchain/x/token/types"
+)
+
+// SupplyKeeper defines the expected supply interface
+type SupplyKeeper interface {
+	SendCoinsFromModuleToAccount(ctx sdk.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) error
+	SendCoinsFromAccountToModule(ctx sdk.Context, senderAddr sdk.AccAddress, recipientModule string, amt sdk.Coins) error
+	MintCoins(ctx sdk.Context, moduleName string, amt sdk.Coins) error
+	BurnCoins(ctx sdk.Context, moduleName string, amt sdk.Coins) error
+	GetModuleAddress(moduleName string) sdk.AccAddress
+}
+
+// TokenKeeper defines the expected token interface
+type TokenKeeper interface {
+	GetTokenInfo(ctx sdk.Context, symbol string) token.Token
+	TokenExist(ctx sdk.Context, symbol string) bool
+	NewToken(ctx sdk.Context, token token.Token)
+}
+
+// EvmKeeper defines the expected evm interface
+type EvmKeeper interface {
+	CallEvm(ctx sdk.Context, from ethcmn.Address, to *ethcmn.Address, data []byte) ([]byte, ethcmn.Address, error)
+}
@@ -0,0 +1,39 @@
+package types
+
+import (
+	"fmt"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// GenesisState is the erc20 state that must be provided at genesis
+type GenesisState struct {
+	TokenMappings []TokenMapping `json:"token_mappings" yaml:"token_mappings"`
+}
+
+// DefaultGenesisState returns the default genesis state of the erc20 module
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		TokenMappings: []TokenMapping{},
+	}
+}
+
+// ValidateGenesis validates the erc20 genesis parameters
+func ValidateGenesis(data GenesisState) error {
+	denoms := make(map[string]struct{}, len(data.TokenMappings))
+	contracts := make(map[ethcmn.Address]struct{}, len(data.TokenMappings))
+	for _, mapping := range data.TokenMappings {
+		if err := mapping.Validate(); err != nil {
+			return err
+		}
+		if _, ok := denoms[mapping.Denom]; ok {
+			return fmt.Errorf("duplicated denom in token mappings: %s", mapping.Denom)
+		}
+		if _, ok := contracts[mapping.ContractAddress()]; ok {
+			return fmt.Errorf("duplicated contract in token mappings: %s", mapping.Contract)
+		}
+		denoms[mapping.Denom] = struct{}{}
+		contracts[mapping.ContractAddress()] = struct{}{}
+	}
+	return nil
+}
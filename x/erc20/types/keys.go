@@ -0,0 +1,42 @@
+package types
+
+import (
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/supply"
+)
+
+const (
+	// ModuleName is the name of the module
+	ModuleName = "erc20"
+
+	// StoreKey to be used when creating the KVStore
+	StoreKey = ModuleName
+
+	// RouterKey to be used for routing msgs
+	RouterKey = ModuleName
+
+	// QuerierRoute to be used for querier msgs
+	QuerierRoute = ModuleName
+
+	// EvmAccountName is the name of the account which deploys and owns the canonical wrappers, escrows the erc20 tokens
+	// and receives the erc20 tokens to convert. It is not a module account, since the accounts in the evm must be eth accounts.
+	EvmAccountName = "erc20_evm_account"
+)
+
+var (
+	DenomToMappingPrefix    = []byte{0x01}
+	ContractToMappingPrefix = []byte{0x02}
+
+	// ModuleEvmAddress is the address of the evm account of the module
+	ModuleEvmAddress = ethcmn.BytesToAddress(supply.NewModuleAddress(EvmAccountName))
+)
+
+// GetDenomToMappingKey builds the key of a mapping by its native denom
+func GetDenomToMappingKey(denom string) []byte {
+	return append(DenomToMappingPrefix, []byte(denom)...)
+}
+
+// GetContractToMappingKey builds the key of the native denom of a mapping by its erc20 contract
+func GetContractToMappingKey(contract ethcmn.Address) []byte {
+	return append(ContractToMappingPrefix, contract.Bytes()...)
+}
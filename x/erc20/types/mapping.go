@@ -0,0 +1,54 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+// TokenMapping is the mapping between a native denom and an erc20 contract.
+// The tokens of a wrapper contract are minted and burnt by the module, while the tokens of an external contract
+// are escrowed by the evm account of the module.
+type TokenMapping struct {
+	Denom    string `json:"denom" yaml:"denom"`
+	Contract string `json:"contract" yaml:"contract"`
+	Wrapper  bool   `json:"wrapper" yaml:"wrapper"`
+}
+
+// NewTokenMapping creates a new instance of TokenMapping
+func NewTokenMapping(denom string, contract ethcmn.Address, wrapper bool) TokenMapping {
+	return TokenMapping{
+		Denom:    denom,
+		Contract: contract.Hex(),
+		Wrapper:  wrapper,
+	}
+}
+
+// ContractAddress returns the address of the erc20 contract
+func (tm TokenMapping) ContractAddress() ethcmn.Address {
+	return ethcmn.HexToAddress(tm.Contract)
+}
+
+// Validate checks the denom and the contract address of the mapping
+func (tm TokenMapping) Validate() error {
+	if err := sdk.ValidateDenom(tm.Denom); err != nil {
+		return err
+	}
+	if tm.Denom == sdk.DefaultBondDenom {
+		return fmt.Errorf("%s can not be mapped", sdk.DefaultBondDenom)
+	}
+	if !ethcmn.IsHexAddress(tm.Contract) {
+		return fmt.Errorf("invalid contract address: %s", tm.Contract)
+	}
+	return nil
+}
+
+// String returns a human readable string representation of a TokenMapping
+func (tm TokenMapping) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`TokenMapping:
+ Denom:		%s
+ Contract:	%s
+ Wrapper:	%t`, tm.Denom, tm.Contract, tm.Wrapper))
+}
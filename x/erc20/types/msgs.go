@@ -0,0 +1,54 @@
+package types
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+)
+
+const convertNativeMsgType = "convert_native"
+
+// MsgConvertNative converts the native tokens of the sender to the mapped erc20 tokens of its evm address
+type MsgConvertNative struct {
+	Sender sdk.AccAddress `json:"sender" yaml:"sender"`
+	Amount sdk.SysCoin    `json:"amount" yaml:"amount"`
+}
+
+// NewMsgConvertNative creates a new instance of MsgConvertNative
+func NewMsgConvertNative(sender sdk.AccAddress, amount sdk.SysCoin) MsgConvertNative {
+	return MsgConvertNative{
+		Sender: sender,
+		Amount: amount,
+	}
+}
+
+var _ sdk.Msg = MsgConvertNative{}
+
+func (m MsgConvertNative) Route() string {
+	return RouterKey
+}
+
+func (m MsgConvertNative) Type() string {
+	return convertNativeMsgType
+}
+
+func (m MsgConvertNative) ValidateBasic() sdk.Error {
+	if m.Sender.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "sender is required")
+	}
+	if !m.Amount.IsValid() || !m.Amount.Amount.IsPositive() {
+		return sdkerrors.Wrap(ErrInvalidAmount, m.Amount.String())
+	}
+	if m.Amount.Denom == sdk.DefaultBondDenom {
+		return sdkerrors.Wrapf(ErrMappingNotFound, "%s is not convertible", sdk.DefaultBondDenom)
+	}
+	return nil
+}
+
+func (m MsgConvertNative) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(m)
+	return sdk.MustSortJSON(bz)
+}
+
+func (m MsgConvertNative) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{m.Sender}
+}
@@ -0,0 +1,111 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	govtypes "github.com/okex/exchain/x/gov/types"
+)
+
+const (
+	// proposalTypeTokenMapping defines the type for a TokenMappingProposal
+	proposalTypeTokenMapping = "TokenMapping"
+)
+
+func init() {
+	govtypes.RegisterProposalType(proposalTypeTokenMapping)
+	govtypes.RegisterProposalTypeCodec(TokenMappingProposal{}, "okexchain/erc20/TokenMappingProposal")
+}
+
+var _ govtypes.Content = (*TokenMappingProposal)(nil)
+
+// TokenMappingProposal - structure for the proposal to map a native denom to an erc20 contract.
+// The canonical wrapper of the denom is deployed if the contract is empty.
+type TokenMappingProposal struct {
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description" yaml:"description"`
+	Denom       string `json:"denom" yaml:"denom"`
+	Contract    string `json:"contract" yaml:"contract"`
+}
+
+// NewTokenMappingProposal creates a new instance of TokenMappingProposal
+func NewTokenMappingProposal(title, description, denom, contract string) TokenMappingProposal {
+	return TokenMappingProposal{
+		Title:       title,
+		Description: description,
+		Denom:       denom,
+		Contract:    contract,
+	}
+}
+
+// GetTitle returns title of a token mapping proposal object
+func (tp TokenMappingProposal) GetTitle() string {
+	return tp.Title
+}
+
+// GetDescription returns description of a token mapping proposal object
+func (tp TokenMappingProposal) GetDescription() string {
+	return tp.Description
+}
+
+// ProposalRoute returns route key of a token mapping proposal object
+func (tp TokenMappingProposal) ProposalRoute() string {
+	return RouterKey
+}
+
+// ProposalType returns type of a token mapping proposal object
+func (tp TokenMappingProposal) ProposalType() string {
+	return proposalTypeTokenMapping
+}
+
+// IsWrapper returns true if the proposal deploys the canonical wrapper of the denom
+func (tp TokenMappingProposal) IsWrapper() bool {
+	return len(tp.Contract) == 0
+}
+
+// ValidateBasic validates a token mapping proposal
+func (tp TokenMappingProposal) ValidateBasic() sdk.Error {
+	if len(strings.TrimSpace(tp.Title)) == 0 {
+		return govtypes.ErrInvalidProposalContent("title is required")
+	}
+	if len(tp.Title) > govtypes.MaxTitleLength {
+		return govtypes.ErrInvalidProposalContent("title length is longer than the maximum title length")
+	}
+
+	if len(tp.Description) == 0 {
+		return govtypes.ErrInvalidProposalContent("description is required")
+	}
+
+	if len(tp.Description) > govtypes.MaxDescriptionLength {
+		return govtypes.ErrInvalidProposalContent("description length is longer than the maximum description length")
+	}
+
+	if tp.ProposalType() != proposalTypeTokenMapping {
+		return govtypes.ErrInvalidProposalType(tp.ProposalType())
+	}
+
+	contract := tp.Contract
+	if tp.IsWrapper() {
+		// the address is not known until the wrapper is deployed
+		contract = ethcmn.Address{}.Hex()
+	}
+	if err := (TokenMapping{Denom: tp.Denom, Contract: contract}).Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMapping, err.Error())
+	}
+
+	return nil
+}
+
+// String returns a human readable string representation of a TokenMappingProposal
+func (tp TokenMappingProposal) String() string {
+	return fmt.Sprintf(`TokenMappingProposal:
+ Title:					%s
+ Description:        	%s
+ Type:                	%s
+ Denom:					%s
+ Contract:				%s`,
+		tp.Title, tp.Description, tp.ProposalType(), tp.Denom, tp.Contract)
+}
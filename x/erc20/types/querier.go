@@ -0,0 +1,20 @@
+package types
+
+const (
+	QueryTokenMapping  = "token-mapping"
+	QueryTokenMappings = "token-mappings"
+)
+
+// QueryTokenMappingParams defines the params for the following queries:
+// - 'custom/erc20/token-mapping'
+// Key is either a native denom or an erc20 contract address
+type QueryTokenMappingParams struct {
+	Key string
+}
+
+// NewQueryTokenMappingParams creates a new instance of QueryTokenMappingParams
+func NewQueryTokenMappingParams(key string) QueryTokenMappingParams {
+	return QueryTokenMappingParams{
+		Key: key,
+	}
+}
@@ -18,10 +18,12 @@ var (
 	NewKeeper         = keeper.NewKeeper
 	TxDecoder         = types.TxDecoder
 	NewSimulateKeeper = keeper.NewSimulateKeeper
+	NewMultiEvmHooks  = types.NewMultiEvmHooks
 )
 
 //nolint
 type (
 	Keeper       = keeper.Keeper
 	GenesisState = types.GenesisState
+	EvmHooks     = types.EvmHooks
 )
@@ -36,6 +36,8 @@ type Keeper struct {
 	supplyKeeper  types.SupplyKeeper
 	bankKeeper    types.BankKeeper
 	govKeeper     GovKeeper
+	hooks         types.EvmHooks
+	precompiles   map[common.Address]types.PrecompiledContract
 
 	// Transaction counter in a block. Used on StateSB's Prepare function.
 	// It is reset to 0 every block on BeginBlock so there's no point in storing the counter
@@ -50,7 +52,7 @@ type Keeper struct {
 	LogsManages *LogsManager
 
 	// add inner block data
-	innerBlockData BlockInnerData
+	innerBlockData *BlockInnerData
 }
 
 // NewKeeper generates new evm module keeper
@@ -117,6 +119,43 @@ func NewSimulateKeeper(
 	}
 }
 
+// SetHooks sets the hooks called after the evm txs
+func (k *Keeper) SetHooks(eh types.EvmHooks) *Keeper {
+	if k.hooks != nil {
+		panic("cannot set evm hooks twice")
+	}
+	k.hooks = eh
+	return k
+}
+
+// SetPrecompiles registers the precompiled contracts of the native modules
+func (k *Keeper) SetPrecompiles(contracts ...types.PrecompiledContract) *Keeper {
+	if k.precompiles == nil {
+		k.precompiles = make(map[common.Address]types.PrecompiledContract)
+	}
+	for _, contract := range contracts {
+		if _, ok := k.precompiles[contract.Address()]; ok {
+			panic(fmt.Sprintf("precompiled contract %s is set twice", contract.Address().Hex()))
+		}
+		types.RegisterPrecompileAddress(contract.Address())
+		k.precompiles[contract.Address()] = contract
+	}
+	return k
+}
+
+// HasHooks returns whether the hooks called after the evm txs are set
+func (k Keeper) HasHooks() bool {
+	return k.hooks != nil
+}
+
+// PostTxProcessing calls the hooks with the logs of an executed evm tx
+func (k Keeper) PostTxProcessing(ctx sdk.Context, from common.Address, to *common.Address, logs []*ethtypes.Log) error {
+	if k.hooks == nil {
+		return nil
+	}
+	return k.hooks.PostTxProcessing(ctx, from, to, logs)
+}
+
 func (k Keeper) OnAccountUpdated(acc auth.Account) {
 	account := acc.GetAddress()
 	k.Watcher.DeleteAccount(account)
@@ -133,6 +172,7 @@ func (k Keeper) GenerateCSDBParams() types.CommitStateDBParams {
 		Watcher:       k.Watcher,
 		Ada:           k.Ada,
 		Cdc:           k.cdc,
+		Precompiles:   k.precompiles,
 	}
 }
 
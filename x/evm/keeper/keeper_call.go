@@ -0,0 +1,56 @@
+package keeper
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	ethermint "github.com/okex/exchain/app/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/evm/types"
+)
+
+// CallEvm executes an evm call from the from address, which is usually a module account, or creates a contract
+// if to is nil. The call is limited by the module call gas limit param. The state changes are committed to the store of ctx and the gas used is consumed from its gas meter.
+func (k *Keeper) CallEvm(ctx sdk.Context, from ethcmn.Address, to *ethcmn.Address, data []byte) (ret []byte, contract ethcmn.Address, err error) {
+	chainID, err := ethermint.ParseChainID(ctx.ChainID())
+	if err != nil {
+		return nil, contract, err
+	}
+	config, found := k.GetChainConfig(ctx)
+	if !found {
+		return nil, contract, types.ErrChainConfigNotFound
+	}
+
+	// the store operations are paid by the evm gas, the same as the evm txs
+	currentGasMeter := ctx.GasMeter()
+	ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	gasLimit := k.GetModuleCallGasLimit(ctx)
+	csdb := types.CreateEmptyCommitStateDB(k.GenerateCSDBParams(), ctx)
+	evm := types.NewModuleEVM(ctx, csdb, from, chainID, gasLimit, config)
+	defer csdb.ReleasePrecompileCall()
+
+	var leftOverGas uint64
+	if to == nil {
+		ret, contract, leftOverGas, err = evm.Create(vm.AccountRef(from), data, gasLimit, big.NewInt(0))
+	} else {
+		ret, leftOverGas, err = evm.Call(vm.AccountRef(from), *to, data, gasLimit, big.NewInt(0))
+	}
+	currentGasMeter.ConsumeGas(gasLimit-leftOverGas, "evm call of module")
+	if err != nil {
+		if reason, errUnpack := abi.UnpackRevert(ret); errUnpack == nil {
+			err = fmt.Errorf("%s: %s", err.Error(), reason)
+		}
+		return nil, contract, err
+	}
+
+	if err = csdb.Finalise(true); err != nil {
+		return nil, contract, err
+	}
+	if _, err = csdb.Commit(true); err != nil {
+		return nil, contract, err
+	}
+	return ret, contract, nil
+}
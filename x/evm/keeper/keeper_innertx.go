@@ -1,25 +1,122 @@
 package keeper
 
+import (
+	"sync"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/types/innertx"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/evm/types"
+)
+
 func initInnerDB() error {
-	return nil
+	return types.InitInnerTxDB()
+}
+
+// BlockInnerData caches the inner txs and the erc20 contracts of the block being executed
+type BlockInnerData struct {
+	mtx sync.Mutex
+
+	BlockHash   string
+	BlockHeight int64
+	TxHashes    []string
+	TxMap       map[string][]*innertx.InnerTx
+	Contracts   []*innertx.ERC20Contract
+}
+
+func defaultBlockInnerData() *BlockInnerData {
+	return &BlockInnerData{
+		TxMap: make(map[string][]*innertx.InnerTx),
+	}
+}
+
+func (d *BlockInnerData) reset(height int64, blockHash string) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	d.BlockHash = blockHash
+	d.BlockHeight = height
+	d.TxHashes = nil
+	d.TxMap = make(map[string][]*innertx.InnerTx)
+	d.Contracts = nil
 }
 
-type BlockInnerData = interface{}
+func (d *BlockInnerData) addInnerTxs(txHash string, innerTxs ...*innertx.InnerTx) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
 
-func defaultBlockInnerData() BlockInnerData {
-	return nil
+	if _, ok := d.TxMap[txHash]; !ok {
+		d.TxHashes = append(d.TxHashes, txHash)
+	}
+	d.TxMap[txHash] = append(d.TxMap[txHash], innerTxs...)
+}
+
+func (d *BlockInnerData) addContracts(contracts []*innertx.ERC20Contract) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	d.Contracts = append(d.Contracts, contracts...)
 }
 
 // InitInnerBlock init inner block data
-func (k *Keeper) InitInnerBlock(...interface{}) {}
+func (k *Keeper) InitInnerBlock(height int64, blockHash string) {
+	if !types.IsInnerTxEnabled() || k.innerBlockData == nil {
+		return
+	}
+	k.innerBlockData.reset(height, blockHash)
+}
+
+// UpdateInnerBlockData writes the inner txs of the block into the inner tx db
+func (k *Keeper) UpdateInnerBlockData(ctx sdk.Context) {
+	if !types.IsInnerTxEnabled() || k.innerBlockData == nil {
+		return
+	}
 
-func (k *Keeper) UpdateInnerBlockData(...interface{}) {}
+	data := k.innerBlockData
+	data.mtx.Lock()
+	block := &types.BlockInnerTxs{
+		BlockHash:   data.BlockHash,
+		BlockHeight: data.BlockHeight,
+		Txs:         make([]types.TxInnerTxs, 0, len(data.TxHashes)),
+	}
+	for _, txHash := range data.TxHashes {
+		block.Txs = append(block.Txs, types.TxInnerTxs{TxHash: txHash, InnerTxs: data.TxMap[txHash]})
+	}
+	contracts := data.Contracts
+	data.mtx.Unlock()
+
+	if err := types.SaveBlockInnerTxs(block, contracts); err != nil {
+		k.Logger(ctx).Error("failed to save inner txs", "height", block.BlockHeight, "error", err)
+	}
+	data.reset(0, "")
+}
 
 // AddInnerTx add inner tx
-func (k *Keeper) AddInnerTx(...interface{}) {}
+func (k *Keeper) AddInnerTx(txHash string, innerTxs []*innertx.InnerTx) {
+	if !types.IsInnerTxEnabled() || k.innerBlockData == nil || len(innerTxs) == 0 {
+		return
+	}
+	k.innerBlockData.addInnerTxs(txHash, innerTxs...)
+}
 
 // AddContract add erc20 contract
-func (k *Keeper) AddContract(...interface{}) {}
+func (k *Keeper) AddContract(contracts []*innertx.ERC20Contract) {
+	if !types.IsInnerTxEnabled() || k.innerBlockData == nil || len(contracts) == 0 {
+		return
+	}
+	k.innerBlockData.addContracts(contracts)
+}
 
-func (k *Keeper) UpdateInnerTx(...interface{}) {
+// UpdateInnerTx records a cosmos level coin transfer of the tx
+func (k *Keeper) UpdateInnerTx(txBytes []byte, depth int64, from, to sdk.AccAddress, callType, name string, amt sdk.Coins, err error) {
+	// transfers out of a tx, e.g. in BeginBlock or EndBlock, are not recorded
+	if !types.IsInnerTxEnabled() || k.innerBlockData == nil || len(txBytes) == 0 {
+		return
+	}
+	k.innerBlockData.mtx.Lock()
+	height := k.innerBlockData.BlockHeight
+	k.innerBlockData.mtx.Unlock()
+	txHash := ethcmn.BytesToHash(tmtypes.Tx(txBytes).Hash(height)).Hex()
+	k.innerBlockData.addInnerTxs(txHash, innertx.NewCosmosInnerTx(depth, from, to, callType, name, amt, err))
 }
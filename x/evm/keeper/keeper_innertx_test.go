@@ -0,0 +1,86 @@
+package keeper_test
+
+import (
+	"errors"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/types/innertx"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/evm/types"
+	"github.com/spf13/viper"
+)
+
+func (suite *KeeperTestSuite) TestInnerTx() {
+	viper.Set(types.FlagEnableInnerTx, true)
+	viper.Set("home", suite.T().TempDir())
+	suite.Require().NoError(types.InitInnerTxDB())
+	defer func() {
+		viper.Set(types.FlagEnableInnerTx, false)
+		suite.Require().NoError(types.InitInnerTxDB())
+	}()
+
+	k := suite.app.EvmKeeper
+	height := int64(5)
+	blockHash := ethcmn.HexToHash(hex)
+	k.InitInnerBlock(height, blockHash.Hex())
+
+	from := sdk.AccAddress(suite.address.Bytes())
+	to := sdk.AccAddress(ethcmn.HexToAddress("0x1000000000000000000000000000000000000001").Bytes())
+	coins := sdk.NewCoins(sdk.NewDecCoinFromDec(sdk.DefaultBondDenom, sdk.NewDec(2)))
+	cosmosTx := []byte("cosmos tx")
+	cosmosTxHash := ethcmn.BytesToHash(tmtypes.Tx(cosmosTx).Hash(height))
+	k.UpdateInnerTx(cosmosTx, innertx.CosmosDepth, from, to, innertx.CosmosCallType, innertx.SendCallName, coins, nil)
+	k.UpdateInnerTx(cosmosTx, innertx.CosmosDepth, from, to, innertx.CosmosCallType, innertx.SendCallName, coins, errors.New("failed"))
+	// transfers out of a tx are ignored
+	k.UpdateInnerTx(nil, innertx.CosmosDepth, from, to, innertx.CosmosCallType, innertx.SendCallName, coins, nil)
+
+	evmTxHash := ethcmn.HexToHash("0x01")
+	evmTxs := []*innertx.InnerTx{
+		innertx.NewEvmInnerTx(0, innertx.CosmosCallType, innertx.EvmCallName, suite.address.Hex(), to.String(), nil),
+		innertx.NewEvmInnerTx(1, innertx.EvmCallType, innertx.EvmDelegateCallName, to.String(), suite.address.Hex(), nil),
+	}
+	k.AddInnerTx(evmTxHash.Hex(), evmTxs)
+	contract := &innertx.ERC20Contract{Address: suite.address.Hex(), TxHash: evmTxHash.Hex(), BlockHeight: height}
+	k.AddContract([]*innertx.ERC20Contract{contract})
+	k.UpdateInnerBlockData(suite.ctx)
+
+	innerTxs, err := types.GetInnerTxsFromDB(cosmosTxHash)
+	suite.Require().NoError(err)
+	suite.Require().Len(innerTxs, 2)
+	suite.Require().Equal(from.String(), innerTxs[0].From)
+	suite.Require().Equal(to.String(), innerTxs[0].To)
+	suite.Require().Equal(coins.String(), innerTxs[0].Value)
+	suite.Require().Equal(sdk.NewDec(2).BigInt().String(), innerTxs[0].ValueWei)
+	suite.Require().False(innerTxs[0].IsError)
+	suite.Require().True(innerTxs[1].IsError)
+	suite.Require().Equal("failed", innerTxs[1].Error)
+
+	innerTxs, err = types.GetInnerTxsFromDB(evmTxHash)
+	suite.Require().NoError(err)
+	suite.Require().Equal(evmTxs, innerTxs)
+
+	block, err := types.GetBlockInnerTxsByHeightFromDB(height)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(block)
+	suite.Require().Equal(blockHash.Hex(), block.BlockHash)
+	suite.Require().Len(block.Txs, 2)
+	suite.Require().Equal(cosmosTxHash.Hex(), block.Txs[0].TxHash)
+	suite.Require().Equal(evmTxHash.Hex(), block.Txs[1].TxHash)
+
+	block, err = types.GetBlockInnerTxsByHashFromDB(blockHash)
+	suite.Require().NoError(err)
+	suite.Require().Equal(height, block.BlockHeight)
+
+	erc20, err := types.GetERC20ContractFromDB(suite.address)
+	suite.Require().NoError(err)
+	suite.Require().Equal(contract, erc20)
+
+	// the data of an unknown tx or block is nil
+	innerTxs, err = types.GetInnerTxsFromDB(ethcmn.HexToHash("0x02"))
+	suite.Require().NoError(err)
+	suite.Require().Nil(innerTxs)
+	block, err = types.GetBlockInnerTxsByHeightFromDB(height + 1)
+	suite.Require().NoError(err)
+	suite.Require().Nil(block)
+}
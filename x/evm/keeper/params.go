@@ -15,3 +15,16 @@ func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
 func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
 	k.paramSpace.SetParamSet(ctx, &params)
 }
+
+// GetModuleCallGasLimit returns the gas limit of the evm calls made by the modules, which is the default if it
+// hasn't been set
+func (k Keeper) GetModuleCallGasLimit(ctx sdk.Context) (gasLimit uint64) {
+	gasLimit = types.DefaultModuleCallGasLimit
+	k.paramSpace.GetIfExists(ctx, types.ParamStoreKeyModuleCallGasLimit, &gasLimit)
+	return
+}
+
+// SetModuleCallGasLimit sets the gas limit of the evm calls made by the modules
+func (k Keeper) SetModuleCallGasLimit(ctx sdk.Context, gasLimit uint64) {
+	k.paramSpace.Set(ctx, types.ParamStoreKeyModuleCallGasLimit, gasLimit)
+}
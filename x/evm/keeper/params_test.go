@@ -11,3 +11,12 @@ func (suite *KeeperTestSuite) TestParams() {
 	newParams := suite.app.EvmKeeper.GetParams(suite.ctx)
 	suite.Require().Equal(newParams, params)
 }
+
+func (suite *KeeperTestSuite) TestModuleCallGasLimit() {
+	// the default is used if the param hasn't been set
+	suite.Require().Equal(types.DefaultModuleCallGasLimit, suite.app.EvmKeeper.GetModuleCallGasLimit(suite.ctx))
+	suite.app.EvmKeeper.SetModuleCallGasLimit(suite.ctx, 100000)
+	suite.Require().Equal(uint64(100000), suite.app.EvmKeeper.GetModuleCallGasLimit(suite.ctx))
+	// the params set doesn't include it
+	suite.Require().Equal(types.DefaultParams(), suite.app.EvmKeeper.GetParams(suite.ctx))
+}
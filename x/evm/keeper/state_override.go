@@ -0,0 +1,67 @@
+package keeper
+
+import (
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/evm/types"
+)
+
+// ApplyStateOverrides writes the state overrides of a simulated call into the state of ctx.
+// ctx must be a discarded cache context as the overrides are never meant to be committed.
+func (k *Keeper) ApplyStateOverrides(ctx sdk.Context, overrides types.StateOverrides) error {
+	if err := overrides.ValidateBasic(); err != nil {
+		return err
+	}
+
+	// the replaced storages are cleared before the state db caches any of their slots
+	for addr, account := range overrides {
+		if account.State != nil {
+			k.deleteStorage(ctx, addr)
+		}
+	}
+
+	csdb := types.CreateEmptyCommitStateDB(k.GenerateCSDBParams(), ctx)
+	for addr, account := range overrides {
+		if account.Nonce != nil {
+			csdb.SetNonce(addr, uint64(*account.Nonce))
+		}
+		if account.Code != nil {
+			csdb.SetCode(addr, *account.Code)
+		}
+		if account.Balance != nil {
+			csdb.SetBalance(addr, account.Balance.ToInt())
+		}
+
+		slots := account.StateDiff
+		if account.State != nil {
+			slots = account.State
+		}
+		if slots != nil {
+			for key, value := range *slots {
+				csdb.SetState(addr, key, value)
+			}
+		}
+	}
+
+	if err := csdb.Finalise(false); err != nil {
+		return err
+	}
+	_, err := csdb.Commit(false)
+	return err
+}
+
+func (k *Keeper) deleteStorage(ctx sdk.Context, addr ethcmn.Address) {
+	store := ctx.KVStore(k.storeKey)
+	prefix := types.AddressStoragePrefix(addr)
+
+	var keys [][]byte
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
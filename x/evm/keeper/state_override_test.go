@@ -0,0 +1,88 @@
+package keeper_test
+
+import (
+	"math/big"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/okex/exchain/x/evm/types"
+)
+
+func (suite *KeeperTestSuite) TestApplyStateOverrides() {
+	contract := ethcmn.HexToAddress("0x2222222222222222222222222222222222222222")
+	key1, key2 := ethcmn.BytesToHash([]byte{0x1}), ethcmn.BytesToHash([]byte{0x2})
+	value1, value2 := ethcmn.BytesToHash([]byte{0x11}), ethcmn.BytesToHash([]byte{0x22})
+	overridden := ethcmn.BytesToHash([]byte{0x33})
+
+	testCases := []struct {
+		name     string
+		override types.OverrideAccount
+		expPass  bool
+		expKey1  ethcmn.Hash
+		expKey2  ethcmn.Hash
+	}{
+		{
+			"state diff replaces the given slots only",
+			types.OverrideAccount{StateDiff: &map[ethcmn.Hash]ethcmn.Hash{key1: overridden}},
+			true, overridden, value2,
+		},
+		{
+			"state replaces the whole storage",
+			types.OverrideAccount{State: &map[ethcmn.Hash]ethcmn.Hash{key1: overridden}},
+			true, overridden, ethcmn.Hash{},
+		},
+		{
+			"state and state diff both set",
+			types.OverrideAccount{
+				State:     &map[ethcmn.Hash]ethcmn.Hash{key1: overridden},
+				StateDiff: &map[ethcmn.Hash]ethcmn.Hash{key2: overridden},
+			},
+			false, value1, value2,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+			suite.stateDB.SetState(contract, key1, value1)
+			suite.stateDB.SetState(contract, key2, value2)
+			suite.Require().NoError(suite.stateDB.Finalise(false))
+
+			ctx, _ := suite.ctx.CacheContext()
+			err := suite.app.EvmKeeper.ApplyStateOverrides(ctx, types.StateOverrides{contract: tc.override})
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().NoError(err)
+
+			csdb := types.CreateEmptyCommitStateDB(suite.app.EvmKeeper.GenerateCSDBParams(), ctx)
+			suite.Require().Equal(tc.expKey1, csdb.GetState(contract, key1))
+			suite.Require().Equal(tc.expKey2, csdb.GetState(contract, key2))
+
+			// the state of the parent context is left untouched
+			csdb = types.CreateEmptyCommitStateDB(suite.app.EvmKeeper.GenerateCSDBParams(), suite.ctx)
+			suite.Require().Equal(value1, csdb.GetState(contract, key1))
+			suite.Require().Equal(value2, csdb.GetState(contract, key2))
+		})
+	}
+}
+
+func (suite *KeeperTestSuite) TestApplyStateOverrides_Account() {
+	code := hexutil.Bytes{0x60, 0x00}
+	nonce := hexutil.Uint64(7)
+	balance := (*hexutil.Big)(big.NewInt(1000))
+
+	overrides := types.StateOverrides{
+		suite.address: {Nonce: &nonce, Code: &code, Balance: balance},
+	}
+	suite.Require().NoError(suite.app.EvmKeeper.ApplyStateOverrides(suite.ctx, overrides))
+
+	csdb := types.CreateEmptyCommitStateDB(suite.app.EvmKeeper.GenerateCSDBParams(), suite.ctx)
+	suite.Require().Equal(uint64(nonce), csdb.GetNonce(suite.address))
+	suite.Require().Equal([]byte(code), csdb.GetCode(suite.address))
+	suite.Require().Equal(ethcrypto.Keccak256Hash(code), csdb.GetCodeHash(suite.address))
+	suite.Require().Equal(big.NewInt(1000), csdb.GetBalance(suite.address))
+}
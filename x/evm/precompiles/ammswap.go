@@ -0,0 +1,134 @@
+package precompiles
+
+import (
+	"fmt"
+	"math/big"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	ammswap "github.com/okex/exchain/x/ammswap/types"
+	"github.com/okex/exchain/x/evm/types"
+)
+
+// SwapABI is the abi of the ammswap precompiled contract
+const SwapABI = `[
+	{
+		"type": "function", "name": "quote", "stateMutability": "view",
+		"inputs": [
+			{"name": "soldDenom", "type": "string"},
+			{"name": "soldAmount", "type": "uint256"},
+			{"name": "boughtDenom", "type": "string"}
+		],
+		"outputs": [{"name": "boughtAmount", "type": "uint256"}, {"name": "route", "type": "string[]"}]
+	},
+	{
+		"type": "function", "name": "swap", "stateMutability": "nonpayable",
+		"inputs": [
+			{"name": "soldDenom", "type": "string"},
+			{"name": "soldAmount", "type": "uint256"},
+			{"name": "boughtDenom", "type": "string"},
+			{"name": "minBoughtAmount", "type": "uint256"},
+			{"name": "deadline", "type": "uint256"}
+		],
+		"outputs": [{"name": "boughtAmount", "type": "uint256"}]
+	},
+	{
+		"type": "event", "name": "Swap", "anonymous": false,
+		"inputs": [
+			{"name": "sender", "type": "address", "indexed": true},
+			{"name": "soldDenom", "type": "string", "indexed": false},
+			{"name": "soldAmount", "type": "uint256", "indexed": false},
+			{"name": "boughtDenom", "type": "string", "indexed": false},
+			{"name": "boughtAmount", "type": "uint256", "indexed": false}
+		]
+	}
+]`
+
+// SwapPrecompile exposes the swaps of the ammswap module to the evm
+type SwapPrecompile struct {
+	contract
+
+	router      sdk.Router
+	tokenKeeper TokenKeeper
+	swapKeeper  SwapKeeper
+}
+
+// NewSwapPrecompile creates a new instance of SwapPrecompile
+func NewSwapPrecompile(router sdk.Router, tokenKeeper TokenKeeper, swapKeeper SwapKeeper) *SwapPrecompile {
+	p := &SwapPrecompile{router: router, tokenKeeper: tokenKeeper, swapKeeper: swapKeeper}
+	p.contract = newContract(SwapAddress, SwapABI, map[string]method{
+		"quote": {readOnly: true, run: p.quote},
+		"swap":  {run: p.swap},
+	})
+	return p
+}
+
+// quote returns the amount bought by the best route of the swap and the route
+func (p *SwapPrecompile) quote(ctx sdk.Context, _ *types.PrecompileCall, args []interface{}) (
+	[]interface{}, error) {
+	route, bought, err := p.findRoute(ctx, args[0].(string), args[1].(*big.Int), args[2].(string))
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{amountFromDec(bought.Amount), route}, nil
+}
+
+// swap sells the token of the caller through the best route, and returns the amount bought
+func (p *SwapPrecompile) swap(ctx sdk.Context, call *types.PrecompileCall, args []interface{}) (
+	[]interface{}, error) {
+	soldDenom, soldAmount, boughtDenom := args[0].(string), args[1].(*big.Int), args[2].(string)
+	minBoughtAmount, deadline := args[3].(*big.Int), args[4].(*big.Int)
+	if err := validateNativeDenom(soldDenom); err != nil {
+		return nil, err
+	}
+	if err := validateNativeDenom(boughtDenom); err != nil {
+		return nil, err
+	}
+	if !deadline.IsInt64() {
+		return nil, fmt.Errorf("invalid deadline %s", deadline)
+	}
+	minBought, err := decFromAmount(minBoughtAmount)
+	if err != nil {
+		return nil, err
+	}
+	route, _, err := p.findRoute(ctx, soldDenom, soldAmount, boughtDenom)
+	if err != nil {
+		return nil, err
+	}
+
+	sold, _ := decFromAmount(soldAmount)
+	sender := sdk.AccAddress(call.Caller.Bytes())
+	before := p.tokenKeeper.GetCoins(ctx, sender).AmountOf(boughtDenom)
+	msg := ammswap.NewMsgTokenToTokenWithPath(sdk.NewDecCoinFromDec(soldDenom, sold),
+		sdk.NewDecCoinFromDec(boughtDenom, minBought), route[1:len(route)-1], deadline.Int64(), sender, sender)
+	if err := deliverMsg(ctx, p.router, msg); err != nil {
+		return nil, err
+	}
+	boughtAmount := amountFromDec(p.tokenKeeper.GetCoins(ctx, sender).AmountOf(boughtDenom).Sub(before))
+
+	if err := p.emit(call, "Swap", []ethcmn.Hash{addressTopic(call.Caller)},
+		soldDenom, soldAmount, boughtDenom, boughtAmount); err != nil {
+		return nil, err
+	}
+	return []interface{}{boughtAmount}, nil
+}
+
+// findRoute returns the best route of the swap and the token bought by it
+func (p *SwapPrecompile) findRoute(ctx sdk.Context, soldDenom string, soldAmount *big.Int, boughtDenom string) (
+	[]string, sdk.SysCoin, error) {
+	if err := sdk.ValidateDenom(soldDenom); err != nil {
+		return nil, sdk.SysCoin{}, err
+	}
+	if err := sdk.ValidateDenom(boughtDenom); err != nil {
+		return nil, sdk.SysCoin{}, err
+	}
+	sold, err := decFromAmount(soldAmount)
+	if err != nil {
+		return nil, sdk.SysCoin{}, err
+	}
+	route, amounts, err := p.swapKeeper.FindBestSwapRoute(ctx, sdk.NewDecCoinFromDec(soldDenom, sold), boughtDenom)
+	if err != nil {
+		return nil, sdk.SysCoin{}, err
+	}
+	return route, amounts[len(amounts)-1], nil
+}
@@ -0,0 +1,21 @@
+package precompiles
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	staking "github.com/okex/exchain/x/staking/types"
+)
+
+// StakingKeeper defines the expected staking interface
+type StakingKeeper interface {
+	GetDelegator(ctx sdk.Context, delAddr sdk.AccAddress) (staking.Delegator, bool)
+}
+
+// TokenKeeper defines the expected token interface
+type TokenKeeper interface {
+	GetCoins(ctx sdk.Context, addr sdk.AccAddress) sdk.SysCoins
+}
+
+// SwapKeeper defines the expected ammswap interface
+type SwapKeeper interface {
+	FindBestSwapRoute(ctx sdk.Context, soldToken sdk.SysCoin, buyToken string) ([]string, []sdk.SysCoin, error)
+}
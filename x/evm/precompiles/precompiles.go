@@ -0,0 +1,184 @@
+package precompiles
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/evm/types"
+)
+
+// addresses of the precompiled contracts of the native modules
+var (
+	StakingAddress = ethcmn.HexToAddress("0x0000000000000000000000000000000000001000")
+	TokenAddress   = ethcmn.HexToAddress("0x0000000000000000000000000000000000001001")
+	SwapAddress    = ethcmn.HexToAddress("0x0000000000000000000000000000000000001002")
+)
+
+// max bit length of the amounts passed to the native modules
+const maxAmountBitLen = 255
+
+// gas of decoding the input of a call, the native execution of the call is metered and charged besides it
+const (
+	callGas      uint64 = 700
+	inputWordGas uint64 = 6
+)
+
+// NewPrecompiles creates the precompiled contracts of the native modules, the msgs of the writing methods are
+// delivered to the handlers of router
+func NewPrecompiles(router sdk.Router, stakingKeeper StakingKeeper, tokenKeeper TokenKeeper,
+	swapKeeper SwapKeeper) []types.PrecompiledContract {
+	return []types.PrecompiledContract{
+		NewStakingPrecompile(router, stakingKeeper),
+		NewTokenPrecompile(router, tokenKeeper),
+		NewSwapPrecompile(router, tokenKeeper, swapKeeper),
+	}
+}
+
+// runFunc runs a method of the precompiled contract with the unpacked inputs, and returns the outputs to pack
+type runFunc func(ctx sdk.Context, call *types.PrecompileCall, args []interface{}) ([]interface{}, error)
+
+// method is a method of the precompiled contract
+type method struct {
+	readOnly bool
+	run      runFunc
+}
+
+// contract implements types.PrecompiledContract with the methods of its abi
+type contract struct {
+	address ethcmn.Address
+	abi     abi.ABI
+	methods map[string]method
+}
+
+func newContract(address ethcmn.Address, abiJSON string, methods map[string]method) contract {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		panic(fmt.Sprintf("invalid abi of precompiled contract %s: %s", address.Hex(), err))
+	}
+	for name := range methods {
+		if _, ok := parsed.Methods[name]; !ok {
+			panic(fmt.Sprintf("method %s is not in the abi of precompiled contract %s", name, address.Hex()))
+		}
+	}
+	return contract{address: address, abi: parsed, methods: methods}
+}
+
+// ABI returns the abi of the precompiled contract
+func (c contract) ABI() abi.ABI {
+	return c.abi
+}
+
+// Address returns the address of the precompiled contract
+func (c contract) Address() ethcmn.Address {
+	return c.address
+}
+
+// RequiredGas returns the gas of decoding the input
+func (c contract) RequiredGas(input []byte) uint64 {
+	return callGas + uint64(len(input)+31)/32*inputWordGas
+}
+
+// IsReadOnly returns true if the method called by the input doesn't modify the state
+func (c contract) IsReadOnly(input []byte) bool {
+	_, m, ok := c.lookup(input)
+	return ok && m.readOnly
+}
+
+// Run unpacks the input, runs the method and packs its outputs
+func (c contract) Run(ctx sdk.Context, call *types.PrecompileCall) ([]byte, error) {
+	abiMethod, m, ok := c.lookup(call.Input)
+	if !ok {
+		return nil, fmt.Errorf("unknown method of precompiled contract %s", c.address.Hex())
+	}
+	args, err := abiMethod.Inputs.Unpack(call.Input[4:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid inputs of %s: %s", abiMethod.Name, err)
+	}
+	outputs, err := m.run(ctx, call, args)
+	if err != nil {
+		return nil, err
+	}
+	return abiMethod.Outputs.Pack(outputs...)
+}
+
+func (c contract) lookup(input []byte) (*abi.Method, method, bool) {
+	if len(input) < 4 {
+		return nil, method{}, false
+	}
+	abiMethod, err := c.abi.MethodById(input[:4])
+	if err != nil {
+		return nil, method{}, false
+	}
+	m, ok := c.methods[abiMethod.Name]
+	return abiMethod, m, ok
+}
+
+// emit adds the evm log of the event, topics are the indexed inputs of the event, data are the others
+func (c contract) emit(call *types.PrecompileCall, name string, topics []ethcmn.Hash, data ...interface{}) error {
+	event, ok := c.abi.Events[name]
+	if !ok {
+		return fmt.Errorf("unknown event %s", name)
+	}
+	bz, err := event.Inputs.NonIndexed().Pack(data...)
+	if err != nil {
+		return err
+	}
+	call.AddLog(append([]ethcmn.Hash{event.ID}, topics...), bz)
+	return nil
+}
+
+// deliverMsg delivers the msg to the handler of its module as a tx does, and emits the events of the handler
+func deliverMsg(ctx sdk.Context, router sdk.Router, msg sdk.Msg) error {
+	if err := msg.ValidateBasic(); err != nil {
+		return err
+	}
+	handler := router.Route(ctx, msg.Route())
+	if handler == nil {
+		return fmt.Errorf("unrecognized message route: %s", msg.Route())
+	}
+	res, err := handler(ctx, msg)
+	if err != nil {
+		return err
+	}
+	if res != nil {
+		ctx.EventManager().EmitEvents(res.Events)
+	}
+	return nil
+}
+
+// addressTopic returns the topic of the indexed address
+func addressTopic(addr ethcmn.Address) ethcmn.Hash {
+	return ethcmn.BytesToHash(addr.Bytes())
+}
+
+// decFromAmount converts the amount of the evm, which has 18 decimals, to sdk.Dec
+func decFromAmount(amount *big.Int) (sdk.Dec, error) {
+	if amount.Sign() < 0 || amount.BitLen() > maxAmountBitLen {
+		return sdk.Dec{}, fmt.Errorf("invalid amount %s", amount)
+	}
+	return sdk.NewDecFromBigIntWithPrec(amount, sdk.Precision), nil
+}
+
+// amountFromDec converts sdk.Dec to the amount of the evm, which has 18 decimals
+func amountFromDec(dec sdk.Dec) *big.Int {
+	if dec.IsNil() {
+		return new(big.Int)
+	}
+	return dec.BigInt()
+}
+
+// validateNativeDenom checks the denom of the native tokens handled by the precompiled contracts, the native token
+// of the chain is rejected since its balance belongs to the evm
+func validateNativeDenom(denom string) error {
+	if err := sdk.ValidateDenom(denom); err != nil {
+		return err
+	}
+	if denom == sdk.DefaultBondDenom {
+		return fmt.Errorf("%s is not supported, use the value of the evm instead", denom)
+	}
+	return nil
+}
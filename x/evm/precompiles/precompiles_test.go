@@ -0,0 +1,322 @@
+package precompiles_test
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/okex/exchain/app"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/evm/precompiles"
+	"github.com/okex/exchain/x/evm/types"
+	tokentypes "github.com/okex/exchain/x/token/types"
+)
+
+const testDenom = "xxb"
+
+var (
+	stakingABI = mustParseABI(precompiles.StakingABI)
+	tokenABI   = mustParseABI(precompiles.TokenABI)
+	swapABI    = mustParseABI(precompiles.SwapABI)
+)
+
+func mustParseABI(abiJSON string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+type PrecompilesTestSuite struct {
+	suite.Suite
+
+	ctx  sdk.Context
+	app  *app.OKExChainApp
+	addr ethcmn.Address
+}
+
+func (suite *PrecompilesTestSuite) SetupTest() {
+	tmtypes.UnittestOnlySetMilestoneEarthHeight(1)
+	suite.app = app.Setup(false)
+	suite.ctx = suite.app.BaseApp.NewContext(false, abci.Header{Height: 1, ChainID: "ethermint-3", Time: time.Now().UTC()})
+	suite.addr = ethcmn.HexToAddress("0x756F45E3FA69347A9A973A725E3C98bC4db0b4c1")
+
+	suite.app.TokenKeeper.NewToken(suite.ctx, tokentypes.Token{
+		Symbol:              testDenom,
+		OriginalSymbol:      "XXB",
+		WholeName:           "XXB Token",
+		OriginalTotalSupply: sdk.NewDec(100),
+		Owner:               suite.addr.Bytes(),
+	})
+	suite.mint(suite.addr, sdk.NewDec(100))
+}
+
+func (suite *PrecompilesTestSuite) TearDownTest() {
+	tmtypes.UnittestOnlySetMilestoneEarthHeight(0)
+}
+
+func TestPrecompilesTestSuite(t *testing.T) {
+	suite.Run(t, new(PrecompilesTestSuite))
+}
+
+func (suite *PrecompilesTestSuite) mint(addr ethcmn.Address, amount sdk.Dec) {
+	coins := sdk.NewCoins(sdk.NewDecCoinFromDec(testDenom, amount))
+	suite.Require().NoError(suite.app.SupplyKeeper.MintCoins(suite.ctx, tokentypes.ModuleName, coins))
+	suite.Require().NoError(suite.app.SupplyKeeper.SendCoinsFromModuleToAccount(suite.ctx, tokentypes.ModuleName,
+		addr.Bytes(), coins))
+}
+
+func (suite *PrecompilesTestSuite) balance(addr ethcmn.Address) sdk.Dec {
+	return suite.app.BankKeeper.GetCoins(suite.ctx, addr.Bytes()).AmountOf(testDenom)
+}
+
+// call runs the evm call as the module calls do, and returns the logs of the call
+func (suite *PrecompilesTestSuite) call(from, to ethcmn.Address, data []byte) ([]byte, []*ethtypes.Log, error) {
+	ret, _, logs, err := suite.callWithGas(from, to, data, types.DefaultModuleCallGasLimit)
+	return ret, logs, err
+}
+
+// callWithGas runs the evm call with the gas, and returns the gas left and the logs of the call
+func (suite *PrecompilesTestSuite) callWithGas(from, to ethcmn.Address, data []byte, gas uint64) ([]byte, uint64,
+	[]*ethtypes.Log, error) {
+	config, found := suite.app.EvmKeeper.GetChainConfig(suite.ctx)
+	suite.Require().True(found)
+	csdb := types.CreateEmptyCommitStateDB(suite.app.EvmKeeper.GenerateCSDBParams(), suite.ctx)
+	csdb.Prepare(ethcmn.HexToHash("0x01"), ethcmn.Hash{}, 0)
+	evm := types.NewModuleEVM(suite.ctx, csdb, from, big.NewInt(3), gas, config)
+	defer csdb.ReleasePrecompileCall()
+
+	ret, leftOverGas, err := evm.Call(vm.AccountRef(from), to, data, gas, big.NewInt(0))
+	if err != nil {
+		return ret, leftOverGas, nil, err
+	}
+	logs, err := csdb.GetLogs(ethcmn.HexToHash("0x01"))
+	suite.Require().NoError(err)
+	suite.Require().NoError(csdb.Finalise(true))
+	_, err = csdb.Commit(true)
+	suite.Require().NoError(err)
+	return ret, leftOverGas, logs, nil
+}
+
+// deploy deploys a contract with the runtime code
+func (suite *PrecompilesTestSuite) deploy(runtime []byte) ethcmn.Address {
+	size := byte(len(runtime))
+	initCode := append([]byte{0x60, size, 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, size, 0x60, 0x00, 0xf3}, runtime...)
+	_, contract, err := suite.app.EvmKeeper.CallEvm(suite.ctx, suite.addr, nil, initCode)
+	suite.Require().NoError(err)
+	return contract
+}
+
+// deployForwarder deploys a contract which calls the token precompiled contract with its calldata, and reverts
+// after the call if revert is true
+func (suite *PrecompilesTestSuite) deployForwarder(revert bool) ethcmn.Address {
+	runtime := ethcmn.Hex2Bytes("366000600037" + "6000600036600060006110015af1" + "50")
+	if revert {
+		runtime = append(runtime, ethcmn.Hex2Bytes("60006000fd")...)
+	} else {
+		runtime = append(runtime, ethcmn.Hex2Bytes("00")...)
+	}
+	return suite.deploy(runtime)
+}
+
+// deployGasForwarder deploys a contract which calls the token precompiled contract with its calldata and the gas
+func (suite *PrecompilesTestSuite) deployGasForwarder(gas uint16) ethcmn.Address {
+	return suite.deploy(ethcmn.Hex2Bytes("366000600037" + "600060003660006000611001" + fmt.Sprintf("61%04x", gas) +
+		"f1" + "5000"))
+}
+
+// deployStaticForwarder deploys a contract which calls the contract with its calldata by STATICCALL
+func (suite *PrecompilesTestSuite) deployStaticForwarder(contract ethcmn.Address) ethcmn.Address {
+	return suite.deploy(ethcmn.Hex2Bytes("366000600037" + "6000600036600073" + ethcmn.Bytes2Hex(contract.Bytes()) +
+		"5afa" + "5000"))
+}
+
+func (suite *PrecompilesTestSuite) TestToken() {
+	to := ethcmn.HexToAddress("0x00000000000000000000000000000000000000aa")
+	amount := sdk.NewDecWithPrec(15, 1)
+
+	data, err := tokenABI.Pack("transfer", to, testDenom, amount.BigInt())
+	suite.Require().NoError(err)
+	_, logs, err := suite.call(suite.addr, precompiles.TokenAddress, data)
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewDec(100).Sub(amount), suite.balance(suite.addr))
+	suite.Require().Equal(amount, suite.balance(to))
+
+	suite.Require().Len(logs, 1)
+	suite.Require().Equal(precompiles.TokenAddress, logs[0].Address)
+	suite.Require().Equal([]ethcmn.Hash{tokenABI.Events["Transfer"].ID, suite.addr.Hash(), to.Hash()}, logs[0].Topics)
+	values, err := tokenABI.Events["Transfer"].Inputs.NonIndexed().Unpack(logs[0].Data)
+	suite.Require().NoError(err)
+	suite.Require().Equal([]interface{}{testDenom, amount.BigInt()}, values)
+
+	data, err = tokenABI.Pack("balanceOf", to, testDenom)
+	suite.Require().NoError(err)
+	ret, _, err := suite.app.EvmKeeper.CallEvm(suite.ctx, suite.addr, &precompiles.TokenAddress, data)
+	suite.Require().NoError(err)
+	out, err := tokenABI.Unpack("balanceOf", ret)
+	suite.Require().NoError(err)
+	suite.Require().Equal(amount.BigInt(), out[0])
+
+	// the native token of the chain belongs to the evm
+	data, err = tokenABI.Pack("transfer", to, sdk.DefaultBondDenom, amount.BigInt())
+	suite.Require().NoError(err)
+	_, _, err = suite.app.EvmKeeper.CallEvm(suite.ctx, suite.addr, &precompiles.TokenAddress, data)
+	suite.Require().Error(err)
+	suite.Require().Contains(err.Error(), "not supported")
+
+	// insufficient coins
+	data, err = tokenABI.Pack("transfer", to, testDenom, sdk.NewDec(1000).BigInt())
+	suite.Require().NoError(err)
+	_, _, err = suite.app.EvmKeeper.CallEvm(suite.ctx, suite.addr, &precompiles.TokenAddress, data)
+	suite.Require().Error(err)
+	suite.Require().Equal(sdk.NewDec(100).Sub(amount), suite.balance(suite.addr))
+}
+
+func (suite *PrecompilesTestSuite) TestContractCall() {
+	to := ethcmn.HexToAddress("0x00000000000000000000000000000000000000aa")
+	data, err := tokenABI.Pack("transfer", to, testDenom, sdk.NewDec(1).BigInt())
+	suite.Require().NoError(err)
+
+	forwarder := suite.deployForwarder(false)
+	suite.mint(forwarder, sdk.NewDec(10))
+	_, _, err = suite.app.EvmKeeper.CallEvm(suite.ctx, suite.addr, &forwarder, data)
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewDec(9), suite.balance(forwarder))
+	suite.Require().Equal(sdk.NewDec(1), suite.balance(to))
+
+	// the writes of the precompiled contract are discarded with the revert of its caller
+	reverter := suite.deployForwarder(true)
+	suite.mint(reverter, sdk.NewDec(10))
+	_, _, err = suite.app.EvmKeeper.CallEvm(suite.ctx, suite.addr, &reverter, data)
+	suite.Require().Error(err)
+	suite.Require().Equal(sdk.NewDec(10), suite.balance(reverter))
+	suite.Require().Equal(sdk.NewDec(1), suite.balance(to))
+}
+
+func (suite *PrecompilesTestSuite) TestStaking() {
+	data, err := stakingABI.Pack("delegation", suite.addr)
+	suite.Require().NoError(err)
+	ret, _, err := suite.app.EvmKeeper.CallEvm(suite.ctx, suite.addr, &precompiles.StakingAddress, data)
+	suite.Require().NoError(err)
+	out, err := stakingABI.Unpack("delegation", ret)
+	suite.Require().NoError(err)
+	suite.Require().Zero(out[0].(*big.Int).Sign())
+	suite.Require().Zero(out[1].(*big.Int).Sign())
+	suite.Require().Empty(out[2])
+
+	// the caller has never deposited
+	validator := ethcmn.HexToAddress("0x00000000000000000000000000000000000000bb")
+	data, err = stakingABI.Pack("addShares", []ethcmn.Address{validator})
+	suite.Require().NoError(err)
+	_, _, err = suite.app.EvmKeeper.CallEvm(suite.ctx, suite.addr, &precompiles.StakingAddress, data)
+	suite.Require().Error(err)
+}
+
+func (suite *PrecompilesTestSuite) TestSwap() {
+	data, err := swapABI.Pack("quote", testDenom, sdk.NewDec(1).BigInt(), "yyb")
+	suite.Require().NoError(err)
+	_, _, err = suite.app.EvmKeeper.CallEvm(suite.ctx, suite.addr, &precompiles.SwapAddress, data)
+	suite.Require().Error(err)
+
+	data, err = swapABI.Pack("swap", testDenom, sdk.NewDec(1).BigInt(), sdk.DefaultBondDenom, big.NewInt(0),
+		big.NewInt(suite.ctx.BlockTime().Unix()+60))
+	suite.Require().NoError(err)
+	_, _, err = suite.app.EvmKeeper.CallEvm(suite.ctx, suite.addr, &precompiles.SwapAddress, data)
+	suite.Require().Error(err)
+	suite.Require().Contains(err.Error(), "not supported")
+}
+
+func (suite *PrecompilesTestSuite) TestInvalidCall() {
+	// unknown method
+	_, _, err := suite.app.EvmKeeper.CallEvm(suite.ctx, suite.addr, &precompiles.TokenAddress, []byte{1, 2, 3, 4})
+	suite.Require().Error(err)
+
+	// too short input
+	_, _, err = suite.app.EvmKeeper.CallEvm(suite.ctx, suite.addr, &precompiles.TokenAddress, []byte{1})
+	suite.Require().Error(err)
+}
+
+func (suite *PrecompilesTestSuite) TestStaticContext() {
+	to := ethcmn.HexToAddress("0x00000000000000000000000000000000000000aa")
+	data, err := tokenABI.Pack("transfer", to, testDenom, sdk.NewDec(1).BigInt())
+	suite.Require().NoError(err)
+
+	// the forwarder calls the writing method by CALL in the static context of its caller
+	forwarder := suite.deployForwarder(false)
+	suite.mint(forwarder, sdk.NewDec(10))
+	caller := suite.deployStaticForwarder(forwarder)
+	_, _, err = suite.app.EvmKeeper.CallEvm(suite.ctx, suite.addr, &caller, data)
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewDec(10), suite.balance(forwarder))
+	suite.Require().True(suite.balance(to).IsZero())
+
+	// the read-only methods can be called in a static context
+	data, err = tokenABI.Pack("balanceOf", forwarder, testDenom)
+	suite.Require().NoError(err)
+	_, _, err = suite.app.EvmKeeper.CallEvm(suite.ctx, suite.addr, &caller, data)
+	suite.Require().NoError(err)
+}
+
+func (suite *PrecompilesTestSuite) TestGas() {
+	to := ethcmn.HexToAddress("0x00000000000000000000000000000000000000aa")
+	data, err := tokenABI.Pack("transfer", to, testDenom, sdk.NewDec(1).BigInt())
+	suite.Require().NoError(err)
+	inputGas := precompiles.NewTokenPrecompile(nil, suite.app.TokenKeeper).RequiredGas(data)
+
+	// the native execution is charged besides the gas of the input
+	_, leftOverGas, _, err := suite.callWithGas(suite.addr, precompiles.TokenAddress, data, types.DefaultModuleCallGasLimit)
+	suite.Require().NoError(err)
+	gasUsed := types.DefaultModuleCallGasLimit - leftOverGas
+	suite.Require().Greater(gasUsed, inputGas)
+
+	// the native execution is limited by the gas of the call site, the call reverts without the gas of the native
+	// execution and its writes are discarded
+	_, leftOverGas, _, err = suite.callWithGas(suite.addr, precompiles.TokenAddress, data, inputGas+1)
+	suite.Require().Equal(vm.ErrExecutionReverted, err)
+	suite.Require().Zero(leftOverGas)
+	suite.Require().Equal(sdk.NewDec(99), suite.balance(suite.addr))
+	suite.Require().Equal(sdk.NewDec(1), suite.balance(to))
+
+	// the gas of a nested call site is the gas passed by the caller
+	enough := suite.deployGasForwarder(uint16(gasUsed))
+	short := suite.deployGasForwarder(uint16(inputGas + 1))
+	suite.mint(enough, sdk.NewDec(10))
+	suite.mint(short, sdk.NewDec(10))
+	_, _, err = suite.app.EvmKeeper.CallEvm(suite.ctx, suite.addr, &short, data)
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewDec(10), suite.balance(short))
+	_, _, err = suite.app.EvmKeeper.CallEvm(suite.ctx, suite.addr, &enough, data)
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewDec(9), suite.balance(enough))
+	suite.Require().Equal(sdk.NewDec(2), suite.balance(to))
+}
+
+func (suite *PrecompilesTestSuite) TestBeforeEarth() {
+	tmtypes.UnittestOnlySetMilestoneEarthHeight(2)
+
+	// the precompiled contracts behave like the accounts without code
+	to := ethcmn.HexToAddress("0x00000000000000000000000000000000000000aa")
+	data, err := tokenABI.Pack("transfer", to, testDenom, sdk.NewDec(1).BigInt())
+	suite.Require().NoError(err)
+	ret, leftOverGas, logs, err := suite.callWithGas(suite.addr, precompiles.TokenAddress, data,
+		types.DefaultModuleCallGasLimit)
+	suite.Require().NoError(err)
+	suite.Require().Empty(ret)
+	suite.Require().Empty(logs)
+	suite.Require().Equal(types.DefaultModuleCallGasLimit, leftOverGas)
+	suite.Require().Equal(sdk.NewDec(100), suite.balance(suite.addr))
+
+	_, _, err = suite.app.EvmKeeper.CallEvm(suite.ctx, suite.addr, &precompiles.TokenAddress, []byte{1})
+	suite.Require().NoError(err)
+}
@@ -0,0 +1,92 @@
+package precompiles
+
+import (
+	"fmt"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/evm/types"
+	staking "github.com/okex/exchain/x/staking/types"
+)
+
+// StakingABI is the abi of the staking precompiled contract
+const StakingABI = `[
+	{
+		"type": "function", "name": "delegation", "stateMutability": "view",
+		"inputs": [{"name": "delegator", "type": "address"}],
+		"outputs": [
+			{"name": "tokens", "type": "uint256"},
+			{"name": "shares", "type": "uint256"},
+			{"name": "validators", "type": "address[]"}
+		]
+	},
+	{
+		"type": "function", "name": "addShares", "stateMutability": "nonpayable",
+		"inputs": [{"name": "validators", "type": "address[]"}],
+		"outputs": [{"name": "success", "type": "bool"}]
+	},
+	{
+		"type": "event", "name": "SharesAdded", "anonymous": false,
+		"inputs": [
+			{"name": "delegator", "type": "address", "indexed": true},
+			{"name": "validators", "type": "address[]", "indexed": false},
+			{"name": "shares", "type": "uint256", "indexed": false}
+		]
+	}
+]`
+
+// StakingPrecompile exposes the shares of the staking module to the evm
+type StakingPrecompile struct {
+	contract
+
+	router        sdk.Router
+	stakingKeeper StakingKeeper
+}
+
+// NewStakingPrecompile creates a new instance of StakingPrecompile
+func NewStakingPrecompile(router sdk.Router, stakingKeeper StakingKeeper) *StakingPrecompile {
+	p := &StakingPrecompile{router: router, stakingKeeper: stakingKeeper}
+	p.contract = newContract(StakingAddress, StakingABI, map[string]method{
+		"delegation": {readOnly: true, run: p.delegation},
+		"addShares":  {run: p.addShares},
+	})
+	return p
+}
+
+// delegation returns the self-delegated tokens, the shares and the voted validators of the delegator
+func (p *StakingPrecompile) delegation(ctx sdk.Context, _ *types.PrecompileCall, args []interface{}) (
+	[]interface{}, error) {
+	delegator, found := p.stakingKeeper.GetDelegator(ctx, sdk.AccAddress(args[0].(ethcmn.Address).Bytes()))
+	if !found {
+		delegator = staking.NewDelegator(sdk.AccAddress(args[0].(ethcmn.Address).Bytes()))
+	}
+	validators := make([]ethcmn.Address, len(delegator.ValidatorAddresses))
+	for i, valAddr := range delegator.ValidatorAddresses {
+		validators[i] = ethcmn.BytesToAddress(valAddr)
+	}
+	return []interface{}{amountFromDec(delegator.Tokens), amountFromDec(delegator.Shares), validators}, nil
+}
+
+// addShares votes the validators with all the shares of the caller
+func (p *StakingPrecompile) addShares(ctx sdk.Context, call *types.PrecompileCall, args []interface{}) (
+	[]interface{}, error) {
+	validators := args[0].([]ethcmn.Address)
+	valAddrs := make([]sdk.ValAddress, len(validators))
+	for i, validator := range validators {
+		valAddrs[i] = validator.Bytes()
+	}
+	delAddr := sdk.AccAddress(call.Caller.Bytes())
+	if err := deliverMsg(ctx, p.router, staking.NewMsgAddShares(delAddr, valAddrs)); err != nil {
+		return nil, err
+	}
+
+	delegator, found := p.stakingKeeper.GetDelegator(ctx, delAddr)
+	if !found {
+		return nil, fmt.Errorf("delegator %s not found", delAddr)
+	}
+	if err := p.emit(call, "SharesAdded", []ethcmn.Hash{addressTopic(call.Caller)},
+		validators, amountFromDec(delegator.Shares)); err != nil {
+		return nil, err
+	}
+	return []interface{}{true}, nil
+}
@@ -0,0 +1,90 @@
+package precompiles
+
+import (
+	"math/big"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/evm/types"
+	token "github.com/okex/exchain/x/token/types"
+)
+
+// TokenABI is the abi of the token precompiled contract
+const TokenABI = `[
+	{
+		"type": "function", "name": "balanceOf", "stateMutability": "view",
+		"inputs": [{"name": "account", "type": "address"}, {"name": "denom", "type": "string"}],
+		"outputs": [{"name": "balance", "type": "uint256"}]
+	},
+	{
+		"type": "function", "name": "transfer", "stateMutability": "nonpayable",
+		"inputs": [
+			{"name": "to", "type": "address"},
+			{"name": "denom", "type": "string"},
+			{"name": "amount", "type": "uint256"}
+		],
+		"outputs": [{"name": "success", "type": "bool"}]
+	},
+	{
+		"type": "event", "name": "Transfer", "anonymous": false,
+		"inputs": [
+			{"name": "from", "type": "address", "indexed": true},
+			{"name": "to", "type": "address", "indexed": true},
+			{"name": "denom", "type": "string", "indexed": false},
+			{"name": "amount", "type": "uint256", "indexed": false}
+		]
+	}
+]`
+
+// TokenPrecompile exposes the native tokens of the token module to the evm
+type TokenPrecompile struct {
+	contract
+
+	router      sdk.Router
+	tokenKeeper TokenKeeper
+}
+
+// NewTokenPrecompile creates a new instance of TokenPrecompile
+func NewTokenPrecompile(router sdk.Router, tokenKeeper TokenKeeper) *TokenPrecompile {
+	p := &TokenPrecompile{router: router, tokenKeeper: tokenKeeper}
+	p.contract = newContract(TokenAddress, TokenABI, map[string]method{
+		"balanceOf": {readOnly: true, run: p.balanceOf},
+		"transfer":  {run: p.transfer},
+	})
+	return p
+}
+
+// balanceOf returns the balance of the native token of the account
+func (p *TokenPrecompile) balanceOf(ctx sdk.Context, _ *types.PrecompileCall, args []interface{}) (
+	[]interface{}, error) {
+	account, denom := args[0].(ethcmn.Address), args[1].(string)
+	if err := validateNativeDenom(denom); err != nil {
+		return nil, err
+	}
+	balance := p.tokenKeeper.GetCoins(ctx, account.Bytes()).AmountOf(denom)
+	return []interface{}{amountFromDec(balance)}, nil
+}
+
+// transfer sends the native token from the caller to the recipient
+func (p *TokenPrecompile) transfer(ctx sdk.Context, call *types.PrecompileCall, args []interface{}) (
+	[]interface{}, error) {
+	to, denom, amount := args[0].(ethcmn.Address), args[1].(string), args[2].(*big.Int)
+	if err := validateNativeDenom(denom); err != nil {
+		return nil, err
+	}
+	dec, err := decFromAmount(amount)
+	if err != nil {
+		return nil, err
+	}
+	coins := sdk.SysCoins{sdk.NewDecCoinFromDec(denom, dec)}
+	msg := token.NewMsgTokenSend(call.Caller.Bytes(), to.Bytes(), coins)
+	if err := deliverMsg(ctx, p.router, msg); err != nil {
+		return nil, err
+	}
+
+	if err := p.emit(call, "Transfer", []ethcmn.Hash{addressTopic(call.Caller), addressTopic(to)},
+		denom, amount); err != nil {
+		return nil, err
+	}
+	return []interface{}{true}, nil
+}
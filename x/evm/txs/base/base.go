@@ -3,6 +3,7 @@ package base
 import (
 	bam "github.com/okex/exchain/libs/cosmos-sdk/baseapp"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/types/innertx"
 	authexported "github.com/okex/exchain/libs/cosmos-sdk/x/auth/exported"
 	"github.com/okex/exchain/x/common/analyzer"
 	"github.com/okex/exchain/x/evm/keeper"
@@ -23,8 +24,8 @@ type Config struct {
 type Result struct {
 	ExecResult     *types.ExecutionResult
 	ResultData     *types.ResultData
-	InnerTxs       interface{}
-	Erc20Contracts interface{}
+	InnerTxs       []*innertx.InnerTx
+	Erc20Contracts []*innertx.ERC20Contract
 }
 
 // Tx evm tx
@@ -51,6 +52,11 @@ func (tx *Tx) GetChainConfig() (types.ChainConfig, bool) {
 // Transition execute evm tx
 func (tx *Tx) Transition(config types.ChainConfig) (result Result, err error) {
 	result.ExecResult, result.ResultData, err, result.InnerTxs, result.Erc20Contracts = tx.StateTransition.TransitionDb(tx.Ctx, config)
+	// the hooks of the other modules react on the logs, and fail the tx with their error. The simulated txs call them
+	// as well, so that their gas is estimated
+	if err == nil && result.ResultData != nil {
+		err = tx.Keeper.PostTxProcessing(tx.Ctx, tx.StateTransition.Sender, tx.StateTransition.Recipient, result.ResultData.Logs)
+	}
 	// async mod goes immediately
 	if tx.Ctx.IsAsync() {
 		tx.Keeper.LogsManages.Set(string(tx.Ctx.TxBytes()), keeper.TxResult{
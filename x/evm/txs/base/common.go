@@ -26,20 +26,29 @@ func msg2st(ctx *sdk.Context, k *Keeper, msg *types.MsgEthereumTx) (st types.Sta
 	txHash := tmtypes.Tx(ctx.TxBytes()).Hash(ctx.BlockHeight())
 	ethHash := common.BytesToHash(txHash)
 
+	// the effective gas price of the dynamic fee txs is paid since Earth
+	price := msg.Data.Price
+	if tmtypes.HigherThanEarth(ctx.BlockHeight()) {
+		price = msg.EffectiveGasPrice()
+	}
+
 	st = types.StateTransition{
-		AccountNonce: msg.Data.AccountNonce,
-		Price:        msg.Data.Price,
-		GasLimit:     msg.Data.GasLimit,
-		Recipient:    msg.Data.Recipient,
-		Amount:       msg.Data.Amount,
-		Payload:      msg.Data.Payload,
-		Csdb:         types.CreateEmptyCommitStateDB(k.GenerateCSDBParams(), *ctx),
-		ChainID:      chainIDEpoch,
-		TxHash:       &ethHash,
-		Sender:       sender,
-		Simulate:     ctx.IsCheckTx(),
-		TraceTx:      ctx.IsTraceTx(),
-		TraceTxLog:   ctx.IsTraceTxLog(),
+		AccountNonce:  msg.Data.AccountNonce,
+		Price:         price,
+		GasLimit:      msg.Data.GasLimit,
+		Recipient:     msg.Data.Recipient,
+		Amount:        msg.Data.Amount,
+		Payload:       msg.Data.Payload,
+		AccessList:    msg.Data.Accesses,
+		Csdb:          types.CreateEmptyCommitStateDB(k.GenerateCSDBParams(), *ctx),
+		ChainID:       chainIDEpoch,
+		TxHash:        &ethHash,
+		Sender:        sender,
+		Simulate:      ctx.IsCheckTx(),
+		TraceTx:       ctx.IsTraceTx(),
+		TraceTxLog:    ctx.IsTraceTxLog(),
+		TraceTxConfig: ctx.TraceTxConfig(),
+		PostTxHooks:   k.HasHooks(),
 	}
 
 	return
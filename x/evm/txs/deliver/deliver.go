@@ -77,6 +77,7 @@ func (tx *Tx) RestoreWatcherTransactionReceipt(msg *types.MsgEthereumTx) {
 func (tx *Tx) Commit(msg *types.MsgEthereumTx, result *base.Result) {
 	if result.InnerTxs != nil {
 		tx.Keeper.AddInnerTx(tx.StateTransition.TxHash.Hex(), result.InnerTxs)
+		tx.Keeper.Watcher.SaveInnerTxs(*tx.StateTransition.TxHash, uint64(tx.Keeper.TxCount-1), result.InnerTxs)
 	}
 	if result.Erc20Contracts != nil {
 		tx.Keeper.AddContract(result.Erc20Contracts)
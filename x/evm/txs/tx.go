@@ -80,7 +80,7 @@ func TransitionEvmTx(tx Tx, msg *types.MsgEthereumTx) (result *sdk.Result, err e
 
 	defer func() {
 		senderAccount := tx.GetSenderAccount()
-		tx.RefundFeesWatcher(senderAccount, msg.GetFee(), msg.Data.Price)
+		tx.RefundFeesWatcher(senderAccount, msg.GetFee(), msg.EffectiveGasPrice())
 		if e := recover(); e != nil {
 			tx.ResetWatcher(senderAccount)
 			panic(e)
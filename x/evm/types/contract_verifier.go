@@ -29,6 +29,10 @@ func (cv ContractVerifier) Verify(stateDB vm.StateDB, op vm.OpCode, from, to com
 	if !ok {
 		panic(ErrContractBlockedVerify{"unknown stateDB expected CommitStateDB"})
 	}
+	// hand the call of the precompiled contracts over
+	if err := csdb.verifyPrecompileCall(op, from, to, input, value); err != nil {
+		return err
+	}
 	//check whether contract has been blocked
 	if !cv.params.EnableContractBlockedList {
 		return nil
@@ -111,6 +111,17 @@ func ErrBlockedContractMethodIsNotExist(address sdk.Address, err error) sdk.Enve
 	}
 }
 
+// ErrPrecompileCall returns an error when the call of a precompiled contract is invalid
+func ErrPrecompileCall(descriptor string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{
+		Err: sdkerrors.New(
+			DefaultParamspace,
+			21,
+			descriptor,
+		),
+	}
+}
+
 type ErrContractBlockedVerify struct {
 	Descriptor string
 }
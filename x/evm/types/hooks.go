@@ -0,0 +1,30 @@
+package types
+
+import (
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+// EvmHooks event hooks for the evm txs, which are called after the state transition of a tx succeeded.
+// An error returned by the hooks fails the whole tx.
+type EvmHooks interface {
+	PostTxProcessing(ctx sdk.Context, from ethcmn.Address, to *ethcmn.Address, logs []*ethtypes.Log) error
+}
+
+// combine multiple evm hooks, all hook functions are run in array sequence
+type MultiEvmHooks []EvmHooks
+
+func NewMultiEvmHooks(hooks ...EvmHooks) MultiEvmHooks {
+	return hooks
+}
+
+// PostTxProcessing calls the hooks in sequence and stops at the first error
+func (mh MultiEvmHooks) PostTxProcessing(ctx sdk.Context, from ethcmn.Address, to *ethcmn.Address, logs []*ethtypes.Log) error {
+	for i := range mh {
+		if err := mh[i].PostTxProcessing(ctx, from, to, logs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,444 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	json "github.com/json-iterator/go"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/types/innertx"
+	dbm "github.com/okex/exchain/libs/tm-db"
+	"github.com/spf13/viper"
+)
+
+const (
+	innerTxDir = "innertx"
+
+	FlagEnableInnerTx = "evm-innertx-enable"
+)
+
+var (
+	innerTxDB     dbm.DB
+	enableInnerTx bool
+
+	prefixInnerTx       = []byte{0x01}
+	prefixBlockInnerTxs = []byte{0x02}
+	prefixBlockHash     = []byte{0x03}
+	prefixERC20Contract = []byte{0x04}
+
+	errInnerTxDBNotOpened = errors.New("inner tx db is not opened, please start the node with --" + FlagEnableInnerTx)
+	errInternalFailure    = errors.New("internal failure")
+
+	// method selectors which must all be present in the code of an erc20 contract
+	erc20Selectors = [][]byte{
+		{0x18, 0x16, 0x0d, 0xdd}, // totalSupply()
+		{0x70, 0xa0, 0x82, 0x31}, // balanceOf(address)
+		{0xa9, 0x05, 0x9c, 0xbb}, // transfer(address,uint256)
+		{0x23, 0xb8, 0x72, 0xdd}, // transferFrom(address,address,uint256)
+		{0x09, 0x5e, 0xa7, 0xb3}, // approve(address,uint256)
+		{0xdd, 0x62, 0xed, 0x3e}, // allowance(address,address)
+	}
+)
+
+// TxInnerTxs is the inner txs of a tx
+type TxInnerTxs struct {
+	TxHash   string             `json:"tx_hash"`
+	InnerTxs []*innertx.InnerTx `json:"inner_txs"`
+}
+
+// BlockInnerTxs is the inner txs of all the txs in a block
+type BlockInnerTxs struct {
+	BlockHash   string       `json:"block_hash"`
+	BlockHeight int64        `json:"block_height"`
+	Txs         []TxInnerTxs `json:"txs"`
+}
+
+// InitInnerTxDB opens the inner tx db if the inner tx recording is enabled
+func InitInnerTxDB() (err error) {
+	enableInnerTx = viper.GetBool(FlagEnableInnerTx)
+	if !enableInnerTx || innerTxDB != nil {
+		return nil
+	}
+
+	dataDir := filepath.Join(viper.GetString("home"), "data")
+	innerTxDB, err = sdk.NewLevelDB(innerTxDir, dataDir)
+	return err
+}
+
+// CloseInnerTxDB closes the inner tx db
+func CloseInnerTxDB() {
+	if innerTxDB != nil {
+		innerTxDB.Close()
+	}
+}
+
+// IsInnerTxEnabled returns whether the inner txs are recorded
+func IsInnerTxEnabled() bool {
+	return enableInnerTx && innerTxDB != nil
+}
+
+func innerTxKey(txHash common.Hash) []byte {
+	return append(prefixInnerTx, txHash.Bytes()...)
+}
+
+func blockInnerTxsKey(blockHash common.Hash) []byte {
+	return append(prefixBlockInnerTxs, blockHash.Bytes()...)
+}
+
+func blockHashKey(height int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(height))
+	return append(prefixBlockHash, bz...)
+}
+
+func erc20ContractKey(addr common.Address) []byte {
+	return append(prefixERC20Contract, addr.Bytes()...)
+}
+
+// SaveBlockInnerTxs writes the inner txs and the erc20 contracts of a block into the inner tx db
+func SaveBlockInnerTxs(block *BlockInnerTxs, contracts []*innertx.ERC20Contract) error {
+	if innerTxDB == nil {
+		return errInnerTxDBNotOpened
+	}
+
+	batch := innerTxDB.NewBatch()
+	defer batch.Close()
+	for _, tx := range block.Txs {
+		bz, err := json.Marshal(tx.InnerTxs)
+		if err != nil {
+			return err
+		}
+		batch.Set(innerTxKey(common.HexToHash(tx.TxHash)), bz)
+	}
+	for _, contract := range contracts {
+		bz, err := json.Marshal(contract)
+		if err != nil {
+			return err
+		}
+		batch.Set(erc20ContractKey(common.HexToAddress(contract.Address)), bz)
+	}
+
+	blockHash := common.HexToHash(block.BlockHash)
+	bz, err := json.Marshal(block)
+	if err != nil {
+		return err
+	}
+	batch.Set(blockInnerTxsKey(blockHash), bz)
+	batch.Set(blockHashKey(block.BlockHeight), blockHash.Bytes())
+	return batch.WriteSync()
+}
+
+// GetInnerTxsFromDB returns the inner txs of a tx, nil is returned if the tx is not found
+func GetInnerTxsFromDB(txHash common.Hash) ([]*innertx.InnerTx, error) {
+	if innerTxDB == nil {
+		return nil, errInnerTxDBNotOpened
+	}
+	bz, err := innerTxDB.Get(innerTxKey(txHash))
+	if err != nil || len(bz) == 0 {
+		return nil, err
+	}
+
+	var innerTxs []*innertx.InnerTx
+	if err = json.Unmarshal(bz, &innerTxs); err != nil {
+		return nil, err
+	}
+	return innerTxs, nil
+}
+
+// GetBlockInnerTxsByHashFromDB returns the inner txs of a block, nil is returned if the block is not found
+func GetBlockInnerTxsByHashFromDB(blockHash common.Hash) (*BlockInnerTxs, error) {
+	if innerTxDB == nil {
+		return nil, errInnerTxDBNotOpened
+	}
+	bz, err := innerTxDB.Get(blockInnerTxsKey(blockHash))
+	if err != nil || len(bz) == 0 {
+		return nil, err
+	}
+
+	var block BlockInnerTxs
+	if err = json.Unmarshal(bz, &block); err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
+// GetBlockInnerTxsByHeightFromDB returns the inner txs of the block at height, nil is returned if the block is not found
+func GetBlockInnerTxsByHeightFromDB(height int64) (*BlockInnerTxs, error) {
+	if innerTxDB == nil {
+		return nil, errInnerTxDBNotOpened
+	}
+	bz, err := innerTxDB.Get(blockHashKey(height))
+	if err != nil || len(bz) == 0 {
+		return nil, err
+	}
+	return GetBlockInnerTxsByHashFromDB(common.BytesToHash(bz))
+}
+
+// GetERC20ContractFromDB returns the erc20 contract at addr, nil is returned if it is not recorded
+func GetERC20ContractFromDB(addr common.Address) (*innertx.ERC20Contract, error) {
+	if innerTxDB == nil {
+		return nil, errInnerTxDBNotOpened
+	}
+	bz, err := innerTxDB.Get(erc20ContractKey(addr))
+	if err != nil || len(bz) == 0 {
+		return nil, err
+	}
+
+	var contract innertx.ERC20Contract
+	if err = json.Unmarshal(bz, &contract); err != nil {
+		return nil, err
+	}
+	return &contract, nil
+}
+
+// isERC20Code checks whether the runtime code dispatches all the erc20 methods
+func isERC20Code(code []byte) bool {
+	if len(code) == 0 {
+		return false
+	}
+	for _, selector := range erc20Selectors {
+		if !bytes.Contains(code, append([]byte{byte(vm.PUSH4)}, selector...)) {
+			return false
+		}
+	}
+	return true
+}
+
+type innerTxFrame struct {
+	tx      *innertx.InnerTx
+	gasIn   uint64
+	gasCost uint64
+	// gas available in the frame, it is unknown for the calls to the accounts without code
+	gas    uint64
+	hasGas bool
+	calls  []*innerTxFrame
+}
+
+// InnerTxTracer reconstructs the call frames of an evm execution from the executed opcodes,
+// in the same way as the call tracer of go-ethereum. All the events are forwarded to the
+// wrapped tracer.
+type InnerTxTracer struct {
+	vm.Tracer
+
+	callstack   []*innerTxFrame
+	descended   bool
+	precompiles map[common.Address]struct{}
+	created     []common.Address
+}
+
+// NewInnerTxTracer creates an InnerTxTracer with the root frame of the tx
+func NewInnerTxTracer(tracer vm.Tracer, root *innertx.InnerTx) *InnerTxTracer {
+	return &InnerTxTracer{
+		Tracer:    tracer,
+		callstack: []*innerTxFrame{{tx: root}},
+	}
+}
+
+func (t *InnerTxTracer) top() *innerTxFrame {
+	return t.callstack[len(t.callstack)-1]
+}
+
+func (t *InnerTxTracer) pop() *innerTxFrame {
+	frame := t.top()
+	t.callstack = t.callstack[:len(t.callstack)-1]
+	return frame
+}
+
+func (t *InnerTxTracer) isPrecompiled(env *vm.EVM, addr common.Address) bool {
+	if t.precompiles == nil {
+		t.precompiles = make(map[common.Address]struct{})
+		for _, p := range vm.ActivePrecompiles(env.ChainConfig().Rules(env.Context.BlockNumber)) {
+			t.precompiles[p] = struct{}{}
+		}
+	}
+	_, ok := t.precompiles[addr]
+	return ok
+}
+
+func (t *InnerTxTracer) push(tx *innertx.InnerTx, gas, cost uint64) {
+	t.callstack = append(t.callstack, &innerTxFrame{tx: tx, gasIn: gas, gasCost: cost})
+	t.descended = true
+}
+
+// CaptureState implements vm.Tracer interface
+func (t *InnerTxTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64,
+	scope *vm.ScopeContext, rData []byte, depth int, err error) {
+	t.Tracer.CaptureState(env, pc, op, gas, cost, scope, rData, depth, err)
+	if err != nil {
+		t.fault(gas, err)
+		return
+	}
+
+	stack := scope.Stack
+	from := EthAddressStringer(scope.Contract.Address()).String()
+	switch op {
+	case vm.CREATE, vm.CREATE2:
+		name := innertx.EvmCreateName
+		if op == vm.CREATE2 {
+			name = innertx.EvmCreate2Name
+		}
+		t.push(innertx.NewEvmInnerTx(int64(len(t.callstack)), innertx.EvmCallType, name, from, "",
+			stack.Back(0).ToBig()), gas, cost)
+		return
+	case vm.SELFDESTRUCT:
+		to := common.Address(stack.Back(0).Bytes20())
+		tx := innertx.NewEvmInnerTx(int64(len(t.callstack)), innertx.EvmCallType, innertx.EvmSelfDestructName, from,
+			EthAddressStringer(to).String(), env.StateDB.GetBalance(scope.Contract.Address()))
+		tx.GasUsed = cost
+		t.top().calls = append(t.top().calls, &innerTxFrame{tx: tx})
+		return
+	case vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL:
+		to := common.Address(stack.Back(1).Bytes20())
+		if t.isPrecompiled(env, to) {
+			return
+		}
+		var name string
+		var value *big.Int
+		switch op {
+		case vm.CALL:
+			name, value = innertx.EvmCallName, stack.Back(2).ToBig()
+		case vm.CALLCODE:
+			name, value = innertx.EvmCallCodeName, stack.Back(2).ToBig()
+		case vm.DELEGATECALL:
+			name = innertx.EvmDelegateCallName
+		default:
+			name = innertx.EvmStaticCallName
+		}
+		t.push(innertx.NewEvmInnerTx(int64(len(t.callstack)), innertx.EvmCallType, name, from,
+			EthAddressStringer(to).String(), value), gas, cost)
+		return
+	}
+
+	// the first opcode after descending into a frame tells the gas of the frame
+	if t.descended {
+		if depth >= len(t.callstack) {
+			t.top().gas, t.top().hasGas = gas, true
+		}
+		t.descended = false
+	}
+
+	if op == vm.REVERT {
+		innertx.SetReverted(t.top().tx)
+		return
+	}
+
+	// the frame on the top has returned into its caller
+	if depth == len(t.callstack)-1 && len(t.callstack) > 1 {
+		frame := t.pop()
+		ret := stack.Back(0)
+		if frame.tx.Name == innertx.EvmCreateName || frame.tx.Name == innertx.EvmCreate2Name {
+			if frame.gasIn >= frame.gasCost+gas {
+				frame.tx.GasUsed = frame.gasIn - frame.gasCost - gas
+			}
+			if !ret.IsZero() {
+				addr := common.Address(ret.Bytes20())
+				frame.tx.To = EthAddressStringer(addr).String()
+				t.created = append(t.created, addr)
+			} else {
+				innertx.SetError(frame.tx, errInternalFailure)
+			}
+		} else {
+			if frame.hasGas && frame.gasIn+frame.gas >= frame.gasCost+gas {
+				frame.tx.GasUsed = frame.gasIn + frame.gas - frame.gasCost - gas
+			}
+			if ret.IsZero() && !frame.tx.IsError {
+				innertx.SetError(frame.tx, errInternalFailure)
+			}
+		}
+		t.top().calls = append(t.top().calls, frame)
+	}
+}
+
+// CaptureFault implements vm.Tracer interface
+func (t *InnerTxTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64,
+	scope *vm.ScopeContext, depth int, err error) {
+	t.Tracer.CaptureFault(env, pc, op, gas, cost, scope, depth, err)
+	t.fault(gas, err)
+}
+
+// fault closes the frame on the top which is failed with err. The error of the root frame
+// is set by the caller of the evm.
+func (t *InnerTxTracer) fault(gas uint64, err error) {
+	if len(t.callstack) <= 1 || t.top().tx.IsError {
+		return
+	}
+	frame := t.pop()
+	innertx.SetError(frame.tx, err)
+	if frame.hasGas {
+		frame.tx.GasUsed = frame.gas
+	}
+	t.top().calls = append(t.top().calls, frame)
+}
+
+// CaptureEnd implements vm.Tracer interface
+func (t *InnerTxTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) {
+	t.Tracer.CaptureEnd(output, gasUsed, d, err)
+}
+
+// InnerTxs returns all the recorded frames in the order of execution
+func (t *InnerTxTracer) InnerTxs() []*innertx.InnerTx {
+	var innerTxs []*innertx.InnerTx
+	var walk func(frame *innerTxFrame)
+	walk = func(frame *innerTxFrame) {
+		innerTxs = append(innerTxs, frame.tx)
+		for _, call := range frame.calls {
+			walk(call)
+		}
+	}
+	walk(t.callstack[0])
+	return innerTxs
+}
+
+// CreatedContracts returns the addresses of the contracts created by the inner creations
+func (t *InnerTxTracer) CreatedContracts() []common.Address {
+	return t.created
+}
+
+// ParseERC20Contracts returns the erc20 contracts among addrs whose code is kept in the state db
+func ParseERC20Contracts(csdb *CommitStateDB, addrs []common.Address, txHash string, height int64) []*innertx.ERC20Contract {
+	var contracts []*innertx.ERC20Contract
+	for _, addr := range addrs {
+		if isERC20Code(csdb.GetCode(addr)) {
+			contracts = append(contracts, &innertx.ERC20Contract{
+				Address:     EthAddressStringer(addr).String(),
+				TxHash:      txHash,
+				BlockHeight: height,
+			})
+		}
+	}
+	return contracts
+}
+
+// parseInnerTxs completes the root frame with the result of the evm execution and returns the recorded
+// inner txs together with the erc20 contracts created by the tx
+func (st StateTransition) parseInnerTxs(ctx sdk.Context, csdb *CommitStateDB, tracer *InnerTxTracer,
+	contractAddress common.Address, gasUsed uint64, err error) ([]*innertx.InnerTx, []*innertx.ERC20Contract) {
+	root := tracer.callstack[0].tx
+	root.GasUsed = gasUsed
+	innertx.SetError(root, err)
+
+	created := tracer.CreatedContracts()
+	if st.Recipient == nil {
+		root.Name = innertx.EvmCreateName
+		root.To = EthAddressStringer(contractAddress).String()
+		created = append([]common.Address{contractAddress}, created...)
+	} else {
+		root.Name = innertx.EvmCallName
+	}
+
+	var contracts []*innertx.ERC20Contract
+	if err == nil {
+		txHash := ""
+		if st.TxHash != nil {
+			txHash = st.TxHash.Hex()
+		}
+		contracts = ParseERC20Contracts(csdb, created, txHash, ctx.BlockHeight())
+	}
+	return tracer.InnerTxs(), contracts
+}
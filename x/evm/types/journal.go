@@ -192,6 +192,11 @@ type (
 		address *ethcmn.Address
 		slot    *ethcmn.Hash
 	}
+
+	// Changes to the native state by the precompiled contracts.
+	nativeCacheChange struct {
+		prev int
+	}
 )
 
 func (ch createObjectChange) revert(s *CommitStateDB) {
@@ -362,3 +367,11 @@ func (ch accessListAddSlotChange) revert(s *CommitStateDB) {
 func (ch accessListAddSlotChange) dirtied() *ethcmn.Address {
 	return nil
 }
+
+func (ch nativeCacheChange) revert(s *CommitStateDB) {
+	s.nativeCaches = s.nativeCaches[:ch.prev]
+}
+
+func (ch nativeCacheChange) dirtied() *ethcmn.Address {
+	return nil
+}
@@ -116,6 +116,39 @@ func newMsgEthereumTx(
 	return MsgEthereumTx{Data: txData}
 }
 
+// NewAccessListMsgEthereumTx returns a new EIP-2930 access list transaction message.
+func NewAccessListMsgEthereumTx(
+	chainID *big.Int, nonce uint64, to *ethcmn.Address, amount *big.Int,
+	gasLimit uint64, gasPrice *big.Int, payload []byte, accesses ethtypes.AccessList,
+) MsgEthereumTx {
+	msg := newMsgEthereumTx(nonce, to, amount, gasLimit, gasPrice, payload)
+	msg.Data.Type = ethtypes.AccessListTxType
+	msg.Data.ChainID = new(big.Int).Set(chainID)
+	msg.Data.Accesses = accesses
+	return msg
+}
+
+// NewDynamicFeeMsgEthereumTx returns a new EIP-1559 dynamic fee transaction message.
+// The gas price of the message is the fee cap, the sender pays the effective gas price.
+func NewDynamicFeeMsgEthereumTx(
+	chainID *big.Int, nonce uint64, to *ethcmn.Address, amount *big.Int,
+	gasLimit uint64, gasTipCap, gasFeeCap *big.Int, payload []byte, accesses ethtypes.AccessList,
+) MsgEthereumTx {
+	msg := newMsgEthereumTx(nonce, to, amount, gasLimit, gasFeeCap, payload)
+	msg.Data.Type = ethtypes.DynamicFeeTxType
+	msg.Data.ChainID = new(big.Int).Set(chainID)
+	msg.Data.Accesses = accesses
+	msg.Data.GasTipCap = new(big.Int)
+	msg.Data.GasFeeCap = new(big.Int)
+	if gasTipCap != nil {
+		msg.Data.GasTipCap.Set(gasTipCap)
+	}
+	if gasFeeCap != nil {
+		msg.Data.GasFeeCap.Set(gasFeeCap)
+	}
+	return msg
+}
+
 func (msg MsgEthereumTx) String() string {
 	return msg.Data.String()
 }
@@ -129,6 +162,10 @@ func (msg MsgEthereumTx) Type() string { return TypeMsgEthereumTx }
 // ValidateBasic implements the sdk.Msg interface. It performs basic validation
 // checks of a Transaction. If returns an error if validation fails.
 func (msg MsgEthereumTx) ValidateBasic() error {
+	if err := msg.validateTypedTx(); err != nil {
+		return err
+	}
+
 	if msg.Data.Price.Cmp(big.NewInt(0)) == 0 {
 		return sdkerrors.Wrapf(types.ErrInvalidValue, "gas price cannot be 0")
 	}
@@ -145,6 +182,135 @@ func (msg MsgEthereumTx) ValidateBasic() error {
 	return nil
 }
 
+// validateTypedTx checks the EIP-2718 typed transaction fields
+func (msg MsgEthereumTx) validateTypedTx() error {
+	switch msg.Data.Type {
+	case ethtypes.LegacyTxType:
+		return nil
+	case ethtypes.AccessListTxType:
+	case ethtypes.DynamicFeeTxType:
+		if msg.Data.GasTipCap == nil || msg.Data.GasFeeCap == nil {
+			return sdkerrors.Wrapf(types.ErrInvalidValue, "max fee per gas and max priority fee per gas cannot be empty")
+		}
+		if msg.Data.GasTipCap.Sign() == -1 {
+			return sdkerrors.Wrapf(types.ErrInvalidValue, "max priority fee per gas cannot be negative %s", msg.Data.GasTipCap)
+		}
+		// the tip is the whole effective gas price as there is no base fee
+		if msg.Data.GasTipCap.Sign() == 0 {
+			return sdkerrors.Wrapf(types.ErrInvalidValue, "max priority fee per gas cannot be 0")
+		}
+		if msg.Data.GasTipCap.Cmp(msg.Data.GasFeeCap) > 0 {
+			return sdkerrors.Wrapf(types.ErrInvalidValue, "max priority fee per gas higher than max fee per gas, tip: %s, fee cap: %s",
+				msg.Data.GasTipCap, msg.Data.GasFeeCap)
+		}
+		// the gas price decoded from the envelope is the fee cap, the price paid is EffectiveGasPrice
+		if msg.Data.Price == nil || msg.Data.Price.Cmp(msg.Data.GasFeeCap) != 0 {
+			return sdkerrors.Wrapf(types.ErrInvalidValue, "gas price must be equal to max fee per gas %s", msg.Data.GasFeeCap)
+		}
+	default:
+		return sdkerrors.Wrapf(types.ErrInvalidValue, "unsupported tx type %d", msg.Data.Type)
+	}
+
+	if msg.Data.ChainID == nil || msg.Data.ChainID.Sign() <= 0 {
+		return sdkerrors.Wrapf(types.ErrInvalidValue, "invalid chain id %s of typed tx", msg.Data.ChainID)
+	}
+	return nil
+}
+
+// TxType returns the EIP-2718 type of the transaction
+func (msg MsgEthereumTx) TxType() uint8 {
+	return msg.Data.Type
+}
+
+// IsTyped returns true if the transaction is an EIP-2718 typed transaction
+func (msg MsgEthereumTx) IsTyped() bool {
+	return msg.Data.Type != ethtypes.LegacyTxType
+}
+
+// AccessList returns the access list of the transaction, it's nil for the legacy transactions
+func (msg MsgEthereumTx) AccessList() ethtypes.AccessList {
+	return msg.Data.Accesses
+}
+
+// AsEthTransaction returns the go-ethereum transaction of the message with the signature
+func (msg MsgEthereumTx) AsEthTransaction() *ethtypes.Transaction {
+	switch msg.Data.Type {
+	case ethtypes.AccessListTxType:
+		return ethtypes.NewTx(&ethtypes.AccessListTx{
+			ChainID:    msg.Data.ChainID,
+			Nonce:      msg.Data.AccountNonce,
+			GasPrice:   msg.Data.Price,
+			Gas:        msg.Data.GasLimit,
+			To:         msg.Data.Recipient,
+			Value:      msg.Data.Amount,
+			Data:       msg.Data.Payload,
+			AccessList: msg.Data.Accesses,
+			V:          msg.Data.V,
+			R:          msg.Data.R,
+			S:          msg.Data.S,
+		})
+	case ethtypes.DynamicFeeTxType:
+		return ethtypes.NewTx(&ethtypes.DynamicFeeTx{
+			ChainID:    msg.Data.ChainID,
+			Nonce:      msg.Data.AccountNonce,
+			GasTipCap:  msg.Data.GasTipCap,
+			GasFeeCap:  msg.Data.GasFeeCap,
+			Gas:        msg.Data.GasLimit,
+			To:         msg.Data.Recipient,
+			Value:      msg.Data.Amount,
+			Data:       msg.Data.Payload,
+			AccessList: msg.Data.Accesses,
+			V:          msg.Data.V,
+			R:          msg.Data.R,
+			S:          msg.Data.S,
+		})
+	default:
+		return ethtypes.NewTx(&ethtypes.LegacyTx{
+			Nonce:    msg.Data.AccountNonce,
+			GasPrice: msg.Data.Price,
+			Gas:      msg.Data.GasLimit,
+			To:       msg.Data.Recipient,
+			Value:    msg.Data.Amount,
+			Data:     msg.Data.Payload,
+			V:        msg.Data.V,
+			R:        msg.Data.R,
+			S:        msg.Data.S,
+		})
+	}
+}
+
+// fromEthTransaction sets the data of the message from a go-ethereum typed transaction
+func (msg *MsgEthereumTx) fromEthTransaction(tx *ethtypes.Transaction) {
+	v, r, s := tx.RawSignatureValues()
+	msg.Data = TxData{
+		AccountNonce: tx.Nonce(),
+		Price:        tx.GasPrice(),
+		GasLimit:     tx.Gas(),
+		Recipient:    tx.To(),
+		Amount:       tx.Value(),
+		Payload:      tx.Data(),
+		V:            new(big.Int).Set(v),
+		R:            new(big.Int).Set(r),
+		S:            new(big.Int).Set(s),
+		Type:         tx.Type(),
+		ChainID:      tx.ChainId(),
+	}
+	// empty lists are decoded as nil like amino does
+	if accesses := tx.AccessList(); len(accesses) > 0 {
+		msg.Data.Accesses = make(ethtypes.AccessList, len(accesses))
+		for i, tuple := range accesses {
+			msg.Data.Accesses[i].Address = tuple.Address
+			if len(tuple.StorageKeys) > 0 {
+				msg.Data.Accesses[i].StorageKeys = tuple.StorageKeys
+			}
+		}
+	}
+	if tx.Type() == ethtypes.DynamicFeeTxType {
+		msg.Data.GasTipCap = tx.GasTipCap()
+		msg.Data.GasFeeCap = tx.GasFeeCap()
+	}
+}
+
 // To returns the recipient address of the transaction. It returns nil if the
 // transaction is a contract creation.
 func (msg MsgEthereumTx) To() *ethcmn.Address {
@@ -175,6 +341,9 @@ func (msg MsgEthereumTx) GetSignBytes() []byte {
 // RLPSignBytes returns the RLP hash of an Ethereum transaction message with a
 // given chainID used for signing.
 func (msg MsgEthereumTx) RLPSignBytes(chainID *big.Int) ethcmn.Hash {
+	if msg.IsTyped() {
+		return ethtypes.NewLondonSigner(chainID).Hash(msg.AsEthTransaction())
+	}
 	return rlpHash([]interface{}{
 		msg.Data.AccountNonce,
 		msg.Data.Price,
@@ -200,18 +369,36 @@ func (msg MsgEthereumTx) HomesteadSignHash() ethcmn.Hash {
 }
 
 // EncodeRLP implements the rlp.Encoder interface.
+// The typed transactions are encoded as the rlp string of the EIP-2718 envelope.
 func (msg *MsgEthereumTx) EncodeRLP(w io.Writer) error {
-	return rlp.Encode(w, &msg.Data)
+	if !msg.IsTyped() {
+		return rlp.Encode(w, &msg.Data)
+	}
+
+	envelope, err := msg.AsEthTransaction().MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return rlp.Encode(w, envelope)
 }
 
 // DecodeRLP implements the rlp.Decoder interface.
 func (msg *MsgEthereumTx) DecodeRLP(s *rlp.Stream) error {
-	_, size, err := s.Kind()
+	kind, size, err := s.Kind()
 	if err != nil {
 		// return error if stream is too large
 		return err
 	}
 
+	if kind != rlp.List {
+		// the EIP-2718 envelope of a typed transaction
+		envelope, err := s.Bytes()
+		if err != nil {
+			return err
+		}
+		return msg.unmarshalTypedTx(envelope)
+	}
+
 	if err := s.Decode(&msg.Data); err != nil {
 		return err
 	}
@@ -220,11 +407,46 @@ func (msg *MsgEthereumTx) DecodeRLP(s *rlp.Stream) error {
 	return nil
 }
 
+// MarshalBinary returns the canonical encoding of the transaction: the rlp list for the legacy
+// transactions and the EIP-2718 envelope for the typed transactions.
+func (msg *MsgEthereumTx) MarshalBinary() ([]byte, error) {
+	if !msg.IsTyped() {
+		return rlp.EncodeToBytes(&msg.Data)
+	}
+	return msg.AsEthTransaction().MarshalBinary()
+}
+
+// UnmarshalBinary decodes the canonical encoding of the transaction, see MarshalBinary.
+func (msg *MsgEthereumTx) UnmarshalBinary(b []byte) error {
+	if len(b) > 0 && b[0] > 0x7f {
+		// the first byte of a rlp list
+		return rlp.DecodeBytes(b, msg)
+	}
+	return msg.unmarshalTypedTx(b)
+}
+
+func (msg *MsgEthereumTx) unmarshalTypedTx(envelope []byte) error {
+	if len(envelope) == 0 || envelope[0] == ethtypes.LegacyTxType || envelope[0] > 0x7f {
+		return errors.New("invalid typed transaction envelope")
+	}
+
+	var tx ethtypes.Transaction
+	if err := tx.UnmarshalBinary(envelope); err != nil {
+		return err
+	}
+	msg.fromEthTransaction(&tx)
+	msg.size.Store(ethcmn.StorageSize(len(envelope)))
+	return nil
+}
+
 // Sign calculates a secp256k1 ECDSA signature and signs the transaction. It
 // takes a private key and chainID to sign an Ethereum transaction according to
 // EIP155 standard. It mutates the transaction as it populates the V, R, S
 // fields of the Transaction's Signature.
 func (msg *MsgEthereumTx) Sign(chainID *big.Int, priv *ecdsa.PrivateKey) error {
+	if msg.IsTyped() && (msg.Data.ChainID == nil || msg.Data.ChainID.Cmp(chainID) != 0) {
+		return fmt.Errorf("invalid chain id for signer: have %s want %s", msg.Data.ChainID, chainID)
+	}
 	txHash := msg.RLPSignBytes(chainID)
 
 	sig, err := ethcrypto.Sign(txHash[:], priv)
@@ -241,7 +463,10 @@ func (msg *MsgEthereumTx) Sign(chainID *big.Int, priv *ecdsa.PrivateKey) error {
 
 	var v *big.Int
 
-	if chainID.Sign() == 0 {
+	if msg.IsTyped() {
+		// the y parity of the signature
+		v = big.NewInt(int64(sig[64]))
+	} else if chainID.Sign() == 0 {
 		v = new(big.Int).SetBytes([]byte{sig[64] + 27})
 	} else {
 		v = big.NewInt(int64(sig[64] + 35))
@@ -260,7 +485,12 @@ func (msg *MsgEthereumTx) Sign(chainID *big.Int, priv *ecdsa.PrivateKey) error {
 // A derived address is returned upon success or an error if recovery fails.
 func (msg *MsgEthereumTx) VerifySig(chainID *big.Int, height int64, txBytes []byte, sigCtx sdk.SigCache) (sdk.SigCache, error) {
 	var signer ethtypes.Signer
-	if isProtectedV(msg.Data.V) {
+	if msg.IsTyped() {
+		if msg.Data.ChainID == nil || msg.Data.ChainID.Cmp(chainID) != 0 {
+			return nil, fmt.Errorf("invalid chain id for signer: have %s want %s", msg.Data.ChainID, chainID)
+		}
+		signer = ethtypes.NewLondonSigner(chainID)
+	} else if isProtectedV(msg.Data.V) {
 		signer = ethtypes.NewEIP155Signer(chainID)
 	} else {
 		if tmtypes.HigherThanMercury(height) {
@@ -298,7 +528,12 @@ func (msg *MsgEthereumTx) VerifySig(chainID *big.Int, height int64, txBytes []by
 
 	V := new(big.Int)
 	var sigHash ethcmn.Hash
-	if isProtectedV(msg.Data.V) {
+	if msg.IsTyped() {
+		// the typed transactions are signed with the y parity instead of 27/28
+		V.Add(msg.Data.V, big.NewInt(27))
+
+		sigHash = msg.RLPSignBytes(chainID)
+	} else if isProtectedV(msg.Data.V) {
 		// do not allow recovery for transactions with an unprotected chainID
 		if chainID.Sign() == 0 {
 			return nil, errors.New("chainID cannot be zero")
@@ -340,19 +575,36 @@ func (msg MsgEthereumTx) GetGas() uint64 {
 	return msg.Data.GasLimit
 }
 
-// Fee returns gasprice * gaslimit.
+// EffectiveGasPrice returns the gas price paid by the transaction. It is min(baseFee+tip, feeCap) for the
+// EIP-1559 dynamic fee transactions, where the base fee is zero as the chain has none, and the gas price for the others.
+// The typed transactions are accepted since Earth, so it only differs from the gas price since then.
+func (msg MsgEthereumTx) EffectiveGasPrice() *big.Int {
+	if msg.Data.Type != ethtypes.DynamicFeeTxType {
+		return msg.Data.Price
+	}
+	if msg.Data.GasTipCap.Cmp(msg.Data.GasFeeCap) > 0 {
+		return msg.Data.GasFeeCap
+	}
+	return msg.Data.GasTipCap
+}
+
+// Fee returns the effective gas price * gaslimit, which is paid by the sender before the refund of the unused gas.
 func (msg MsgEthereumTx) Fee() *big.Int {
-	return new(big.Int).Mul(msg.Data.Price, new(big.Int).SetUint64(msg.Data.GasLimit))
+	return new(big.Int).Mul(msg.EffectiveGasPrice(), new(big.Int).SetUint64(msg.Data.GasLimit))
 }
 
 // ChainID returns which chain id this transaction was signed for (if at all)
 func (msg *MsgEthereumTx) ChainID() *big.Int {
+	if msg.IsTyped() {
+		return msg.Data.ChainID
+	}
 	return deriveChainID(msg.Data.V)
 }
 
-// Cost returns amount + gasprice * gaslimit.
+// Cost returns amount + gasprice * gaslimit, which the balance of the sender must cover.
+// The gas price is the fee cap of the dynamic fee transactions, the same as go-ethereum.
 func (msg MsgEthereumTx) Cost() *big.Int {
-	total := msg.Fee()
+	total := new(big.Int).Mul(msg.Data.Price, new(big.Int).SetUint64(msg.Data.GasLimit))
 	total.Add(total, msg.Data.Amount)
 	return total
 }
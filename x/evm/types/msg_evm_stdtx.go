@@ -41,14 +41,14 @@ func (msg MsgEthereumTx) GetTxInfo(ctx sdk.Context) mempool.ExTxInfo {
 		exTxInfo.Sender = ctx.From()
 	}
 
-	exTxInfo.GasPrice = msg.Data.Price
+	exTxInfo.GasPrice = msg.EffectiveGasPrice()
 
 	return exTxInfo
 }
 
-// GetGasPrice return gas price
+// GetGasPrice return the effective gas price
 func (msg MsgEthereumTx) GetGasPrice() *big.Int {
-	return msg.Data.Price
+	return msg.EffectiveGasPrice()
 }
 
 func (msg MsgEthereumTx) GetTxFnSignatureInfo() ([]byte, int) {
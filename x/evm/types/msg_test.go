@@ -260,6 +260,121 @@ func TestMsgEthereumTx_Amino(t *testing.T) {
 	}
 }
 
+func TestMsgEthereumTx_TypedTx(t *testing.T) {
+	chainID := big.NewInt(3)
+	priv, _ := ethsecp256k1.GenerateKey()
+	addr := ethcmn.BytesToAddress(priv.PubKey().Address().Bytes())
+	accesses := ethtypes.AccessList{
+		{Address: addr, StorageKeys: []ethcmn.Hash{ethcmn.BigToHash(big.NewInt(1)), ethcmn.BigToHash(big.NewInt(2))}},
+		{Address: GenerateEthAddress()},
+	}
+
+	testCases := []MsgEthereumTx{
+		NewAccessListMsgEthereumTx(chainID, 1, &addr, big.NewInt(1024), 100000, big.NewInt(2048), []byte("test"), accesses),
+		NewAccessListMsgEthereumTx(chainID, 2, nil, big.NewInt(0), 100000, big.NewInt(2048), []byte("test"), nil),
+		NewDynamicFeeMsgEthereumTx(chainID, 3, &addr, big.NewInt(1024), 100000, big.NewInt(1), big.NewInt(2048), []byte("test"), accesses),
+	}
+
+	for _, msg := range testCases {
+		require.NoError(t, msg.ValidateBasic())
+		require.NoError(t, msg.Sign(chainID, priv.ToECDSA()))
+		require.Equal(t, chainID, msg.ChainID())
+
+		// the signature is compatible with go-ethereum
+		ethTx := msg.AsEthTransaction()
+		sender, err := ethtypes.Sender(ethtypes.NewLondonSigner(chainID), ethTx)
+		require.NoError(t, err)
+		require.Equal(t, addr, sender)
+
+		// the binary encoding is the EIP-2718 envelope
+		raw, err := msg.MarshalBinary()
+		require.NoError(t, err)
+		expectedRaw, err := ethTx.MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, expectedRaw, raw)
+		require.Equal(t, msg.TxType(), raw[0])
+
+		var msg2 MsgEthereumTx
+		require.NoError(t, msg2.UnmarshalBinary(raw))
+		require.Equal(t, msg.Data, msg2.Data)
+		signerCache, err := msg2.VerifySig(chainID, 0, nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, addr, signerCache.GetFrom())
+
+		// the chain id of the signer must be the one of the tx
+		var msg3 MsgEthereumTx
+		require.NoError(t, msg3.UnmarshalBinary(raw))
+		_, err = msg3.VerifySig(big.NewInt(4), 0, nil, nil)
+		require.Error(t, err)
+
+		// the envelope is wrapped as a rlp string in a rlp stream
+		streamRaw, err := rlp.EncodeToBytes([]*MsgEthereumTx{&msg})
+		require.NoError(t, err)
+		var msgs []*MsgEthereumTx
+		require.NoError(t, rlp.DecodeBytes(streamRaw, &msgs))
+		require.Equal(t, 1, len(msgs))
+		require.Equal(t, msg.Data, msgs[0].Data)
+
+		// amino
+		aminoRaw, err := ModuleCdc.MarshalBinaryBare(msg)
+		require.NoError(t, err)
+		var msg4 MsgEthereumTx
+		require.NoError(t, ModuleCdc.UnmarshalBinaryBare(aminoRaw, &msg4))
+		require.Equal(t, msg.Data, msg4.Data)
+		var msg5 MsgEthereumTx
+		v, err := ModuleCdc.UnmarshalBinaryBareWithRegisteredUnmarshaller(aminoRaw, &msg5)
+		require.NoError(t, err)
+		require.Equal(t, msg.Data, v.(MsgEthereumTx).Data)
+	}
+
+	// the legacy tx is still encoded as a rlp list
+	msg := NewMsgEthereumTx(0, &addr, nil, 100000, big.NewInt(1), []byte("test"))
+	require.NoError(t, msg.Sign(chainID, priv.ToECDSA()))
+	raw, err := msg.MarshalBinary()
+	require.NoError(t, err)
+	rlpRaw, err := rlp.EncodeToBytes(&msg)
+	require.NoError(t, err)
+	require.Equal(t, rlpRaw, raw)
+	var msg2 MsgEthereumTx
+	require.NoError(t, msg2.UnmarshalBinary(raw))
+	require.Equal(t, msg.Data, msg2.Data)
+
+	// invalid typed txs
+	msg = NewDynamicFeeMsgEthereumTx(chainID, 0, &addr, nil, 100000, big.NewInt(2), big.NewInt(1), nil, nil)
+	require.Error(t, msg.ValidateBasic())
+	msg = NewDynamicFeeMsgEthereumTx(chainID, 0, &addr, nil, 100000, big.NewInt(1), big.NewInt(2), nil, nil)
+	msg.Data.Price = big.NewInt(3)
+	require.Error(t, msg.ValidateBasic())
+	msg = NewAccessListMsgEthereumTx(big.NewInt(0), 0, &addr, nil, 100000, big.NewInt(1), nil, nil)
+	require.Error(t, msg.ValidateBasic())
+	msg.Data.Type = 3
+	require.Error(t, msg.ValidateBasic())
+	msg = NewAccessListMsgEthereumTx(chainID, 0, &addr, nil, 100000, big.NewInt(1), nil, nil)
+	require.Error(t, msg.Sign(big.NewInt(4), priv.ToECDSA()))
+}
+
+func TestMsgEthereumTx_EffectiveGasPrice(t *testing.T) {
+	addr := GenerateEthAddress()
+	gasLimit := uint64(100000)
+
+	msg := NewMsgEthereumTx(0, &addr, big.NewInt(1), gasLimit, big.NewInt(20), nil)
+	require.Equal(t, big.NewInt(20), msg.EffectiveGasPrice())
+	require.Equal(t, big.NewInt(20*100000), msg.Fee())
+	require.Equal(t, big.NewInt(20*100000+1), msg.Cost())
+
+	// the tip is paid as there is no base fee, the balance must cover the fee cap
+	msg = NewDynamicFeeMsgEthereumTx(big.NewInt(3), 0, &addr, big.NewInt(1), gasLimit, big.NewInt(10), big.NewInt(20), nil, nil)
+	require.NoError(t, msg.ValidateBasic())
+	require.Equal(t, big.NewInt(10), msg.EffectiveGasPrice())
+	require.Equal(t, big.NewInt(10), msg.GetGasPrice())
+	require.Equal(t, big.NewInt(10*100000), msg.Fee())
+	require.Equal(t, big.NewInt(20*100000+1), msg.Cost())
+
+	// the tip can't be 0
+	msg = NewDynamicFeeMsgEthereumTx(big.NewInt(3), 0, &addr, big.NewInt(1), gasLimit, big.NewInt(0), big.NewInt(20), nil, nil)
+	require.Error(t, msg.ValidateBasic())
+}
+
 func BenchmarkMsgEthereumTxUnmarshal(b *testing.B) {
 	cdc := ModuleCdc
 	priv, _ := ethsecp256k1.GenerateKey()
@@ -13,6 +13,9 @@ const (
 	// DefaultParamspace for params keeper
 	DefaultParamspace       = ModuleName
 	DefaultMaxGasLimitPerTx = 30000000
+
+	// DefaultModuleCallGasLimit is the gas limit of the evm calls made by the modules if it hasn't been set
+	DefaultModuleCallGasLimit uint64 = 3000000
 )
 
 // Parameter keys
@@ -23,11 +26,14 @@ var (
 	ParamStoreKeyContractDeploymentWhitelist = []byte("EnableContractDeploymentWhitelist")
 	ParamStoreKeyContractBlockedList         = []byte("EnableContractBlockedList")
 	ParamStoreKeyMaxGasLimitPerTx            = []byte("MaxGasLimitPerTx")
+	ParamStoreKeyModuleCallGasLimit          = []byte("ModuleCallGasLimit")
 )
 
 // ParamKeyTable returns the parameter key table.
+// The module call gas limit is registered out of the Params, as the chains started before it was added haven't set it
 func ParamKeyTable() params.KeyTable {
-	return params.NewKeyTable().RegisterParamSet(&Params{})
+	return params.NewKeyTable().RegisterParamSet(&Params{}).
+		RegisterType(params.NewParamSetPair(ParamStoreKeyModuleCallGasLimit, uint64(0), validateModuleCallGasLimit))
 }
 
 // Params defines the EVM module parameters
@@ -124,3 +130,14 @@ func validateUint64(i interface{}) error {
 	}
 	return nil
 }
+
+func validateModuleCallGasLimit(i interface{}) error {
+	gasLimit, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if gasLimit == 0 {
+		return fmt.Errorf("module call gas limit must be positive")
+	}
+	return nil
+}
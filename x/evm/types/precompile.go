@@ -0,0 +1,388 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+)
+
+// PrecompiledContract is a precompiled contract which exposes the functionality of a native module to the evm.
+//
+// The precompiled contracts are enabled from the earth milestone. The calls of the precompiled contracts run on a
+// cache of the native state, which is journaled by the CommitStateDB, so that they are discarded when the evm
+// reverts, and written when the state is committed. The gas consumed by the native execution is metered, and
+// charged besides RequiredGas. The precompiled contracts are not payable, and only the read-only methods can be
+// called in a static context.
+type PrecompiledContract interface {
+	// Address returns the address of the precompiled contract
+	Address() ethcmn.Address
+	// RequiredGas returns the gas consumed by the call of the input besides the native execution
+	RequiredGas(input []byte) uint64
+	// IsReadOnly returns true if the call of the input doesn't modify the state
+	IsReadOnly(input []byte) bool
+	// Run executes the call on ctx, which is a cache of the native state
+	Run(ctx sdk.Context, call *PrecompileCall) ([]byte, error)
+}
+
+// PrecompileCall is a call of a precompiled contract
+type PrecompileCall struct {
+	Caller ethcmn.Address
+	Input  []byte
+
+	contract PrecompiledContract
+	stateDB  *CommitStateDB
+	shim     *precompileShim
+	key      precompileCallKey
+	// the gas available at the call site, which limits the gas of the call
+	gasLimit uint64
+
+	// the result of the call, which is executed when the evm asks for its gas
+	executed bool
+	ret      []byte
+	err      error
+	gasUsed  uint64
+}
+
+// AddLog emits an evm log from the precompiled contract
+func (call *PrecompileCall) AddLog(topics []ethcmn.Hash, data []byte) {
+	call.stateDB.AddLog(&ethtypes.Log{
+		Address:     call.contract.Address(),
+		Topics:      topics,
+		Data:        data,
+		BlockNumber: uint64(call.stateDB.ctx.BlockHeight()),
+	})
+}
+
+// execute runs the call on a cache of the native state with a gas meter limited by the gas available at the call
+// site. The cache is journaled unless the call is read-only, a failed call reverts with the reason, so that the
+// caller gets the reason and the gas left.
+func (call *PrecompileCall) execute() {
+	if call.executed {
+		return
+	}
+	call.executed = true
+
+	csdb := call.stateDB
+	requiredGas := call.contract.RequiredGas(call.Input)
+	var nativeGasLimit uint64
+	if call.gasLimit > requiredGas {
+		nativeGasLimit = call.gasLimit - requiredGas
+	}
+	cacheCtx, write := csdb.nativeContext().CacheContext()
+	gasMeter := sdk.NewGasMeter(nativeGasLimit)
+	cacheCtx = cacheCtx.WithGasMeter(gasMeter)
+	defer func() {
+		if e := recover(); e != nil {
+			call.ret, call.err = packRevertReason(fmt.Sprintf("%v", e)), vm.ErrExecutionReverted
+		}
+		call.gasUsed = requiredGas + gasMeter.GasConsumedToLimit()
+	}()
+
+	ret, err := call.contract.Run(cacheCtx, call)
+	if err != nil {
+		call.ret, call.err = packRevertReason(err.Error()), vm.ErrExecutionReverted
+		return
+	}
+	if !call.contract.IsReadOnly(call.Input) {
+		csdb.journal.append(nativeCacheChange{prev: len(csdb.nativeCaches)})
+		csdb.nativeCaches = append(csdb.nativeCaches, nativeCache{ctx: cacheCtx, write: write})
+	}
+	call.ret = ret
+}
+
+// precompileShim is registered to the precompiled contracts of the evm for an address. The evm of the fork of
+// go-ethereum only looks the precompiled contracts up in its package tables, so the shim of an address is shared
+// by the evms. It runs the pending call of the input, which is handed over by the ContractVerifier of the evm right
+// before the shim runs, the key of the call is the address of the input, which is unique among the evms running
+// concurrently. The shim without a pending call, which is the case before the earth milestone, behaves like an
+// account without code.
+type precompileShim struct {
+	calls sync.Map
+}
+
+// RequiredGas executes the pending call, and returns the gas it consumed. The evm runs out of gas without
+// running the call if the gas left is not enough, in which case the native state written by the call is reverted
+// with the snapshot of the evm.
+func (s *precompileShim) RequiredGas(input []byte) uint64 {
+	call, ok := s.load(input, false)
+	if !ok {
+		return 0
+	}
+	call.execute()
+	return call.gasUsed
+}
+
+func (s *precompileShim) Run(input []byte) ([]byte, error) {
+	call, ok := s.load(input, true)
+	if !ok {
+		return nil, nil
+	}
+	call.stateDB.pendingPrecompileCall = nil
+	call.execute()
+	return call.ret, call.err
+}
+
+func (s *precompileShim) load(input []byte, remove bool) (*PrecompileCall, bool) {
+	key, ok := newPrecompileCallKey(input)
+	if !ok {
+		return nil, false
+	}
+	var value interface{}
+	if remove {
+		value, ok = s.calls.LoadAndDelete(key)
+	} else {
+		value, ok = s.calls.Load(key)
+	}
+	if !ok {
+		return nil, false
+	}
+	return value.(*PrecompileCall), true
+}
+
+type precompileCallKey struct {
+	data *byte
+	size int
+}
+
+func newPrecompileCallKey(input []byte) (precompileCallKey, bool) {
+	if len(input) == 0 {
+		return precompileCallKey{}, false
+	}
+	return precompileCallKey{data: &input[0], size: len(input)}, true
+}
+
+var (
+	precompileShimsMtx sync.RWMutex
+	precompileShims    = make(map[ethcmn.Address]*precompileShim)
+)
+
+// RegisterPrecompileAddress registers the shim of the address to the precompiled contracts of every fork of the
+// evm, it must be called before the evms run. The calls of the address are dispatched to the PrecompiledContract
+// of the CommitStateDB from the earth milestone, and the shim is inert before it, so that the blocks before the
+// milestone are executed as they were.
+func RegisterPrecompileAddress(addr ethcmn.Address) {
+	precompileShimsMtx.Lock()
+	defer precompileShimsMtx.Unlock()
+
+	if _, ok := precompileShims[addr]; ok {
+		return
+	}
+	if _, ok := vm.PrecompiledContractsBerlin[addr]; ok {
+		panic(fmt.Sprintf("address %s is taken by the precompiled contract of ethereum", addr.Hex()))
+	}
+	shim := &precompileShim{}
+	precompileShims[addr] = shim
+
+	vm.PrecompiledContractsHomestead[addr] = shim
+	vm.PrecompiledContractsByzantium[addr] = shim
+	vm.PrecompiledContractsIstanbul[addr] = shim
+	vm.PrecompiledContractsBerlin[addr] = shim
+	vm.PrecompiledAddressesHomestead = append(vm.PrecompiledAddressesHomestead, addr)
+	vm.PrecompiledAddressesByzantium = append(vm.PrecompiledAddressesByzantium, addr)
+	vm.PrecompiledAddressesIstanbul = append(vm.PrecompiledAddressesIstanbul, addr)
+	vm.PrecompiledAddressesBerlin = append(vm.PrecompiledAddressesBerlin, addr)
+}
+
+func lookupPrecompileShim(addr ethcmn.Address) *precompileShim {
+	precompileShimsMtx.RLock()
+	defer precompileShimsMtx.RUnlock()
+	return precompileShims[addr]
+}
+
+// IsPrecompileAddress returns true if the address is registered as a precompiled contract of a native module
+func IsPrecompileAddress(addr ethcmn.Address) bool {
+	return lookupPrecompileShim(addr) != nil
+}
+
+// precompileTracer follows the call frames of an evm, so that the calls of the precompiled contracts know the gas
+// available at the call site and whether they run in a static context. The fork of go-ethereum passes neither of
+// them to the ContractVerifier. It wraps the tracer of the evm config, which is called only if the config enables
+// debug.
+type precompileTracer struct {
+	tracer vm.Tracer
+	debug  bool
+
+	// the static flags of the call frames, the frame at depth d is statics[d-1]
+	statics []bool
+	// the call site of the last call op, or of the top-level call
+	siteGas    uint64
+	siteStatic bool
+}
+
+func newPrecompileTracer(config vm.Config, gas uint64) *precompileTracer {
+	return &precompileTracer{tracer: config.Tracer, debug: config.Debug, siteGas: gas}
+}
+
+func (t *precompileTracer) CaptureStart(env *vm.EVM, from ethcmn.Address, to ethcmn.Address, create bool,
+	input []byte, gas uint64, value *big.Int) {
+	if t.debug {
+		t.tracer.CaptureStart(env, from, to, create, input, gas, value)
+	}
+}
+
+// CaptureState is called before every op runs, it enters the call frame of the depth, and records the call site of
+// the call ops
+func (t *precompileTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64,
+	scope *vm.ScopeContext, rData []byte, depth int, err error) {
+	if depth > len(t.statics) {
+		t.statics = append(t.statics, t.siteStatic)
+	} else {
+		t.statics = t.statics[:depth]
+	}
+
+	switch op {
+	case vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL:
+		t.siteStatic = t.statics[depth-1] || op == vm.STATICCALL
+		// the callee gets at most the gas requested by the caller
+		t.siteGas = gas
+		if requested := scope.Stack.Back(0); requested.LtUint64(gas) {
+			t.siteGas = requested.Uint64()
+		}
+	case vm.CREATE, vm.CREATE2:
+		t.siteStatic = t.statics[depth-1]
+	}
+
+	if t.debug {
+		t.tracer.CaptureState(env, pc, op, gas, cost, scope, rData, depth, err)
+	}
+}
+
+func (t *precompileTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64,
+	scope *vm.ScopeContext, depth int, err error) {
+	if t.debug {
+		t.tracer.CaptureFault(env, pc, op, gas, cost, scope, depth, err)
+	}
+}
+
+func (t *precompileTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) {
+	if t.debug {
+		t.tracer.CaptureEnd(output, gasUsed, d, err)
+	}
+}
+
+// withPrecompileTracer makes the evm of the config follow its call frames for the precompiled contracts, which is
+// enabled from the earth milestone. gas is the gas of the top-level call.
+func (csdb *CommitStateDB) withPrecompileTracer(config vm.Config, gas uint64) vm.Config {
+	csdb.precompileTracer = nil
+	if len(csdb.precompiles) == 0 || !tmtypes.HigherThanEarth(csdb.ctx.BlockHeight()) {
+		return config
+	}
+	csdb.precompileTracer = newPrecompileTracer(config, gas)
+	config.Debug, config.Tracer = true, csdb.precompileTracer
+	return config
+}
+
+// callSite returns the gas available at the call site of the current call, and whether it runs in a static
+// context. An unknown call site has no gas and is considered static.
+func (csdb *CommitStateDB) callSite() (uint64, bool) {
+	if csdb.precompileTracer == nil {
+		return 0, true
+	}
+	return csdb.precompileTracer.siteGas, csdb.precompileTracer.siteStatic
+}
+
+// verifyPrecompileCall checks the call of a precompiled contract and hands its context over to the shim of the
+// address. The writing methods are rejected in a static context, the gas of the call is limited by the gas available
+// at the call site.
+func (csdb *CommitStateDB) verifyPrecompileCall(op vm.OpCode, from, to ethcmn.Address, input []byte, value *big.Int) error {
+	if op != vm.CALL && op != vm.STATICCALL && op != vm.DELEGATECALL && op != vm.CALLCODE {
+		return nil
+	}
+	shim := lookupPrecompileShim(to)
+	if shim == nil || !tmtypes.HigherThanEarth(csdb.ctx.BlockHeight()) {
+		return nil
+	}
+	csdb.ReleasePrecompileCall()
+
+	contract, ok := csdb.precompiles[to]
+	if !ok {
+		return ErrPrecompileCall(fmt.Sprintf("precompiled contract %s is not enabled", to.Hex()))
+	}
+	switch {
+	case op == vm.DELEGATECALL || op == vm.CALLCODE:
+		return ErrPrecompileCall(fmt.Sprintf("precompiled contract %s can't be called by %s", to.Hex(), op))
+	case value != nil && value.Sign() != 0:
+		return ErrPrecompileCall(fmt.Sprintf("precompiled contract %s is not payable", to.Hex()))
+	}
+	gas, static := csdb.callSite()
+	switch {
+	case len(input) < 4:
+		return ErrPrecompileCall(fmt.Sprintf("invalid input of precompiled contract %s", to.Hex()))
+	case !contract.IsReadOnly(input) && (op == vm.STATICCALL || static):
+		return ErrPrecompileCall(fmt.Sprintf("write protection of precompiled contract %s", to.Hex()))
+	}
+
+	key, _ := newPrecompileCallKey(input)
+	call := &PrecompileCall{
+		Caller:   from,
+		Input:    input,
+		contract: contract,
+		stateDB:  csdb,
+		shim:     shim,
+		key:      key,
+		gasLimit: gas,
+	}
+	shim.calls.Store(key, call)
+	csdb.pendingPrecompileCall = call
+	return nil
+}
+
+// ReleasePrecompileCall drops the pending call of the precompiled contract, which isn't run because of out of gas
+func (csdb *CommitStateDB) ReleasePrecompileCall() {
+	if call := csdb.pendingPrecompileCall; call != nil {
+		call.shim.calls.Delete(call.key)
+		csdb.pendingPrecompileCall = nil
+	}
+}
+
+// nativeCache is a cache of the native state written by a call of the precompiled contracts
+type nativeCache struct {
+	ctx   sdk.Context
+	write func()
+}
+
+// nativeContext returns the context of the native state, which includes the writes of the precompiled contracts
+func (csdb *CommitStateDB) nativeContext() sdk.Context {
+	if len(csdb.nativeCaches) == 0 {
+		return csdb.ctx
+	}
+	return csdb.nativeCaches[len(csdb.nativeCaches)-1].ctx
+}
+
+// commitNativeCaches writes the caches of the precompiled contracts to the context of the CommitStateDB, and
+// emits their events
+func (csdb *CommitStateDB) commitNativeCaches() {
+	if len(csdb.nativeCaches) == 0 {
+		return
+	}
+	// every cache is written to the cache below it
+	for i := len(csdb.nativeCaches) - 1; i >= 0; i-- {
+		csdb.nativeCaches[i].write()
+	}
+	for _, cache := range csdb.nativeCaches {
+		csdb.ctx.EventManager().EmitEvents(cache.ctx.EventManager().Events())
+	}
+	csdb.nativeCaches = nil
+	csdb.nativeWritten = true
+}
+
+var revertSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
+
+// packRevertReason encodes the reason as the revert data of solidity, Error(string)
+func packRevertReason(reason string) []byte {
+	typ, _ := abi.NewType("string", "", nil)
+	data, err := (abi.Arguments{{Type: typ}}).Pack(reason)
+	if err != nil {
+		return nil
+	}
+	return append(append([]byte{}, revertSelector...), data...)
+}
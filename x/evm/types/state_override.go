@@ -0,0 +1,32 @@
+package types
+
+import (
+	"fmt"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// StateOverrides is the collection of the accounts overridden before a simulated call, keyed by address.
+// It shares the json layout of the state override set of eth_call.
+type StateOverrides map[ethcmn.Address]OverrideAccount
+
+// OverrideAccount indicates the overridden fields of an account. The whole storage of the account
+// is replaced by State while only the given slots are replaced by StateDiff.
+type OverrideAccount struct {
+	Nonce     *hexutil.Uint64              `json:"nonce"`
+	Code      *hexutil.Bytes               `json:"code"`
+	Balance   *hexutil.Big                 `json:"balance"`
+	State     *map[ethcmn.Hash]ethcmn.Hash `json:"state"`
+	StateDiff *map[ethcmn.Hash]ethcmn.Hash `json:"stateDiff"`
+}
+
+// ValidateBasic checks that no account overrides both 'state' and 'stateDiff'
+func (so StateOverrides) ValidateBasic() error {
+	for addr, account := range so {
+		if account.State != nil && account.StateDiff != nil {
+			return fmt.Errorf("account %s has both 'state' and 'stateDiff'", addr.Hex())
+		}
+	}
+	return nil
+}
@@ -27,6 +27,7 @@ type StateTransition struct {
 	Recipient    *common.Address
 	Amount       *big.Int
 	Payload      []byte
+	AccessList   ethtypes.AccessList
 
 	ChainID    *big.Int
 	Csdb       *CommitStateDB
@@ -35,6 +36,11 @@ type StateTransition struct {
 	Simulate   bool // i.e CheckTx execution
 	TraceTx    bool // reexcute tx or its predesessors
 	TraceTxLog bool // trace tx for its evm logs (predesessors are set to false)
+	// PostTxHooks is set if the evm hooks react on the logs and the state changes of the tx, which are kept even if
+	// it is simulated
+	PostTxHooks bool
+	// TraceTxConfig is the json encoded go-ethereum TraceConfig for the tracer of TraceTxLog
+	TraceTxConfig []byte
 }
 
 // GasInfo returns the gas limit, gas consumed and gas refunded from the EVM transition
@@ -103,13 +109,25 @@ func (st StateTransition) newEVM(
 		GasPrice: gasPrice,
 	}
 
+	// the precompiled contracts check the static context and the gas of their call sites
+	vmConfig = csdb.withPrecompileTracer(vmConfig, gasLimit)
 	return vm.NewEVM(blockCtx, txCtx, csdb, config.EthereumConfig(st.ChainID), vmConfig)
 }
 
+// NewModuleEVM creates an evm for the calls made by the modules out of the evm txs, the sender is the tx origin
+func NewModuleEVM(ctx sdk.Context, csdb *CommitStateDB, sender common.Address, chainID *big.Int, gasLimit uint64, config ChainConfig) *vm.EVM {
+	params := csdb.GetParams()
+	st := StateTransition{Sender: sender, ChainID: chainID}
+	return st.newEVM(ctx, csdb, gasLimit, big.NewInt(0), config, vm.Config{
+		ExtraEips:        params.ExtraEIPs,
+		ContractVerifier: NewContractVerifier(params),
+	})
+}
+
 // TransitionDb will transition the state by applying the current transaction and
 // returning the evm execution result.
 // NOTE: State transition checks are run during AnteHandler execution.
-func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exeRes *ExecutionResult, resData *ResultData, err error, innerTxs, erc20Contracts interface{}) {
+func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exeRes *ExecutionResult, resData *ResultData, err error, innerTxs []*innertx.InnerTx, erc20Contracts []*innertx.ERC20Contract) {
 	defer func() {
 		if e := recover(); e != nil {
 			// if the msg recovered can be asserted into type 'ErrContractBlockedVerify', it must be captured by the panics of blocked
@@ -125,7 +143,7 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exe
 
 	contractCreation := st.Recipient == nil
 
-	cost, err := core.IntrinsicGas(st.Payload, []ethtypes.AccessTuple{}, contractCreation, config.IsHomestead(), config.IsIstanbul())
+	cost, err := core.IntrinsicGas(st.Payload, st.AccessList, contractCreation, config.IsHomestead(), config.IsIstanbul())
 	if err != nil {
 		return exeRes, resData, sdkerrors.Wrap(err, "invalid intrinsic gas for transaction"), innerTxs, erc20Contracts
 	}
@@ -166,7 +184,14 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exe
 	enableDebug := checkTracesSegment(ctx.BlockHeight(), EthAddressStringer(st.Sender).String(), to)
 
 	var tracer vm.Tracer
-	if st.TraceTxLog || enableDebug {
+	if st.TraceTxLog {
+		var stopTracer func()
+		tracer, stopTracer, err = newTraceTxLogTracer(st.TraceTxConfig, st.TxHash)
+		if err != nil {
+			return exeRes, resData, sdkerrors.Wrap(err, "invalid trace config"), innerTxs, erc20Contracts
+		}
+		defer stopTracer()
+	} else if enableDebug {
 		tracer = vm.NewStructLogger(evmLogConfig)
 	} else {
 		tracer = NewNoOpTracer()
@@ -179,7 +204,17 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exe
 		ContractVerifier: NewContractVerifier(params),
 	}
 
+	// record the inner txs of the tx by tracing the call frames
+	var innerTxTracer *InnerTxTracer
+	if IsInnerTxEnabled() && !st.Simulate && !st.TraceTx && !st.TraceTxLog && !ctx.IsCheckTx() {
+		innerTxTracer = NewInnerTxTracer(tracer, innertx.NewEvmInnerTx(innertx.CosmosDepth, innertx.CosmosCallType,
+			"", EthAddressStringer(st.Sender).String(), to, st.Amount))
+		vmConfig.Debug = true
+		vmConfig.Tracer = innerTxTracer
+	}
+
 	evm := st.newEVM(ctx, csdb, gasLimit, st.Price, config, vmConfig)
+	defer csdb.ReleasePrecompileCall()
 
 	var (
 		ret             []byte
@@ -194,9 +229,6 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exe
 	// Set nonce of sender account before evm state transition for usage in generating Create address
 	csdb.SetNonce(st.Sender, st.AccountNonce)
 
-	//add InnerTx
-	callTx := innertx.AddDefaultInnerTx(evm, innertx.CosmosDepth, EthAddressStringer(st.Sender).String(), "", "", "", st.Amount, nil)
-
 	// create contract or execute call
 	switch contractCreation {
 	case true:
@@ -214,8 +246,6 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exe
 		defer StopTxLog(analyzer.EVMCORE)
 		ret, contractAddress, leftOverGas, err = evm.Create(senderRef, st.Payload, gasLimit, st.Amount)
 		recipientLog = fmt.Sprintf("contract address %s", contractAddress.String())
-
-		innertx.UpdateDefaultInnerTx(callTx, EthAddressStringer(contractAddress).String(), innertx.CosmosCallType, innertx.EvmCreateName, gasLimit-leftOverGas)
 	default:
 		if !params.EnableCall {
 			return exeRes, resData, ErrCallDisabled, innerTxs, erc20Contracts
@@ -228,13 +258,13 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exe
 		ret, leftOverGas, err = evm.Call(senderRef, *st.Recipient, st.Payload, gasLimit, st.Amount)
 
 		recipientLog = fmt.Sprintf("recipient address %s", st.Recipient.String())
-
-		innertx.UpdateDefaultInnerTx(callTx, EthAddressStringer(*st.Recipient).String(), innertx.CosmosCallType, innertx.EvmCallName, gasLimit-leftOverGas)
 	}
 
 	gasConsumed := gasLimit - leftOverGas
 
-	innerTxs, erc20Contracts = innertx.ParseInnerTxAndContract(evm, err != nil)
+	if innerTxTracer != nil {
+		innerTxs, erc20Contracts = st.parseInnerTxs(ctx, csdb, innerTxTracer, contractAddress, gasConsumed, err)
+	}
 
 	defer func() {
 		// Consume gas from evm execution
@@ -289,7 +319,7 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exe
 		logs        []*ethtypes.Log
 	)
 
-	if st.TxHash != nil && !st.Simulate {
+	if st.TxHash != nil && (!st.Simulate || st.PostTxHooks) {
 		logs, err = csdb.GetLogs(*st.TxHash)
 		if err != nil {
 			return
@@ -299,8 +329,8 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exe
 		bloomFilter = ethtypes.BytesToBloom(bloomInt.Bytes())
 	}
 
-	if !st.Simulate || st.TraceTx {
-		// Finalise state if not a simulated transaction or a trace tx
+	if !st.Simulate || st.TraceTx || st.PostTxHooks {
+		// Finalise state if not a simulated transaction or a trace tx, or if the hooks react on it
 		// TODO: change to depend on config
 		if err = csdb.Finalise(true); err != nil {
 			return
@@ -10,8 +10,10 @@ import (
 	"github.com/okex/exchain/app/crypto/ethsecp256k1"
 	ethermint "github.com/okex/exchain/app/types"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/types/innertx"
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
 	"github.com/okex/exchain/x/evm/types"
+	"github.com/spf13/viper"
 )
 
 var (
@@ -518,3 +520,72 @@ func (suite *StateDBTestSuite) TestTransitionDb() {
 	suite.Require().Equal(fromBalance, sdk.NewDec(4940).BigInt())
 	suite.Require().Equal(toBalance, sdk.NewDec(50).BigInt())
 }
+
+func (suite *StateDBTestSuite) TestTransitionDbInnerTxs() {
+	viper.Set(types.FlagEnableInnerTx, true)
+	viper.Set("home", suite.T().TempDir())
+	suite.Require().NoError(types.InitInnerTxDB())
+	defer func() {
+		viper.Set(types.FlagEnableInnerTx, false)
+		suite.Require().NoError(types.InitInnerTxDB())
+	}()
+
+	addr := sdk.AccAddress(suite.address.Bytes())
+	acc := suite.app.AccountKeeper.GetAccount(suite.ctx, addr)
+	_ = acc.SetCoins(sdk.NewCoins(ethermint.NewPhotonCoin(sdk.NewInt(5000))))
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+	suite.stateDB = types.CreateEmptyCommitStateDB(suite.app.EvmKeeper.GenerateCSDBParams(), suite.ctx)
+
+	receiver := ethcmn.HexToAddress("0x1000000000000000000000000000000000000001")
+	beneficiary := ethcmn.HexToAddress("0x1000000000000000000000000000000000000002")
+	// init code: call receiver with 1 wei, then selfdestruct to beneficiary
+	payload := hexutil.MustDecode("0x6000600060006000600173" + receiver.Hex()[2:] + "61fffff150" +
+		"73" + beneficiary.Hex()[2:] + "ff")
+
+	st := types.StateTransition{
+		AccountNonce: 0,
+		Price:        big.NewInt(1),
+		GasLimit:     1000000,
+		Recipient:    nil,
+		Amount:       big.NewInt(10),
+		Payload:      payload,
+		ChainID:      big.NewInt(1),
+		Csdb:         suite.stateDB,
+		TxHash:       &ethcmn.Hash{},
+		Sender:       suite.address,
+	}
+	_, _, err, innerTxs, contracts := st.TransitionDb(suite.ctx, types.DefaultChainConfig())
+	suite.Require().NoError(err)
+	suite.Require().Empty(contracts)
+	suite.Require().Len(innerTxs, 3)
+
+	suite.Require().Equal(int64(0), innerTxs[0].Depth)
+	suite.Require().Equal(innertx.CosmosCallType, innerTxs[0].CallType)
+	suite.Require().Equal(innertx.EvmCreateName, innerTxs[0].Name)
+	suite.Require().Equal(types.EthAddressStringer(suite.address).String(), innerTxs[0].From)
+	suite.Require().Equal("10", innerTxs[0].ValueWei)
+	suite.Require().NotZero(innerTxs[0].GasUsed)
+	contract := innerTxs[0].To
+
+	suite.Require().Equal(int64(1), innerTxs[1].Depth)
+	suite.Require().Equal(innertx.EvmCallType, innerTxs[1].CallType)
+	suite.Require().Equal(innertx.EvmCallName, innerTxs[1].Name)
+	suite.Require().Equal(contract, innerTxs[1].From)
+	suite.Require().Equal(types.EthAddressStringer(receiver).String(), innerTxs[1].To)
+	suite.Require().Equal("1", innerTxs[1].ValueWei)
+	suite.Require().Equal("0.000000000000000001", innerTxs[1].Value)
+	suite.Require().False(innerTxs[1].IsError)
+
+	suite.Require().Equal(int64(1), innerTxs[2].Depth)
+	suite.Require().Equal(innertx.EvmSelfDestructName, innerTxs[2].Name)
+	suite.Require().Equal(contract, innerTxs[2].From)
+	suite.Require().Equal(types.EthAddressStringer(beneficiary).String(), innerTxs[2].To)
+	suite.Require().Equal("9", innerTxs[2].ValueWei)
+
+	// no inner tx is recorded for the simulation
+	st.Simulate = true
+	st.AccountNonce = 1
+	_, _, err, innerTxs, _ = st.TransitionDb(suite.ctx, types.DefaultChainConfig())
+	suite.Require().NoError(err)
+	suite.Require().Nil(innerTxs)
+}
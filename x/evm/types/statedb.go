@@ -45,6 +45,8 @@ type CommitStateDBParams struct {
 	Ada           DbAdapter
 	// Amino codec
 	Cdc *codec.Codec
+	// Precompiles are the precompiled contracts of the native modules
+	Precompiles map[ethcmn.Address]PrecompiledContract
 }
 
 type Watcher interface {
@@ -129,6 +131,14 @@ type CommitStateDB struct {
 
 	// Amino codec
 	cdc *codec.Codec
+
+	// the precompiled contracts of the native modules, the call frames of the evm running on the state, and the
+	// caches of the native state written by the calls of the precompiled contracts
+	precompiles           map[ethcmn.Address]PrecompiledContract
+	precompileTracer      *precompileTracer
+	pendingPrecompileCall *PrecompileCall
+	nativeCaches          []nativeCache
+	nativeWritten         bool
 }
 
 type StoreProxy interface {
@@ -202,6 +212,7 @@ func CreateEmptyCommitStateDB(csdbParams CommitStateDBParams, ctx sdk.Context) *
 		logs:                []*ethtypes.Log{},
 		codeCache:           make(map[ethcmn.Address]CacheCode, 0),
 		dbAdapter:           csdbParams.Ada,
+		precompiles:         csdbParams.Precompiles,
 	}
 }
 
@@ -749,6 +760,7 @@ func (csdb *CommitStateDB) StorageTrie(addr ethcmn.Address) ethstate.Trie {
 // be written. Finally, the root hash (version) will be returned.
 func (csdb *CommitStateDB) Commit(deleteEmptyObjects bool) (ethcmn.Hash, error) {
 	defer csdb.clearJournalAndRefund()
+	csdb.commitNativeCaches()
 
 	// remove dirty state object entries based on the journal
 	for _, dirty := range csdb.journal.dirties {
@@ -791,6 +803,7 @@ func (csdb *CommitStateDB) Commit(deleteEmptyObjects bool) (ethcmn.Hash, error)
 // removing the csdb destructed objects and clearing the journal as well as the
 // refunds.
 func (csdb *CommitStateDB) Finalise(deleteEmptyObjects bool) error {
+	csdb.commitNativeCaches()
 	for _, dirty := range csdb.journal.dirties {
 		stateEntry, exist := csdb.stateObjects[dirty.address]
 		if !exist {
@@ -855,6 +868,10 @@ func (csdb *CommitStateDB) updateStateObject(so *stateObject) error {
 	}
 
 	coins := so.account.GetCoins()
+	if csdb.nativeWritten {
+		// the native tokens of the account may be moved by the precompiled contracts, which never move okt
+		coins = csdb.mergeNativeCoins(so.account.GetAddress(), coins)
+	}
 	balance := coins.AmountOf(newBalance.Denom)
 	if balance.IsZero() || !balance.Equal(newBalance.Amount) {
 		coins = coins.Add(newBalance)
@@ -874,6 +891,24 @@ func (csdb *CommitStateDB) updateStateObject(so *stateObject) error {
 	return nil
 }
 
+// mergeNativeCoins returns the okt of the coins of the state object, and the other coins of the stored account
+func (csdb *CommitStateDB) mergeNativeCoins(addr sdk.AccAddress, coins sdk.Coins) sdk.Coins {
+	acc := csdb.accountKeeper.GetAccount(csdb.ctx, addr)
+	if acc == nil {
+		return coins
+	}
+	merged := sdk.Coins{}
+	for _, coin := range acc.GetCoins() {
+		if coin.Denom != sdk.DefaultBondDenom {
+			merged = append(merged, coin)
+		}
+	}
+	if okt := coins.AmountOf(sdk.DefaultBondDenom); okt.IsPositive() {
+		merged = merged.Add(sdk.NewDecCoinFromDec(sdk.DefaultBondDenom, okt))
+	}
+	return merged
+}
+
 // deleteStateObject removes the given state object from the state store.
 func (csdb *CommitStateDB) deleteStateObject(so *stateObject) {
 	so.deleted = true
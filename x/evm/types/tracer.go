@@ -1,6 +1,7 @@
 package types
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 	"path/filepath"
@@ -22,6 +23,8 @@ import (
 const (
 	tracesDir = "traces"
 
+	defaultTraceTimeout = 5 * time.Second
+
 	FlagEnableTraces           = "evm-trace-enable"
 	FlagTraceSegment           = "evm-trace-segment"
 	FlagTraceFromAddrs         = "evm-trace-from-addrs"
@@ -117,6 +120,55 @@ func checkTracesSegment(height int64, from, to string) bool {
 		(len(traceFromAddrs) == 0 || (len(traceFromAddrs) > 0 && fromOk)) &&
 		(len(traceToAddrs) == 0 || to == "" || (len(traceToAddrs) > 0 && toOk))
 }
+
+// newTraceTxLogTracer creates the tracer of a TraceTxLog tx from the json encoded tracers.TraceConfig.
+// The struct logger is used if no javascript tracer is specified. The returned func must be called
+// after the execution to release the timeout watcher of the javascript tracer.
+func newTraceTxLogTracer(configBytes []byte, txHash *common.Hash) (vm.Tracer, func(), error) {
+	noop := func() {}
+	if len(configBytes) == 0 {
+		return vm.NewStructLogger(evmLogConfig), noop, nil
+	}
+
+	var config tracers.TraceConfig
+	if err := json.Unmarshal(configBytes, &config); err != nil {
+		return nil, noop, err
+	}
+	if config.Tracer == nil {
+		return vm.NewStructLogger(config.LogConfig), noop, nil
+	}
+
+	timeout := defaultTraceTimeout
+	if config.Timeout != nil {
+		var err error
+		if timeout, err = time.ParseDuration(*config.Timeout); err != nil {
+			return nil, noop, err
+		}
+	}
+
+	txContext := &tracers.Context{}
+	if txHash != nil {
+		txContext.TxHash = *txHash
+	}
+	tracer, err := tracers.New(*config.Tracer, txContext)
+	if err != nil {
+		return nil, noop, err
+	}
+
+	// stop the javascript tracer if the execution exceeds the timeout
+	deadline := time.NewTimer(timeout)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-deadline.C:
+			tracer.Stop(errors.New("execution timeout"))
+		case <-done:
+			deadline.Stop()
+		}
+	}()
+	return tracer, func() { close(done) }, nil
+}
+
 func GetTracerResult(tracer vm.Tracer, result *core.ExecutionResult) ([]byte, error) {
 	var (
 		res []byte
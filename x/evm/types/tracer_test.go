@@ -0,0 +1,63 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/stretchr/testify/require"
+)
+
+func marshalTraceConfig(t *testing.T, config tracers.TraceConfig) []byte {
+	bz, err := json.Marshal(config)
+	require.NoError(t, err)
+	return bz
+}
+
+func TestNewTraceTxLogTracer(t *testing.T) {
+	txHash := common.HexToHash("0x01")
+
+	tracer, stop, err := newTraceTxLogTracer(nil, &txHash)
+	require.NoError(t, err)
+	require.IsType(t, &vm.StructLogger{}, tracer)
+	stop()
+
+	tracer, stop, err = newTraceTxLogTracer(marshalTraceConfig(t, tracers.TraceConfig{}), &txHash)
+	require.NoError(t, err)
+	require.IsType(t, &vm.StructLogger{}, tracer)
+	stop()
+
+	_, _, err = newTraceTxLogTracer([]byte("{"), &txHash)
+	require.Error(t, err)
+
+	jsTracer := "{data: [], step: function() {}, fault: function() {}, result: function() { return this.data; }}"
+	invalidTimeout := "1 hour"
+	_, _, err = newTraceTxLogTracer(marshalTraceConfig(t, tracers.TraceConfig{Tracer: &jsTracer, Timeout: &invalidTimeout}), &txHash)
+	require.Error(t, err)
+
+	invalidTracer := "{"
+	_, _, err = newTraceTxLogTracer(marshalTraceConfig(t, tracers.TraceConfig{Tracer: &invalidTracer}), &txHash)
+	require.Error(t, err)
+
+	// the javascript tracer is stopped when the timeout expires
+	timeout := "10ms"
+	tracer, stop, err = newTraceTxLogTracer(marshalTraceConfig(t, tracers.TraceConfig{Tracer: &jsTracer, Timeout: &timeout}), &txHash)
+	require.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
+	tracer.CaptureState(nil, 0, vm.STOP, 0, 0, nil, nil, 1, nil)
+	_, err = tracer.(*tracers.Tracer).GetResult()
+	require.EqualError(t, err, "execution timeout")
+	stop()
+
+	// the default timeout doesn't expire during a short execution, and the tracer isn't stopped once released
+	tracer, stop, err = newTraceTxLogTracer(marshalTraceConfig(t, tracers.TraceConfig{Tracer: &jsTracer}), &txHash)
+	require.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
+	stop()
+	result, err := tracer.(*tracers.Tracer).GetResult()
+	require.NoError(t, err)
+	require.Equal(t, "[]", string(result))
+}
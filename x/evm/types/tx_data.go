@@ -10,6 +10,7 @@ import (
 	"github.com/okex/exchain/app/utils"
 
 	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 )
 
 // TxData implements the Ethereum transaction data structure. It is used
@@ -29,6 +30,14 @@ type TxData struct {
 
 	// hash is only used when marshaling to JSON
 	Hash *ethcmn.Hash `json:"hash" rlp:"-"`
+
+	// EIP-2718 typed transaction fields, they are left empty for the legacy transactions.
+	// V is the y parity (0 or 1) of the signature for the typed transactions.
+	Type      uint8               `json:"type,omitempty" rlp:"-"`
+	ChainID   *big.Int            `json:"chainId,omitempty" rlp:"-"`
+	Accesses  ethtypes.AccessList `json:"accessList,omitempty" rlp:"-"`
+	GasTipCap *big.Int            `json:"maxPriorityFeePerGas,omitempty" rlp:"-"`
+	GasFeeCap *big.Int            `json:"maxFeePerGas,omitempty" rlp:"-"`
 }
 
 // encodableTxData implements the Ethereum transaction data structure. It is used
@@ -48,6 +57,12 @@ type encodableTxData struct {
 
 	// hash is only used when marshaling to JSON
 	Hash *ethcmn.Hash `json:"hash" rlp:"-"`
+
+	Type      uint64              `json:"type"`
+	ChainID   string              `json:"chainId"`
+	Accesses  ethtypes.AccessList `json:"accessList"`
+	GasTipCap string              `json:"maxPriorityFeePerGas"`
+	GasFeeCap string              `json:"maxFeePerGas"`
 }
 
 func (tx *encodableTxData) UnmarshalFromAmino(_ *amino.Codec, data []byte) error {
@@ -120,6 +135,65 @@ func (tx *encodableTxData) UnmarshalFromAmino(_ *amino.Codec, data []byte) error
 			}
 			tx.Hash = new(ethcmn.Hash)
 			copy(tx.Hash[:], subData)
+		case 11:
+			var n int
+			tx.Type, n, err = amino.DecodeUvarint(data)
+			if err != nil {
+				return err
+			}
+			dataLen = uint64(n)
+		case 12:
+			tx.ChainID = string(subData)
+		case 13:
+			var tuple ethtypes.AccessTuple
+			if err = unmarshalAccessTupleFromAmino(&tuple, subData); err != nil {
+				return err
+			}
+			tx.Accesses = append(tx.Accesses, tuple)
+		case 14:
+			tx.GasTipCap = string(subData)
+		case 15:
+			tx.GasFeeCap = string(subData)
+		default:
+			return fmt.Errorf("unexpect feild num %d", pos)
+		}
+	}
+	return nil
+}
+
+func unmarshalAccessTupleFromAmino(tuple *ethtypes.AccessTuple, data []byte) error {
+	for len(data) > 0 {
+		pos, pbType, err := amino.ParseProtoPosAndTypeMustOneByte(data[0])
+		if err != nil {
+			return err
+		}
+		if pbType != amino.Typ3_ByteLength {
+			return fmt.Errorf("invalid access tuple")
+		}
+		data = data[1:]
+
+		dataLen, n, err := amino.DecodeUvarint(data)
+		if err != nil {
+			return err
+		}
+		data = data[n:]
+		if uint64(len(data)) < dataLen {
+			return fmt.Errorf("invalid access tuple")
+		}
+		subData := data[:dataLen]
+		data = data[dataLen:]
+
+		switch pos {
+		case 1:
+			if dataLen != ethcmn.AddressLength {
+				return errors.New("eth addr len error")
+			}
+			copy(tuple.Address[:], subData)
+		case 2:
+			if dataLen != ethcmn.HashLength {
+				return errors.New("hash len error")
+			}
+			tuple.StorageKeys = append(tuple.StorageKeys, ethcmn.BytesToHash(subData))
 		default:
 			return fmt.Errorf("unexpect feild num %d", pos)
 		}
@@ -128,13 +202,52 @@ func (tx *encodableTxData) UnmarshalFromAmino(_ *amino.Codec, data []byte) error
 }
 
 func (td TxData) String() string {
+	var typed string
+	if td.Type != ethtypes.LegacyTxType {
+		typed = fmt.Sprintf(" type=%d chainID=%s accessList=%d gasTipCap=%s gasFeeCap=%s",
+			td.Type, td.ChainID, len(td.Accesses), td.GasTipCap, td.GasFeeCap)
+	}
+
 	if td.Recipient != nil {
 		return fmt.Sprintf("nonce=%d price=%s gasLimit=%d recipient=%s amount=%s data=0x%x v=%s r=%s s=%s",
-			td.AccountNonce, td.Price, td.GasLimit, td.Recipient.Hex(), td.Amount, td.Payload, td.V, td.R, td.S)
+			td.AccountNonce, td.Price, td.GasLimit, td.Recipient.Hex(), td.Amount, td.Payload, td.V, td.R, td.S) + typed
 	}
 
 	return fmt.Sprintf("nonce=%d price=%s gasLimit=%d recipient=nil amount=%s data=0x%x v=%s r=%s s=%s",
-		td.AccountNonce, td.Price, td.GasLimit, td.Amount, td.Payload, td.V, td.R, td.S)
+		td.AccountNonce, td.Price, td.GasLimit, td.Amount, td.Payload, td.V, td.R, td.S) + typed
+}
+
+// marshalOptionalBigInt marshals the big int of the typed transaction fields, nil is marshaled to empty string
+func marshalOptionalBigInt(i *big.Int) (string, error) {
+	if i == nil {
+		return "", nil
+	}
+	return utils.MarshalBigInt(i)
+}
+
+// unmarshalOptionalBigInt is the reverse of marshalOptionalBigInt
+func unmarshalOptionalBigInt(s string) (*big.Int, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	return utils.UnmarshalBigInt(s)
+}
+
+// unmarshalTypedFields sets the typed transaction fields from the amino encodable data
+func (td *TxData) unmarshalTypedFields(e *encodableTxData) (err error) {
+	if e.Type > ethtypes.DynamicFeeTxType {
+		return fmt.Errorf("unsupported tx type %d", e.Type)
+	}
+	td.Type = uint8(e.Type)
+	td.Accesses = e.Accesses
+	if td.ChainID, err = unmarshalOptionalBigInt(e.ChainID); err != nil {
+		return err
+	}
+	if td.GasTipCap, err = unmarshalOptionalBigInt(e.GasTipCap); err != nil {
+		return err
+	}
+	td.GasFeeCap, err = unmarshalOptionalBigInt(e.GasFeeCap)
+	return err
 }
 
 // MarshalAmino defines custom encoding scheme for TxData
@@ -164,6 +277,21 @@ func (td TxData) MarshalAmino() ([]byte, error) {
 		return nil, err
 	}
 
+	chainID, err := marshalOptionalBigInt(td.ChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	gasTipCap, err := marshalOptionalBigInt(td.GasTipCap)
+	if err != nil {
+		return nil, err
+	}
+
+	gasFeeCap, err := marshalOptionalBigInt(td.GasFeeCap)
+	if err != nil {
+		return nil, err
+	}
+
 	e := encodableTxData{
 		AccountNonce: td.AccountNonce,
 		Price:        gasPrice,
@@ -175,6 +303,11 @@ func (td TxData) MarshalAmino() ([]byte, error) {
 		R:            r,
 		S:            s,
 		Hash:         td.Hash,
+		Type:         uint64(td.Type),
+		ChainID:      chainID,
+		Accesses:     td.Accesses,
+		GasTipCap:    gasTipCap,
+		GasFeeCap:    gasFeeCap,
 	}
 
 	return ModuleCdc.MarshalBinaryBare(e)
@@ -249,7 +382,7 @@ func (td *TxData) UnmarshalAmino(data []byte) error {
 		td.S = s
 	}
 
-	return nil
+	return td.unmarshalTypedFields(&e)
 }
 
 func (td *TxData) unmarshalFromAmino(cdc *amino.Codec, data []byte) error {
@@ -319,7 +452,7 @@ func (td *TxData) unmarshalFromAmino(cdc *amino.Codec, data []byte) error {
 		td.S = s
 	}
 
-	return nil
+	return td.unmarshalTypedFields(&e)
 }
 
 func (td *TxData) UnmarshalFromAmino(cdc *amino.Codec, data []byte) error {
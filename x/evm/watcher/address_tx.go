@@ -0,0 +1,141 @@
+package watcher
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	rpctypes "github.com/okex/exchain/app/rpc/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+// the directions of a tx relative to an address, the order is used to merge the entries of a tx
+const (
+	DirectionOut byte = iota + 1
+	DirectionIn
+	DirectionInner
+)
+
+var directionNames = map[byte]string{
+	DirectionOut:   "out",
+	DirectionIn:    "in",
+	DirectionInner: "inner",
+}
+
+const (
+	directionSelf = "self"
+
+	addressTxKeyLen = 1 + common.AddressLength + 8 + 8 + 1
+)
+
+// AddressTx is an entry of the transaction history of an address
+type AddressTx struct {
+	Hash             common.Hash
+	BlockNumber      uint64
+	TransactionIndex uint64
+	Direction        string
+}
+
+// AddressTransaction is a transaction of the history of an address, with the direction of
+// the transaction relative to the address: out, in, self or inner
+type AddressTransaction struct {
+	*rpctypes.Transaction
+	Direction string `json:"direction"`
+}
+
+// MsgAddressTx indexes a tx by one of its participants.
+// key: prefixAddressTx | address | height | tx index | direction, value: tx hash
+type MsgAddressTx struct {
+	addr      common.Address
+	height    uint64
+	index     uint64
+	direction byte
+	txHash    common.Hash
+}
+
+func NewMsgAddressTx(addr common.Address, height, index uint64, direction byte, txHash common.Hash) *MsgAddressTx {
+	return &MsgAddressTx{addr: addr, height: height, index: index, direction: direction, txHash: txHash}
+}
+
+func (m MsgAddressTx) GetType() uint32 {
+	return TypeOthers
+}
+
+func (m MsgAddressTx) GetKey() []byte {
+	return addressTxKey(m.addr, m.height, m.index, m.direction)
+}
+
+func (m MsgAddressTx) GetValue() string {
+	return string(m.txHash.Bytes())
+}
+
+// MsgHeightAddressTx is the reverse of MsgAddressTx by height, which is used to remove the index of
+// the blocks to be replayed.
+// key: prefixHeightAddrTx | height | address | tx index | direction, value: empty
+type MsgHeightAddressTx struct {
+	MsgAddressTx
+}
+
+func (m MsgHeightAddressTx) GetKey() []byte {
+	key := make([]byte, 0, addressTxKeyLen)
+	key = append(key, prefixHeightAddrTx...)
+	key = appendUint64(key, m.height)
+	key = append(key, m.addr.Bytes()...)
+	key = appendUint64(key, m.index)
+	return append(key, m.direction)
+}
+
+func (m MsgHeightAddressTx) GetValue() string {
+	return ""
+}
+
+func addressTxKey(addr common.Address, height, index uint64, direction byte) []byte {
+	key := make([]byte, 0, addressTxKeyLen)
+	key = append(key, prefixAddressTx...)
+	key = append(key, addr.Bytes()...)
+	key = appendUint64(key, height)
+	key = appendUint64(key, index)
+	return append(key, direction)
+}
+
+// addressTxKeyFromHeightKey converts a key of MsgHeightAddressTx to the key of MsgAddressTx
+func addressTxKeyFromHeightKey(key []byte) []byte {
+	if len(key) != addressTxKeyLen {
+		return nil
+	}
+	height := binary.BigEndian.Uint64(key[1:9])
+	addr := common.BytesToAddress(key[9 : 9+common.AddressLength])
+	index := binary.BigEndian.Uint64(key[9+common.AddressLength : 17+common.AddressLength])
+	return addressTxKey(addr, height, index, key[len(key)-1])
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	var bz [8]byte
+	binary.BigEndian.PutUint64(bz[:], v)
+	return append(b, bz[:]...)
+}
+
+// parseParticipant parses the hex or bech32 address of a participant of an inner tx
+func parseParticipant(addr string) (common.Address, bool) {
+	if common.IsHexAddress(addr) {
+		return common.HexToAddress(addr), true
+	}
+	accAddr, err := sdk.AccAddressFromBech32(addr)
+	if err != nil || len(accAddr) != common.AddressLength {
+		return common.Address{}, false
+	}
+	return common.BytesToAddress(accAddr), true
+}
+
+// mergeDirection merges the directions of the entries of the same tx
+func mergeDirection(merged string, direction byte) string {
+	name := directionNames[direction]
+	switch {
+	case merged == "":
+		return name
+	case merged == directionNames[DirectionOut] && name == directionNames[DirectionIn]:
+		return directionSelf
+	default:
+		// the entries are sorted by direction, so the first one dominates
+		return merged
+	}
+}
@@ -0,0 +1,80 @@
+package watcher
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/types/innertx"
+	dbm "github.com/okex/exchain/libs/tm-db"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressTxIndex(t *testing.T) {
+	store := &WatchStore{db: dbm.NewMemDB()}
+	w := &Watcher{store: store, sw: true}
+	q := &Querier{store: store, sw: true}
+
+	alice := common.HexToAddress("0x01")
+	bob := common.HexToAddress("0x02")
+	carol := common.HexToAddress("0x03")
+	txHash := func(height, index uint64) common.Hash {
+		return common.BytesToHash([]byte{byte(height), byte(index)})
+	}
+
+	for height := uint64(1); height <= 3; height++ {
+		w.height = height
+		w.batch = nil
+		// alice -> bob
+		w.saveAddressTx(alice, 0, DirectionOut, txHash(height, 0))
+		w.saveAddressTx(bob, 0, DirectionIn, txHash(height, 0))
+		// alice -> alice, calling carol by an inner tx
+		w.saveAddressTx(alice, 1, DirectionOut, txHash(height, 1))
+		w.saveAddressTx(alice, 1, DirectionIn, txHash(height, 1))
+		w.SaveInnerTxs(txHash(height, 1), 1, []*innertx.InnerTx{
+			{From: alice.Hex(), To: carol.Hex()},
+			{From: sdk.AccAddress(carol.Bytes()).String(), To: "invalid"},
+		})
+		w.commitBatch(w.batch, nil)
+	}
+
+	txs, err := q.GetTransactionsByAddress(alice, 1, 3, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, txs, 6)
+	require.Equal(t, &AddressTx{Hash: txHash(1, 0), BlockNumber: 1, TransactionIndex: 0, Direction: "out"}, txs[0])
+	require.Equal(t, &AddressTx{Hash: txHash(1, 1), BlockNumber: 1, TransactionIndex: 1, Direction: "self"}, txs[1])
+	require.Equal(t, txHash(3, 1), txs[5].Hash)
+
+	// pagination
+	txs, err = q.GetTransactionsByAddress(alice, 1, 3, 3, 2)
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	require.Equal(t, txHash(2, 1), txs[0].Hash)
+	require.Equal(t, txHash(3, 0), txs[1].Hash)
+	txs, err = q.GetTransactionsByAddress(alice, 1, 3, 5, 2)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+
+	// block range
+	txs, err = q.GetTransactionsByAddress(bob, 2, 2, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	require.Equal(t, &AddressTx{Hash: txHash(2, 0), BlockNumber: 2, TransactionIndex: 0, Direction: "in"}, txs[0])
+
+	txs, err = q.GetTransactionsByAddress(carol, 1, 3, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, txs, 3)
+	require.Equal(t, "inner", txs[0].Direction)
+
+	_, err = q.GetTransactionsByAddress(carol, 3, 1, 0, 10)
+	require.Error(t, err)
+
+	// the index of the replayed blocks is removed
+	w.DeleteAddressTxsFromHeight(2)
+	txs, err = q.GetTransactionsByAddress(alice, 1, 3, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	txs, err = q.GetTransactionsByAddress(carol, 0, 100, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+}
@@ -0,0 +1,361 @@
+package watcher
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/spf13/viper"
+)
+
+// FlagLogIndex enables the index of the logs by address and first topic, which answers eth_getLogs for
+// arbitrary block ranges
+const FlagLogIndex = "fast-query-log-index"
+
+const (
+	logPositionLen    = 8 + 8 + 8
+	logAddrKeyLen     = 1 + common.AddressLength + logPositionLen
+	logTopicKeyLen    = 1 + common.AddressLength + common.HashLength + logPositionLen
+	heightLogKeyLen   = 1 + logPositionLen + common.AddressLength
+	logIndexRangeSize = 16
+)
+
+var (
+	logIndexEnable     = false
+	onceLogIndexEnable sync.Once
+)
+
+// IsLogIndexEnabled returns true if the logs are indexed by the watcher
+func IsLogIndexEnabled() bool {
+	onceLogIndexEnable.Do(func() {
+		logIndexEnable = viper.GetBool(FlagLogIndex)
+	})
+	return logIndexEnable
+}
+
+// LogPosition is the position of a log in the chain, the logs are ordered by their positions
+type LogPosition struct {
+	Height   uint64
+	TxIndex  uint64
+	LogIndex uint64
+}
+
+func (p LogPosition) bytes() []byte {
+	bz := make([]byte, 0, logPositionLen)
+	bz = appendUint64(bz, p.Height)
+	bz = appendUint64(bz, p.TxIndex)
+	return appendUint64(bz, p.LogIndex)
+}
+
+func (p LogPosition) less(other LogPosition) bool {
+	if p.Height != other.Height {
+		return p.Height < other.Height
+	}
+	if p.TxIndex != other.TxIndex {
+		return p.TxIndex < other.TxIndex
+	}
+	return p.LogIndex < other.LogIndex
+}
+
+func logPositionFromBytes(bz []byte) LogPosition {
+	return LogPosition{
+		Height:   binary.BigEndian.Uint64(bz[0:8]),
+		TxIndex:  binary.BigEndian.Uint64(bz[8:16]),
+		LogIndex: binary.BigEndian.Uint64(bz[16:24]),
+	}
+}
+
+// Cursor encodes the position as the cursor of the paginated queries
+func (p LogPosition) Cursor() string {
+	return hexutil.Encode(p.bytes())
+}
+
+// ParseLogCursor decodes the cursor of the paginated queries
+func ParseLogCursor(cursor string) (LogPosition, error) {
+	bz, err := hexutil.Decode(cursor)
+	if err != nil || len(bz) != logPositionLen {
+		return LogPosition{}, fmt.Errorf("invalid cursor %s", cursor)
+	}
+	return logPositionFromBytes(bz), nil
+}
+
+// LogIndexEntry is an entry of the log index, which locates the log in the receipt of its tx
+type LogIndexEntry struct {
+	LogPosition
+	TxHash common.Hash
+}
+
+// MsgLogIndex indexes a log by its address, or by its address and first topic.
+// key: prefixLogAddr | address | height | tx index | log index, value: tx hash
+// key: prefixLogTopic | address | topic0 | height | tx index | log index, value: tx hash
+type MsgLogIndex struct {
+	addr   common.Address
+	topic  *common.Hash
+	pos    LogPosition
+	txHash common.Hash
+}
+
+func NewMsgLogIndex(addr common.Address, topic *common.Hash, pos LogPosition, txHash common.Hash) *MsgLogIndex {
+	return &MsgLogIndex{addr: addr, topic: topic, pos: pos, txHash: txHash}
+}
+
+func (m MsgLogIndex) GetType() uint32 {
+	return TypeOthers
+}
+
+func (m MsgLogIndex) GetKey() []byte {
+	return logIndexKey(m.addr, m.topic, m.pos)
+}
+
+func (m MsgLogIndex) GetValue() string {
+	return string(m.txHash.Bytes())
+}
+
+// MsgHeightLogIndex is the reverse of MsgLogIndex by height, which is used to remove the index of
+// the blocks to be replayed.
+// key: prefixHeightLog | height | tx index | log index | address [| topic0], value: empty
+type MsgHeightLogIndex struct {
+	MsgLogIndex
+}
+
+func (m MsgHeightLogIndex) GetKey() []byte {
+	key := make([]byte, 0, heightLogKeyLen+common.HashLength)
+	key = append(key, prefixHeightLog...)
+	key = append(key, m.pos.bytes()...)
+	key = append(key, m.addr.Bytes()...)
+	if m.topic != nil {
+		key = append(key, m.topic.Bytes()...)
+	}
+	return key
+}
+
+func (m MsgHeightLogIndex) GetValue() string {
+	return ""
+}
+
+// MsgLogIndexRange records the range of the blocks whose logs are indexed continuously.
+// key: prefixLogIndexRange, value: from height | to height
+type MsgLogIndexRange struct {
+	from, to uint64
+}
+
+func (m MsgLogIndexRange) GetType() uint32 {
+	return TypeOthers
+}
+
+func (m MsgLogIndexRange) GetKey() []byte {
+	return prefixLogIndexRange
+}
+
+func (m MsgLogIndexRange) GetValue() string {
+	return string(appendUint64(appendUint64(nil, m.from), m.to))
+}
+
+func logIndexPrefix(addr common.Address, topic *common.Hash) []byte {
+	if topic == nil {
+		prefix := make([]byte, 0, logAddrKeyLen)
+		prefix = append(prefix, prefixLogAddr...)
+		return append(prefix, addr.Bytes()...)
+	}
+	prefix := make([]byte, 0, logTopicKeyLen)
+	prefix = append(prefix, prefixLogTopic...)
+	prefix = append(prefix, addr.Bytes()...)
+	return append(prefix, topic.Bytes()...)
+}
+
+func logIndexKey(addr common.Address, topic *common.Hash, pos LogPosition) []byte {
+	return append(logIndexPrefix(addr, topic), pos.bytes()...)
+}
+
+// logIndexKeyFromHeightKey converts a key of MsgHeightLogIndex to the key of MsgLogIndex
+func logIndexKeyFromHeightKey(key []byte) []byte {
+	if len(key) != heightLogKeyLen && len(key) != heightLogKeyLen+common.HashLength {
+		return nil
+	}
+	pos := logPositionFromBytes(key[1 : 1+logPositionLen])
+	addr := common.BytesToAddress(key[1+logPositionLen : heightLogKeyLen])
+	var topic *common.Hash
+	if len(key) > heightLogKeyLen {
+		t := common.BytesToHash(key[heightLogKeyLen:])
+		topic = &t
+	}
+	return logIndexKey(addr, topic, pos)
+}
+
+// logIndexMsgs returns the index entries of the logs of a tx, the log index of the position is the index of the
+// log in the block
+func logIndexMsgs(height, txIndex uint64, txHash common.Hash, logs []*ethtypes.Log) []WatchMessage {
+	msgs := make([]WatchMessage, 0, len(logs)*4)
+	for _, log := range logs {
+		pos := LogPosition{Height: height, TxIndex: txIndex, LogIndex: uint64(log.Index)}
+		addrMsg := NewMsgLogIndex(log.Address, nil, pos, txHash)
+		msgs = append(msgs, addrMsg, &MsgHeightLogIndex{*addrMsg})
+		if len(log.Topics) > 0 {
+			topic := log.Topics[0]
+			topicMsg := NewMsgLogIndex(log.Address, &topic, pos, txHash)
+			msgs = append(msgs, topicMsg, &MsgHeightLogIndex{*topicMsg})
+		}
+	}
+	return msgs
+}
+
+func getLogIndexRange(store *WatchStore) (from, to uint64, ok bool) {
+	bz, err := store.Get(prefixLogIndexRange)
+	if err != nil || len(bz) != logIndexRangeSize {
+		return 0, 0, false
+	}
+	return binary.BigEndian.Uint64(bz[:8]), binary.BigEndian.Uint64(bz[8:]), true
+}
+
+func setLogIndexRange(store *WatchStore, from, to uint64) {
+	store.Set(prefixLogIndexRange, []byte(MsgLogIndexRange{from: from, to: to}.GetValue()))
+}
+
+// GetLogIndexRange returns the range of the blocks whose logs are indexed continuously
+func (q Querier) GetLogIndexRange() (from, to uint64, ok bool) {
+	if !q.enabled() || !IsLogIndexEnabled() {
+		return 0, 0, false
+	}
+	return getLogIndexRange(q.store)
+}
+
+// GetLogIndexEntries returns the entries of the logs emitted by the addresses with the first topics between
+// the blocks, in the order of their positions. The logs with any topic are returned if topics is empty.
+// The query starts from the cursor if it's not nil, and the returned cursor is the position of the next entry,
+// which is nil if there are no more entries.
+func (q Querier) GetLogIndexEntries(addresses []common.Address, topics []common.Hash, fromBlock, toBlock uint64,
+	cursor *LogPosition, limit int) ([]*LogIndexEntry, *LogPosition, error) {
+	if !q.enabled() || !IsLogIndexEnabled() {
+		return nil, nil, errors.New(MsgFunctionDisable)
+	}
+	if len(addresses) == 0 {
+		return nil, nil, errors.New("addresses are required by the log index")
+	}
+	if fromBlock > toBlock {
+		return nil, nil, errors.New("fromBlock must not be greater than toBlock")
+	}
+	if limit <= 0 {
+		return nil, nil, errors.New("limit must be positive")
+	}
+
+	start := LogPosition{Height: fromBlock}
+	if cursor != nil && start.less(*cursor) {
+		start = *cursor
+	}
+
+	var prefixes [][]byte
+	for _, addr := range addresses {
+		if len(topics) == 0 {
+			prefixes = append(prefixes, logIndexPrefix(addr, nil))
+			continue
+		}
+		for i := range topics {
+			prefixes = append(prefixes, logIndexPrefix(addr, &topics[i]))
+		}
+	}
+
+	// every prefix returns at most limit+1 entries, which are enough to fill the page and find the next cursor
+	var entries []*LogIndexEntry
+	for _, prefix := range prefixes {
+		found, err := q.iterateLogIndex(prefix, start, toBlock, limit+1)
+		if err != nil {
+			return nil, nil, err
+		}
+		entries = append(entries, found...)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].LogPosition.less(entries[j].LogPosition)
+	})
+
+	if len(entries) > limit {
+		next := entries[limit].LogPosition
+		return entries[:limit], &next, nil
+	}
+	return entries, nil, nil
+}
+
+func (q Querier) iterateLogIndex(prefix []byte, start LogPosition, toBlock uint64, limit int) ([]*LogIndexEntry, error) {
+	end := sdk.PrefixEndBytes(prefix)
+	if toBlock < ^uint64(0) {
+		end = append(append([]byte{}, prefix...), LogPosition{Height: toBlock + 1}.bytes()...)
+	}
+	it := q.store.Iterator(append(append([]byte{}, prefix...), start.bytes()...), end)
+	if it == nil {
+		return nil, errors.New("failed to iterate the log index")
+	}
+	defer it.Close()
+
+	var entries []*LogIndexEntry
+	for ; it.Valid() && len(entries) < limit; it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+logPositionLen {
+			continue
+		}
+		entries = append(entries, &LogIndexEntry{
+			LogPosition: logPositionFromBytes(key[len(prefix):]),
+			TxHash:      common.BytesToHash(it.Value()),
+		})
+	}
+	return entries, nil
+}
+
+// BackfillLogIndex indexes the logs of the blocks between fromBlock and toBlock, which are read from the receipts
+// of the watcher db, a zero toBlock is the latest block of the db. The range must be continuous with the indexed
+// range, if any. progress is called after each block is indexed.
+func BackfillLogIndex(store *WatchStore, fromBlock, toBlock uint64, progress func(height uint64)) error {
+	q := Querier{store: store, sw: true}
+	if toBlock == 0 {
+		latest, err := q.GetLatestBlockNumber()
+		if err != nil {
+			return fmt.Errorf("failed to get the latest block: %s", err)
+		}
+		toBlock = latest
+	}
+	if fromBlock == 0 || fromBlock > toBlock {
+		return fmt.Errorf("invalid block range %d-%d", fromBlock, toBlock)
+	}
+	from, to, ok := getLogIndexRange(store)
+	if ok && (fromBlock > to+1 || toBlock+1 < from) {
+		return fmt.Errorf("the blocks %d-%d are not continuous with the indexed blocks %d-%d", fromBlock, toBlock, from, to)
+	}
+
+	for height := fromBlock; height <= toBlock; height++ {
+		block, err := q.GetBlockByNumber(height, false)
+		if err != nil {
+			return fmt.Errorf("failed to get block %d: %s", height, err)
+		}
+		txs, _ := block.Transactions.([]interface{})
+		for _, tx := range txs {
+			hashStr, ok := tx.(string)
+			if !ok {
+				return fmt.Errorf("invalid tx hash of block %d", height)
+			}
+			hash := common.HexToHash(hashStr)
+			receipt, err := q.GetTransactionReceipt(hash)
+			if err != nil {
+				return fmt.Errorf("failed to get receipt of tx %s: %s", hash.Hex(), err)
+			}
+			for _, msg := range logIndexMsgs(height, uint64(receipt.TransactionIndex), hash, receipt.Logs) {
+				store.Set(msg.GetKey(), []byte(msg.GetValue()))
+			}
+		}
+		if progress != nil {
+			progress(height)
+		}
+	}
+
+	if !ok || fromBlock < from {
+		from = fromBlock
+	}
+	if !ok || toBlock > to {
+		to = toBlock
+	}
+	setLogIndexRange(store, from, to)
+	return nil
+}
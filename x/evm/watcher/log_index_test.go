@@ -0,0 +1,152 @@
+package watcher
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	dbm "github.com/okex/exchain/libs/tm-db"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testToken    = common.HexToAddress("0x01")
+	testPair     = common.HexToAddress("0x02")
+	testTransfer = common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	testApproval = common.HexToHash("0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b22291e5b200ac8c7b925")
+)
+
+func testLogTxHash(height, index uint64) common.Hash {
+	return common.BytesToHash([]byte{byte(height), byte(index)})
+}
+
+// testLogs returns the logs of the tx: a transfer and an approval of the token, and a log of the pair without topics
+func testLogs(height, txIndex uint64) []*ethtypes.Log {
+	txHash := testLogTxHash(height, txIndex)
+	return []*ethtypes.Log{
+		{Address: testToken, Topics: []common.Hash{testTransfer}, BlockNumber: height, TxHash: txHash, TxIndex: uint(txIndex), Index: uint(txIndex * 3)},
+		{Address: testToken, Topics: []common.Hash{testApproval}, BlockNumber: height, TxHash: txHash, TxIndex: uint(txIndex), Index: uint(txIndex*3 + 1)},
+		{Address: testPair, Topics: []common.Hash{}, BlockNumber: height, TxHash: txHash, TxIndex: uint(txIndex), Index: uint(txIndex*3 + 2)},
+	}
+}
+
+func TestLogIndex(t *testing.T) {
+	onceLogIndexEnable.Do(func() {})
+	logIndexEnable = true
+	store := &WatchStore{db: dbm.NewMemDB()}
+	w := &Watcher{store: store, sw: true, logIndex: true, log: log.NewNopLogger()}
+	q := &Querier{store: store, sw: true}
+
+	for height := uint64(1); height <= 3; height++ {
+		w.height = height
+		w.batch = nil
+		for txIndex := uint64(0); txIndex < 2; txIndex++ {
+			w.batch = append(w.batch, logIndexMsgs(height, txIndex, testLogTxHash(height, txIndex), testLogs(height, txIndex))...)
+		}
+		w.saveLogIndexRange()
+		w.commitBatch(w.batch, nil)
+	}
+	from, to, ok := q.GetLogIndexRange()
+	require.True(t, ok)
+	require.Equal(t, uint64(1), from)
+	require.Equal(t, uint64(3), to)
+
+	entries, next, err := q.GetLogIndexEntries([]common.Address{testToken}, []common.Hash{testTransfer}, 1, 3, nil, 10)
+	require.NoError(t, err)
+	require.Nil(t, next)
+	require.Len(t, entries, 6)
+	require.Equal(t, &LogIndexEntry{LogPosition: LogPosition{Height: 1, TxIndex: 1, LogIndex: 3}, TxHash: testLogTxHash(1, 1)}, entries[1])
+
+	// the logs with any topic, in the order of their positions
+	entries, _, err = q.GetLogIndexEntries([]common.Address{testToken, testPair}, nil, 2, 2, nil, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 6)
+	for i, entry := range entries {
+		require.Equal(t, uint64(i), entry.LogIndex)
+	}
+
+	// pagination
+	var cursor *LogPosition
+	var pages []*LogIndexEntry
+	for {
+		entries, cursor, err = q.GetLogIndexEntries([]common.Address{testToken}, []common.Hash{testTransfer, testApproval}, 1, 3, cursor, 5)
+		require.NoError(t, err)
+		pages = append(pages, entries...)
+		if cursor == nil {
+			break
+		}
+		parsed, err := ParseLogCursor(cursor.Cursor())
+		require.NoError(t, err)
+		require.Equal(t, *cursor, parsed)
+	}
+	require.Len(t, pages, 12)
+	require.Equal(t, LogPosition{Height: 3, TxIndex: 1, LogIndex: 4}, pages[11].LogPosition)
+
+	_, _, err = q.GetLogIndexEntries(nil, nil, 1, 3, nil, 10)
+	require.Error(t, err)
+	_, _, err = q.GetLogIndexEntries([]common.Address{testToken}, nil, 3, 1, nil, 10)
+	require.Error(t, err)
+
+	// the index of the replayed blocks is removed
+	w.DeleteLogIndexFromHeight(2)
+	entries, _, err = q.GetLogIndexEntries([]common.Address{testToken, testPair}, nil, 1, 3, nil, 100)
+	require.NoError(t, err)
+	require.Len(t, entries, 6)
+	_, to, ok = q.GetLogIndexRange()
+	require.True(t, ok)
+	require.Equal(t, uint64(1), to)
+
+	// the range restarts after a gap of the indexed blocks
+	w.height = 5
+	w.batch = nil
+	w.saveLogIndexRange()
+	w.commitBatch(w.batch, nil)
+	from, to, _ = q.GetLogIndexRange()
+	require.Equal(t, uint64(5), from)
+	require.Equal(t, uint64(5), to)
+}
+
+func TestBackfillLogIndex(t *testing.T) {
+	onceLogIndexEnable.Do(func() {})
+	logIndexEnable = true
+	store := &WatchStore{db: dbm.NewMemDB()}
+	q := &Querier{store: store, sw: true}
+
+	for height := uint64(1); height <= 3; height++ {
+		blockHash := common.BytesToHash([]byte{0xbb, byte(height)})
+		var txs []common.Hash
+		for txIndex := uint64(0); txIndex < 2; txIndex++ {
+			txHash := testLogTxHash(height, txIndex)
+			txs = append(txs, txHash)
+			receipt, err := json.Marshal(TransactionReceipt{TransactionIndex: hexutil.Uint64(txIndex), Logs: testLogs(height, txIndex)})
+			require.NoError(t, err)
+			store.Set(append(prefixReceipt, txHash.Bytes()...), receipt)
+		}
+		block, err := json.Marshal(EthBlock{Transactions: txs})
+		require.NoError(t, err)
+		store.Set(append(prefixBlock, blockHash.Bytes()...), block)
+		info := NewMsgBlockInfo(height, blockHash)
+		store.Set(info.GetKey(), []byte(info.GetValue()))
+	}
+
+	var indexed []uint64
+	require.NoError(t, BackfillLogIndex(store, 2, 3, func(height uint64) {
+		indexed = append(indexed, height)
+	}))
+	require.Equal(t, []uint64{2, 3}, indexed)
+	entries, _, err := q.GetLogIndexEntries([]common.Address{testToken}, []common.Hash{testTransfer}, 1, 3, nil, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+	require.Equal(t, LogPosition{Height: 2, TxIndex: 0, LogIndex: 0}, entries[0].LogPosition)
+
+	// the backfilled blocks must be continuous with the indexed blocks
+	require.NoError(t, BackfillLogIndex(store, 1, 1, nil))
+	from, to, ok := q.GetLogIndexRange()
+	require.True(t, ok)
+	require.Equal(t, uint64(1), from)
+	require.Equal(t, uint64(3), to)
+	require.Error(t, BackfillLogIndex(store, 5, 6, nil))
+}
@@ -1,9 +1,11 @@
 package watcher
 
 import (
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"math"
 	"strconv"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -436,3 +438,65 @@ func (q Querier) HasContractDeploymentWhitelist(key []byte) bool {
 	}
 	return q.store.Has(append(prefixWhiteList, key...))
 }
+
+// GetTransactionsByAddress returns the history of the txs which the address takes part in between the blocks,
+// in the order of the txs. The entries of a tx with different directions are merged into one.
+func (q Querier) GetTransactionsByAddress(addr common.Address, fromBlock, toBlock, offset, limit uint64) ([]*AddressTx, error) {
+	if !q.enabled() {
+		return nil, errors.New(MsgFunctionDisable)
+	}
+	if fromBlock > toBlock {
+		return nil, errors.New("fromBlock must not be greater than toBlock")
+	}
+
+	prefix := append(append([]byte{}, prefixAddressTx...), addr.Bytes()...)
+	end := sdk.PrefixEndBytes(prefix)
+	if toBlock < math.MaxUint64 {
+		end = appendUint64(append([]byte{}, prefix...), toBlock+1)
+	}
+	it := q.store.Iterator(appendUint64(append([]byte{}, prefix...), fromBlock), end)
+	if it == nil {
+		return nil, errors.New("failed to iterate the address index")
+	}
+	defer it.Close()
+
+	var (
+		txs     []*AddressTx
+		current *AddressTx
+		skipped uint64
+	)
+	for ; it.Valid(); it.Next() {
+		key := it.Key()
+		if len(key) != addressTxKeyLen {
+			continue
+		}
+		height := binary.BigEndian.Uint64(key[len(prefix) : len(prefix)+8])
+		index := binary.BigEndian.Uint64(key[len(prefix)+8 : len(prefix)+16])
+		direction := key[len(key)-1]
+
+		if current != nil && current.BlockNumber == height && current.TransactionIndex == index {
+			current.Direction = mergeDirection(current.Direction, direction)
+			continue
+		}
+		if current != nil {
+			if skipped < offset {
+				skipped++
+			} else {
+				txs = append(txs, current)
+			}
+		}
+		if uint64(len(txs)) >= limit {
+			return txs, nil
+		}
+		current = &AddressTx{
+			Hash:             common.BytesToHash(it.Value()),
+			BlockNumber:      height,
+			TransactionIndex: index,
+			Direction:        mergeDirection("", direction),
+		}
+	}
+	if current != nil && skipped >= offset && uint64(len(txs)) < limit {
+		txs = append(txs, current)
+	}
+	return txs, nil
+}
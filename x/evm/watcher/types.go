@@ -26,19 +26,25 @@ import (
 )
 
 var (
-	prefixTx           = []byte{0x01}
-	prefixBlock        = []byte{0x02}
-	prefixReceipt      = []byte{0x03}
-	prefixCode         = []byte{0x04}
-	prefixBlockInfo    = []byte{0x05}
-	prefixLatestHeight = []byte{0x06}
-	prefixAccount      = []byte{0x07}
-	PrefixState        = []byte{0x08}
-	prefixCodeHash     = []byte{0x09}
-	prefixParams       = []byte{0x10}
-	prefixWhiteList    = []byte{0x11}
-	prefixBlackList    = []byte{0x12}
-	prefixRpcDb        = []byte{0x13}
+	prefixTx            = []byte{0x01}
+	prefixBlock         = []byte{0x02}
+	prefixReceipt       = []byte{0x03}
+	prefixCode          = []byte{0x04}
+	prefixBlockInfo     = []byte{0x05}
+	prefixLatestHeight  = []byte{0x06}
+	prefixAccount       = []byte{0x07}
+	PrefixState         = []byte{0x08}
+	prefixCodeHash      = []byte{0x09}
+	prefixParams        = []byte{0x10}
+	prefixWhiteList     = []byte{0x11}
+	prefixBlackList     = []byte{0x12}
+	prefixRpcDb         = []byte{0x13}
+	prefixAddressTx     = []byte{0x14}
+	prefixHeightAddrTx  = []byte{0x15}
+	prefixLogAddr       = []byte{0x16}
+	prefixLogTopic      = []byte{0x17}
+	prefixHeightLog     = []byte{0x18}
+	prefixLogIndexRange = []byte{0x19}
 
 	KeyLatestHeight = "LatestHeight"
 
@@ -479,6 +485,30 @@ type TransactionReceipt struct {
 	TransactionIndex  hexutil.Uint64  `json:"transactionIndex"`
 	From              string          `json:"from"`
 	To                *common.Address `json:"to"`
+
+	// EIP-2718 typed transaction fields
+	Type                 hexutil.Uint64       `json:"type"`
+	Accesses             *ethtypes.AccessList `json:"accessList,omitempty"`
+	MaxFeePerGas         *hexutil.Big         `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big         `json:"maxPriorityFeePerGas,omitempty"`
+}
+
+// SetTypedTxFields sets the type, access list and fee caps of the EIP-2718 typed transaction to the receipt
+func (tr *TransactionReceipt) SetTypedTxFields(tx *types.MsgEthereumTx) {
+	tr.Type = hexutil.Uint64(tx.TxType())
+	if !tx.IsTyped() {
+		return
+	}
+
+	accesses := tx.AccessList()
+	if accesses == nil {
+		accesses = ethtypes.AccessList{}
+	}
+	tr.Accesses = &accesses
+	if tx.TxType() == ethtypes.DynamicFeeTxType {
+		tr.MaxFeePerGas = (*hexutil.Big)(tx.Data.GasFeeCap)
+		tr.MaxPriorityFeePerGas = (*hexutil.Big)(tx.Data.GasTipCap)
+	}
 }
 
 func NewMsgTransactionReceipt(status uint32, tx *types.MsgEthereumTx, txHash, blockHash common.Hash, txIndex, height uint64, data *types.ResultData, cumulativeGas, GasUsed uint64) *MsgTransactionReceipt {
@@ -497,6 +527,7 @@ func NewMsgTransactionReceipt(status uint32, tx *types.MsgEthereumTx, txHash, bl
 		From:              types.EthAddressStringer(common.BytesToAddress(tx.From().Bytes())).String(),
 		To:                tx.To(),
 	}
+	tr.SetTypedTxFields(tx)
 
 	//contract address will be set to 0x0000000000000000000000000000000000000000 if contract deploy failed
 	if tr.ContractAddress != nil && types.EthAddressStringer(*tr.ContractAddress).String() == "0x0000000000000000000000000000000000000000" {
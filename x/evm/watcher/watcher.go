@@ -13,6 +13,7 @@ import (
 	"github.com/okex/exchain/app/rpc/namespaces/eth/state"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/types/innertx"
 
 	"github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
@@ -40,6 +41,13 @@ type Watcher struct {
 	delayEraseKey [][]byte
 	log           log.Logger
 
+	// the logs are indexed by address and first topic if logIndex is true, logIndexFrom and logIndexTo cache
+	// the range of the indexed blocks, which is loaded lazily
+	logIndex       bool
+	logIndexLoaded bool
+	logIndexFrom   uint64
+	logIndexTo     uint64
+
 	// for state delta transfering in network
 	watchData *WatchData
 
@@ -69,7 +77,7 @@ func GetWatchLruSize() int {
 }
 
 func NewWatcher(logger log.Logger) *Watcher {
-	watcher := &Watcher{store: InstanceOfWatchStore(), cumulativeGas: make(map[uint64]uint64), sw: IsWatcherEnabled(), firstUse: true, delayEraseKey: make([][]byte, 0), watchData: &WatchData{}, log: logger}
+	watcher := &Watcher{store: InstanceOfWatchStore(), cumulativeGas: make(map[uint64]uint64), sw: IsWatcherEnabled(), firstUse: true, delayEraseKey: make([][]byte, 0), watchData: &WatchData{}, log: logger, logIndex: IsLogIndexEnabled()}
 	checkWd = viper.GetBool(FlagCheckWd)
 	return watcher
 }
@@ -116,6 +124,32 @@ func (w *Watcher) SaveEthereumTx(msg *evmtypes.MsgEthereumTx, txHash common.Hash
 		w.batch = append(w.batch, wMsg)
 	}
 	w.UpdateBlockTxs(txHash)
+
+	if from := msg.From(); len(from) != 0 {
+		w.saveAddressTx(common.BytesToAddress(from.Bytes()), index, DirectionOut, txHash)
+	}
+	if to := msg.To(); to != nil {
+		w.saveAddressTx(*to, index, DirectionIn, txHash)
+	}
+}
+
+// SaveInnerTxs indexes the participants of the inner calls of a tx by address
+func (w *Watcher) SaveInnerTxs(txHash common.Hash, index uint64, innerTxs []*innertx.InnerTx) {
+	if !w.Enabled() {
+		return
+	}
+	for _, innerTx := range innerTxs {
+		for _, participant := range []string{innerTx.From, innerTx.To} {
+			if addr, ok := parseParticipant(participant); ok {
+				w.saveAddressTx(addr, index, DirectionInner, txHash)
+			}
+		}
+	}
+}
+
+func (w *Watcher) saveAddressTx(addr common.Address, index uint64, direction byte, txHash common.Hash) {
+	wMsg := NewMsgAddressTx(addr, w.height, index, direction, txHash)
+	w.batch = append(w.batch, wMsg, &MsgHeightAddressTx{*wMsg})
 }
 
 func (w *Watcher) SaveContractCode(addr common.Address, code []byte) {
@@ -147,6 +181,13 @@ func (w *Watcher) SaveTransactionReceipt(status uint32, msg *evmtypes.MsgEthereu
 	if wMsg != nil {
 		w.batch = append(w.batch, wMsg)
 	}
+	// the created contract is the recipient of a contract creation tx
+	if msg.To() == nil && data.ContractAddress != (common.Address{}) {
+		w.saveAddressTx(data.ContractAddress, txIndex, DirectionIn, txHash)
+	}
+	if w.logIndex {
+		w.batch = append(w.batch, logIndexMsgs(w.height, txIndex, txHash, data.Logs)...)
+	}
 }
 
 func (w *Watcher) UpdateCumulativeGas(txIndex, gasUsed uint64) {
@@ -243,6 +284,30 @@ func (w *Watcher) SaveBlock(bloom ethtypes.Bloom) {
 		w.batch = append(w.batch, wInfo)
 	}
 	w.SaveLatestHeight(w.height)
+	if w.logIndex {
+		w.saveLogIndexRange()
+	}
+}
+
+// saveLogIndexRange extends the range of the indexed blocks to the current height, the range restarts from the
+// current height if the blocks before it were not indexed
+func (w *Watcher) saveLogIndexRange() {
+	if !w.logIndexLoaded {
+		from, to, ok := getLogIndexRange(w.store)
+		if ok {
+			w.logIndexFrom, w.logIndexTo = from, to
+		} else {
+			w.logIndexFrom, w.logIndexTo = w.height, w.height
+		}
+		w.logIndexLoaded = true
+	}
+	if w.logIndexTo+1 < w.height || w.logIndexFrom > w.height {
+		w.log.Info("the logs of the blocks before are not indexed, use the backfill command to index them",
+			"height", w.height)
+		w.logIndexFrom = w.height
+	}
+	w.logIndexTo = w.height
+	w.batch = append(w.batch, &MsgLogIndexRange{from: w.logIndexFrom, to: w.logIndexTo})
 }
 
 func (w *Watcher) SaveLatestHeight(height uint64) {
@@ -358,6 +423,65 @@ func (w *Watcher) CommitCodeHashToDb(hash []byte, code []byte) {
 	}
 }
 
+// DeleteAddressTxsFromHeight removes the address index of the blocks from the height on,
+// which will be indexed again when the blocks are replayed
+func (w *Watcher) DeleteAddressTxsFromHeight(height uint64) {
+	if !w.Enabled() {
+		return
+	}
+	start := appendUint64(append([]byte{}, prefixHeightAddrTx...), height)
+	it := w.store.Iterator(start, sdk.PrefixEndBytes(prefixHeightAddrTx))
+	if it == nil {
+		return
+	}
+	var keys [][]byte
+	for ; it.Valid(); it.Next() {
+		keys = append(keys, append([]byte{}, it.Key()...))
+	}
+	it.Close()
+
+	for _, key := range keys {
+		if addrKey := addressTxKeyFromHeightKey(key); addrKey != nil {
+			w.store.Delete(addrKey)
+		}
+		w.store.Delete(key)
+	}
+}
+
+// DeleteLogIndexFromHeight removes the log index of the blocks from the height on,
+// which will be indexed again when the blocks are replayed
+func (w *Watcher) DeleteLogIndexFromHeight(height uint64) {
+	if !w.Enabled() || !w.logIndex {
+		return
+	}
+	start := appendUint64(append([]byte{}, prefixHeightLog...), height)
+	it := w.store.Iterator(start, sdk.PrefixEndBytes(prefixHeightLog))
+	if it == nil {
+		return
+	}
+	var keys [][]byte
+	for ; it.Valid(); it.Next() {
+		keys = append(keys, append([]byte{}, it.Key()...))
+	}
+	it.Close()
+
+	for _, key := range keys {
+		if logKey := logIndexKeyFromHeightKey(key); logKey != nil {
+			w.store.Delete(logKey)
+		}
+		w.store.Delete(key)
+	}
+
+	if from, to, ok := getLogIndexRange(w.store); ok && to >= height {
+		if from >= height {
+			w.store.Delete(prefixLogIndexRange)
+		} else {
+			setLogIndexRange(w.store, from, height-1)
+		}
+	}
+	w.logIndexLoaded = false
+}
+
 func (w *Watcher) Reset() {
 	if !w.Enabled() {
 		return
@@ -466,7 +590,7 @@ func (w *Watcher) GetWatchDataFunc() func() ([]byte, error) {
 	value.DelayEraseKey = w.delayEraseKey
 
 	// hold it in temp
-	batch:=w.batch
+	batch := w.batch
 	return func() ([]byte, error) {
 		ddsBatch := make([]*Batch, len(batch))
 		for i, b := range batch {
@@ -535,7 +659,6 @@ func key2Bytes(key string) []byte {
 	return []byte(key)
 }
 
-
 func filterCopy(origin *WatchData) *WatchData {
 	return &WatchData{
 		Batches:       filterBatch(origin.Batches),
@@ -5,6 +5,7 @@ import (
 	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
 	client "github.com/okex/exchain/libs/cosmos-sdk/client/flags"
 	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/x/order/keeper"
 	"github.com/okex/exchain/x/order/types"
 	"github.com/spf13/cobra"
@@ -25,6 +26,8 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 		GetCmdDepthBook(queryRoute, cdc),
 		GetCmdQueryStore(queryRoute, cdc),
 		GetCmdQueryParams(queryRoute, cdc),
+		GetCmdQueryTriggerOrder(queryRoute, cdc),
+		GetCmdQueryTriggerOrders(queryRoute, cdc),
 	)...)
 
 	queryCmd.Flags().StringP(client.FlagNode, "n", "tcp://localhost:26657", "Node to connect to")
@@ -54,6 +57,72 @@ func GetCmdQueryOrder(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	}
 }
 
+// GetCmdQueryTriggerOrder queries trigger order info by triggerOrderID
+func GetCmdQueryTriggerOrder(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "trigger [trigger-order-id]",
+		Short: "Query a trigger order",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			triggerOrderID := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryTriggerOrder, triggerOrderID),
+				nil)
+			if err != nil {
+				fmt.Printf("trigger order does not exist - %s \n", triggerOrderID)
+				return nil
+			}
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+}
+
+// GetCmdQueryTriggerOrders queries the pending trigger orders of a product
+func GetCmdQueryTriggerOrders(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "triggers [product]",
+		Short: "Query the pending trigger orders of a trading pair",
+		Long: strings.TrimSpace(`Query the pending trigger orders of a trading pair:
+
+$ exchaincli query order triggers mytoken_okt --sender okexchain1...
+`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			product := args[0]
+
+			var sender sdk.AccAddress
+			if senderStr := viper.GetString("sender"); senderStr != "" {
+				addr, err := sdk.AccAddressFromBech32(senderStr)
+				if err != nil {
+					return err
+				}
+				sender = addr
+			}
+			bz, err := cdc.MarshalJSON(keeper.NewQueryTriggerOrdersParams(product, sender))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryTriggerOrders),
+				bz)
+			if err != nil {
+				fmt.Printf("get trigger orders of %s failed: %v\n", product, err.Error())
+				return nil
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+	cmd.Flags().String("sender", "", "only return the trigger orders placed by this address")
+	return cmd
+}
+
 // GetCmdDepthBook queries order book about a product
 func GetCmdDepthBook(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
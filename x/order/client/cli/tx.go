@@ -26,6 +26,8 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 	txCmd.AddCommand(client.PostCommands(
 		getCmdNewOrder(cdc),
 		getCmdCancelOrder(cdc),
+		getCmdNewTriggerOrder(cdc),
+		getCmdCancelTriggerOrder(cdc),
 	)...)
 
 	return txCmd
@@ -37,6 +39,7 @@ func getCmdNewOrder(cdc *codec.Codec) *cobra.Command {
 	var side string
 	var price string
 	var quantity string
+	var timeInForce string
 	cmd := &cobra.Command{
 		Use:   "new",
 		Short: "place a new order",
@@ -53,7 +56,7 @@ func getCmdNewOrder(cdc *codec.Codec) *cobra.Command {
 				return errors.New("invalid param counts")
 			}
 
-			err := handleNewOrder(cmd, cdc, product, side, price, quantity)
+			err := handleNewOrder(cmd, cdc, product, side, price, quantity, timeInForce)
 			return err
 
 		},
@@ -63,15 +66,21 @@ func getCmdNewOrder(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().StringVarP(&side, "side", "s", "", "BUY or SELL (default \"SELL\")")
 	cmd.Flags().StringVarP(&price, "price", "p", "", "The price of the order")
 	cmd.Flags().StringVarP(&quantity, "quantity", "q", "", "The quantity of the order")
+	cmd.Flags().StringVarP(&timeInForce, "time-in-force", "", "", "GTC, IOC, FOK or POST_ONLY (default \"GTC\")")
 	return cmd
 }
 
-func handleNewOrder(cmd *cobra.Command, cdc *codec.Codec, product string, side string, price string, quantity string) error {
+func handleNewOrder(cmd *cobra.Command, cdc *codec.Codec, product string, side string, price string, quantity string,
+	timeInForce string) error {
 	var items []types.OrderItem
 	productArr := strings.Split(product, ",")
 	sideArr := strings.Split(side, ",")
 	priceArr := strings.Split(price, ",")
 	quantityArr := strings.Split(quantity, ",")
+	timeInForceArr := make([]string, len(productArr))
+	if len(timeInForce) > 0 {
+		timeInForceArr = strings.Split(timeInForce, ",")
+	}
 	if len(productArr) != len(sideArr) {
 		return errors.New("invalid param side counts")
 	}
@@ -84,6 +93,10 @@ func handleNewOrder(cmd *cobra.Command, cdc *codec.Codec, product string, side s
 		return errors.New("invalid param quantity counts")
 	}
 
+	if len(productArr) != len(timeInForceArr) {
+		return errors.New("invalid param time-in-force counts")
+	}
+
 	for i := 0; i < len(productArr); i++ {
 		product := productArr[i]
 		side := sideArr[i]
@@ -96,10 +109,11 @@ func handleNewOrder(cmd *cobra.Command, cdc *codec.Codec, product string, side s
 			return errors.New(err.Error())
 		}
 		items = append(items, types.OrderItem{
-			Product:  product,
-			Side:     side,
-			Price:    price,
-			Quantity: quantity,
+			Product:     product,
+			Side:        side,
+			Price:       price,
+			Quantity:    quantity,
+			TimeInForce: timeInForceArr[i],
 		})
 	}
 	inBuf := bufio.NewReader(cmd.InOrStdin())
@@ -131,3 +145,68 @@ func getCmdCancelOrder(cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+func getCmdNewTriggerOrder(cdc *codec.Codec) *cobra.Command {
+	// new trigger order flags
+	var product string
+	var side string
+	var triggerType string
+	var triggerPrice string
+	var price string
+	var quantity string
+	cmd := &cobra.Command{
+		Use:   "new-trigger",
+		Short: "place a new stop-loss or take-profit order",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(product) == 0 || len(side) == 0 || len(triggerType) == 0 || len(triggerPrice) == 0 ||
+				len(quantity) == 0 {
+				return errors.New("invalid param format")
+			}
+			triggerPriceDec, err := sdk.NewDecFromStr(triggerPrice)
+			if err != nil {
+				return errors.New(err.Error())
+			}
+			priceDec, err := sdk.NewDecFromStr(price)
+			if err != nil {
+				return errors.New(err.Error())
+			}
+			quantityDec, err := sdk.NewDecFromStr(quantity)
+			if err != nil {
+				return errors.New(err.Error())
+			}
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := authtxb.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgNewTriggerOrder(cliCtx.GetFromAddress(), product, side, triggerType,
+				triggerPriceDec, priceDec, quantityDec)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().StringVarP(&product, "product", "", "", "Trading pair in full name of the tokens: ${baseAssetSymbol}_${quoteAssetSymbol}, for example \"mycoin_okt\".")
+	cmd.Flags().StringVarP(&side, "side", "s", "", "BUY or SELL")
+	cmd.Flags().StringVarP(&triggerType, "trigger-type", "", "", "STOP_LOSS or TAKE_PROFIT")
+	cmd.Flags().StringVarP(&triggerPrice, "trigger-price", "", "", "The last price which activates the order")
+	cmd.Flags().StringVarP(&price, "price", "p", "0", "The price of the activated order, 0 places a market order")
+	cmd.Flags().StringVarP(&quantity, "quantity", "q", "", "The quantity of the order")
+	return cmd
+}
+
+func getCmdCancelTriggerOrder(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "cancel-trigger [trigger-order-id]",
+		Short: "cancel a pending trigger order",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := authtxb.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgCancelTriggerOrder(cliCtx.GetFromAddress(), args[0])
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
 	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/libs/cosmos-sdk/types/rest"
 
 	"github.com/okex/exchain/x/common"
@@ -19,9 +20,78 @@ import (
 // RegisterRoutes - Central function to define routes that get registered by the main application
 func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc("/order/depthbook", orderBookHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/order/triggers", triggerOrdersHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/order/trigger/{triggerOrderID}", triggerOrderDetailHandler(cliCtx)).Methods("GET")
 	r.HandleFunc("/order/{orderID}", orderDetailHandler(cliCtx)).Methods("GET")
 }
 
+func triggerOrderDetailHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		triggerOrderID := mux.Vars(r)["triggerOrderID"]
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/order/%s/%s", types.QueryTriggerOrder,
+			triggerOrderID), nil)
+		if err != nil {
+			sdkErr := common.ParseSDKError(err.Error())
+			common.HandleErrorMsg(w, cliCtx, sdkErr.Code, sdkErr.Message)
+			return
+		}
+
+		order := &types.TriggerOrder{}
+		codec.Cdc.MustUnmarshalJSON(res, order)
+		response := common.GetBaseResponse(order)
+		resBytes, err := json.Marshal(response)
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, common.CodeMarshalJSONFailed, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, resBytes)
+	}
+}
+
+func triggerOrdersHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		product := r.URL.Query().Get("product")
+		senderStr := r.URL.Query().Get("sender")
+		// validate request
+		if product == "" {
+			common.HandleErrorMsg(w, cliCtx, types.CodeProductIsEmpty, "invalid params: product is required")
+			return
+		}
+		var sender sdk.AccAddress
+		if senderStr != "" {
+			addr, err := sdk.AccAddressFromBech32(senderStr)
+			if err != nil {
+				common.HandleErrorMsg(w, cliCtx, common.CodeCreateAddrFromBech32Failed, err.Error())
+				return
+			}
+			sender = addr
+		}
+		bz, err := cliCtx.Codec.MarshalJSON(keeper.NewQueryTriggerOrdersParams(product, sender))
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, common.CodeMarshalJSONFailed, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/order/%s", types.QueryTriggerOrders), bz)
+		if err != nil {
+			sdkErr := common.ParseSDKError(err.Error())
+			common.HandleErrorMsg(w, cliCtx, sdkErr.Code, sdkErr.Message)
+			return
+		}
+
+		var orders []*types.TriggerOrder
+		codec.Cdc.MustUnmarshalJSON(res, &orders)
+		response := common.GetBaseResponse(orders)
+		resBytes, err := json.Marshal(response)
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, common.CodeMarshalJSONFailed, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, resBytes)
+	}
+}
+
 func orderDetailHandler(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
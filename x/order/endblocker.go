@@ -12,7 +12,9 @@ import (
 
 // EndBlocker called every block
 // 1. execute matching engine
-// 2. flush cache
+// 2. activate trigger orders
+// 3. emit the changes of the depth books
+// 4. flush cache
 func EndBlocker(ctx sdk.Context, keeper keeper.Keeper) {
 
 	seq := perf.GetPerf().OnEndBlockEnter(ctx, types.ModuleName)
@@ -20,7 +22,10 @@ func EndBlocker(ctx sdk.Context, keeper keeper.Keeper) {
 
 	match.GetEngine().Run(ctx, keeper)
 
-	// flush cache at the end
+	match.ActivateTriggerOrders(ctx, keeper)
+
+	// flush cache at the end, after emitting the changes of the depth books
+	keeper.EmitDepthBookEvents(ctx)
 	keeper.Cache2Disk(ctx)
 
 	keeper.SetMetric()
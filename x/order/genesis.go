@@ -10,8 +10,10 @@ import (
 
 // GenesisState - all order state that must be provided at genesis
 type GenesisState struct {
-	Params     types.Params   `json:"params"`
-	OpenOrders []*types.Order `json:"open_orders"`
+	Params          types.Params          `json:"params"`
+	OpenOrders      []*types.Order        `json:"open_orders"`
+	TriggerOrders   []*types.TriggerOrder `json:"trigger_orders,omitempty"`
+	TriggerOrderSeq uint64                `json:"trigger_order_seq,omitempty"`
 }
 
 // DefaultGenesisState - default GenesisState used by Cosmos Hub
@@ -54,6 +56,17 @@ func InitGenesis(ctx sdk.Context, keeper keeper.Keeper, data GenesisState) {
 	if len(data.OpenOrders) > 0 {
 		keeper.Cache2Disk(ctx)
 	}
+
+	// reset trigger orders, the sequence keeps the ids of new trigger orders unique
+	for _, order := range data.TriggerOrders {
+		if order == nil {
+			panic("the nil pointer is not expected")
+		}
+		keeper.SetTriggerOrder(ctx, order)
+	}
+	if data.TriggerOrderSeq > 0 {
+		keeper.SetTriggerOrderSeq(ctx, data.TriggerOrderSeq)
+	}
 }
 
 // ExportGenesis writes the current store values
@@ -110,7 +123,9 @@ func ExportGenesis(ctx sdk.Context, keeper keeper.Keeper) (data GenesisState) {
 	}
 
 	return GenesisState{
-		Params:     *params,
-		OpenOrders: openOrders,
+		Params:          *params,
+		OpenOrders:      openOrders,
+		TriggerOrders:   keeper.GetAllTriggerOrders(ctx),
+		TriggerOrderSeq: keeper.GetTriggerOrderSeq(ctx),
 	}
 }
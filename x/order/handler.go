@@ -13,6 +13,7 @@ import (
 	"github.com/okex/exchain/x/common"
 	"github.com/okex/exchain/x/common/perf"
 	"github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/match"
 	"github.com/okex/exchain/x/order/types"
 	"github.com/willf/bitset"
 )
@@ -23,6 +24,10 @@ func CalculateGas(msg sdk.Msg, params *types.Params) (gas uint64) {
 		gas = msg.CalculateGas(params.NewOrderMsgGasUnit)
 	case types.MsgCancelOrders:
 		gas = msg.CalculateGas(params.CancelOrderMsgGasUnit)
+	case types.MsgNewTriggerOrder:
+		gas = msg.CalculateGas(params.NewOrderMsgGasUnit)
+	case types.MsgCancelTriggerOrder:
+		gas = msg.CalculateGas(params.CancelOrderMsgGasUnit)
 	default:
 		gas = math.MaxUint64
 	}
@@ -65,6 +70,16 @@ func NewOrderHandler(keeper keeper.Keeper) sdk.Handler {
 			handlerFun = func() (*sdk.Result, error) {
 				return handleMsgCancelOrders(ctx, keeper, msg, logger)
 			}
+		case types.MsgNewTriggerOrder:
+			name = "handleMsgNewTriggerOrder"
+			handlerFun = func() (*sdk.Result, error) {
+				return handleMsgNewTriggerOrder(ctx, keeper, msg, logger)
+			}
+		case types.MsgCancelTriggerOrder:
+			name = "handleMsgCancelTriggerOrder"
+			handlerFun = func() (*sdk.Result, error) {
+				return handleMsgCancelTriggerOrder(ctx, keeper, msg, logger)
+			}
 		default:
 			errMsg := fmt.Sprintf("Invalid msg type: %v", msg.Type())
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -108,6 +123,28 @@ func checkOrderNewMsg(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgNewOrd
 	if msg.Quantity.LT(tokenPair.MinQuantity) {
 		return types.ErrMsgQuantityLessThan(tokenPair.MinQuantity.String())
 	}
+	return checkTimeInForce(ctx, keeper, msg)
+}
+
+// checkTimeInForce rejects the post-only orders which would cross the depth book, and the FOK orders
+// of the continuous auction products which can't be filled completely on arrival.
+// The FOK orders of the other products are checked by the periodic auction at EndBlock
+func checkTimeInForce(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgNewOrder) error {
+	switch msg.TimeInForce {
+	case types.TimeInForcePostOnly:
+		book := keeper.GetDepthBookCopy(msg.Product)
+		if book.CrossedQuantity(msg.Side, msg.Price).IsPositive() {
+			return types.ErrPostOnlyOrderWouldCross(msg.Product)
+		}
+	case types.TimeInForceFOK:
+		if !match.IsContinuousAuctionProduct(ctx, keeper, msg.Product) {
+			return nil
+		}
+		book := keeper.GetDepthBookCopy(msg.Product)
+		if book.CrossedQuantity(msg.Side, msg.Price).LT(msg.Quantity) {
+			return types.ErrFOKOrderCannotBeFilled(msg.Product)
+		}
+	}
 	return nil
 }
 
@@ -115,7 +152,7 @@ func getOrderFromMsg(ctx sdk.Context, k keeper.Keeper, msg types.MsgNewOrder, ra
 	feeParams := k.GetParams(ctx)
 	feePerBlockAmount := feeParams.FeePerBlock.Amount.Mul(sdk.MustNewDecFromStr(ratio))
 	feePerBlock := sdk.NewDecCoinFromDec(feeParams.FeePerBlock.Denom, feePerBlockAmount)
-	return types.NewOrder(
+	order := types.NewOrder(
 		fmt.Sprintf("%X", types2.Tx(ctx.TxBytes()).Hash(ctx.BlockHeight())),
 		msg.Sender,
 		msg.Product,
@@ -126,6 +163,8 @@ func getOrderFromMsg(ctx sdk.Context, k keeper.Keeper, msg types.MsgNewOrder, ra
 		feeParams.OrderExpireBlocks,
 		feePerBlock,
 	)
+	order.TimeInForce = msg.TimeInForce
+	return order
 }
 
 func handleNewOrder(ctx sdk.Context, k Keeper, sender sdk.AccAddress,
@@ -134,11 +173,12 @@ func handleNewOrder(ctx sdk.Context, k Keeper, sender sdk.AccAddress,
 	cacheItem := ctx.MultiStore().CacheMultiStore()
 	ctxItem := ctx.WithMultiStore(cacheItem)
 	msg := MsgNewOrder{
-		Sender:   sender,
-		Product:  item.Product,
-		Side:     item.Side,
-		Price:    item.Price,
-		Quantity: item.Quantity,
+		Sender:      sender,
+		Product:     item.Product,
+		Side:        item.Side,
+		Price:       item.Price,
+		Quantity:    item.Quantity,
+		TimeInForce: item.TimeInForce,
 	}
 	order := getOrderFromMsg(ctxItem, k, msg, ratio)
 	err := checkOrderNewMsg(ctxItem, k, msg)
@@ -147,7 +187,7 @@ func handleNewOrder(ctx sdk.Context, k Keeper, sender sdk.AccAddress,
 		if k.IsProductLocked(ctx, msg.Product) {
 			err = types.ErrIsProductLocked(order.Product)
 		} else {
-			err = k.PlaceOrder(ctxItem, order)
+			err = placeOrder(ctxItem, k, order, logger)
 		}
 	}
 
@@ -172,6 +212,33 @@ func handleNewOrder(ctx sdk.Context, k Keeper, sender sdk.AccAddress,
 	return res, cacheItem, err
 }
 
+// placeOrder places the order and crosses it with the depth book for the continuous auction products.
+// The store writes are discarded with cacheItem if it fails or panics, so are the caches of the keeper
+func placeOrder(ctx sdk.Context, k Keeper, order *types.Order, logger log.Logger) (err error) {
+	snapshot := k.SnapshotCache()
+	defer func() {
+		if r := recover(); r != nil {
+			k.RestoreCache(snapshot)
+			panic(r)
+		}
+		if err != nil {
+			k.RestoreCache(snapshot)
+		}
+	}()
+
+	if err = k.PlaceOrder(ctx, order); err != nil {
+		return err
+	}
+	// continuous auction products cross the depth book right away
+	match.MatchOrderOnPlace(ctx, k, order)
+	// the unfilled remainder of the IOC and FOK orders doesn't rest in the depth book
+	if order.IsImmediate() && order.Status == types.OrderStatusOpen &&
+		match.IsContinuousAuctionProduct(ctx, k, order.Product) {
+		k.CancelOrder(ctx, order, logger)
+	}
+	return nil
+}
+
 func handleMsgNewOrders(ctx sdk.Context, k Keeper, msg types.MsgNewOrders,
 	logger log.Logger) (*sdk.Result, error) {
 	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
@@ -217,11 +284,12 @@ func ValidateMsgNewOrders(ctx sdk.Context, k keeper.Keeper, msg types.MsgNewOrde
 
 	for _, item := range msg.OrderItems {
 		msg := MsgNewOrder{
-			Sender:   msg.Sender,
-			Product:  item.Product,
-			Side:     item.Side,
-			Price:    item.Price,
-			Quantity: item.Quantity,
+			Sender:      msg.Sender,
+			Product:     item.Product,
+			Side:        item.Side,
+			Price:       item.Price,
+			Quantity:    item.Quantity,
+			TimeInForce: item.TimeInForce,
 		}
 		err := checkOrderNewMsg(ctx, k, msg)
 		if err != nil {
@@ -0,0 +1,133 @@
+package order
+
+import (
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	types2 "github.com/okex/exchain/libs/tendermint/types"
+
+	"github.com/okex/exchain/x/common"
+	"github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/types"
+)
+
+// checkTriggerOrderMsg checks the product, prices and quantity of the trigger order,
+// returns the price precision of the product
+func checkTriggerOrderMsg(ctx sdk.Context, k keeper.Keeper, msg types.MsgNewTriggerOrder) (int64, error) {
+	err := checkOrderNewMsg(ctx, k, MsgNewOrder{
+		Sender:   msg.Sender,
+		Product:  msg.Product,
+		Side:     msg.Side,
+		Price:    msg.TriggerPrice,
+		Quantity: msg.Quantity,
+	})
+	if err != nil {
+		return 0, err
+	}
+	tokenPair := k.GetDexKeeper().GetTokenPair(ctx, msg.Product)
+	if !msg.Price.RoundDecimal(tokenPair.MaxPriceDigit).Equal(msg.Price) {
+		return 0, types.ErrPriceOverAccuracy(msg.Price, tokenPair.MaxPriceDigit)
+	}
+	return tokenPair.MaxPriceDigit, nil
+}
+
+func getTriggerOrderFromMsg(ctx sdk.Context, msg types.MsgNewTriggerOrder) *types.TriggerOrder {
+	return types.NewTriggerOrder(
+		fmt.Sprintf("%X", types2.Tx(ctx.TxBytes()).Hash(ctx.BlockHeight())),
+		msg.Sender,
+		msg.Product,
+		msg.Side,
+		msg.TriggerType,
+		msg.TriggerPrice,
+		msg.Price,
+		msg.Quantity,
+		ctx.BlockHeader().Time.Unix(),
+	)
+}
+
+func handleMsgNewTriggerOrder(ctx sdk.Context, k Keeper, msg types.MsgNewTriggerOrder,
+	logger log.Logger) (*sdk.Result, error) {
+	pricePrecision, err := checkTriggerOrderMsg(ctx, k, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	order := getTriggerOrderFromMsg(ctx, msg)
+	if err := k.PlaceTriggerOrder(ctx, order, pricePrecision); err != nil {
+		return common.ErrInsufficientCoins(DefaultParamspace, err.Error()).Result()
+	}
+
+	logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>\n"+
+		"    msg<Product:%s,Sender:%s,TriggerPrice:%s,Price:%s,Quantity:%s,Side:%s,TriggerType:%s>\n"+
+		"    result<The User have created a trigger order {ID:%s} >\n",
+		ctx.BlockHeight(), "handleMsgNewTriggerOrder",
+		msg.Product, msg.Sender, msg.TriggerPrice, msg.Price, msg.Quantity, msg.Side, msg.TriggerType,
+		order.TriggerOrderID))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute("trigger_order_id", order.TriggerOrderID),
+	))
+	return &sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}, nil
+}
+
+func validateCancelTriggerOrder(ctx sdk.Context, k keeper.Keeper, msg types.MsgCancelTriggerOrder) (
+	*types.TriggerOrder, int64, error) {
+	order := k.GetTriggerOrder(ctx, msg.TriggerOrderID)
+	if order == nil {
+		return nil, 0, types.ErrTriggerOrderIsNotExist(msg.TriggerOrderID)
+	}
+	if !order.Sender.Equals(msg.Sender) {
+		return nil, 0, types.ErrNotOrderOwner(msg.TriggerOrderID)
+	}
+	tokenPair := k.GetDexKeeper().GetTokenPair(ctx, order.Product)
+	if tokenPair == nil {
+		return nil, 0, types.ErrTokenPairNotExist(order.Product)
+	}
+	return order, tokenPair.MaxPriceDigit, nil
+}
+
+func handleMsgCancelTriggerOrder(ctx sdk.Context, k Keeper, msg types.MsgCancelTriggerOrder,
+	logger log.Logger) (*sdk.Result, error) {
+	order, pricePrecision, err := validateCancelTriggerOrder(ctx, k, msg)
+	if err != nil {
+		return nil, err
+	}
+	k.CancelTriggerOrder(ctx, order, pricePrecision)
+
+	logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>\n"+
+		"    msg<Sender:%s,ID:%s>\n"+
+		"    result<The User have canceled a trigger order {ID:%s} >\n",
+		ctx.BlockHeight(), "handleMsgCancelTriggerOrder",
+		msg.Sender, msg.TriggerOrderID, msg.TriggerOrderID))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute("trigger_order_id", msg.TriggerOrderID),
+	))
+	return &sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}, nil
+}
+
+// ValidateMsgNewTriggerOrder validates whether the msg of newTriggerOrder is valid.
+func ValidateMsgNewTriggerOrder(ctx sdk.Context, k keeper.Keeper, msg types.MsgNewTriggerOrder) error {
+	pricePrecision, err := checkTriggerOrderMsg(ctx, k, msg)
+	if err != nil {
+		return err
+	}
+	cacheCtx, _ := ctx.CacheContext()
+	if err := k.PlaceTriggerOrder(cacheCtx, getTriggerOrderFromMsg(ctx, msg), pricePrecision); err != nil {
+		return common.ErrInsufficientCoins(DefaultParamspace, err.Error())
+	}
+	return nil
+}
+
+// ValidateMsgCancelTriggerOrder validates whether the msg of cancelTriggerOrder is valid.
+func ValidateMsgCancelTriggerOrder(ctx sdk.Context, k keeper.Keeper, msg types.MsgCancelTriggerOrder) error {
+	_, _, err := validateCancelTriggerOrder(ctx, k, msg)
+	return err
+}
@@ -234,3 +234,35 @@ func (c *DiskCache) removeOrder(order *types.Order) {
 
 	c.closeOrder(order.OrderID)
 }
+
+// copy returns a copy of the cache, the depth books and the orderIDs are copied by key
+func (c *DiskCache) copy() *DiskCache {
+	ret := *c
+	ret.closedOrderIDs = append([]string{}, c.closedOrderIDs...)
+	ret.priceMap = make(map[string]sdk.Dec, len(c.priceMap))
+	for product, price := range c.priceMap {
+		ret.priceMap[product] = price
+	}
+
+	orderIDsMap := OrderIDsMap{make(map[string][]string, len(c.orderIDsMap.Data)), copyKeys(c.orderIDsMap.updatedItems)}
+	for key, orderIDs := range c.orderIDsMap.Data {
+		orderIDsMap.Data[key] = append([]string{}, orderIDs...)
+	}
+	ret.orderIDsMap = &orderIDsMap
+
+	depthBookMap := DepthBookMap{make(map[string]*types.DepthBook, len(c.depthBookMap.data)),
+		copyKeys(c.depthBookMap.updatedItems), copyKeys(c.depthBookMap.newItems)}
+	for product, book := range c.depthBookMap.data {
+		depthBookMap.data[product] = book.Copy()
+	}
+	ret.depthBookMap = &depthBookMap
+	return &ret
+}
+
+func copyKeys(keys map[string]struct{}) map[string]struct{} {
+	ret := make(map[string]struct{}, len(keys))
+	for key := range keys {
+		ret[key] = struct{}{}
+	}
+	return ret
+}
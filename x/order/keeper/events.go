@@ -0,0 +1,67 @@
+package keeper
+
+import (
+	"encoding/json"
+	"strconv"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+
+	"github.com/okex/exchain/x/order/types"
+)
+
+// EmitDealEvents emits an event for every deal of the product matched at price
+func (k Keeper) EmitDealEvents(ctx sdk.Context, product string, price sdk.Dec, deals []types.Deal) {
+	events := make(sdk.Events, 0, len(deals))
+	for _, deal := range deals {
+		events = append(events, sdk.NewEvent(
+			types.EventTypeDeal,
+			sdk.NewAttribute(types.AttributeKeyProduct, product),
+			sdk.NewAttribute(types.AttributeKeyPrice, price.String()),
+			sdk.NewAttribute(types.AttributeKeyOrderID, deal.OrderID),
+			sdk.NewAttribute(types.AttributeKeySide, deal.Side),
+			sdk.NewAttribute(types.AttributeKeyQuantity, deal.Quantity.String()),
+			sdk.NewAttribute(types.AttributeKeyFee, deal.Fee),
+		))
+	}
+	ctx.EventManager().EmitEvents(events)
+}
+
+// EmitDepthBookEvents emits the changes of the depth books updated in the block, the changes are the price levels
+// which differ from the depth books stored by the last block, so it must be called before Cache2Disk
+func (k Keeper) EmitDepthBookEvents(ctx sdk.Context) {
+	for _, product := range k.diskCache.GetUpdatedDepthbookKeys() {
+		book := k.diskCache.getDepthBook(product)
+		if book == nil {
+			book = &types.DepthBook{}
+		}
+		changes := book.Diff(k.GetDepthBookFromDB(ctx, product))
+		if len(changes) == 0 {
+			continue
+		}
+		bz, err := json.Marshal(changes)
+		if err != nil {
+			continue
+		}
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeDepthBook,
+			sdk.NewAttribute(types.AttributeKeyProduct, product),
+			sdk.NewAttribute(types.AttributeKeyChanges, string(bz)),
+		))
+	}
+}
+
+// emitOrderUpdateEvent emits the current status of the order
+func (k Keeper) emitOrderUpdateEvent(ctx sdk.Context, order *types.Order) {
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeOrderUpdate,
+		sdk.NewAttribute(types.AttributeKeyOrderID, order.OrderID),
+		sdk.NewAttribute(types.AttributeKeySender, order.Sender.String()),
+		sdk.NewAttribute(types.AttributeKeyProduct, order.Product),
+		sdk.NewAttribute(types.AttributeKeySide, order.Side),
+		sdk.NewAttribute(types.AttributeKeyPrice, order.Price.String()),
+		sdk.NewAttribute(types.AttributeKeyQuantity, order.Quantity.String()),
+		sdk.NewAttribute(types.AttributeKeyStatus, strconv.FormatInt(order.Status, 10)),
+		sdk.NewAttribute(types.AttributeKeyRemainQuantity, order.RemainQuantity.String()),
+		sdk.NewAttribute(types.AttributeKeyFilledAvgPrice, order.FilledAvgPrice.String()),
+	))
+}
@@ -0,0 +1,69 @@
+package keeper
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/exchain/x/dex"
+	"github.com/okex/exchain/x/order/types"
+)
+
+func findEvents(events sdk.Events, eventType string) []map[string]string {
+	var res []map[string]string
+	for _, event := range events {
+		if event.Type != eventType {
+			continue
+		}
+		attrs := make(map[string]string)
+		for _, attr := range event.Attributes {
+			attrs[string(attr.Key)] = string(attr.Value)
+		}
+		res = append(res, attrs)
+	}
+	return res
+}
+
+func TestOrderEvents(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10).WithEventManager(sdk.NewEventManager())
+
+	require.Nil(t, testInput.DexKeeper.SaveTokenPair(ctx, dex.GetBuiltInTokenPair()))
+	order := mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0")
+	order.Sender = testInput.TestAddrs[0]
+	require.Nil(t, keeper.PlaceOrder(ctx, order))
+
+	updates := findEvents(ctx.EventManager().Events(), types.EventTypeOrderUpdate)
+	require.Equal(t, 1, len(updates))
+	require.Equal(t, order.OrderID, updates[0][types.AttributeKeyOrderID])
+	require.Equal(t, order.Sender.String(), updates[0][types.AttributeKeySender])
+	require.Equal(t, strconv.FormatInt(types.OrderStatusOpen, 10), updates[0][types.AttributeKeyStatus])
+
+	// the depth book changed in the block
+	keeper.EmitDepthBookEvents(ctx)
+	depthBooks := findEvents(ctx.EventManager().Events(), types.EventTypeDepthBook)
+	require.Equal(t, 1, len(depthBooks))
+	require.Equal(t, types.TestTokenPair, depthBooks[0][types.AttributeKeyProduct])
+	var changes []types.DepthBookItem
+	require.Nil(t, json.Unmarshal([]byte(depthBooks[0][types.AttributeKeyChanges]), &changes))
+	require.Equal(t, 1, len(changes))
+	require.Equal(t, sdk.MustNewDecFromStr("1.0"), changes[0].BuyQuantity)
+
+	keeper.Cache2Disk(ctx)
+	ctx = ctx.WithBlockHeight(11).WithEventManager(sdk.NewEventManager())
+	keeper.CancelOrder(ctx, order, ctx.Logger())
+	updates = findEvents(ctx.EventManager().Events(), types.EventTypeOrderUpdate)
+	require.Equal(t, 1, len(updates))
+	require.Equal(t, strconv.FormatInt(types.OrderStatusCancelled, 10), updates[0][types.AttributeKeyStatus])
+
+	keeper.EmitDepthBookEvents(ctx)
+	depthBooks = findEvents(ctx.EventManager().Events(), types.EventTypeDepthBook)
+	require.Equal(t, 1, len(depthBooks))
+	require.Nil(t, json.Unmarshal([]byte(depthBooks[0][types.AttributeKeyChanges]), &changes))
+	require.Equal(t, 1, len(changes))
+	require.True(t, changes[0].BuyQuantity.IsZero())
+}
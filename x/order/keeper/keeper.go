@@ -1,6 +1,7 @@
 package keeper
 
 import (
+	"bytes"
 	"log"
 	"sync"
 
@@ -153,6 +154,24 @@ func (k Keeper) GetDiskCache() *DiskCache {
 	return k.diskCache
 }
 
+// CacheSnapshot is a copy of the caches of the keeper taken by SnapshotCache
+type CacheSnapshot struct {
+	cache     *Cache
+	diskCache *DiskCache
+}
+
+// SnapshotCache copies the caches of the keeper, so that they can be restored when the store writes made
+// after the snapshot are discarded
+func (k Keeper) SnapshotCache() CacheSnapshot {
+	return CacheSnapshot{cache: k.cache.copy(), diskCache: k.diskCache.copy()}
+}
+
+// RestoreCache restores the caches of the keeper to the snapshot, which can't be restored again
+func (k Keeper) RestoreCache(snapshot CacheSnapshot) {
+	*k.cache = *snapshot.cache
+	*k.diskCache = *snapshot.diskCache
+}
+
 // nolint
 func (k Keeper) GetTokenKeeper() TokenKeeper {
 	return k.tokenKeeper
@@ -351,6 +370,16 @@ func (k Keeper) GetBlockMatchResult() *types.BlockMatchResult {
 	return k.cache.getBlockMatchResult()
 }
 
+// GetBlockDealsNum returns the number of the deals made by the continuous auction in the current block
+func (k Keeper) GetBlockDealsNum() int64 {
+	return k.cache.getDealsNum()
+}
+
+// AddBlockDealsNum adds the deals made by the continuous auction in the current block
+func (k Keeper) AddBlockDealsNum(num int64) {
+	k.cache.addDealsNum(num)
+}
+
 // nolint
 func (k Keeper) SetBlockMatchResult(result *types.BlockMatchResult) {
 	if k.enableBackend {
@@ -446,8 +475,15 @@ func (k Keeper) AddCollectedFees(ctx sdk.Context, coins sdk.SysCoins, from sdk.A
 
 // GetParams gets inflation params from the global param store
 func (k Keeper) GetParams(ctx sdk.Context) *types.Params {
-	var param types.Params
-	k.paramSpace.GetParamSet(ctx, &param)
+	param := types.Params{ContinuousAuctionProducts: []string{}}
+	for _, pair := range param.ParamSetPairs() {
+		// the param is missing on the chains created before the continuous auction
+		if bytes.Equal(pair.Key, types.KeyContinuousAuctionProducts) {
+			k.paramSpace.GetIfExists(ctx, pair.Key, pair.Value)
+			continue
+		}
+		k.paramSpace.Get(ctx, pair.Key, pair.Value)
+	}
 	return &param
 }
 
@@ -509,6 +545,7 @@ func (k Keeper) UpdateOrder(order *types.Order, ctx sdk.Context) {
 	k.SetOrder(ctx, order.OrderID, order)
 	// record updated orderID
 	k.addUpdatedOrderID(order.OrderID)
+	k.emitOrderUpdateEvent(ctx, order)
 	if order.Status == types.OrderStatusFilled {
 		k.diskCache.closeOrder(order.OrderID)
 		k.cache.IncreaseFullFillNum()
@@ -1,11 +1,18 @@
 package keeper
 
 import (
+	"bytes"
+	"reflect"
 	"testing"
 
 	"github.com/okex/exchain/x/common"
 
+	"github.com/okex/exchain/libs/cosmos-sdk/store"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	dbm "github.com/okex/exchain/libs/tm-db"
+	"github.com/okex/exchain/x/params"
 	"github.com/stretchr/testify/require"
 
 	"github.com/okex/exchain/x/dex"
@@ -380,3 +387,71 @@ func TestFilterDelistedProducts(t *testing.T) {
 	cleanProducts := keeper.FilterDelistedProducts(ctx, productsList)
 	require.EqualValues(t, expectedProductsList, cleanProducts)
 }
+
+func TestGetParamsWithoutContinuousAuctionProducts(t *testing.T) {
+	db := dbm.NewMemDB()
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	require.NoError(t, ms.LoadLatestVersion())
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+
+	paramsKeeper := params.NewKeeper(MakeTestCodec(), keyParams, tkeyParams)
+	keeper := Keeper{paramSpace: paramsKeeper.Subspace(types.DefaultParamspace).WithKeyTable(types.ParamKeyTable())}
+
+	// the params of a chain created before the continuous auction
+	legacyParams := types.DefaultTestParams()
+	for _, pair := range legacyParams.ParamSetPairs() {
+		if !bytes.Equal(pair.Key, types.KeyContinuousAuctionProducts) {
+			keeper.paramSpace.Set(ctx, pair.Key, reflect.Indirect(reflect.ValueOf(pair.Value)).Interface())
+		}
+	}
+	require.NotPanics(t, func() { keeper.GetParams(ctx) })
+	require.Equal(t, legacyParams.MaxDealsPerBlock, keeper.GetParams(ctx).MaxDealsPerBlock)
+	require.Empty(t, keeper.GetParams(ctx).ContinuousAuctionProducts)
+
+	legacyParams.ContinuousAuctionProducts = []string{types.TestTokenPair}
+	keeper.SetParams(ctx, &legacyParams)
+	require.Equal(t, []string{types.TestTokenPair}, keeper.GetParams(ctx).ContinuousAuctionProducts)
+}
+
+func TestSnapshotCache(t *testing.T) {
+	testInput := CreateTestInputWithBalance(t, 1, 100)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx
+	feeParams := types.DefaultTestParams()
+	keeper.SetParams(ctx, &feeParams)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	order := mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0")
+	order.Sender = testInput.TestAddrs[0]
+	require.Nil(t, keeper.PlaceOrder(ctx, order))
+	keeper.AddBlockDealsNum(1)
+	key := types.FormatOrderIDsKey(types.TestTokenPair, order.Price, types.BuyOrder)
+	lastPrice := keeper.GetLastPrice(ctx, types.TestTokenPair)
+
+	snapshot := keeper.SnapshotCache()
+
+	// the writes after the snapshot, including the ones in place on the depth book and the orderIDs
+	order2 := mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "2.0")
+	order2.Sender = testInput.TestAddrs[0]
+	require.Nil(t, keeper.PlaceOrder(ctx, order2))
+	keeper.AddBlockDealsNum(2)
+	keeper.SetLastPrice(ctx, types.TestTokenPair, lastPrice.Add(sdk.OneDec()))
+	require.EqualValues(t, "3.000000000000000000", keeper.GetDepthBookCopy(types.TestTokenPair).Items[0].BuyQuantity.String())
+	require.EqualValues(t, 2, len(keeper.GetProductPriceOrderIDs(key)))
+
+	keeper.RestoreCache(snapshot)
+	book := keeper.GetDepthBookCopy(types.TestTokenPair)
+	require.EqualValues(t, 1, len(book.Items))
+	require.EqualValues(t, "1.000000000000000000", book.Items[0].BuyQuantity.String())
+	require.EqualValues(t, []string{order.OrderID}, keeper.GetProductPriceOrderIDs(key))
+	require.EqualValues(t, 1, keeper.GetBlockDealsNum())
+	require.EqualValues(t, 1, keeper.GetOperationMetric().OpenNum)
+	require.EqualValues(t, lastPrice, keeper.GetLastPrice(ctx, types.TestTokenPair))
+}
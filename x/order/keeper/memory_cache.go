@@ -11,6 +11,8 @@ type Cache struct {
 	updatedOrderIDs    []string
 	blockMatchResult   *types.BlockMatchResult
 	handlerTxMsgResult []bitset.BitSet
+	// deals made by the continuous auction in this block, capped by MaxDealsPerBlock
+	dealsNum int64
 
 	// for statistic
 	cancelNum      int64 // canceled orders num in this block
@@ -32,6 +34,7 @@ func (c *Cache) reset() {
 	c.updatedOrderIDs = []string{}
 	c.blockMatchResult = &types.BlockMatchResult{}
 	c.handlerTxMsgResult = []bitset.BitSet{}
+	c.dealsNum = 0
 
 	c.cancelNum = 0
 	c.expireNum = 0
@@ -47,6 +50,14 @@ func (c *Cache) setBlockMatchResult(result *types.BlockMatchResult) {
 	c.blockMatchResult = result
 }
 
+func (c *Cache) addDealsNum(num int64) {
+	c.dealsNum += num
+}
+
+func (c *Cache) getDealsNum() int64 {
+	return c.dealsNum
+}
+
 func (c *Cache) addTxHandlerMsgResult(resultSet bitset.BitSet) {
 	c.handlerTxMsgResult = append(c.handlerTxMsgResult, resultSet)
 }
@@ -131,3 +142,20 @@ func (c *Cache) GetExpireNum() int64 {
 func (c *Cache) GetPartialFillNum() int64 {
 	return c.partialFillNum
 }
+
+// copy returns a copy of the cache, the match result of the block is copied by product
+func (c *Cache) copy() *Cache {
+	ret := *c
+	ret.updatedOrderIDs = append([]string{}, c.updatedOrderIDs...)
+	ret.handlerTxMsgResult = append([]bitset.BitSet{}, c.handlerTxMsgResult...)
+	if c.blockMatchResult != nil {
+		result := *c.blockMatchResult
+		result.ResultMap = make(map[string]types.MatchResult, len(c.blockMatchResult.ResultMap))
+		for product, matchResult := range c.blockMatchResult.ResultMap {
+			matchResult.Deals = append([]types.Deal{}, matchResult.Deals...)
+			result.ResultMap[product] = matchResult
+		}
+		ret.blockMatchResult = &result
+	}
+	return &ret
+}
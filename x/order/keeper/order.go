@@ -51,6 +51,7 @@ func (k Keeper) PlaceOrder(ctx sdk.Context, order *types.Order) error {
 
 	// update depth book and orderIDsMap in cache
 	k.InsertOrderIntoDepthBook(order)
+	k.emitOrderUpdateEvent(ctx, order)
 	return nil
 }
 
@@ -98,6 +99,7 @@ func (k Keeper) quitOrder(ctx sdk.Context, order *types.Order, feeType string, l
 
 	// remove order from depth book cache
 	k.RemoveOrderFromDepthBook(order, feeType)
+	k.emitOrderUpdateEvent(ctx, order)
 	return fee
 }
 
@@ -115,3 +117,19 @@ func (k Keeper) DropExpiredOrdersByBlockHeight(ctx sdk.Context, expiredBlockHeig
 		}
 	}
 }
+
+// GetOpenOrdersByBlockHeight gets the open orders placed at blockHeight
+func (k Keeper) GetOpenOrdersByBlockHeight(ctx sdk.Context, blockHeight int64) []*types.Order {
+	store := ctx.KVStore(k.orderStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.GetOrderKey(types.FormatOrderIDPrefix(blockHeight)))
+	defer iter.Close()
+	var orders []*types.Order
+	for ; iter.Valid(); iter.Next() {
+		order := &types.Order{}
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), order)
+		if order.Status == types.OrderStatusOpen {
+			orders = append(orders, order)
+		}
+	}
+	return orders
+}
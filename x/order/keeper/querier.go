@@ -26,6 +26,10 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return queryStore(ctx, path[1:], req, keeper)
 		case types.QueryParameters:
 			return queryParameters(ctx, keeper)
+		case types.QueryTriggerOrder:
+			return queryTriggerOrder(ctx, path[1:], req, keeper)
+		case types.QueryTriggerOrders:
+			return queryTriggerOrders(ctx, path[1:], req, keeper)
 
 		case types.QueryDepthBookV2:
 			return queryDepthBookV2(ctx, path[1:], req, keeper)
@@ -46,6 +50,50 @@ func queryOrder(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Ke
 	return bz, nil
 }
 
+// nolint: unparam
+func queryTriggerOrder(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) (res []byte,
+	err sdk.Error) {
+	order := keeper.GetTriggerOrder(ctx, path[0])
+	if order == nil {
+		return nil, types.ErrTriggerOrderIsNotExist(path[0])
+	}
+	bz := keeper.cdc.MustMarshalJSON(order)
+	return bz, nil
+}
+
+// QueryTriggerOrdersParams as input parameters when querying the trigger orders of a product
+type QueryTriggerOrdersParams struct {
+	Product string
+	Sender  sdk.AccAddress
+}
+
+// NewQueryTriggerOrdersParams creates a new instance of QueryTriggerOrdersParams
+func NewQueryTriggerOrdersParams(product string, sender sdk.AccAddress) QueryTriggerOrdersParams {
+	return QueryTriggerOrdersParams{
+		Product: product,
+		Sender:  sender,
+	}
+}
+
+// nolint: unparam
+func queryTriggerOrders(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte,
+	sdk.Error) {
+	var params QueryTriggerOrdersParams
+	err := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, common.ErrUnMarshalJSONFailed("incorrectly formatted request Data")
+	}
+
+	orders := make([]*types.TriggerOrder, 0)
+	for _, order := range keeper.GetTriggerOrdersByProduct(ctx, params.Product) {
+		if params.Sender.Empty() || order.Sender.Equals(params.Sender) {
+			orders = append(orders, order)
+		}
+	}
+	bz := keeper.cdc.MustMarshalJSON(orders)
+	return bz, nil
+}
+
 // QueryDepthBookParams as input parameters when querying the depthBook
 type QueryDepthBookParams struct {
 	Product string
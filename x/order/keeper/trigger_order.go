@@ -0,0 +1,178 @@
+package keeper
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+
+	"github.com/okex/exchain/x/order/types"
+	token "github.com/okex/exchain/x/token/types"
+)
+
+// SetTriggerOrder sets the trigger order and indexes it by product and trigger price
+func (k Keeper) SetTriggerOrder(ctx sdk.Context, order *types.TriggerOrder) {
+	store := ctx.KVStore(k.orderStoreKey)
+	store.Set(types.GetTriggerOrderKey(order.TriggerOrderID), k.cdc.MustMarshalBinaryBare(order))
+	store.Set(types.GetProductTriggerOrderKey(order), []byte(order.TriggerOrderID))
+}
+
+// GetTriggerOrder gets the trigger order which is not activated or cancelled
+func (k Keeper) GetTriggerOrder(ctx sdk.Context, triggerOrderID string) *types.TriggerOrder {
+	bz := ctx.KVStore(k.orderStoreKey).Get(types.GetTriggerOrderKey(triggerOrderID))
+	if bz == nil {
+		return nil
+	}
+	order := &types.TriggerOrder{}
+	k.cdc.MustUnmarshalBinaryBare(bz, order)
+	return order
+}
+
+// DropTriggerOrder deletes the trigger order and its index
+func (k Keeper) DropTriggerOrder(ctx sdk.Context, order *types.TriggerOrder) {
+	store := ctx.KVStore(k.orderStoreKey)
+	store.Delete(types.GetTriggerOrderKey(order.TriggerOrderID))
+	store.Delete(types.GetProductTriggerOrderKey(order))
+}
+
+// GetTriggerOrdersByProduct gets the trigger orders of the product. The orders triggered when the price falls
+// come first, and the orders of each direction are ordered by trigger price
+func (k Keeper) GetTriggerOrdersByProduct(ctx sdk.Context, product string) []*types.TriggerOrder {
+	return k.getTriggerOrdersByIndex(ctx, sdk.KVStorePrefixIterator(ctx.KVStore(k.orderStoreKey),
+		types.GetProductTriggerOrdersKey(product)))
+}
+
+// GetTriggeredOrders gets the trigger orders of the product which are triggered at price. Only the orders whose
+// trigger price is crossed by price are iterated in the trigger price index
+func (k Keeper) GetTriggeredOrders(ctx sdk.Context, product string, price sdk.Dec) []*types.TriggerOrder {
+	if !price.IsPositive() {
+		return nil
+	}
+	store := ctx.KVStore(k.orderStoreKey)
+	priceBytes := sdk.SortableDecBytes(price)
+
+	// the orders triggered when the price falls to a trigger price not lower than price
+	onFall := types.GetTriggerPriceIndexKey(product, true)
+	orders := k.getTriggerOrdersByIndex(ctx,
+		store.Iterator(append(onFall, priceBytes...), sdk.PrefixEndBytes(onFall)))
+
+	// the orders triggered when the price rises to a trigger price not higher than price
+	onRise := types.GetTriggerPriceIndexKey(product, false)
+	return append(orders, k.getTriggerOrdersByIndex(ctx,
+		store.Iterator(onRise, sdk.PrefixEndBytes(append(onRise, priceBytes...))))...)
+}
+
+func (k Keeper) getTriggerOrdersByIndex(ctx sdk.Context, iter sdk.Iterator) []*types.TriggerOrder {
+	defer iter.Close()
+
+	var orders []*types.TriggerOrder
+	for ; iter.Valid(); iter.Next() {
+		if order := k.GetTriggerOrder(ctx, string(iter.Value())); order != nil {
+			orders = append(orders, order)
+		}
+	}
+	return orders
+}
+
+// GetAllTriggerOrders gets all the trigger orders which are not activated or cancelled
+func (k Keeper) GetAllTriggerOrders(ctx sdk.Context) []*types.TriggerOrder {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.orderStoreKey), types.TriggerOrderKey)
+	defer iter.Close()
+
+	var orders []*types.TriggerOrder
+	for ; iter.Valid(); iter.Next() {
+		order := &types.TriggerOrder{}
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), order)
+		orders = append(orders, order)
+	}
+	return orders
+}
+
+// GetTriggerOrderSeq gets the sequence of the last placed trigger order
+func (k Keeper) GetTriggerOrderSeq(ctx sdk.Context) uint64 {
+	bz := ctx.KVStore(k.orderStoreKey).Get(types.TriggerOrderSeqKey)
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+// SetTriggerOrderSeq sets the sequence of the last placed trigger order
+func (k Keeper) SetTriggerOrderSeq(ctx sdk.Context, seq uint64) {
+	ctx.KVStore(k.orderStoreKey).Set(types.TriggerOrderSeqKey, sdk.Uint64ToBigEndian(seq))
+}
+
+func (k Keeper) nextTriggerOrderSeq(ctx sdk.Context) uint64 {
+	seq := k.GetTriggerOrderSeq(ctx) + 1
+	k.SetTriggerOrderSeq(ctx, seq)
+	return seq
+}
+
+// PlaceTriggerOrder locks the coins of the order to be activated, and sets the trigger order to keeper
+func (k Keeper) PlaceTriggerOrder(ctx sdk.Context, order *types.TriggerOrder, pricePrecision int64) error {
+	needLockCoins := order.NeedLockCoins(pricePrecision)
+	if err := k.LockCoins(ctx, order.Sender, needLockCoins, token.LockCoinsTypeQuantity); err != nil {
+		ctx.Logger().With("module", "order").Info(fmt.Sprintf("place trigger order failed: %v, %v", err, order))
+		return err
+	}
+
+	order.TriggerOrderID = types.FormatTriggerOrderID(k.nextTriggerOrderSeq(ctx))
+	k.SetTriggerOrder(ctx, order)
+	return nil
+}
+
+// CancelTriggerOrder unlocks the coins of the trigger order and drops it
+func (k Keeper) CancelTriggerOrder(ctx sdk.Context, order *types.TriggerOrder, pricePrecision int64) {
+	k.UnlockCoins(ctx, order.Sender, order.NeedLockCoins(pricePrecision), token.LockCoinsTypeQuantity)
+	k.DropTriggerOrder(ctx, order)
+}
+
+// ActivateTriggerOrder places the regular order of the trigger order. The coins locked by the trigger order
+// are locked again by PlaceOrder, the trigger order is dropped even if the order fails to be placed
+func (k Keeper) ActivateTriggerOrder(ctx sdk.Context, triggerOrder *types.TriggerOrder,
+	pricePrecision int64) (*types.Order, error) {
+	k.CancelTriggerOrder(ctx, triggerOrder, pricePrecision)
+
+	feeParams := k.GetParams(ctx)
+	order := types.NewOrder(
+		triggerOrder.TxHash,
+		triggerOrder.Sender,
+		triggerOrder.Product,
+		triggerOrder.Side,
+		triggerOrder.OrderPrice(pricePrecision),
+		triggerOrder.Quantity,
+		ctx.BlockHeader().Time.Unix(),
+		feeParams.OrderExpireBlocks,
+		feeParams.FeePerBlock,
+	)
+	order.TimeInForce = triggerOrder.OrderTimeInForce()
+
+	cacheCtx, write := ctx.CacheContext()
+	if err := k.PlaceOrder(cacheCtx, order); err != nil {
+		return nil, err
+	}
+	write()
+	return order, nil
+}
+
+// SetTriggeredOrderIDs sets the ids of the orders activated by the trigger orders,
+// which are matched in the next periodic auction
+func (k Keeper) SetTriggeredOrderIDs(ctx sdk.Context, orderIDs []string) {
+	store := ctx.KVStore(k.orderStoreKey)
+	if len(orderIDs) == 0 {
+		store.Delete(types.TriggeredOrderIDsKey)
+		return
+	}
+	store.Set(types.TriggeredOrderIDsKey, k.cdc.MustMarshalBinaryBare(orderIDs))
+}
+
+// GetTriggeredOrderIDs gets the ids of the orders activated by the trigger orders
+func (k Keeper) GetTriggeredOrderIDs(ctx sdk.Context) []string {
+	bz := ctx.KVStore(k.orderStoreKey).Get(types.TriggeredOrderIDsKey)
+	if bz == nil {
+		return nil
+	}
+	var orderIDs []string
+	k.cdc.MustUnmarshalBinaryBare(bz, &orderIDs)
+	return orderIDs
+}
@@ -0,0 +1,157 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/exchain/x/common"
+	"github.com/okex/exchain/x/dex"
+	"github.com/okex/exchain/x/order/types"
+)
+
+func mockTriggerOrder(sender sdk.AccAddress, side, triggerType, triggerPrice, price,
+	quantity string) *types.TriggerOrder {
+	return types.NewTriggerOrder("", sender, types.TestTokenPair, side, triggerType,
+		sdk.MustNewDecFromStr(triggerPrice), sdk.MustNewDecFromStr(price), sdk.MustNewDecFromStr(quantity), 0)
+}
+
+func TestPlaceAndCancelTriggerOrder(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	order := mockTriggerOrder(testInput.TestAddrs[0], types.BuyOrder, types.TriggerOrderTypeStopLoss,
+		"10.5", "11.0", "1.0")
+	err = keeper.PlaceTriggerOrder(ctx, order, tokenPair.MaxPriceDigit)
+	require.Nil(t, err)
+	require.EqualValues(t, types.FormatTriggerOrderID(1), order.TriggerOrderID)
+	require.EqualValues(t, 1, keeper.GetTriggerOrderSeq(ctx))
+
+	// the quote coins of the activated order are locked
+	acc := testInput.AccountKeeper.GetAccount(ctx, testInput.TestAddrs[0])
+	expectCoins := sdk.SysCoins{
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr("89")),
+		sdk.NewDecCoinFromDec(common.TestToken, sdk.MustNewDecFromStr("100")),
+	}
+	require.EqualValues(t, expectCoins.String(), acc.GetCoins().String())
+	require.EqualValues(t, order, keeper.GetTriggerOrder(ctx, order.TriggerOrderID))
+	require.Equal(t, 1, len(keeper.GetTriggerOrdersByProduct(ctx, types.TestTokenPair)))
+	require.Equal(t, 0, len(keeper.GetDepthBookCopy(types.TestTokenPair).Items))
+
+	// not enough balance
+	order2 := mockTriggerOrder(testInput.TestAddrs[0], types.BuyOrder, types.TriggerOrderTypeStopLoss,
+		"10.5", "11.0", "10.0")
+	err = keeper.PlaceTriggerOrder(ctx, order2, tokenPair.MaxPriceDigit)
+	require.Error(t, err)
+	require.Equal(t, 1, len(keeper.GetAllTriggerOrders(ctx)))
+
+	keeper.CancelTriggerOrder(ctx, order, tokenPair.MaxPriceDigit)
+	acc = testInput.AccountKeeper.GetAccount(ctx, testInput.TestAddrs[0])
+	expectCoins = sdk.SysCoins{
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr("100")),
+		sdk.NewDecCoinFromDec(common.TestToken, sdk.MustNewDecFromStr("100")),
+	}
+	require.EqualValues(t, expectCoins.String(), acc.GetCoins().String())
+	require.Nil(t, keeper.GetTriggerOrder(ctx, order.TriggerOrderID))
+	require.Equal(t, 0, len(keeper.GetTriggerOrdersByProduct(ctx, types.TestTokenPair)))
+}
+
+func TestActivateTriggerOrder(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	// a market sell order is placed below the trigger price
+	triggerOrder := mockTriggerOrder(testInput.TestAddrs[0], types.SellOrder, types.TriggerOrderTypeTakeProfit,
+		"10.0", "0", "2.0")
+	err = keeper.PlaceTriggerOrder(ctx, triggerOrder, tokenPair.MaxPriceDigit)
+	require.Nil(t, err)
+
+	order, err := keeper.ActivateTriggerOrder(ctx, triggerOrder, tokenPair.MaxPriceDigit)
+	require.Nil(t, err)
+	require.EqualValues(t, types.FormatOrderID(10, 1), order.OrderID)
+	require.EqualValues(t, sdk.MustNewDecFromStr("9.5"), order.Price)
+	require.EqualValues(t, types.TimeInForceIOC, order.TimeInForce)
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, order.OrderID).Status)
+	require.Nil(t, keeper.GetTriggerOrder(ctx, triggerOrder.TriggerOrderID))
+
+	// the base coins stay locked by the activated order
+	acc := testInput.AccountKeeper.GetAccount(ctx, testInput.TestAddrs[0])
+	expectCoins := sdk.SysCoins{
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr("99.7408")),
+		sdk.NewDecCoinFromDec(common.TestToken, sdk.MustNewDecFromStr("98")),
+	}
+	require.EqualValues(t, expectCoins.String(), acc.GetCoins().String())
+	depthBook := keeper.GetDepthBookCopy(types.TestTokenPair)
+	require.Equal(t, 1, len(depthBook.Items))
+	require.Equal(t, sdk.MustNewDecFromStr("2.0"), depthBook.Items[0].SellQuantity)
+}
+
+func TestTriggeredOrderIDs(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx
+
+	require.Nil(t, keeper.GetTriggeredOrderIDs(ctx))
+	orderIDs := []string{types.FormatOrderID(10, 1), types.FormatOrderID(10, 2)}
+	keeper.SetTriggeredOrderIDs(ctx, orderIDs)
+	require.EqualValues(t, orderIDs, keeper.GetTriggeredOrderIDs(ctx))
+	keeper.SetTriggeredOrderIDs(ctx, nil)
+	require.Nil(t, keeper.GetTriggeredOrderIDs(ctx))
+}
+
+func TestGetTriggeredOrders(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx
+
+	// sell stop-losses and buy take-profits are triggered when the price falls,
+	// buy stop-losses and sell take-profits when it rises
+	orders := []*types.TriggerOrder{
+		mockTriggerOrder(testInput.TestAddrs[0], types.SellOrder, types.TriggerOrderTypeStopLoss, "9.0", "0", "1.0"),
+		mockTriggerOrder(testInput.TestAddrs[0], types.BuyOrder, types.TriggerOrderTypeTakeProfit, "8.0", "0", "1.0"),
+		mockTriggerOrder(testInput.TestAddrs[0], types.BuyOrder, types.TriggerOrderTypeStopLoss, "11.0", "0", "1.0"),
+		mockTriggerOrder(testInput.TestAddrs[0], types.SellOrder, types.TriggerOrderTypeTakeProfit, "12.0", "0", "1.0"),
+		mockTriggerOrder(testInput.TestAddrs[0], types.SellOrder, types.TriggerOrderTypeStopLoss, "100.0", "0", "1.0"),
+	}
+	for i, order := range orders {
+		order.TriggerOrderID = types.FormatTriggerOrderID(uint64(i + 1))
+		keeper.SetTriggerOrder(ctx, order)
+	}
+	require.Equal(t, 5, len(keeper.GetTriggerOrdersByProduct(ctx, types.TestTokenPair)))
+	require.Equal(t, 0, len(keeper.GetTriggerOrdersByProduct(ctx, "btc_"+common.NativeToken)))
+
+	testCases := []struct {
+		price    string
+		expected []*types.TriggerOrder
+	}{
+		{"10.0", []*types.TriggerOrder{orders[4]}},
+		{"9.0", []*types.TriggerOrder{orders[0], orders[4]}},
+		{"1.0", []*types.TriggerOrder{orders[1], orders[0], orders[4]}},
+		{"11.0", []*types.TriggerOrder{orders[4], orders[2]}},
+		{"200.0", []*types.TriggerOrder{orders[2], orders[3]}},
+		{"0", nil},
+	}
+	for _, tc := range testCases {
+		price := sdk.MustNewDecFromStr(tc.price)
+		triggered := keeper.GetTriggeredOrders(ctx, types.TestTokenPair, price)
+		require.EqualValues(t, tc.expected, triggered, tc.price)
+		for _, order := range triggered {
+			require.True(t, order.IsTriggered(price))
+		}
+	}
+
+	keeper.DropTriggerOrder(ctx, orders[4])
+	require.Equal(t, 0, len(keeper.GetTriggeredOrders(ctx, types.TestTokenPair, sdk.MustNewDecFromStr("10.0"))))
+	require.Equal(t, 4, len(keeper.GetTriggerOrdersByProduct(ctx, types.TestTokenPair)))
+}
@@ -4,13 +4,26 @@ import (
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 
 	"github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/match/periodicauction"
+	"github.com/okex/exchain/x/order/types"
 )
 
-// nolint
+// CaEngine is the continuous auction match engine.
+// Orders are matched on arrival by MatchOrder, so Run only cleans up the closed orders
 type CaEngine struct {
 }
 
 // nolint
 func (e *CaEngine) Run(ctx sdk.Context, keeper keeper.Keeper) {
-	// TODO
+	periodicauction.Cleanup(ctx, keeper)
+}
+
+// MatchOrder crosses a newly placed order against the depth book with price-time priority.
+// The taker is filled at the price of each maker, and the unfilled remainder rests in the depth book.
+func (e *CaEngine) MatchOrder(ctx sdk.Context, keeper keeper.Keeper, taker *types.Order) []types.Deal {
+	deals, execution, lastPrice := matchOrder(ctx, keeper, taker)
+	if execution.IsPositive() {
+		saveMatchResult(ctx, keeper, taker.Product, deals, execution, lastPrice)
+	}
+	return deals
 }
@@ -0,0 +1,128 @@
+package continuousauction
+
+import (
+	"testing"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/exchain/x/common"
+	"github.com/okex/exchain/x/dex"
+	orderkeeper "github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/types"
+)
+
+func TestCaEngine_MatchOrder(t *testing.T) {
+	common.InitConfig()
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	feeParams := types.DefaultTestParams()
+	keeper.SetParams(ctx, &feeParams)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	testInput.DexKeeper.SetOperator(ctx, dex.DEXOperator{
+		Address:            tokenPair.Owner,
+		HandlingFeeAddress: tokenPair.Owner,
+	})
+	keeper.ResetCache(ctx)
+
+	engine := &CaEngine{}
+	// makers rest in the depth book
+	makers := []*types.Order{
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.1", "1.0"),
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "0.5"),
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "0.5"),
+	}
+	for _, maker := range makers {
+		maker.Sender = testInput.TestAddrs[1]
+		require.NoError(t, keeper.PlaceOrder(ctx, maker))
+		require.Empty(t, engine.MatchOrder(ctx, keeper, maker))
+	}
+
+	// the taker crosses the best price first, and the earlier maker first at the same price
+	taker := types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.1", "1.2")
+	taker.Sender = testInput.TestAddrs[0]
+	require.NoError(t, keeper.PlaceOrder(ctx, taker))
+	deals := engine.MatchOrder(ctx, keeper, taker)
+	require.Equal(t, 6, len(deals))
+
+	require.EqualValues(t, types.OrderStatusFilled, taker.Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("10.016666666666666667"), taker.FilledAvgPrice)
+	require.EqualValues(t, types.OrderStatusFilled, keeper.GetOrder(ctx, makers[1].OrderID).Status)
+	require.EqualValues(t, types.OrderStatusFilled, keeper.GetOrder(ctx, makers[2].OrderID).Status)
+	order0 := keeper.GetOrder(ctx, makers[0].OrderID)
+	require.EqualValues(t, types.OrderStatusOpen, order0.Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.8"), order0.RemainQuantity)
+
+	// only the remainder of the first maker is left in the depth book
+	depthBook := keeper.GetDepthBookCopy(types.TestTokenPair)
+	require.Equal(t, 1, len(depthBook.Items))
+	require.EqualValues(t, sdk.MustNewDecFromStr("10.1"), depthBook.Items[0].Price)
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.8"), depthBook.Items[0].SellQuantity)
+	require.True(t, depthBook.Items[0].BuyQuantity.IsZero())
+	require.Equal(t, []string{makers[0].OrderID},
+		keeper.GetProductPriceOrderIDs(types.FormatOrderIDsKey(types.TestTokenPair, makers[0].Price, types.SellOrder)))
+	require.Empty(t, keeper.GetProductPriceOrderIDs(types.FormatOrderIDsKey(types.TestTokenPair, taker.Price, types.BuyOrder)))
+
+	// the match result of the block
+	matchResult := keeper.GetBlockMatchResult().ResultMap[types.TestTokenPair]
+	require.EqualValues(t, sdk.MustNewDecFromStr("10.1"), matchResult.Price)
+	require.EqualValues(t, sdk.MustNewDecFromStr("1.2"), matchResult.Quantity)
+	require.EqualValues(t, sdk.MustNewDecFromStr("10.1"), keeper.GetLastPrice(ctx, types.TestTokenPair))
+
+	// a buy taker below the best ask rests in the depth book
+	taker = types.MockOrder("", types.TestTokenPair, types.BuyOrder, "9.9", "1.0")
+	taker.Sender = testInput.TestAddrs[0]
+	require.NoError(t, keeper.PlaceOrder(ctx, taker))
+	require.Empty(t, engine.MatchOrder(ctx, keeper, taker))
+	depthBook = keeper.GetDepthBookCopy(types.TestTokenPair)
+	require.Equal(t, 2, len(depthBook.Items))
+	require.EqualValues(t, sdk.MustNewDecFromStr("1.0"), depthBook.Items[1].BuyQuantity)
+}
+
+func TestCaEngine_MaxDealsPerBlock(t *testing.T) {
+	common.InitConfig()
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	feeParams := types.DefaultTestParams()
+	feeParams.MaxDealsPerBlock = 2
+	keeper.SetParams(ctx, &feeParams)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	require.Nil(t, testInput.DexKeeper.SaveTokenPair(ctx, tokenPair))
+	testInput.DexKeeper.SetOperator(ctx, dex.DEXOperator{
+		Address:            tokenPair.Owner,
+		HandlingFeeAddress: tokenPair.Owner,
+	})
+	keeper.ResetCache(ctx)
+
+	engine := &CaEngine{}
+	for i := 0; i < 3; i++ {
+		maker := types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "0.5")
+		maker.Sender = testInput.TestAddrs[1]
+		require.NoError(t, keeper.PlaceOrder(ctx, maker))
+		require.Empty(t, engine.MatchOrder(ctx, keeper, maker))
+	}
+
+	// every taker fills a maker, until the trades of the block reach MaxDealsPerBlock
+	for i := 0; i < 3; i++ {
+		taker := types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "0.5")
+		taker.Sender = testInput.TestAddrs[0]
+		require.NoError(t, keeper.PlaceOrder(ctx, taker))
+		deals := engine.MatchOrder(ctx, keeper, taker)
+		if i < 2 {
+			require.Equal(t, 2, len(deals))
+		} else {
+			require.Empty(t, deals)
+		}
+	}
+	require.EqualValues(t, 2, keeper.GetBlockDealsNum())
+
+	// the cap is reset in the next block
+	keeper.ResetCache(ctx)
+	require.EqualValues(t, 0, keeper.GetBlockDealsNum())
+}
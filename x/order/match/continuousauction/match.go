@@ -0,0 +1,167 @@
+package continuousauction
+
+import (
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+
+	"github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/match/periodicauction"
+	"github.com/okex/exchain/x/order/types"
+)
+
+func oppositeSide(side string) string {
+	if side == types.BuyOrder {
+		return types.SellOrder
+	}
+	return types.BuyOrder
+}
+
+// crossed returns true if a taker with takerPrice can trade with makers at makerPrice
+func crossed(side string, takerPrice, makerPrice sdk.Dec) bool {
+	if side == types.BuyOrder {
+		return makerPrice.LTE(takerPrice)
+	}
+	return makerPrice.GTE(takerPrice)
+}
+
+// matchOrder returns the deals of both sides, the executed quantity and the latest trade price
+func matchOrder(ctx sdk.Context, k keeper.Keeper, taker *types.Order) (deals []types.Deal,
+	execution sdk.Dec, lastPrice sdk.Dec) {
+	execution = sdk.ZeroDec()
+	feeParams := k.GetParams(ctx)
+	// the deals of all the takers in the block are capped by MaxDealsPerBlock
+	remainDeals := feeParams.MaxDealsPerBlock - k.GetBlockDealsNum()
+	makerSide := oppositeSide(taker.Side)
+
+	// the taker has been inserted into the depth book by PlaceOrder, take it out while matching
+	book := k.GetDepthBookCopy(taker.Product)
+	book.RemoveOrder(taker)
+
+	// Fill sell makers from low to high for a buy taker, buy makers from high to low for a sell taker
+	index := 0
+	if taker.Side == types.BuyOrder {
+		index = len(book.Items) - 1
+	}
+	for index >= 0 && index < len(book.Items) && taker.RemainQuantity.IsPositive() && remainDeals > 0 {
+		item := book.Items[index]
+		if !crossed(taker.Side, taker.Price, item.Price) {
+			break
+		}
+
+		makerQuantity := item.BuyQuantity
+		if makerSide == types.SellOrder {
+			makerQuantity = item.SellQuantity
+		}
+		if makerQuantity.IsPositive() {
+			key := types.FormatOrderIDsKey(taker.Product, item.Price, makerSide)
+			levelDeals, filledAmount, filledDealsCnt := fillPriceLevel(ctx, k, key, taker, item.Price,
+				feeParams, remainDeals)
+			remainDeals -= filledDealsCnt
+			k.AddBlockDealsNum(filledDealsCnt)
+			deals = append(deals, levelDeals...)
+			book.Sub(index, filledAmount, makerSide)
+			if filledAmount.IsPositive() {
+				execution = execution.Add(filledAmount)
+				lastPrice = item.Price
+			}
+		}
+
+		removed := book.RemoveIfEmpty(index)
+		if taker.Side == types.BuyOrder {
+			index--
+		} else if !removed {
+			index++
+		}
+	}
+
+	if taker.RemainQuantity.IsPositive() {
+		book.InsertOrder(taker)
+	} else {
+		// the taker is the latest order at its price, drop it from orderIDsMap
+		key := types.FormatOrderIDsKey(taker.Product, taker.Price, taker.Side)
+		k.SetOrderIDs(key, removeOrderID(k.GetProductPriceOrderIDs(key), taker.OrderID))
+	}
+	k.SetDepthBook(taker.Product, book)
+
+	return deals, execution, lastPrice
+}
+
+// fillPriceLevel fills the makers at key in time priority until the taker is fully filled,
+// returns the deals of both sides, the filled amount of the makers and the number of trades
+func fillPriceLevel(ctx sdk.Context, k keeper.Keeper, key string, taker *types.Order, price sdk.Dec,
+	feeParams *types.Params, remainDeals int64) ([]types.Deal, sdk.Dec, int64) {
+
+	var deals []types.Deal
+	filledAmount := sdk.ZeroDec()
+	filledDealsCnt := int64(0)
+	orderIDs := k.GetProductPriceOrderIDs(key)
+
+	index := 0
+	for index < len(orderIDs) && taker.RemainQuantity.IsPositive() && filledDealsCnt < remainDeals {
+		maker := k.GetOrder(ctx, orderIDs[index])
+		if maker == nil {
+			ctx.Logger().Error(fmt.Sprintf("[Order] Not exist orderID: %s", orderIDs[index]))
+			index++
+			continue
+		}
+
+		fillQuantity := sdk.MinDec(maker.RemainQuantity, taker.RemainQuantity)
+		if deal := periodicauction.FillOrder(maker, ctx, k, price, fillQuantity, feeParams); deal != nil {
+			deals = append(deals, *deal)
+		}
+		if deal := periodicauction.FillOrder(taker, ctx, k, price, fillQuantity, feeParams); deal != nil {
+			deals = append(deals, *deal)
+		}
+		filledAmount = filledAmount.Add(fillQuantity)
+		filledDealsCnt++
+
+		if maker.Status == types.OrderStatusFilled {
+			index++
+		}
+	}
+
+	// Note: orderIDs cannot be nil, we will use empty slice to remove Data on keeper
+	unFilledOrderIDs := make([]string, len(orderIDs)-index)
+	copy(unFilledOrderIDs, orderIDs[index:])
+	k.SetOrderIDs(key, unFilledOrderIDs)
+
+	return deals, filledAmount, filledDealsCnt
+}
+
+func removeOrderID(orderIDs []string, orderID string) []string {
+	res := make([]string, 0, len(orderIDs))
+	for _, id := range orderIDs {
+		if id != orderID {
+			res = append(res, id)
+		}
+	}
+	return res
+}
+
+// saveMatchResult merges the deals of a taker into the match result of the current block
+func saveMatchResult(ctx sdk.Context, k keeper.Keeper, product string, deals []types.Deal,
+	execution, lastPrice sdk.Dec) {
+	blockHeight := ctx.BlockHeight()
+	k.SetLastPrice(ctx, product, lastPrice)
+	k.EmitDealEvents(ctx, product, lastPrice, deals)
+
+	blockMatchResult := k.GetBlockMatchResult()
+	if blockMatchResult == nil || blockMatchResult.BlockHeight != blockHeight {
+		blockMatchResult = &types.BlockMatchResult{
+			BlockHeight: blockHeight,
+			ResultMap:   make(map[string]types.MatchResult),
+			TimeStamp:   ctx.BlockHeader().Time.Unix(),
+		}
+	}
+
+	matchResult, ok := blockMatchResult.ResultMap[product]
+	if !ok {
+		matchResult = types.MatchResult{BlockHeight: blockHeight, Quantity: sdk.ZeroDec(), Deals: []types.Deal{}}
+	}
+	matchResult.Price = lastPrice
+	matchResult.Quantity = matchResult.Quantity.Add(execution)
+	matchResult.Deals = append(matchResult.Deals, deals...)
+	blockMatchResult.ResultMap[product] = matchResult
+	k.SetBlockMatchResult(blockMatchResult)
+}
@@ -8,25 +8,30 @@ import (
 	"github.com/okex/exchain/x/order/keeper"
 	"github.com/okex/exchain/x/order/match/continuousauction"
 	"github.com/okex/exchain/x/order/match/periodicauction"
+	"github.com/okex/exchain/x/order/types"
 )
 
 // nolint
-const DefaultAuctionType = "periodicauction"
+const (
+	DefaultAuctionType    = "periodicauction"
+	ContinuousAuctionType = "continuousauction"
+)
 
 // nolint
 var (
 	once        sync.Once
 	engine      Engine
 	auctionType = DefaultAuctionType
+	caEngine    = &continuousauction.CaEngine{}
 )
 
-// GetEngine : periodic auction only today
+// GetEngine returns the engine run at EndBlock
 func GetEngine() Engine {
 	once.Do(func() {
 		if auctionType == DefaultAuctionType {
 			engine = &periodicauction.PaEngine{}
 		} else {
-			engine = &continuousauction.CaEngine{}
+			engine = caEngine
 		}
 	})
 	return engine
@@ -36,3 +41,17 @@ func GetEngine() Engine {
 type Engine interface {
 	Run(ctx sdk.Context, keeper keeper.Keeper)
 }
+
+// IsContinuousAuctionProduct returns true if orders of the product are matched on arrival
+func IsContinuousAuctionProduct(ctx sdk.Context, keeper keeper.Keeper, product string) bool {
+	return auctionType == ContinuousAuctionType || keeper.GetParams(ctx).IsContinuousAuctionProduct(product)
+}
+
+// MatchOrderOnPlace crosses a newly placed order against the depth book if its product is
+// matched by the continuous auction, the other orders wait for the periodic auction at EndBlock
+func MatchOrderOnPlace(ctx sdk.Context, keeper keeper.Keeper, order *types.Order) []types.Deal {
+	if !IsContinuousAuctionProduct(ctx, keeper, order.Product) {
+		return nil
+	}
+	return caEngine.MatchOrder(ctx, keeper, order)
+}
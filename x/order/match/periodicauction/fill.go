@@ -225,6 +225,12 @@ func chargeFee(order *types.Order, ctx sdk.Context, keeper orderkeeper.Keeper, f
 	return
 }
 
+// FillOrder fills an order at fillPrice, it's shared with the continuous auction engine
+func FillOrder(order *types.Order, ctx sdk.Context, keeper orderkeeper.Keeper,
+	fillPrice, fillQuantity sdk.Dec, feeParams *types.Params) *types.Deal {
+	return fillOrder(order, ctx, keeper, fillPrice, fillQuantity, feeParams)
+}
+
 // Fill an order. Update order, charge fee and transfer tokens. Return a deal.
 // If an order is fully filled but still lock some coins, unlock it.
 func fillOrder(order *types.Order, ctx sdk.Context, keeper orderkeeper.Keeper,
@@ -12,7 +12,12 @@ type PaEngine struct {
 
 // nolint
 func (e *PaEngine) Run(ctx sdk.Context, keeper keeper.Keeper) {
+	Cleanup(ctx, keeper)
+	matchOrders(ctx, keeper)
+}
+
+// Cleanup drops the expired orders and the orders whose token pair have been delisted
+func Cleanup(ctx sdk.Context, keeper keeper.Keeper) {
 	cleanupExpiredOrders(ctx, keeper)
 	cleanupOrdersWhoseTokenPairHaveBeenDelisted(ctx, keeper)
-	matchOrders(ctx, keeper)
 }
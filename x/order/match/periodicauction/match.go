@@ -265,14 +265,17 @@ func cleanupExpiredOrders(ctx sdk.Context, keeper keeper.Keeper) {
 func matchOrders(ctx sdk.Context, keeper keeper.Keeper) {
 	blockHeight := ctx.BlockHeight()
 	orderNum := keeper.GetBlockOrderNum(ctx, blockHeight)
+	triggeredOrderIDs := keeper.GetTriggeredOrderIDs(ctx)
 	// no new orders in this block & no product lock in previous blocks, skip match
-	if orderNum == 0 && !keeper.AnyProductLocked(ctx) {
+	if orderNum == 0 && len(triggeredOrderIDs) == 0 && !keeper.AnyProductLocked(ctx) {
 		return
 	}
 
-	// step0: get active products
+	// step0: get active products, including the ones of the orders activated by trigger orders in the last block
 	products := keeper.GetDiskCache().GetNewDepthbookKeys()
+	products = appendTriggeredProducts(ctx, keeper, products, triggeredOrderIDs)
 	products = keeper.FilterDelistedProducts(ctx, products)
+	products = filterContinuousAuctionProducts(ctx, keeper, products)
 	keeper.GetDexKeeper().SortProducts(ctx, products) // sort products
 
 	// step1: calc best price and max execution for every active product, save latest price
@@ -289,19 +292,47 @@ func matchOrders(ctx sdk.Context, keeper keeper.Keeper) {
 	// step2: execute match results, fill orders in match results, transfer tokens and collect fees
 	executeMatch(ctx, keeper, products, updatedProductsBasePrice, lockMap)
 
+	// step2.1: cancel the unfilled remainder of the IOC and FOK orders placed in this block,
+	// and the ones activated by trigger orders in the last block
+	cancelImmediateOrders(ctx, keeper, blockHeight, "")
+	keeper.SetTriggeredOrderIDs(ctx, cancelTriggeredImmediateOrders(ctx, keeper, triggeredOrderIDs))
+
 	// step3: save match results for querying
 	if len(updatedProductsBasePrice) > 0 {
-		blockMatchResult := &types.BlockMatchResult{
-			BlockHeight: blockHeight,
-			ResultMap:   updatedProductsBasePrice,
-			TimeStamp:   ctx.BlockHeader().Time.Unix(),
+		blockMatchResult := keeper.GetBlockMatchResult()
+		if blockMatchResult == nil || blockMatchResult.BlockHeight != blockHeight {
+			blockMatchResult = &types.BlockMatchResult{
+				BlockHeight: blockHeight,
+				ResultMap:   make(map[string]types.MatchResult),
+				TimeStamp:   ctx.BlockHeader().Time.Unix(),
+			}
+		}
+		// results of the continuous auction products have been saved while handling txs
+		for product, matchResult := range updatedProductsBasePrice {
+			blockMatchResult.ResultMap[product] = matchResult
 		}
 		keeper.SetBlockMatchResult(blockMatchResult)
 	}
 }
 
+// filterContinuousAuctionProducts drops the products which are matched on arrival by the continuous auction
+func filterContinuousAuctionProducts(ctx sdk.Context, keeper keeper.Keeper, products []string) []string {
+	params := keeper.GetParams(ctx)
+	if len(params.ContinuousAuctionProducts) == 0 {
+		return products
+	}
+	var batchProducts []string
+	for _, product := range products {
+		if !params.IsContinuousAuctionProduct(product) {
+			batchProducts = append(batchProducts, product)
+		}
+	}
+	return batchProducts
+}
+
 func calcMatchPriceAndExecution(ctx sdk.Context, k keeper.Keeper, products []string) map[string]types.MatchResult {
 	resultMap := make(map[string]types.MatchResult)
+	fokOrders := getFOKOrders(ctx, k, ctx.BlockHeight())
 
 	for _, product := range products {
 		tokenPair := k.GetDexKeeper().GetTokenPair(ctx, product)
@@ -311,6 +342,19 @@ func calcMatchPriceAndExecution(ctx sdk.Context, k keeper.Keeper, products []str
 		book := k.GetDepthBookCopy(product)
 		bestPrice, maxExecution := periodicAuctionMatchPrice(book, tokenPair.MaxPriceDigit,
 			k.GetLastPrice(ctx, product))
+		// the FOK orders which can't be filled completely are killed before the match,
+		// then the match price is calculated again without them
+		for len(fokOrders[product]) > 0 && maxExecution.IsPositive() {
+			var killed bool
+			fokOrders[product], killed = killUnfillableFOKOrders(ctx, k, book, fokOrders[product],
+				bestPrice, maxExecution)
+			if !killed {
+				break
+			}
+			book = k.GetDepthBookCopy(product)
+			bestPrice, maxExecution = periodicAuctionMatchPrice(book, tokenPair.MaxPriceDigit,
+				k.GetLastPrice(ctx, product))
+		}
 		if maxExecution.IsPositive() {
 			k.SetLastPrice(ctx, product, bestPrice)
 			resultMap[product] = types.MatchResult{BlockHeight: ctx.BlockHeight(), Price: bestPrice,
@@ -354,6 +398,7 @@ func executeMatchedUpdatedProduct(ctx sdk.Context, k keeper.Keeper,
 		matchResult.Price, matchResult.Quantity, &buyExecutedCnt, &sellExecutedCnt, blockRemainDeals, feeParams)
 	matchResult.Deals = deals
 	updatedProductsBasePrice[product] = matchResult
+	k.EmitDealEvents(ctx, product, matchResult.Price, deals)
 
 	logger.Info(fmt.Sprintf("matchResult(%d-%s): price: %v, quantity: %v, buyExecuted: %v"+
 		", sellExecuted: %v, dealsNum: %d", matchResult.BlockHeight, product,
@@ -393,6 +438,7 @@ func executeLockedProduct(ctx sdk.Context, k keeper.Keeper,
 			Quantity:    lock.Quantity,
 			Deals:       deals,
 		}
+		k.EmitDealEvents(ctx, product, lock.Price, deals)
 	}
 
 	logger.Info(fmt.Sprintf("BlockHeight<%d> execute locked product(%s<%d>): price: %v, "+
@@ -407,6 +453,8 @@ func executeLockedProduct(ctx sdk.Context, k keeper.Keeper,
 		k.UnlockProduct(ctx, product)
 		logger.Info(fmt.Sprintf("BlockHeight<%d> unlock product(%s<%d>)", blockHeight,
 			product, lock.BlockHeight))
+		// the match of the locked block is done, cancel the unfilled remainder of its IOC and FOK orders
+		cancelImmediateOrders(ctx, k, lock.BlockHeight, product)
 	} else {
 		// update product lock
 		k.SetProductLock(ctx, product, lock)
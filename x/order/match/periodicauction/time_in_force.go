@@ -0,0 +1,103 @@
+package periodicauction
+
+import (
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+
+	"github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/types"
+)
+
+// getFOKOrders groups the open FOK orders placed at blockHeight by product
+func getFOKOrders(ctx sdk.Context, k keeper.Keeper, blockHeight int64) map[string][]*types.Order {
+	fokOrders := make(map[string][]*types.Order)
+	for _, order := range k.GetOpenOrdersByBlockHeight(ctx, blockHeight) {
+		if order.TimeInForce == types.TimeInForceFOK {
+			fokOrders[order.Product] = append(fokOrders[order.Product], order)
+		}
+	}
+	return fokOrders
+}
+
+// filledQuantity returns the quantity of the order filled by the match at bestPrice with maxExecution.
+// Orders are filled by price priority first and then by time priority, see fillOrderByKey
+func filledQuantity(ctx sdk.Context, k keeper.Keeper, book *types.DepthBook, order *types.Order,
+	bestPrice, maxExecution sdk.Dec) sdk.Dec {
+	if (order.Side == types.BuyOrder && order.Price.LT(bestPrice)) ||
+		(order.Side == types.SellOrder && order.Price.GT(bestPrice)) {
+		return sdk.ZeroDec()
+	}
+
+	// the quantity filled before the order
+	ahead := sdk.ZeroDec()
+	for _, item := range book.Items {
+		if order.Side == types.BuyOrder && item.Price.GT(order.Price) {
+			ahead = ahead.Add(item.BuyQuantity)
+		} else if order.Side == types.SellOrder && item.Price.LT(order.Price) {
+			ahead = ahead.Add(item.SellQuantity)
+		}
+	}
+	key := types.FormatOrderIDsKey(order.Product, order.Price, order.Side)
+	for _, orderID := range k.GetProductPriceOrderIDs(key) {
+		if orderID == order.OrderID {
+			break
+		}
+		if o := k.GetOrder(ctx, orderID); o != nil {
+			ahead = ahead.Add(o.RemainQuantity)
+		}
+	}
+
+	if ahead.GTE(maxExecution) {
+		return sdk.ZeroDec()
+	}
+	return sdk.MinDec(order.RemainQuantity, maxExecution.Sub(ahead))
+}
+
+// killUnfillableFOKOrders cancels the FOK orders which can't be filled completely by the match at bestPrice,
+// returns the FOK orders left and whether any order is cancelled.
+// The partially filled ones are cancelled first, since the orders which are not filled at all may be filled
+// by the match calculated again without them
+func killUnfillableFOKOrders(ctx sdk.Context, k keeper.Keeper, book *types.DepthBook, fokOrders []*types.Order,
+	bestPrice, maxExecution sdk.Dec) (left []*types.Order, killed bool) {
+	logger := ctx.Logger().With("module", "order")
+
+	var partialFilled, unfilled []*types.Order
+	for _, order := range fokOrders {
+		filled := filledQuantity(ctx, k, book, order, bestPrice, maxExecution)
+		if filled.Equal(order.RemainQuantity) {
+			left = append(left, order)
+		} else if filled.IsPositive() {
+			partialFilled = append(partialFilled, order)
+		} else {
+			unfilled = append(unfilled, order)
+		}
+	}
+
+	killing := partialFilled
+	if len(killing) == 0 {
+		killing = unfilled
+	} else {
+		left = append(left, unfilled...)
+	}
+	for _, order := range killing {
+		k.CancelOrder(ctx, order, logger)
+		logger.Info(fmt.Sprintf("FOK order(%s) can't be filled completely, cancelled", order.OrderID))
+	}
+	return left, len(killing) > 0
+}
+
+// cancelImmediateOrders cancels the unfilled remainder of the IOC and FOK orders placed at blockHeight.
+// The orders of the locked products are left until the products are unlocked.
+// If product is not empty, only the orders of the product are cancelled
+func cancelImmediateOrders(ctx sdk.Context, k keeper.Keeper, blockHeight int64, product string) {
+	logger := ctx.Logger().With("module", "order")
+	for _, order := range k.GetOpenOrdersByBlockHeight(ctx, blockHeight) {
+		if !order.IsImmediate() || (product != "" && order.Product != product) ||
+			k.IsProductLocked(ctx, order.Product) {
+			continue
+		}
+		k.CancelOrder(ctx, order, logger)
+		logger.Info(fmt.Sprintf("the unfilled remainder of %s order(%s) cancelled", order.TimeInForce, order.OrderID))
+	}
+}
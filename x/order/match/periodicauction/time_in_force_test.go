@@ -0,0 +1,95 @@
+package periodicauction
+
+import (
+	"testing"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/exchain/x/dex"
+	orderkeeper "github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/types"
+)
+
+func placeTimeInForceOrders(t *testing.T, testInput orderkeeper.TestInput, orders []*types.Order) {
+	for i, order := range orders {
+		order.Sender = testInput.TestAddrs[i%2]
+		err := testInput.OrderKeeper.PlaceOrder(testInput.Ctx, order)
+		require.Nil(t, err)
+	}
+}
+
+func TestMatchOrdersWithIOC(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx
+	err := testInput.DexKeeper.SaveTokenPair(ctx, dex.GetBuiltInTokenPair())
+	require.Nil(t, err)
+
+	ioc := mockOrder("", types.TestTokenPair, types.BuyOrder, "10.1", "3.0")
+	ioc.TimeInForce = types.TimeInForceIOC
+	placeTimeInForceOrders(t, testInput, []*types.Order{
+		ioc,
+		mockOrder("", types.TestTokenPair, types.SellOrder, "9.9", "1.0"),
+	})
+
+	matchOrders(ctx, keeper)
+
+	// the unfilled remainder of the IOC order is cancelled
+	order := keeper.GetOrder(ctx, ioc.OrderID)
+	require.EqualValues(t, types.OrderStatusPartialFilledCancelled, order.Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("2.0"), order.RemainQuantity)
+	require.EqualValues(t, 0, len(keeper.GetDepthBookCopy(types.TestTokenPair).Items))
+}
+
+func TestMatchOrdersWithFOK(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx
+	err := testInput.DexKeeper.SaveTokenPair(ctx, dex.GetBuiltInTokenPair())
+	require.Nil(t, err)
+
+	unfillable := mockOrder("", types.TestTokenPair, types.BuyOrder, "10.1", "3.0")
+	unfillable.TimeInForce = types.TimeInForceFOK
+	fillable := mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0")
+	fillable.TimeInForce = types.TimeInForceFOK
+	placeTimeInForceOrders(t, testInput, []*types.Order{
+		unfillable,
+		mockOrder("", types.TestTokenPair, types.SellOrder, "9.9", "1.0"),
+		fillable,
+	})
+
+	matchOrders(ctx, keeper)
+
+	// the FOK order which can't be filled completely is killed before the match
+	order := keeper.GetOrder(ctx, unfillable.OrderID)
+	require.EqualValues(t, types.OrderStatusCancelled, order.Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("3.0"), order.RemainQuantity)
+
+	order = keeper.GetOrder(ctx, fillable.OrderID)
+	require.EqualValues(t, types.OrderStatusFilled, order.Status)
+	require.EqualValues(t, 0, len(keeper.GetDepthBookCopy(types.TestTokenPair).Items))
+}
+
+func TestMatchOrdersWithUnmatchedFOK(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx
+	err := testInput.DexKeeper.SaveTokenPair(ctx, dex.GetBuiltInTokenPair())
+	require.Nil(t, err)
+
+	fok := mockOrder("", types.TestTokenPair, types.BuyOrder, "9.0", "1.0")
+	fok.TimeInForce = types.TimeInForceFOK
+	placeTimeInForceOrders(t, testInput, []*types.Order{
+		fok,
+		mockOrder("", types.TestTokenPair, types.SellOrder, "9.9", "1.0"),
+	})
+
+	matchOrders(ctx, keeper)
+
+	// nothing is matched, the FOK order is cancelled while the GTC order rests
+	require.EqualValues(t, types.OrderStatusCancelled, keeper.GetOrder(ctx, fok.OrderID).Status)
+	book := keeper.GetDepthBookCopy(types.TestTokenPair)
+	require.EqualValues(t, 1, len(book.Items))
+	require.EqualValues(t, sdk.MustNewDecFromStr("1.0"), book.Items[0].SellQuantity)
+}
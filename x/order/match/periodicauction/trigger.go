@@ -0,0 +1,50 @@
+package periodicauction
+
+import (
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+
+	"github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/types"
+)
+
+// appendTriggeredProducts appends the products of the orders activated by trigger orders to products
+func appendTriggeredProducts(ctx sdk.Context, k keeper.Keeper, products []string,
+	triggeredOrderIDs []string) []string {
+	exists := make(map[string]struct{}, len(products))
+	for _, product := range products {
+		exists[product] = struct{}{}
+	}
+	for _, orderID := range triggeredOrderIDs {
+		order := k.GetOrder(ctx, orderID)
+		if order == nil {
+			continue
+		}
+		if _, ok := exists[order.Product]; !ok {
+			exists[order.Product] = struct{}{}
+			products = append(products, order.Product)
+		}
+	}
+	return products
+}
+
+// cancelTriggeredImmediateOrders cancels the unfilled remainder of the IOC orders activated by trigger orders,
+// returns the ids of the orders whose products are still locked
+func cancelTriggeredImmediateOrders(ctx sdk.Context, k keeper.Keeper, triggeredOrderIDs []string) []string {
+	logger := ctx.Logger().With("module", "order")
+	var lockedOrderIDs []string
+	for _, orderID := range triggeredOrderIDs {
+		order := k.GetOrder(ctx, orderID)
+		if order == nil || !order.IsImmediate() || order.Status != types.OrderStatusOpen {
+			continue
+		}
+		if k.IsProductLocked(ctx, order.Product) {
+			lockedOrderIDs = append(lockedOrderIDs, orderID)
+			continue
+		}
+		k.CancelOrder(ctx, order, logger)
+		logger.Info(fmt.Sprintf("the unfilled remainder of %s order(%s) cancelled", order.TimeInForce, order.OrderID))
+	}
+	return lockedOrderIDs
+}
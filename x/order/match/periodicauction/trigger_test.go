@@ -0,0 +1,49 @@
+package periodicauction
+
+import (
+	"testing"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/exchain/x/dex"
+	orderkeeper "github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/types"
+)
+
+func TestMatchOrdersWithTriggeredOrders(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	sell := mockOrder("", types.TestTokenPair, types.SellOrder, "9.9", "1.0")
+	sell.Sender = testInput.TestAddrs[1]
+	err = keeper.PlaceOrder(ctx, sell)
+	require.Nil(t, err)
+
+	// a market buy order is activated at the end of block 10
+	triggerOrder := types.NewTriggerOrder("", testInput.TestAddrs[0], types.TestTokenPair, types.BuyOrder,
+		types.TriggerOrderTypeStopLoss, sdk.MustNewDecFromStr("10.0"), sdk.ZeroDec(), sdk.MustNewDecFromStr("2.0"), 0)
+	err = keeper.PlaceTriggerOrder(ctx, triggerOrder, tokenPair.MaxPriceDigit)
+	require.Nil(t, err)
+	buy, err := keeper.ActivateTriggerOrder(ctx, triggerOrder, tokenPair.MaxPriceDigit)
+	require.Nil(t, err)
+	keeper.SetTriggeredOrderIDs(ctx, []string{buy.OrderID})
+	keeper.Cache2Disk(ctx)
+
+	// no new orders in block 11, the triggered order is matched anyway
+	ctx = ctx.WithBlockHeight(11)
+	keeper.ResetCache(ctx)
+	require.EqualValues(t, 0, keeper.GetBlockOrderNum(ctx, 11))
+	matchOrders(ctx, keeper)
+
+	require.EqualValues(t, types.OrderStatusFilled, keeper.GetOrder(ctx, sell.OrderID).Status)
+	order := keeper.GetOrder(ctx, buy.OrderID)
+	require.EqualValues(t, types.OrderStatusPartialFilledCancelled, order.Status)
+	require.EqualValues(t, sdk.OneDec(), order.RemainQuantity)
+	require.Nil(t, keeper.GetTriggeredOrderIDs(ctx))
+	require.EqualValues(t, 0, len(keeper.GetDepthBookCopy(types.TestTokenPair).Items))
+}
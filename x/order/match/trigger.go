@@ -0,0 +1,56 @@
+package match
+
+import (
+	"fmt"
+	"sort"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+
+	"github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/types"
+)
+
+// ActivateTriggerOrders activates the trigger orders of the products traded in this block at the latest price.
+// The activated orders of the continuous auction products cross the depth book right away,
+// the others are matched by the next periodic auction
+func ActivateTriggerOrders(ctx sdk.Context, k keeper.Keeper) {
+	blockMatchResult := k.GetBlockMatchResult()
+	if blockMatchResult == nil || blockMatchResult.BlockHeight != ctx.BlockHeight() {
+		return
+	}
+	products := make([]string, 0, len(blockMatchResult.ResultMap))
+	for product := range blockMatchResult.ResultMap {
+		products = append(products, product)
+	}
+	sort.Strings(products)
+
+	logger := ctx.Logger().With("module", "order")
+	triggeredOrderIDs := k.GetTriggeredOrderIDs(ctx)
+	for _, product := range products {
+		// the orders can't be placed until the product is unlocked
+		tokenPair := k.GetDexKeeper().GetTokenPair(ctx, product)
+		if tokenPair == nil || k.IsProductLocked(ctx, product) {
+			continue
+		}
+		price := k.GetLastPrice(ctx, product)
+		for _, triggerOrder := range k.GetTriggeredOrders(ctx, product, price) {
+			order, err := k.ActivateTriggerOrder(ctx, triggerOrder, tokenPair.MaxPriceDigit)
+			if err != nil {
+				logger.Info(fmt.Sprintf("trigger order(%s) failed to be activated: %v", triggerOrder.TriggerOrderID, err))
+				continue
+			}
+			logger.Info(fmt.Sprintf("trigger order(%s) activated as order(%s) at price %s",
+				triggerOrder.TriggerOrderID, order.OrderID, price))
+
+			if !IsContinuousAuctionProduct(ctx, k, product) {
+				triggeredOrderIDs = append(triggeredOrderIDs, order.OrderID)
+				continue
+			}
+			caEngine.MatchOrder(ctx, k, order)
+			if order.IsImmediate() && order.Status == types.OrderStatusOpen {
+				k.CancelOrder(ctx, order, logger)
+			}
+		}
+	}
+	k.SetTriggeredOrderIDs(ctx, triggeredOrderIDs)
+}
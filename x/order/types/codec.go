@@ -8,6 +8,8 @@ import (
 func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgNewOrders{}, "okexchain/order/MsgNew", nil)
 	cdc.RegisterConcrete(MsgCancelOrders{}, "okexchain/order/MsgCancel", nil)
+	cdc.RegisterConcrete(MsgNewTriggerOrder{}, "okexchain/order/MsgNewTrigger", nil)
+	cdc.RegisterConcrete(MsgCancelTriggerOrder{}, "okexchain/order/MsgCancelTrigger", nil)
 }
 
 // ModuleCdc generic sealed codec to be used throughout this module
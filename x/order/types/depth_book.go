@@ -102,6 +102,19 @@ func (depthBook *DepthBook) RemoveOrder(order *Order) {
 	}
 }
 
+// CrossedQuantity returns the quantity of the opposite side which an order of side at price would trade with
+func (depthBook *DepthBook) CrossedQuantity(side string, price sdk.Dec) sdk.Dec {
+	quantity := sdk.ZeroDec()
+	for _, item := range depthBook.Items {
+		if side == BuyOrder && item.Price.LTE(price) {
+			quantity = quantity.Add(item.SellQuantity)
+		} else if side == SellOrder && item.Price.GTE(price) {
+			quantity = quantity.Add(item.BuyQuantity)
+		}
+	}
+	return quantity
+}
+
 // Sub : subtract the buy or sell quantity
 func (depthBook *DepthBook) Sub(index int, num sdk.Dec, side string) {
 	if side == BuyOrder {
@@ -126,3 +139,30 @@ func (depthBook *DepthBook) Copy() *DepthBook {
 	itemList = append(itemList, depthBook.Items...)
 	return &DepthBook{Items: itemList}
 }
+
+// Diff returns the price levels changed from prev, sorted by price desc. The levels removed from prev are returned
+// with zero quantities.
+func (depthBook *DepthBook) Diff(prev *DepthBook) []DepthBookItem {
+	prevItems := make(map[string]DepthBookItem, len(prev.Items))
+	for _, item := range prev.Items {
+		prevItems[item.Price.String()] = item
+	}
+
+	var changes []DepthBookItem
+	for _, item := range depthBook.Items {
+		key := item.Price.String()
+		prevItem, ok := prevItems[key]
+		delete(prevItems, key)
+		if ok && prevItem.BuyQuantity.Equal(item.BuyQuantity) && prevItem.SellQuantity.Equal(item.SellQuantity) {
+			continue
+		}
+		changes = append(changes, item)
+	}
+	for _, item := range prevItems {
+		changes = append(changes, DepthBookItem{Price: item.Price, BuyQuantity: sdk.ZeroDec(), SellQuantity: sdk.ZeroDec()})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Price.GT(changes[j].Price)
+	})
+	return changes
+}
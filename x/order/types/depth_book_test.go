@@ -96,3 +96,43 @@ func TestCopy(t *testing.T) {
 	require.EqualValues(t, 1, len(depthBook.Items))
 	require.EqualValues(t, sdk.MustNewDecFromStr("0.5"), depthBook.Items[0].Price)
 }
+
+func TestCrossedQuantity(t *testing.T) {
+	depthBook := &DepthBook{}
+	depthBook.InsertOrder(MockOrder("", TestTokenPair, BuyOrder, "0.5", "1.1"))
+	depthBook.InsertOrder(MockOrder("", TestTokenPair, BuyOrder, "0.4", "1.5"))
+	depthBook.InsertOrder(MockOrder("", TestTokenPair, SellOrder, "0.6", "2.1"))
+	depthBook.InsertOrder(MockOrder("", TestTokenPair, SellOrder, "0.7", "1.0"))
+
+	require.EqualValues(t, sdk.ZeroDec(), depthBook.CrossedQuantity(BuyOrder, sdk.MustNewDecFromStr("0.55")))
+	require.EqualValues(t, sdk.MustNewDecFromStr("2.1"), depthBook.CrossedQuantity(BuyOrder, sdk.MustNewDecFromStr("0.6")))
+	require.EqualValues(t, sdk.MustNewDecFromStr("3.1"), depthBook.CrossedQuantity(BuyOrder, sdk.MustNewDecFromStr("1")))
+	require.EqualValues(t, sdk.ZeroDec(), depthBook.CrossedQuantity(SellOrder, sdk.MustNewDecFromStr("0.55")))
+	require.EqualValues(t, sdk.MustNewDecFromStr("2.6"), depthBook.CrossedQuantity(SellOrder, sdk.MustNewDecFromStr("0.4")))
+}
+
+func TestDepthBookDiff(t *testing.T) {
+	prev := &DepthBook{}
+	prev.InsertOrder(MockOrder("", TestTokenPair, BuyOrder, "0.5", "1.1"))
+	prev.InsertOrder(MockOrder("", TestTokenPair, SellOrder, "0.6", "2.1"))
+	prev.InsertOrder(MockOrder("", TestTokenPair, SellOrder, "0.7", "1"))
+
+	book := prev.Copy()
+	require.Empty(t, book.Diff(prev))
+
+	book = &DepthBook{}
+	book.InsertOrder(MockOrder("", TestTokenPair, BuyOrder, "0.5", "1.1"))
+	book.InsertOrder(MockOrder("", TestTokenPair, SellOrder, "0.6", "1"))
+	book.InsertOrder(MockOrder("", TestTokenPair, BuyOrder, "0.4", "3"))
+
+	changes := book.Diff(prev)
+	require.Equal(t, 3, len(changes))
+	// the level at 0.7 is removed
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.7"), changes[0].Price)
+	require.True(t, changes[0].BuyQuantity.IsZero())
+	require.True(t, changes[0].SellQuantity.IsZero())
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.6"), changes[1].Price)
+	require.EqualValues(t, sdk.MustNewDecFromStr("1"), changes[1].SellQuantity)
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.4"), changes[2].Price)
+	require.EqualValues(t, sdk.MustNewDecFromStr("3"), changes[2].BuyQuantity)
+}
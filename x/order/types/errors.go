@@ -38,6 +38,12 @@ const (
 	CodeNotOrderOwner                         uint32 = 63026
 	CodeProductIsEmpty                        uint32 = 63027
 	CodeAllOrderFailedToExecute               uint32 = 63028
+	CodeOrderItemTimeInForceIsInvalid         uint32 = 63029
+	CodePostOnlyOrderWouldCross               uint32 = 63030
+	CodeFOKOrderCannotBeFilled                uint32 = 63031
+	CodeTriggerOrderTypeIsInvalid             uint32 = 63032
+	CodeTriggerOrderIsNotExist                uint32 = 63033
+	CodeTriggerPriceIsOutOfRange              uint32 = 63034
 )
 
 func ErrInvalidAddress(address string) sdk.EnvelopedErr {
@@ -152,3 +158,27 @@ func ErrNotOrderOwner(orderID string) sdk.EnvelopedErr {
 func ErrAllOrderFailedToExecute() sdk.EnvelopedErr {
 	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeAllOrderFailedToExecute, "all order items failed to execute")}
 }
+
+func ErrOrderItemTimeInForceIsInvalid(timeInForce string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeOrderItemTimeInForceIsInvalid, fmt.Sprintf("order item's time in force(%s) is not \"GTC\", \"IOC\", \"FOK\" or \"POST_ONLY\"", timeInForce))}
+}
+
+func ErrPostOnlyOrderWouldCross(product string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodePostOnlyOrderWouldCross, fmt.Sprintf("post-only order of %s would cross the depth book", product))}
+}
+
+func ErrFOKOrderCannotBeFilled(product string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeFOKOrderCannotBeFilled, fmt.Sprintf("FOK order of %s can't be filled completely", product))}
+}
+
+func ErrTriggerOrderTypeIsInvalid(orderType string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeTriggerOrderTypeIsInvalid, fmt.Sprintf("trigger order's type(%s) is not \"STOP_LOSS\" or \"TAKE_PROFIT\"", orderType))}
+}
+
+func ErrTriggerOrderIsNotExist(triggerOrderID string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeTriggerOrderIsNotExist, fmt.Sprintf("trigger order(%v) does not exist or has been activated", triggerOrderID))}
+}
+
+func ErrTriggerPriceIsOutOfRange(price sdk.Dec) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeTriggerPriceIsOutOfRange, fmt.Sprintf("trigger price(%s) is larger than %s", price, sdk.MaxSortableDec))}
+}
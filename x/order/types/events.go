@@ -0,0 +1,21 @@
+package types
+
+// order module event types
+const (
+	EventTypeDeal        = "order_deal"
+	EventTypeDepthBook   = "order_depth_book"
+	EventTypeOrderUpdate = "order_update"
+
+	AttributeKeyProduct        = "product"
+	AttributeKeyPrice          = "price"
+	AttributeKeyOrderID        = "order_id"
+	AttributeKeySide           = "side"
+	AttributeKeyQuantity       = "quantity"
+	AttributeKeyFee            = "fee"
+	AttributeKeySender         = "sender"
+	AttributeKeyStatus         = "status"
+	AttributeKeyRemainQuantity = "remain_quantity"
+	AttributeKeyFilledAvgPrice = "filled_avg_price"
+	AttributeKeyChanges        = "changes"
+	AttributeValueCategory     = ModuleName
+)
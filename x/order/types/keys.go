@@ -20,11 +20,13 @@ const (
 	RouterKey = ModuleName
 
 	// QueryOrderDetail query endpoints supported by the governance Querier
-	QueryOrderDetail = "detail"
-	QueryDepthBook   = "depthbook"
-	QueryParameters  = "params"
-	QueryStore       = "store"
-	QueryDepthBookV2 = "depthbookV2"
+	QueryOrderDetail   = "detail"
+	QueryDepthBook     = "depthbook"
+	QueryParameters    = "params"
+	QueryStore         = "store"
+	QueryDepthBookV2   = "depthbookV2"
+	QueryTriggerOrder  = "trigger"
+	QueryTriggerOrders = "triggers"
 
 	OrderStoreKey = ModuleName
 )
@@ -46,6 +48,12 @@ var (
 	LastExpiredBlockHeightKey = []byte{0x18}
 	OpenOrderNumKey           = []byte{0x19}
 	StoreOrderNumKey          = []byte{0x20}
+
+	// trigger order keys
+	TriggerOrderKey        = []byte{0x21}
+	ProductTriggerOrderKey = []byte{0x22} // iterator key of the trigger orders of a product by trigger price
+	TriggerOrderSeqKey     = []byte{0x23}
+	TriggeredOrderIDsKey   = []byte{0x24}
 )
 
 // nolint
@@ -78,6 +86,33 @@ func GetExpireBlockHeightKey(blockHeight int64) []byte {
 	return append(ExpireBlockHeightKey, sdk.Uint64ToBigEndian(uint64(blockHeight))...)
 }
 
+// nolint
+func GetTriggerOrderKey(triggerOrderID string) []byte {
+	return append(TriggerOrderKey, []byte(triggerOrderID)...)
+}
+
+// GetProductTriggerOrdersKey returns the prefix of the trigger orders of the product
+func GetProductTriggerOrdersKey(product string) []byte {
+	return append(ProductTriggerOrderKey, []byte(product+":")...)
+}
+
+// GetTriggerPriceIndexKey returns the prefix of the trigger orders of the product triggered when the price
+// falls or rises, which are ordered by the trigger price
+func GetTriggerPriceIndexKey(product string, onFall bool) []byte {
+	direction := byte(0x02)
+	if onFall {
+		direction = 0x01
+	}
+	return append(GetProductTriggerOrdersKey(product), direction)
+}
+
+// nolint
+func GetProductTriggerOrderKey(order *TriggerOrder) []byte {
+	key := append(GetTriggerPriceIndexKey(order.Product, order.IsTriggeredOnFall()),
+		sdk.SortableDecBytes(order.TriggerPrice)...)
+	return append(key, []byte(order.TriggerOrderID)...)
+}
+
 // nolint
 func FormatOrderIDsKey(product string, price sdk.Dec, side string) string {
 	return fmt.Sprintf("%v:%v:%v", product, price.String(), side)
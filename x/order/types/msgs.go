@@ -19,6 +19,8 @@ type MsgNewOrder struct {
 	Side     string         `json:"side"`     // BUY/SELL
 	Price    sdk.Dec        `json:"price"`    // price of the order
 	Quantity sdk.Dec        `json:"quantity"` // quantity of the order
+	// GTC/IOC/FOK/POST_ONLY, empty means GTC
+	TimeInForce string `json:"time_in_force,omitempty"`
 }
 
 // NewMsgNewOrder is a constructor function for MsgNewOrder
@@ -66,6 +68,8 @@ type OrderItem struct {
 	Side     string  `json:"side"`     // BUY/SELL
 	Price    sdk.Dec `json:"price"`    // price of the order
 	Quantity sdk.Dec `json:"quantity"` // quantity of the order
+	// GTC/IOC/FOK/POST_ONLY, empty means GTC
+	TimeInForce string `json:"time_in_force,omitempty"`
 }
 
 // nolint
@@ -121,6 +125,9 @@ func (msg MsgNewOrders) ValidateBasic() sdk.Error {
 		if !(item.Price.IsPositive() && item.Quantity.IsPositive()) {
 			return ErrOrderItemPriceOrQuantityIsNotPositive()
 		}
+		if !IsValidTimeInForce(item.TimeInForce) {
+			return ErrOrderItemTimeInForceIsInvalid(item.TimeInForce)
+		}
 	}
 
 	return nil
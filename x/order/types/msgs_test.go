@@ -91,6 +91,17 @@ func TestMsgNewOrderInvalid(t *testing.T) {
 	orderMsg = NewMsgNewOrder(addr, common.TestToken+"_"+common.TestToken, BuyOrder, testPrice, "-1")
 	err = orderMsg.ValidateBasic()
 	require.NotNil(t, err)
+
+	//invalid time in force
+	orderMsg = NewMsgNewOrder(addr, "btc_"+common.NativeToken, BuyOrder, testPrice, testQuantity)
+	orderMsg.OrderItems[0].TimeInForce = "GTD"
+	err = orderMsg.ValidateBasic()
+	require.NotNil(t, err)
+
+	for _, timeInForce := range []string{TimeInForceGTC, TimeInForceIOC, TimeInForceFOK, TimeInForcePostOnly} {
+		orderMsg.OrderItems[0].TimeInForce = timeInForce
+		require.Nil(t, orderMsg.ValidateBasic())
+	}
 }
 
 func TestMsgCancelOrder(t *testing.T) {
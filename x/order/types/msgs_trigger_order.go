@@ -0,0 +1,132 @@
+package types
+
+import (
+	"strings"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+// MsgNewTriggerOrder places a stop-loss or take-profit order
+type MsgNewTriggerOrder struct {
+	Sender       sdk.AccAddress `json:"sender"`        // order maker address
+	Product      string         `json:"product"`       // product for trading pair in full name of the tokens
+	Side         string         `json:"side"`          // BUY/SELL
+	TriggerType  string         `json:"trigger_type"`  // STOP_LOSS/TAKE_PROFIT
+	TriggerPrice sdk.Dec        `json:"trigger_price"` // price which activates the order
+	Price        sdk.Dec        `json:"price"`         // price of the activated order, zero means a market order
+	Quantity     sdk.Dec        `json:"quantity"`      // quantity of the order
+}
+
+// NewMsgNewTriggerOrder is a constructor function for MsgNewTriggerOrder
+func NewMsgNewTriggerOrder(sender sdk.AccAddress, product, side, triggerType string,
+	triggerPrice, price, quantity sdk.Dec) MsgNewTriggerOrder {
+	return MsgNewTriggerOrder{
+		Sender:       sender,
+		Product:      product,
+		Side:         side,
+		TriggerType:  triggerType,
+		TriggerPrice: triggerPrice,
+		Price:        price,
+		Quantity:     quantity,
+	}
+}
+
+// nolint
+func (msg MsgNewTriggerOrder) Route() string { return "order" }
+
+// nolint
+func (msg MsgNewTriggerOrder) Type() string { return "new_trigger" }
+
+// ValidateBasic : Implements Msg.
+func (msg MsgNewTriggerOrder) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return ErrInvalidAddress(msg.Sender.String())
+	}
+	if len(msg.Product) == 0 {
+		return ErrOrderItemProductCountsIsEmpty()
+	}
+	symbols := strings.Split(msg.Product, "_")
+	if len(symbols) != 2 {
+		return ErrOrderItemProductFormat()
+	}
+	if symbols[0] == symbols[1] {
+		return ErrOrderItemProductSymbolIsEqual()
+	}
+	if msg.Side != BuyOrder && msg.Side != SellOrder {
+		return ErrOrderItemSideIsNotBuyAndSell()
+	}
+	if msg.TriggerType != TriggerOrderTypeStopLoss && msg.TriggerType != TriggerOrderTypeTakeProfit {
+		return ErrTriggerOrderTypeIsInvalid(msg.TriggerType)
+	}
+	if !(msg.TriggerPrice.IsPositive() && msg.Quantity.IsPositive()) || msg.Price.IsNegative() {
+		return ErrOrderItemPriceOrQuantityIsNotPositive()
+	}
+	// the trigger orders are indexed by the trigger price
+	if !sdk.ValidSortableDec(msg.TriggerPrice) {
+		return ErrTriggerPriceIsOutOfRange(msg.TriggerPrice)
+	}
+	return nil
+}
+
+// GetSignBytes : encodes the message for signing
+func (msg MsgNewTriggerOrder) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgNewTriggerOrder) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// Calculate customize gas
+func (msg MsgNewTriggerOrder) CalculateGas(gasUnit uint64) uint64 {
+	return gasUnit
+}
+
+// MsgCancelTriggerOrder cancels a trigger order which is not activated yet
+type MsgCancelTriggerOrder struct {
+	Sender         sdk.AccAddress `json:"sender"` // order maker address
+	TriggerOrderID string         `json:"trigger_order_id"`
+}
+
+// NewMsgCancelTriggerOrder is a constructor function for MsgCancelTriggerOrder
+func NewMsgCancelTriggerOrder(sender sdk.AccAddress, triggerOrderID string) MsgCancelTriggerOrder {
+	return MsgCancelTriggerOrder{
+		Sender:         sender,
+		TriggerOrderID: triggerOrderID,
+	}
+}
+
+// nolint
+func (msg MsgCancelTriggerOrder) Route() string { return "order" }
+
+// nolint
+func (msg MsgCancelTriggerOrder) Type() string { return "cancel_trigger" }
+
+// nolint
+func (msg MsgCancelTriggerOrder) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return ErrInvalidAddress(msg.Sender.String())
+	}
+	if msg.TriggerOrderID == "" {
+		return ErrUserInputOrderIDIsEmpty()
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgCancelTriggerOrder) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgCancelTriggerOrder) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// Calculate customize gas
+func (msg MsgCancelTriggerOrder) CalculateGas(gasUnit uint64) uint64 {
+	return gasUnit
+}
@@ -61,6 +61,24 @@ const (
 	OrderExtraInfoKeyReceiveFee = "receiveFee"
 )
 
+// nolint
+const (
+	TimeInForceGTC      = "GTC"       // good till cancelled, the order rests until it's filled, cancelled or expired
+	TimeInForceIOC      = "IOC"       // immediate or cancel, the unfilled remainder is cancelled after the match
+	TimeInForceFOK      = "FOK"       // fill or kill, the order is cancelled unless it can be filled completely
+	TimeInForcePostOnly = "POST_ONLY" // the order is rejected if it would cross the depth book
+)
+
+// IsValidTimeInForce returns true if the time in force is supported, the empty one means GTC
+func IsValidTimeInForce(timeInForce string) bool {
+	switch timeInForce {
+	case "", TimeInForceGTC, TimeInForceIOC, TimeInForceFOK, TimeInForcePostOnly:
+		return true
+	default:
+		return false
+	}
+}
+
 // nolint
 type Order struct {
 	TxHash            string         `json:"txhash"`           // txHash of the place order tx
@@ -77,7 +95,8 @@ type Order struct {
 	Timestamp         int64          `json:"timestamp"`        // created timestamp
 	OrderExpireBlocks int64          `json:"order_expire_blocks"`
 	FeePerBlock       sdk.SysCoin    `json:"fee_per_block"`
-	ExtraInfo         string         `json:"extra_info"` // extra info of order in json format
+	ExtraInfo         string         `json:"extra_info"`              // extra info of order in json format
+	TimeInForce       string         `json:"time_in_force,omitempty"` // GTC/IOC/FOK/POST_ONLY, empty means GTC
 }
 
 // nolint
@@ -104,6 +123,11 @@ func NewOrder(txHash string, sender sdk.AccAddress, product, side string, price,
 	return order
 }
 
+// IsImmediate returns true if the unfilled remainder of the order is cancelled right after the match
+func (order *Order) IsImmediate() bool {
+	return order.TimeInForce == TimeInForceIOC || order.TimeInForce == TimeInForceFOK
+}
+
 func (order *Order) String() string {
 	if orderJSON, err := json.Marshal(order); err != nil {
 		panic(err)
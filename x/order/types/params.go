@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"strings"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/x/common"
@@ -24,13 +25,14 @@ const (
 
 // nolint : Parameter keys
 var (
-	KeyOrderExpireBlocks     = []byte("OrderExpireBlocks")
-	KeyMaxDealsPerBlock      = []byte("MaxDealsPerBlock")
-	KeyFeePerBlock           = []byte("FeePerBlock")
-	KeyTradeFeeRate          = []byte("TradeFeeRate")
-	KeyNewOrderMsgGasUnit    = []byte("NewOrderMsgGasUnit")
-	KeyCancelOrderMsgGasUnit = []byte("CancelOrderMsgGasUnit")
-	DefaultFeePerBlock       = sdk.NewDecCoinFromDec(DefaultFeeDenomPerBlock, sdk.MustNewDecFromStr(DefaultFeeAmountPerBlock))
+	KeyOrderExpireBlocks         = []byte("OrderExpireBlocks")
+	KeyMaxDealsPerBlock          = []byte("MaxDealsPerBlock")
+	KeyFeePerBlock               = []byte("FeePerBlock")
+	KeyTradeFeeRate              = []byte("TradeFeeRate")
+	KeyNewOrderMsgGasUnit        = []byte("NewOrderMsgGasUnit")
+	KeyCancelOrderMsgGasUnit     = []byte("CancelOrderMsgGasUnit")
+	KeyContinuousAuctionProducts = []byte("ContinuousAuctionProducts")
+	DefaultFeePerBlock           = sdk.NewDecCoinFromDec(DefaultFeeDenomPerBlock, sdk.MustNewDecFromStr(DefaultFeeAmountPerBlock))
 )
 
 // nolint
@@ -44,6 +46,8 @@ type Params struct {
 	TradeFeeRate          sdk.Dec     `json:"trade_fee_rate"`
 	NewOrderMsgGasUnit    uint64      `json:"new_order_msg_gas_unit"`
 	CancelOrderMsgGasUnit uint64      `json:"cancel_order_msg_gas_unit"`
+	// products matched by the continuous auction engine instead of the periodic auction
+	ContinuousAuctionProducts []string `json:"continuous_auction_products"`
 }
 
 // ParamKeyTable for auth module
@@ -56,6 +60,24 @@ func validateParams(value interface{}) error {
 	return nil
 }
 
+func validateProducts(value interface{}) error {
+	products, ok := value.([]string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", value)
+	}
+	seen := make(map[string]struct{}, len(products))
+	for _, product := range products {
+		if len(strings.Split(product, "_")) != 2 {
+			return fmt.Errorf("invalid product: %s", product)
+		}
+		if _, ok := seen[product]; ok {
+			return fmt.Errorf("duplicate product: %s", product)
+		}
+		seen[product] = struct{}{}
+	}
+	return nil
+}
+
 // ParamSetPairs implements the ParamSet interface and returns all the key/value pairs
 // pairs of auth module's parameters.
 // nolint
@@ -67,19 +89,31 @@ func (p *Params) ParamSetPairs() params.ParamSetPairs {
 		{KeyTradeFeeRate, &p.TradeFeeRate, common.ValidateRateNotNeg("trade fee rate")},
 		{KeyNewOrderMsgGasUnit, &p.NewOrderMsgGasUnit, common.ValidateUint64Positive("new order msg gas unit")},
 		{KeyCancelOrderMsgGasUnit, &p.CancelOrderMsgGasUnit, common.ValidateUint64Positive("cancel order msg gas unit")},
+		{KeyContinuousAuctionProducts, &p.ContinuousAuctionProducts, validateProducts},
 	}
 }
 
 // DefaultParams returns a default set of parameters.
 func DefaultParams() Params {
 	return Params{
-		OrderExpireBlocks:     DefaultOrderExpireBlocks,
-		MaxDealsPerBlock:      DefaultMaxDealsPerBlock,
-		FeePerBlock:           DefaultFeePerBlock,
-		TradeFeeRate:          sdk.MustNewDecFromStr(DefaultFeeRateTrade),
-		NewOrderMsgGasUnit:    DefaultNewOrderMsgGasUnit,
-		CancelOrderMsgGasUnit: DefaultCancelOrderMsgGasUnit,
+		OrderExpireBlocks:         DefaultOrderExpireBlocks,
+		MaxDealsPerBlock:          DefaultMaxDealsPerBlock,
+		FeePerBlock:               DefaultFeePerBlock,
+		TradeFeeRate:              sdk.MustNewDecFromStr(DefaultFeeRateTrade),
+		NewOrderMsgGasUnit:        DefaultNewOrderMsgGasUnit,
+		CancelOrderMsgGasUnit:     DefaultCancelOrderMsgGasUnit,
+		ContinuousAuctionProducts: []string{},
+	}
+}
+
+// IsContinuousAuctionProduct returns true if the product is matched by the continuous auction engine
+func (p Params) IsContinuousAuctionProduct(product string) bool {
+	for _, item := range p.ContinuousAuctionProducts {
+		if item == product {
+			return true
+		}
 	}
+	return false
 }
 
 // String implements the stringer interface.
@@ -90,7 +124,8 @@ func (p Params) String() string {
   FeePerBlock: %s
   TradeFeeRate: %s
   NewOrderMsgGasUnit: %d
-  CancelOrderMsgGasUnit: %d`, p.OrderExpireBlocks,
+  CancelOrderMsgGasUnit: %d
+  ContinuousAuctionProducts: %v`, p.OrderExpireBlocks,
 		p.MaxDealsPerBlock, p.FeePerBlock,
-		p.TradeFeeRate, p.NewOrderMsgGasUnit, p.CancelOrderMsgGasUnit)
+		p.TradeFeeRate, p.NewOrderMsgGasUnit, p.CancelOrderMsgGasUnit, p.ContinuousAuctionProducts)
 }
@@ -54,6 +54,7 @@ func TestParamsString(t *testing.T) {
   FeePerBlock: 0.000000000000000000` + common.NativeToken + `
   TradeFeeRate: 0.001000000000000000
   NewOrderMsgGasUnit: 40000
-  CancelOrderMsgGasUnit: 30000`
+  CancelOrderMsgGasUnit: 30000
+  ContinuousAuctionProducts: []`
 	require.EqualValues(t, expectString, param.String())
 }
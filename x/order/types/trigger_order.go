@@ -0,0 +1,116 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+// nolint
+const (
+	TriggerOrderTypeStopLoss   = "STOP_LOSS"
+	TriggerOrderTypeTakeProfit = "TAKE_PROFIT"
+)
+
+// MarketOrderSlippage bounds the limit price of the order activated by a market trigger order,
+// buy orders are placed above the trigger price and sell orders below it
+var MarketOrderSlippage = sdk.MustNewDecFromStr("0.05")
+
+// TriggerOrder is a conditional order kept out of the depth book. It's activated as a regular order
+// once the latest price of the product reaches the trigger price
+type TriggerOrder struct {
+	TriggerOrderID string         `json:"trigger_order_id"`
+	TxHash         string         `json:"txhash"`        // txHash of the place trigger order tx
+	Sender         sdk.AccAddress `json:"sender"`        // order maker address
+	Product        string         `json:"product"`       // product for trading pair
+	Side           string         `json:"side"`          // BUY/SELL
+	TriggerType    string         `json:"trigger_type"`  // STOP_LOSS/TAKE_PROFIT
+	TriggerPrice   sdk.Dec        `json:"trigger_price"` // price which activates the order
+	Price          sdk.Dec        `json:"price"`         // price of the activated order, zero means a market order
+	Quantity       sdk.Dec        `json:"quantity"`      // quantity of the activated order
+	Timestamp      int64          `json:"timestamp"`     // created timestamp
+}
+
+// NewTriggerOrder creates a new trigger order
+func NewTriggerOrder(txHash string, sender sdk.AccAddress, product, side, triggerType string,
+	triggerPrice, price, quantity sdk.Dec, timestamp int64) *TriggerOrder {
+	return &TriggerOrder{
+		TxHash:       txHash,
+		Sender:       sender,
+		Product:      product,
+		Side:         side,
+		TriggerType:  triggerType,
+		TriggerPrice: triggerPrice,
+		Price:        price,
+		Quantity:     quantity,
+		Timestamp:    timestamp,
+	}
+}
+
+func (order *TriggerOrder) String() string {
+	if orderJSON, err := json.Marshal(order); err != nil {
+		panic(err)
+	} else {
+		return string(orderJSON)
+	}
+}
+
+// IsMarket returns true if the order is activated as a market order
+func (order *TriggerOrder) IsMarket() bool {
+	return !order.Price.IsPositive()
+}
+
+// IsTriggered returns true if the latest price reaches the trigger price.
+// A sell stop-loss or a buy take-profit is triggered when the price falls to the trigger price,
+// a buy stop-loss or a sell take-profit is triggered when the price rises to it
+func (order *TriggerOrder) IsTriggered(price sdk.Dec) bool {
+	if !price.IsPositive() {
+		return false
+	}
+	if order.IsTriggeredOnFall() {
+		return price.LTE(order.TriggerPrice)
+	}
+	return price.GTE(order.TriggerPrice)
+}
+
+// IsTriggeredOnFall returns true if the order is triggered when the price falls to the trigger price
+func (order *TriggerOrder) IsTriggeredOnFall() bool {
+	return (order.TriggerType == TriggerOrderTypeStopLoss) == (order.Side == SellOrder)
+}
+
+// OrderPrice returns the price of the activated order. A market order is placed as an IOC order
+// whose price is bounded by MarketOrderSlippage around the trigger price
+func (order *TriggerOrder) OrderPrice(pricePrecision int64) sdk.Dec {
+	if !order.IsMarket() {
+		return order.Price
+	}
+	if order.Side == BuyOrder {
+		return order.TriggerPrice.Mul(sdk.OneDec().Add(MarketOrderSlippage)).RoundDecimal(pricePrecision)
+	}
+	return order.TriggerPrice.Mul(sdk.OneDec().Sub(MarketOrderSlippage)).RoundDecimal(pricePrecision)
+}
+
+// OrderTimeInForce returns the time in force of the activated order
+func (order *TriggerOrder) OrderTimeInForce() string {
+	if order.IsMarket() {
+		return TimeInForceIOC
+	}
+	return TimeInForceGTC
+}
+
+// NeedLockCoins returns the coins locked until the order is activated or cancelled,
+// it's the same as the one of the activated order
+func (order *TriggerOrder) NeedLockCoins(pricePrecision int64) sdk.SysCoins {
+	return (&Order{
+		Product:  order.Product,
+		Side:     order.Side,
+		Price:    order.OrderPrice(pricePrecision),
+		Quantity: order.Quantity,
+	}).NeedLockCoins()
+}
+
+// FormatTriggerOrderID formats the id of the trigger order with the sequence
+func FormatTriggerOrderID(seq uint64) string {
+	return fmt.Sprintf("TID%d", seq)
+}
@@ -0,0 +1,100 @@
+package types
+
+import (
+	"encoding/hex"
+	"testing"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/exchain/x/common"
+)
+
+func mockTriggerOrder(side, triggerType, triggerPrice, price string) *TriggerOrder {
+	return NewTriggerOrder("", nil, TestTokenPair, side, triggerType, sdk.MustNewDecFromStr(triggerPrice),
+		sdk.MustNewDecFromStr(price), sdk.OneDec(), 0)
+}
+
+func TestTriggerOrderIsTriggered(t *testing.T) {
+	testCases := []struct {
+		side, triggerType string
+		below, above      bool
+	}{
+		{SellOrder, TriggerOrderTypeStopLoss, true, false},
+		{BuyOrder, TriggerOrderTypeTakeProfit, true, false},
+		{BuyOrder, TriggerOrderTypeStopLoss, false, true},
+		{SellOrder, TriggerOrderTypeTakeProfit, false, true},
+	}
+	for _, tc := range testCases {
+		order := mockTriggerOrder(tc.side, tc.triggerType, "10.0", "0")
+		require.True(t, order.IsTriggered(sdk.MustNewDecFromStr("10.0")))
+		require.Equal(t, tc.below, order.IsTriggered(sdk.MustNewDecFromStr("9.9")))
+		require.Equal(t, tc.above, order.IsTriggered(sdk.MustNewDecFromStr("10.1")))
+		// no trades no trigger
+		require.False(t, order.IsTriggered(sdk.ZeroDec()))
+	}
+}
+
+func TestTriggerOrderPrice(t *testing.T) {
+	// limit order
+	order := mockTriggerOrder(BuyOrder, TriggerOrderTypeStopLoss, "10.0", "10.2")
+	require.False(t, order.IsMarket())
+	require.EqualValues(t, sdk.MustNewDecFromStr("10.2"), order.OrderPrice(2))
+	require.EqualValues(t, TimeInForceGTC, order.OrderTimeInForce())
+
+	// market order
+	order = mockTriggerOrder(BuyOrder, TriggerOrderTypeStopLoss, "1.11", "0")
+	require.True(t, order.IsMarket())
+	require.EqualValues(t, sdk.MustNewDecFromStr("1.17"), order.OrderPrice(2))
+	require.EqualValues(t, TimeInForceIOC, order.OrderTimeInForce())
+	require.EqualValues(t, sdk.MustNewDecFromStr("1.17"), order.NeedLockCoins(2).AmountOf(sdk.DefaultBondDenom))
+
+	order = mockTriggerOrder(SellOrder, TriggerOrderTypeStopLoss, "1.11", "0")
+	require.EqualValues(t, sdk.MustNewDecFromStr("1.05"), order.OrderPrice(2))
+	require.EqualValues(t, sdk.OneDec(), order.NeedLockCoins(2).AmountOf(common.TestToken))
+}
+
+func TestMsgNewTriggerOrder(t *testing.T) {
+	addr, err := hex.DecodeString("1212121212121212123412121212121212121234")
+	require.Nil(t, err)
+	msg := NewMsgNewTriggerOrder(addr, TestTokenPair, BuyOrder, TriggerOrderTypeStopLoss,
+		sdk.MustNewDecFromStr("10.0"), sdk.ZeroDec(), sdk.OneDec())
+	require.Nil(t, msg.ValidateBasic())
+	require.Equal(t, "order", msg.Route())
+	require.Equal(t, "new_trigger", msg.Type())
+	require.EqualValues(t, addr, msg.GetSigners()[0])
+
+	invalid := msg
+	invalid.Sender = nil
+	require.NotNil(t, invalid.ValidateBasic())
+	invalid = msg
+	invalid.Product = "abc"
+	require.NotNil(t, invalid.ValidateBasic())
+	invalid = msg
+	invalid.Side = "BUYY"
+	require.NotNil(t, invalid.ValidateBasic())
+	invalid = msg
+	invalid.TriggerType = "STOP"
+	require.NotNil(t, invalid.ValidateBasic())
+	invalid = msg
+	invalid.TriggerPrice = sdk.ZeroDec()
+	require.NotNil(t, invalid.ValidateBasic())
+	invalid = msg
+	invalid.Price = sdk.MustNewDecFromStr("-1.0")
+	require.NotNil(t, invalid.ValidateBasic())
+	invalid = msg
+	invalid.TriggerPrice = sdk.MaxSortableDec.Add(sdk.OneDec())
+	require.NotNil(t, invalid.ValidateBasic())
+}
+
+func TestMsgCancelTriggerOrder(t *testing.T) {
+	addr, err := hex.DecodeString("1212121212121212123412121212121212121234")
+	require.Nil(t, err)
+	msg := NewMsgCancelTriggerOrder(addr, FormatTriggerOrderID(1))
+	require.Nil(t, msg.ValidateBasic())
+	require.Equal(t, "cancel_trigger", msg.Type())
+	require.EqualValues(t, addr, msg.GetSigners()[0])
+
+	require.NotNil(t, NewMsgCancelTriggerOrder(addr, "").ValidateBasic())
+	require.NotNil(t, NewMsgCancelTriggerOrder(nil, FormatTriggerOrderID(1)).ValidateBasic())
+}
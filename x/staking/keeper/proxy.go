@@ -2,6 +2,7 @@ package keeper
 
 import (
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	"github.com/okex/exchain/x/staking/types"
 )
 
@@ -74,16 +75,22 @@ func (k Keeper) UpdateShares(ctx sdk.Context, delAddr sdk.AccAddress, tokens sdk
 			return types.ErrAddSharesToDismission(vals[i].OperatorAddress.String())
 		}
 
-		// 1.delete related store
+		// 1.call the hooks of distribution module before the shares change
+		k.BeforeDelegationSharesModified(ctx, delAddr, vals[i].OperatorAddress)
+
+		// 2.delete related store
 		k.DeleteValidatorByPowerIndex(ctx, vals[i])
 
-		// 2.update shares
+		// 3.update shares
 		k.SetShares(ctx, delAddr, vals[i].OperatorAddress, shares)
 
-		// 3.update validator
+		// 4.update validator
 		vals[i].DelegatorShares = vals[i].DelegatorShares.Sub(lastShares).Add(shares)
 		k.SetValidator(ctx, vals[i])
 		k.SetValidatorByPowerIndex(ctx, vals[i])
+
+		// 5.call the hooks of distribution module after the shares change
+		k.AfterDelegationModified(ctx, delAddr, vals[i].OperatorAddress)
 	}
 
 	// update the delegator struct
@@ -117,6 +124,9 @@ func (k Keeper) WithdrawLastShares(ctx sdk.Context, delAddr sdk.AccAddress, last
 }
 
 func (k Keeper) withdrawShares(ctx sdk.Context, delAddr sdk.AccAddress, val types.Validator, shares types.Shares) {
+	// 0.call the hooks of distribution module before the shares are withdrawn
+	k.BeforeDelegationSharesModified(ctx, delAddr, val.OperatorAddress)
+
 	// 1.delete shares entity
 	k.DeleteShares(ctx, val.OperatorAddress, delAddr)
 
@@ -137,14 +147,29 @@ func (k Keeper) withdrawShares(ctx sdk.Context, delAddr sdk.AccAddress, val type
 }
 
 func (k Keeper) addShares(ctx sdk.Context, delAddr sdk.AccAddress, val types.Validator, shares types.Shares) {
+	// 0.call the hooks of distribution module before the shares are added, which reward the delegators from the
+	// earth milestone
+	lastShares := sdk.ZeroDec()
+	if tmtypes.HigherThanEarth(ctx.BlockHeight()) {
+		if shares, found := k.GetShares(ctx, delAddr, val.OperatorAddress); found {
+			lastShares = shares
+			k.BeforeDelegationSharesModified(ctx, delAddr, val.OperatorAddress)
+		} else {
+			k.BeforeDelegationCreated(ctx, delAddr, val.OperatorAddress)
+		}
+	}
+
 	// 1.update shares entity
 	k.SetShares(ctx, delAddr, val.OperatorAddress, shares)
 
 	// 2.update validator entity
 	k.DeleteValidatorByPowerIndex(ctx, val)
-	val.DelegatorShares = val.GetDelegatorShares().Add(shares)
+	val.DelegatorShares = val.GetDelegatorShares().Sub(lastShares).Add(shares)
 	k.SetValidator(ctx, val)
 	k.SetValidatorByPowerIndex(ctx, val)
+
+	// 3.call the hooks of distribution module after the shares are added
+	k.AfterDelegationModified(ctx, delAddr, val.OperatorAddress)
 }
 
 // GetLastValsAddedSharesExisted gets last validators that the delegator added shares to last time
@@ -86,4 +86,11 @@ type StakingHooks interface {
 	// required by okexchain
 	// Must be called when a validator is destroyed by tx
 	AfterValidatorDestroyed(ctx sdk.Context, consAddr sdk.ConsAddress, valAddr sdk.ValAddress)
+
+	// Must be called before the shares are added by a delegator to a validator
+	BeforeDelegationCreated(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress)
+	// Must be called before the shares added by a delegator to a validator are modified or withdrawn
+	BeforeDelegationSharesModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress)
+	// Must be called after the shares added by a delegator to a validator are set
+	AfterDelegationModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress)
 }